@@ -20,6 +20,7 @@ func main() {
 	redisAddr := strings.TrimSpace(os.Getenv("REDIS_ADDR"))
 	streamKey := getEnv("CANDLE_TICK_STREAM", "kalency:v1:stream:ticks")
 	prefix := getEnv("CANDLE_KEY_PREFIX", "v1")
+	updateStreamKey := getEnv("CANDLE_UPDATE_STREAM", "kalency:v1:stream:candles")
 	startID := getEnv("CANDLE_START_ID", "$")
 	batchSize := getEnvInt("CANDLE_BATCH_SIZE", 100)
 	blockMS := getEnvInt("CANDLE_BLOCK_MS", 250)
@@ -41,12 +42,13 @@ func main() {
 	tickSource := store.NewRedisTickStreamSource(client, streamKey)
 	candleStore := store.NewRedisCandleStore(client, prefix)
 	svc := candle.NewService(candleStore, candle.Config{TTL: ttl})
+	svc.SetPublisher(store.NewRedisCandleStreamPublisher(client, updateStreamKey))
 
 	go runAggregator(context.Background(), tickSource, svc, startID, batchSize, time.Duration(blockMS)*time.Millisecond)
 
 	server := httpapi.NewServer()
 	addr := ":" + port
-	log.Printf("candle-aggregator listening on %s (redis=%s stream=%s)", addr, redisAddr, streamKey)
+	log.Printf("candle-aggregator listening on %s (redis=%s stream=%s updates=%s)", addr, redisAddr, streamKey, updateStreamKey)
 	if err := http.ListenAndServe(addr, server); err != nil {
 		_ = client.Close()
 		log.Fatal(err)
@@ -82,6 +84,10 @@ func runAggregator(ctx context.Context, source tickSource, svc *candle.Service,
 				log.Printf("process tick failed: %v", err)
 			}
 		}
+		// Reads return at least every block, so quiet symbols still close.
+		if err := svc.FlushClosed(ctx, time.Now().UTC()); err != nil {
+			log.Printf("flush closed candles failed: %v", err)
+		}
 	}
 }
 
@@ -9,8 +9,21 @@ import (
 
 const DefaultCandleTTL = 30 * 24 * time.Hour
 
+// closeGrace is how long past its end a quiet bucket waits for late ticks
+// before FlushClosed sends it as final.
+const closeGrace = 2 * time.Second
+
+// CandleStore merges a single-tick candle into its bucket and returns the
+// bucket's resulting OHLCV.
 type CandleStore interface {
-	UpsertCandle(ctx context.Context, candle Candle, ttl time.Duration) error
+	UpsertCandle(ctx context.Context, candle Candle, ttl time.Duration) (Candle, error)
+}
+
+// CandlePublisher receives every candle after it is written. final is set
+// once a later tick has opened the next bucket of the same timeframe, or
+// FlushClosed found the bucket over.
+type CandlePublisher interface {
+	PublishCandle(ctx context.Context, candle Candle, final bool) error
 }
 
 type Config struct {
@@ -35,8 +48,19 @@ var defaultTimeframeOrder = []string{"1s", "5s", "1m", "5m", "1h"}
 
 type Service struct {
 	store      CandleStore
+	publisher  CandlePublisher
 	timeframes []timeframeDef
 	ttl        time.Duration
+
+	// forming holds the latest published candle per symbol and timeframe.
+	forming map[string]formingCandle
+}
+
+type formingCandle struct {
+	candle   Candle
+	duration time.Duration
+	// closed is set once the candle was sent as final.
+	closed bool
 }
 
 func NewService(store CandleStore, cfg Config) *Service {
@@ -50,7 +74,11 @@ func NewService(store CandleStore, cfg Config) *Service {
 		timeframes = parseTimeframes(defaultTimeframeOrder)
 	}
 
-	return &Service{store: store, timeframes: timeframes, ttl: ttl}
+	return &Service{store: store, timeframes: timeframes, ttl: ttl, forming: map[string]formingCandle{}}
+}
+
+func (s *Service) SetPublisher(publisher CandlePublisher) {
+	s.publisher = publisher
 }
 
 func (s *Service) ProcessTick(ctx context.Context, tick Tick) error {
@@ -72,6 +100,9 @@ func (s *Service) ProcessTick(ctx context.Context, tick Tick) error {
 		tick.TS = time.Now().UTC()
 	}
 
+	// Every timeframe is stored before anything is published: publishing is
+	// best-effort and must not cost a later timeframe its candle.
+	merged := make([]Candle, 0, len(s.timeframes))
 	for _, timeframe := range s.timeframes {
 		bucketStart := bucketStart(tick.TS, timeframe.Duration)
 		point := Candle{
@@ -84,11 +115,64 @@ func (s *Service) ProcessTick(ctx context.Context, tick Tick) error {
 			Close:       tick.Price,
 			Volume:      tick.Volume,
 		}
-		if err := s.store.UpsertCandle(ctx, point, s.ttl); err != nil {
+		candle, err := s.store.UpsertCandle(ctx, point, s.ttl)
+		if err != nil {
 			return err
 		}
+		merged = append(merged, candle)
+	}
+
+	var errs []error
+	for i, candle := range merged {
+		if err := s.publish(ctx, candle, s.timeframes[i].Duration); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FlushClosed sends as final every forming candle whose bucket ended more
+// than closeGrace before now, so a symbol that goes quiet still closes its
+// buckets. The aggregator calls it after every read of the tick stream.
+func (s *Service) FlushClosed(ctx context.Context, now time.Time) error {
+	if s.publisher == nil {
+		return nil
+	}
+
+	var errs []error
+	for key, forming := range s.forming {
+		if forming.closed || now.Before(forming.candle.BucketStart.Add(forming.duration+closeGrace)) {
+			continue
+		}
+		if err := s.publisher.PublishCandle(ctx, forming.candle, true); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		forming.closed = true
+		s.forming[key] = forming
+	}
+	return errors.Join(errs...)
+}
+
+// publish closes the previous bucket when merged starts a newer one, then
+// sends merged. A late tick for an already closed bucket is sent as final.
+func (s *Service) publish(ctx context.Context, merged Candle, duration time.Duration) error {
+	if s.publisher == nil {
+		return nil
+	}
+
+	key := merged.Symbol + "|" + merged.Timeframe
+	previous, ok := s.forming[key]
+	if ok && (merged.BucketStart.Before(previous.candle.BucketStart) ||
+		previous.closed && merged.BucketStart.Equal(previous.candle.BucketStart)) {
+		return s.publisher.PublishCandle(ctx, merged, true)
+	}
+	var closeErr error
+	if ok && !previous.closed && merged.BucketStart.After(previous.candle.BucketStart) {
+		closeErr = s.publisher.PublishCandle(ctx, previous.candle, true)
 	}
-	return nil
+	s.forming[key] = formingCandle{candle: merged, duration: duration}
+	return errors.Join(closeErr, s.publisher.PublishCandle(ctx, merged, false))
 }
 
 func parseTimeframes(raw []string) []timeframeDef {
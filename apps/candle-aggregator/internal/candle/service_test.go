@@ -2,6 +2,7 @@ package candle
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -15,8 +16,22 @@ type recordingStore struct {
 	calls []recordedUpsert
 }
 
-func (r *recordingStore) UpsertCandle(_ context.Context, candle Candle, ttl time.Duration) error {
+func (r *recordingStore) UpsertCandle(_ context.Context, candle Candle, ttl time.Duration) (Candle, error) {
 	r.calls = append(r.calls, recordedUpsert{Candle: candle, TTL: ttl})
+	return candle, nil
+}
+
+type publishedCandle struct {
+	Candle Candle
+	Final  bool
+}
+
+type recordingPublisher struct {
+	published []publishedCandle
+}
+
+func (r *recordingPublisher) PublishCandle(_ context.Context, candle Candle, final bool) error {
+	r.published = append(r.published, publishedCandle{Candle: candle, Final: final})
 	return nil
 }
 
@@ -64,3 +79,104 @@ func TestServiceProcessTickWritesAllTimeframes(t *testing.T) {
 		}
 	}
 }
+
+func TestServicePublishesFormingAndClosedCandles(t *testing.T) {
+	publisher := &recordingPublisher{}
+	svc := NewService(&recordingStore{}, Config{Timeframes: []string{"1m"}})
+	svc.SetPublisher(publisher)
+
+	base := time.Date(2026, 2, 14, 12, 34, 0, 0, time.UTC)
+	for _, tick := range []Tick{
+		{Symbol: "BTC-USD", Price: 100, TS: base.Add(10 * time.Second)},
+		{Symbol: "BTC-USD", Price: 101, TS: base.Add(70 * time.Second)},
+		{Symbol: "BTC-USD", Price: 99, TS: base.Add(20 * time.Second)},
+	} {
+		if err := svc.ProcessTick(context.Background(), tick); err != nil {
+			t.Fatalf("process tick failed: %v", err)
+		}
+	}
+
+	want := []struct {
+		bucket time.Time
+		close  float64
+		final  bool
+	}{
+		{base, 100, false},
+		{base, 100, true},
+		{base.Add(time.Minute), 101, false},
+		{base, 99, true},
+	}
+	if len(publisher.published) != len(want) {
+		t.Fatalf("expected %d published candles, got %+v", len(want), publisher.published)
+	}
+	for i, expected := range want {
+		got := publisher.published[i]
+		if !got.Candle.BucketStart.Equal(expected.bucket) || got.Candle.Close != expected.close || got.Final != expected.final {
+			t.Fatalf("published[%d]: expected bucket=%s close=%f final=%t, got %+v", i, expected.bucket, expected.close, expected.final, got)
+		}
+	}
+}
+
+type failingPublisher struct {
+	recordingPublisher
+	failTimeframe string
+}
+
+func (f *failingPublisher) PublishCandle(ctx context.Context, candle Candle, final bool) error {
+	if candle.Timeframe == f.failTimeframe {
+		return errors.New("xadd failed")
+	}
+	return f.recordingPublisher.PublishCandle(ctx, candle, final)
+}
+
+func TestServiceStoresEveryTimeframeWhenPublishFails(t *testing.T) {
+	store := &recordingStore{}
+	publisher := &failingPublisher{failTimeframe: "1s"}
+	svc := NewService(store, Config{Timeframes: []string{"1s", "1m"}})
+	svc.SetPublisher(publisher)
+
+	err := svc.ProcessTick(context.Background(), Tick{Symbol: "BTC-USD", Price: 100, TS: time.Date(2026, 2, 14, 12, 34, 5, 0, time.UTC)})
+	if err == nil {
+		t.Fatal("expected the publish error to be reported")
+	}
+	if len(store.calls) != 2 {
+		t.Fatalf("expected both timeframes stored, got %+v", store.calls)
+	}
+	if len(publisher.published) != 1 || publisher.published[0].Candle.Timeframe != "1m" {
+		t.Fatalf("expected the 1m candle to still be published, got %+v", publisher.published)
+	}
+}
+
+func TestServiceFlushClosedFinalizesQuietBuckets(t *testing.T) {
+	publisher := &recordingPublisher{}
+	svc := NewService(&recordingStore{}, Config{Timeframes: []string{"1m"}})
+	svc.SetPublisher(publisher)
+
+	base := time.Date(2026, 2, 14, 12, 34, 0, 0, time.UTC)
+	if err := svc.ProcessTick(context.Background(), Tick{Symbol: "BTC-USD", Price: 100, TS: base.Add(10 * time.Second)}); err != nil {
+		t.Fatalf("process tick failed: %v", err)
+	}
+
+	if err := svc.FlushClosed(context.Background(), base.Add(time.Minute)); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	if len(publisher.published) != 1 {
+		t.Fatalf("expected no flush inside the grace period, got %+v", publisher.published)
+	}
+	if err := svc.FlushClosed(context.Background(), base.Add(time.Minute+closeGrace)); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	if err := svc.FlushClosed(context.Background(), base.Add(2*time.Minute)); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	if len(publisher.published) != 2 || !publisher.published[1].Final || publisher.published[1].Candle.Close != 100 {
+		t.Fatalf("expected one final candle from the flush, got %+v", publisher.published)
+	}
+
+	if err := svc.ProcessTick(context.Background(), Tick{Symbol: "BTC-USD", Price: 102, TS: base.Add(2 * time.Minute)}); err != nil {
+		t.Fatalf("process tick failed: %v", err)
+	}
+	if len(publisher.published) != 3 || publisher.published[2].Final {
+		t.Fatalf("expected only the new forming candle after a flushed bucket, got %+v", publisher.published)
+	}
+}
@@ -24,7 +24,7 @@ func NewRedisCandleStore(client redis.UniversalClient, prefix string) *RedisCand
 	return &RedisCandleStore{client: client, prefix: prefix}
 }
 
-func (s *RedisCandleStore) UpsertCandle(ctx context.Context, point candle.Candle, ttl time.Duration) error {
+func (s *RedisCandleStore) UpsertCandle(ctx context.Context, point candle.Candle, ttl time.Duration) (candle.Candle, error) {
 	if ttl <= 0 {
 		ttl = candle.DefaultCandleTTL
 	}
@@ -42,7 +42,24 @@ func (s *RedisCandleStore) UpsertCandle(ctx context.Context, point candle.Candle
 		strconv.FormatInt(int64(ttl.Seconds()), 10),
 	}
 
-	return upsertCandleScript.Run(ctx, s.client, []string{key}, args...).Err()
+	values, err := upsertCandleScript.Run(ctx, s.client, []string{key}, args...).StringSlice()
+	if err != nil {
+		return candle.Candle{}, err
+	}
+	if len(values) != 5 {
+		return candle.Candle{}, fmt.Errorf("unexpected upsert result %v", values)
+	}
+
+	merged := point
+	fields := []*float64{&merged.Open, &merged.High, &merged.Low, &merged.Close, &merged.Volume}
+	for i, field := range fields {
+		parsed, err := strconv.ParseFloat(values[i], 64)
+		if err != nil {
+			return candle.Candle{}, err
+		}
+		*field = parsed
+	}
+	return merged, nil
 }
 
 func (s *RedisCandleStore) candleKey(point candle.Candle) string {
@@ -99,5 +116,5 @@ redis.call('HSET', key,
   'volume', tostring(volume_next)
 )
 redis.call('EXPIRE', key, ttl_seconds)
-return 1
+return {open_cur, high_cur, low_cur, close_in, tostring(volume_next)}
 `)
@@ -27,12 +27,16 @@ func TestRedisCandleStoreUpsertCandleAggregatesOHLCV(t *testing.T) {
 	first := candle.Candle{Symbol: "BTC-USD", Timeframe: "1m", BucketStart: bucket, Open: 100, High: 100, Low: 100, Close: 100, Volume: 2}
 	second := candle.Candle{Symbol: "BTC-USD", Timeframe: "1m", BucketStart: bucket, Open: 110, High: 110, Low: 110, Close: 110, Volume: 3}
 
-	if err := store.UpsertCandle(context.Background(), first, 30*24*time.Hour); err != nil {
+	if _, err := store.UpsertCandle(context.Background(), first, 30*24*time.Hour); err != nil {
 		t.Fatalf("first upsert failed: %v", err)
 	}
-	if err := store.UpsertCandle(context.Background(), second, 30*24*time.Hour); err != nil {
+	merged, err := store.UpsertCandle(context.Background(), second, 30*24*time.Hour)
+	if err != nil {
 		t.Fatalf("second upsert failed: %v", err)
 	}
+	if merged.Open != 100 || merged.High != 110 || merged.Low != 100 || merged.Close != 110 || merged.Volume != 5 {
+		t.Fatalf("unexpected merged candle %+v", merged)
+	}
 
 	key := "v1:candle:BTC-USD:1m:2026-02-14T12:34:00Z"
 	values, err := client.HGetAll(context.Background(), key).Result()
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/candle-aggregator/internal/candle"
+)
+
+const candleStreamMaxLen = 100000
+
+// RedisCandleStreamPublisher appends every candle update to one stream for
+// the gateway to fan out.
+type RedisCandleStreamPublisher struct {
+	client redis.UniversalClient
+	stream string
+}
+
+func NewRedisCandleStreamPublisher(client redis.UniversalClient, stream string) *RedisCandleStreamPublisher {
+	stream = strings.TrimSpace(stream)
+	if stream == "" {
+		stream = "kalency:v1:stream:candles"
+	}
+	return &RedisCandleStreamPublisher{client: client, stream: stream}
+}
+
+func (p *RedisCandleStreamPublisher) PublishCandle(ctx context.Context, point candle.Candle, final bool) error {
+	return p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: candleStreamMaxLen,
+		Approx: true,
+		Values: map[string]any{
+			"symbol":       point.Symbol,
+			"timeframe":    point.Timeframe,
+			"bucket_start": point.BucketStart.UTC().Format(time.RFC3339),
+			"open":         strconv.FormatFloat(point.Open, 'f', -1, 64),
+			"high":         strconv.FormatFloat(point.High, 'f', -1, 64),
+			"low":          strconv.FormatFloat(point.Low, 'f', -1, 64),
+			"close":        strconv.FormatFloat(point.Close, 'f', -1, 64),
+			"volume":       strconv.FormatFloat(point.Volume, 'f', -1, 64),
+			"final":        strconv.FormatBool(final),
+		},
+	}).Err()
+}
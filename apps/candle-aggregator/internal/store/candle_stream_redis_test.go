@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/candle-aggregator/internal/candle"
+)
+
+func TestRedisCandleStreamPublisherAppendsUpdates(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	publisher := NewRedisCandleStreamPublisher(client, "")
+	point := candle.Candle{
+		Symbol:      "BTC-USD",
+		Timeframe:   "1m",
+		BucketStart: time.Date(2026, 2, 14, 12, 34, 0, 0, time.UTC),
+		Open:        100,
+		High:        110,
+		Low:         99.5,
+		Close:       105,
+		Volume:      5,
+	}
+	if err := publisher.PublishCandle(context.Background(), point, true); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	entries, err := client.XRange(context.Background(), "kalency:v1:stream:candles", "-", "+").Result()
+	if err != nil {
+		t.Fatalf("xrange failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	values := entries[0].Values
+	if values["symbol"] != "BTC-USD" || values["timeframe"] != "1m" || values["bucket_start"] != "2026-02-14T12:34:00Z" {
+		t.Fatalf("unexpected entry %v", values)
+	}
+	if values["low"] != "99.5" || values["volume"] != "5" || values["final"] != "true" {
+		t.Fatalf("unexpected OHLCV fields %v", values)
+	}
+}
@@ -2,19 +2,35 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/apikeys"
+	"kalency/apps/gateway-api/internal/audit"
+	"kalency/apps/gateway-api/internal/bookstream"
 	"kalency/apps/gateway-api/internal/candleclient"
+	"kalency/apps/gateway-api/internal/candlestream"
 	"kalency/apps/gateway-api/internal/gatewayapi"
+	"kalency/apps/gateway-api/internal/killswitch"
 	"kalency/apps/gateway-api/internal/marketsimclient"
 	"kalency/apps/gateway-api/internal/matchingclient"
+	"kalency/apps/gateway-api/internal/ratelimit"
+	"kalency/apps/gateway-api/internal/streamhub"
 	"kalency/apps/gateway-api/internal/tickstream"
+	"kalency/apps/gateway-api/internal/tradestream"
+	"kalency/apps/gateway-api/internal/userstream"
 )
 
+// killSwitchRefreshInterval bounds how long a kill switch set through another
+// gateway replica goes unseen here.
+const killSwitchRefreshInterval = 2 * time.Second
+
 func main() {
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -37,16 +53,59 @@ func main() {
 	if tickStreamKey == "" {
 		tickStreamKey = "kalency:v1:stream:ticks"
 	}
+	candleStreamKey := strings.TrimSpace(os.Getenv("CANDLE_UPDATE_STREAM"))
+	if candleStreamKey == "" {
+		candleStreamKey = "kalency:v1:stream:candles"
+	}
+	executionStreamKey := strings.TrimSpace(os.Getenv("EXECUTION_STREAM_KEY"))
+	if executionStreamKey == "" {
+		executionStreamKey = "kalency:v1:stream:executions"
+	}
 	marketSimURL := strings.TrimSpace(os.Getenv("MARKET_SIM_URL"))
+	tickPolicy := parseSlowConsumerPolicy(os.Getenv("TICK_SLOW_CONSUMER_POLICY"))
+	clientBuffer, _ := strconv.Atoi(strings.TrimSpace(os.Getenv("STREAM_CLIENT_BUFFER")))
 
 	jwtSecret := os.Getenv("JWT_SECRET")
 	if jwtSecret == "" {
 		jwtSecret = "dev-secret"
 	}
 
-	tradingClient := matchingclient.NewHTTPClient(matchingEngineURL)
-	candleService, tickSource, closeCandleService := newRedisIntegrations(candleRedisAddr, candleKeyPrefix, tickStreamKey)
-	defer closeCandleService()
+	devAuth, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("AUTH_DEV_MODE")))
+	accessTokenTTL, _ := time.ParseDuration(strings.TrimSpace(os.Getenv("ACCESS_TOKEN_TTL")))
+	signatureWindow, _ := time.ParseDuration(strings.TrimSpace(os.Getenv("SIGNATURE_WINDOW")))
+	apiKeys := parseAPIKeys(os.Getenv("API_KEYS"))
+	rateLimits := parseRateLimits(map[string]string{
+		gatewayapi.RouteClassOrders:     os.Getenv("RATE_LIMIT_ORDERS"),
+		gatewayapi.RouteClassMarketData: os.Getenv("RATE_LIMIT_MARKET_DATA"),
+		gatewayapi.RouteClassAuth:       os.Getenv("RATE_LIMIT_AUTH"),
+	})
+	persistence := newStores(os.Getenv("POSTGRES_DSN"))
+	defer persistence.close()
+	apiKeyService := apikeys.NewService(persistence.apiKeys)
+	if raw := strings.TrimSpace(os.Getenv("API_KEY_ENCRYPTION_KEY")); raw != "" {
+		encryptionKey, err := hex.DecodeString(raw)
+		if err == nil {
+			err = apiKeyService.SetEncryptionKey(encryptionKey)
+		}
+		if err != nil {
+			log.Fatalf("invalid API_KEY_ENCRYPTION_KEY (want 64 hex characters): %v", err)
+		}
+	} else if os.Getenv("POSTGRES_DSN") != "" {
+		log.Printf("API_KEY_ENCRYPTION_KEY not set; stored API keys cannot sign requests after a restart")
+	}
+
+	tradingClient, matchingEngineTarget := newTradingClient(matchingEngineURL, os.Getenv("MATCHING_ENGINE_GRPC_ADDR"))
+	integrations := newRedisIntegrations(candleRedisAddr, candleKeyPrefix, tickStreamKey, candleStreamKey, executionStreamKey, tickPolicy, clientBuffer)
+	defer integrations.close()
+
+	killSwitches, err := killswitch.NewService(context.Background(), persistence.killSwitches)
+	if err != nil {
+		log.Fatalf("failed to load kill switches: %v", err)
+	}
+	engineAdmin := matchingclient.NewHTTPClient(matchingEngineURL)
+	engineAdmin.SetAdminToken(os.Getenv("MATCHING_ENGINE_ADMIN_TOKEN"))
+	go syncKillSwitches(killSwitches, engineAdmin)
+	go killSwitches.Run(context.Background(), killSwitchRefreshInterval)
 
 	var adminService gatewayapi.AdminService
 	if marketSimURL != "" {
@@ -54,29 +113,168 @@ func main() {
 	}
 
 	apiServer := gatewayapi.NewServer(gatewayapi.Config{
-		JWTSecret:     jwtSecret,
-		APIKeys:       parseAPIKeys(os.Getenv("API_KEYS")),
-		CandleService: candleService,
-		AdminService:  adminService,
-		TickSource:    tickSource,
+		JWTSecret:       jwtSecret,
+		AccessTokenTTL:  accessTokenTTL,
+		DevAuth:         devAuth,
+		Accounts:        accounts.NewService(persistence.accounts),
+		APIKeys:         apiKeys,
+		APIKeyService:   apiKeyService,
+		AuditLog:        persistence.audit,
+		SignatureWindow: signatureWindow,
+		CandleService:   integrations.candleService,
+		AdminService:    adminService,
+		TickHub:         integrations.tickHub,
+		TradeHub:        integrations.tradeHub,
+		BookHubs:        integrations.bookHubs,
+		UserEventHub:    integrations.userEventHub,
+		CandleHub:       integrations.candleHub,
+		RateLimiter:     integrations.rateLimiter,
+		RateLimits:      rateLimits,
+
+		KillSwitches:     killSwitches,
+		KillSwitchEngine: engineAdmin,
+
+		FundingAdmin: engineAdmin,
 	}, tradingClient)
 
 	addr := ":" + port
 	log.Printf(
-		"gateway-api listening on %s (matching-engine=%s candles-enabled=%t market-sim=%q)",
+		"gateway-api listening on %s (matching-engine=%s candles-enabled=%t market-sim=%q dev-auth=%t rate-limits=%t)",
 		addr,
-		matchingEngineURL,
-		candleService != nil,
+		matchingEngineTarget,
+		integrations.candleService != nil,
 		marketSimURL,
+		devAuth,
+		integrations.rateLimiter != nil,
 	)
 	if err := apiServer.Listen(addr); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func newRedisIntegrations(redisAddr, keyPrefix, tickStreamKey string) (gatewayapi.CandleService, gatewayapi.TickSource, func()) {
+type stores struct {
+	accounts     accounts.Store
+	apiKeys      apikeys.Store
+	audit        audit.Log
+	killSwitches killswitch.Store
+	close        func()
+}
+
+// newStores keeps users, API keys, the admin audit log and kill switches in
+// PostgreSQL when dsn is set. Without it they live in memory and are lost on
+// restart.
+func newStores(dsn string) stores {
+	dsn = strings.TrimSpace(dsn)
+	if dsn == "" {
+		log.Printf("account, API key, audit and kill switch stores are in memory (POSTGRES_DSN not set)")
+		return stores{
+			accounts:     accounts.NewMemoryStore(),
+			apiKeys:      apikeys.NewMemoryStore(),
+			audit:        audit.NewMemoryLog(),
+			killSwitches: killswitch.NewMemoryStore(),
+			close:        func() {},
+		}
+	}
+
+	// PostgreSQL may still be starting when the gateway comes up under compose.
+	var (
+		accountStore *accounts.PostgresStore
+		err          error
+	)
+	for attempt := 0; attempt < 15; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		accountStore, err = accounts.NewPostgresStore(ctx, dsn)
+		cancel()
+		if err == nil {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		log.Fatalf("postgres connect failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	keyStore, err := apikeys.NewPostgresStore(ctx, dsn)
+	if err != nil {
+		accountStore.Close()
+		log.Fatalf("postgres connect failed: %v", err)
+	}
+	auditLog, err := audit.NewPostgresLog(ctx, dsn)
+	if err != nil {
+		keyStore.Close()
+		accountStore.Close()
+		log.Fatalf("postgres connect failed: %v", err)
+	}
+	killSwitchStore, err := killswitch.NewPostgresStore(ctx, dsn)
+	if err != nil {
+		auditLog.Close()
+		keyStore.Close()
+		accountStore.Close()
+		log.Fatalf("postgres connect failed: %v", err)
+	}
+	return stores{
+		accounts:     accountStore,
+		apiKeys:      keyStore,
+		audit:        auditLog,
+		killSwitches: killSwitchStore,
+		close: func() {
+			killSwitchStore.Close()
+			auditLog.Close()
+			keyStore.Close()
+			accountStore.Close()
+		},
+	}
+}
+
+// syncKillSwitches re-applies stored kill switches on the matching engine,
+// retrying while it starts up.
+func syncKillSwitches(killSwitches *killswitch.Service, engine gatewayapi.KillSwitchEngine) {
+	if len(killSwitches.List()) == 0 {
+		return
+	}
+	var err error
+	for attempt := 0; attempt < 15; attempt++ {
+		if err = gatewayapi.SyncKillSwitches(killSwitches, engine); err == nil {
+			log.Printf("re-applied %d kill switches on the matching engine", len(killSwitches.List()))
+			return
+		}
+		time.Sleep(2 * time.Second)
+	}
+	log.Printf("kill switch sync with the matching engine failed: %v", err)
+}
+
+// newTradingClient uses the engine's gRPC API when grpcAddr is set and falls
+// back to JSON HTTP otherwise. It also returns the target for logging.
+func newTradingClient(httpURL, grpcAddr string) (gatewayapi.TradingService, string) {
+	grpcAddr = strings.TrimSpace(grpcAddr)
+	if grpcAddr == "" {
+		return matchingclient.NewHTTPClient(httpURL), httpURL
+	}
+	client, err := matchingclient.NewGRPCClient(grpcAddr)
+	if err != nil {
+		log.Printf("matching-engine gRPC client disabled, using HTTP: %v", err)
+		return matchingclient.NewHTTPClient(httpURL), httpURL
+	}
+	return client, "grpc://" + grpcAddr
+}
+
+type redisIntegrations struct {
+	candleService gatewayapi.CandleService
+	tickHub       *streamhub.Hub
+	tradeHub      *streamhub.Hub
+	bookHubs      *streamhub.Registry
+	userEventHub  *streamhub.Hub
+	candleHub     *streamhub.Hub
+	rateLimiter   gatewayapi.RateLimiter
+	close         func()
+}
+
+func newRedisIntegrations(redisAddr, keyPrefix, tickStreamKey, candleStreamKey, executionStreamKey string, tickPolicy streamhub.SlowConsumerPolicy, clientBuffer int) redisIntegrations {
+	disabled := redisIntegrations{close: func() {}}
 	if strings.TrimSpace(redisAddr) == "" {
-		return nil, nil, func() {}
+		return disabled
 	}
 
 	client := redis.NewClient(&redis.Options{Addr: redisAddr})
@@ -85,15 +283,68 @@ func newRedisIntegrations(redisAddr, keyPrefix, tickStreamKey string) (gatewayap
 	if err := client.Ping(ctx).Err(); err != nil {
 		log.Printf("candle integration disabled (redis ping failed): %v", err)
 		_ = client.Close()
-		return nil, nil, func() {}
+		return disabled
 	}
 
-	log.Printf("redis integration enabled (redis=%s prefix=%s tickStream=%s)", redisAddr, keyPrefix, tickStreamKey)
-	return candleclient.NewRedisClient(client, keyPrefix), tickstream.NewRedisTickStreamSource(client, tickStreamKey), func() {
-		_ = client.Close()
+	log.Printf("redis integration enabled (redis=%s prefix=%s tickStream=%s executionStream=%s)", redisAddr, keyPrefix, tickStreamKey, executionStreamKey)
+	hubCtx, stopHubs := context.WithCancel(context.Background())
+	tickHub := streamhub.NewHub(tickstream.NewRedisTickStreamSource(client, tickStreamKey), clientBuffer)
+	tickHub.SetSlowConsumerPolicy(tickPolicy)
+	go tickHub.Run(hubCtx)
+	tradeHub := streamhub.NewHub(tradestream.NewRedisTradeStreamSource(client, executionStreamKey), clientBuffer)
+	go tradeHub.Run(hubCtx)
+	candleHub := streamhub.NewHub(candlestream.NewRedisCandleStreamSource(client, candleStreamKey), clientBuffer)
+	go candleHub.Run(hubCtx)
+	userEventHub := streamhub.NewHub(userstream.NewRedisUserEventSource(client, "kalency:v1:stream:user-events"), clientBuffer)
+	go userEventHub.Run(hubCtx)
+
+	return redisIntegrations{
+		candleService: candleclient.NewRedisClient(client, keyPrefix),
+		tickHub:       tickHub,
+		tradeHub:      tradeHub,
+		bookHubs: streamhub.NewRegistry(hubCtx, func(symbol string) streamhub.Source {
+			return bookstream.NewRedisBookStreamSource(client, "kalency:v1", symbol)
+		}, clientBuffer),
+		userEventHub: userEventHub,
+		candleHub:    candleHub,
+		rateLimiter:  ratelimit.NewRedisLimiter(client, keyPrefix),
+		close: func() {
+			stopHubs()
+			_ = client.Close()
+		},
+	}
+}
+
+// parseSlowConsumerPolicy defaults to conflation: a tick viewer only needs
+// the latest price.
+func parseSlowConsumerPolicy(raw string) streamhub.SlowConsumerPolicy {
+	if strings.EqualFold(strings.TrimSpace(raw), "disconnect") {
+		return streamhub.DropSlowConsumer
+	}
+	return streamhub.ConflateSlowConsumer
+}
+
+// parseRateLimits reads requests/window overrides per route class; classes
+// left empty keep the gateway defaults.
+func parseRateLimits(raw map[string]string) map[string]ratelimit.Limit {
+	limits := map[string]ratelimit.Limit{}
+	for class, value := range raw {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		limit, err := ratelimit.ParseLimit(value)
+		if err != nil {
+			log.Printf("ignoring %s rate limit %q: %v", class, value, err)
+			continue
+		}
+		limits[class] = limit
 	}
+	return limits
 }
 
+// parseAPIKeys reads key:user entries. Static keys always act as traders, so
+// a trailing operator or admin role is logged and dropped rather than read as
+// part of the user ID.
 func parseAPIKeys(raw string) map[string]string {
 	result := map[string]string{}
 	raw = strings.TrimSpace(raw)
@@ -101,18 +352,37 @@ func parseAPIKeys(raw string) map[string]string {
 		return result
 	}
 
-	pairs := strings.Split(raw, ",")
-	for _, pair := range pairs {
-		parts := strings.Split(strings.TrimSpace(pair), ":")
-		if len(parts) != 2 {
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
 			continue
 		}
-		apiKey := strings.TrimSpace(parts[0])
-		userID := strings.TrimSpace(parts[1])
+		apiKey, userID, _ := strings.Cut(entry, ":")
+		apiKey = strings.TrimSpace(apiKey)
+		userID = strings.TrimSpace(userID)
+		role := ""
+		if i := strings.LastIndex(userID, ":"); i >= 0 {
+			if candidate := strings.ToLower(strings.TrimSpace(userID[i+1:])); accounts.RoleRank(candidate) > 0 {
+				role = candidate
+				userID = strings.TrimSpace(userID[:i])
+			}
+		}
 		if apiKey == "" || userID == "" {
+			log.Printf("ignoring API_KEYS entry %q: want key:user", redactKey(entry, apiKey))
 			continue
 		}
+		if role != "" && role != accounts.RoleTrader {
+			log.Printf("API_KEYS entry %q: static keys act as traders; use a stored key or account for %s access", redactKey(entry, apiKey), role)
+		}
 		result[apiKey] = userID
 	}
 	return result
 }
+
+// redactKey keeps the key itself out of the logs.
+func redactKey(entry, apiKey string) string {
+	if apiKey == "" {
+		return entry
+	}
+	return "***" + strings.TrimPrefix(entry, apiKey)
+}
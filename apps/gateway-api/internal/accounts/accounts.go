@@ -0,0 +1,262 @@
+package accounts
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Roles are ordered: each one can do everything the previous one can.
+const (
+	RoleTrader   = "trader"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+const (
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+	minPasswordLength      = 8
+	// bcrypt ignores everything past 72 bytes.
+	maxPasswordLength = 72
+)
+
+var (
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUsernameTaken        = errors.New("username is already taken")
+	ErrInvalidCredentials   = errors.New("invalid username or password")
+	ErrInvalidRefreshToken  = errors.New("invalid refresh token")
+	ErrInvalidUsername      = errors.New("username must be 3 to 64 characters of a-z, 0-9, '_', '-' or '.'")
+	ErrInvalidPassword      = errors.New("password must be 8 to 72 characters")
+	ErrInvalidRole          = errors.New("role must be trader, operator or admin")
+	errRefreshTokenConsumed = errors.New("refresh token already used")
+)
+
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+	Role         string
+	CreatedAt    time.Time
+}
+
+// RoleRank orders roles for comparison; unknown roles rank below trader.
+func RoleRank(role string) int {
+	switch role {
+	case RoleTrader:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// RefreshToken is stored by the SHA-256 of its secret. Every token issued by
+// rotation shares the FamilyID of the login that started the chain.
+type RefreshToken struct {
+	Hash      string
+	UserID    string
+	FamilyID  string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type Store interface {
+	// CreateUser returns ErrUsernameTaken when the username exists.
+	CreateUser(ctx context.Context, user User) error
+	// UserByUsername returns ErrUserNotFound when there is no such user.
+	UserByUsername(ctx context.Context, username string) (User, error)
+	// UserByID returns ErrUserNotFound when there is no such user.
+	UserByID(ctx context.Context, userID string) (User, error)
+	// SetRole returns ErrUserNotFound when there is no such user.
+	SetRole(ctx context.Context, userID, role string) (User, error)
+	CreateRefreshToken(ctx context.Context, token RefreshToken) error
+	// ConsumeRefreshToken marks an unrevoked token as used and returns it.
+	// A token that was already used or revoked is returned with
+	// errRefreshTokenConsumed; an unknown one with ErrInvalidRefreshToken.
+	ConsumeRefreshToken(ctx context.Context, hash string, now time.Time) (RefreshToken, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string, now time.Time) error
+	// CreateSubAccount returns ErrSubAccountNameTaken when the user already
+	// has a sub-account with the name.
+	CreateSubAccount(ctx context.Context, account SubAccount) error
+	// SubAccounts lists a user's sub-accounts, oldest first.
+	SubAccounts(ctx context.Context, userID string) ([]SubAccount, error)
+	// SubAccount returns ErrSubAccountNotFound unless the user owns it.
+	SubAccount(ctx context.Context, userID, accountID string) (SubAccount, error)
+}
+
+type Service struct {
+	store      Store
+	refreshTTL time.Duration
+	now        func() time.Time
+	// dummyHash is compared against when the username is unknown so login
+	// takes the same time whether or not the user exists.
+	dummyHash []byte
+}
+
+func NewService(store Store) *Service {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("kalency-dummy-password"), bcrypt.DefaultCost)
+	return &Service{
+		store:      store,
+		refreshTTL: defaultRefreshTokenTTL,
+		now:        func() time.Time { return time.Now().UTC() },
+		dummyHash:  dummyHash,
+	}
+}
+
+func (s *Service) Register(ctx context.Context, username, password string) (User, error) {
+	username = normalizeUsername(username)
+	if err := validateUsername(username); err != nil {
+		return User{}, err
+	}
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return User{}, ErrInvalidPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return User{}, err
+	}
+	id, err := randomHex(12)
+	if err != nil {
+		return User{}, err
+	}
+	user := User{
+		ID:           "usr-" + id,
+		Username:     username,
+		PasswordHash: string(hash),
+		Role:         RoleTrader,
+		CreatedAt:    s.now(),
+	}
+	if err := s.store.CreateUser(ctx, user); err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+func (s *Service) Login(ctx context.Context, username, password string) (User, error) {
+	user, err := s.store.UserByUsername(ctx, normalizeUsername(username))
+	if errors.Is(err, ErrUserNotFound) {
+		_ = bcrypt.CompareHashAndPassword(s.dummyHash, []byte(password))
+		return User{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return User{}, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return User{}, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+func (s *Service) User(ctx context.Context, userID string) (User, error) {
+	return s.store.UserByID(ctx, userID)
+}
+
+func (s *Service) SetRole(ctx context.Context, userID, role string) (User, error) {
+	if RoleRank(role) == 0 {
+		return User{}, ErrInvalidRole
+	}
+	return s.store.SetRole(ctx, userID, role)
+}
+
+// IssueRefreshToken starts a new token family for a fresh login.
+func (s *Service) IssueRefreshToken(ctx context.Context, userID string) (string, error) {
+	familyID, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+	return s.issueRefreshToken(ctx, userID, familyID)
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one in the same
+// family. Presenting a token that was already exchanged revokes the whole
+// family, since either the client or an attacker holds a stolen copy.
+func (s *Service) RotateRefreshToken(ctx context.Context, token string) (userID, next string, err error) {
+	now := s.now()
+	record, err := s.store.ConsumeRefreshToken(ctx, hashToken(token), now)
+	if errors.Is(err, errRefreshTokenConsumed) {
+		_ = s.store.RevokeRefreshTokenFamily(ctx, record.FamilyID, now)
+		return "", "", ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if !now.Before(record.ExpiresAt) {
+		return "", "", ErrInvalidRefreshToken
+	}
+
+	next, err = s.issueRefreshToken(ctx, record.UserID, record.FamilyID)
+	if err != nil {
+		return "", "", err
+	}
+	return record.UserID, next, nil
+}
+
+// RevokeRefreshToken logs out the session the token belongs to.
+func (s *Service) RevokeRefreshToken(ctx context.Context, token string) error {
+	now := s.now()
+	record, err := s.store.ConsumeRefreshToken(ctx, hashToken(token), now)
+	if err != nil && !errors.Is(err, errRefreshTokenConsumed) {
+		return err
+	}
+	return s.store.RevokeRefreshTokenFamily(ctx, record.FamilyID, now)
+}
+
+func (s *Service) issueRefreshToken(ctx context.Context, userID, familyID string) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	now := s.now()
+	err := s.store.CreateRefreshToken(ctx, RefreshToken{
+		Hash:      hashToken(token),
+		UserID:    userID,
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(s.refreshTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func validateUsername(username string) error {
+	if len(username) < 3 || len(username) > 64 {
+		return ErrInvalidUsername
+	}
+	for _, r := range username {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' && r != '-' && r != '.' {
+			return ErrInvalidUsername
+		}
+	}
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,118 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegisterAndLogin(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore())
+
+	user, err := svc.Register(ctx, " Alice ", "correct-horse")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if user.Username != "alice" || !strings.HasPrefix(user.ID, "usr-") {
+		t.Fatalf("unexpected user %+v", user)
+	}
+	if user.PasswordHash == "correct-horse" {
+		t.Fatalf("password stored in plain text")
+	}
+
+	if _, err := svc.Register(ctx, "alice", "another-password"); !errors.Is(err, ErrUsernameTaken) {
+		t.Fatalf("expected ErrUsernameTaken, got %v", err)
+	}
+
+	loggedIn, err := svc.Login(ctx, "ALICE", "correct-horse")
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if loggedIn.ID != user.ID {
+		t.Fatalf("expected user %s, got %s", user.ID, loggedIn.ID)
+	}
+	if _, err := svc.Login(ctx, "alice", "wrong-password"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials for wrong password, got %v", err)
+	}
+	if _, err := svc.Login(ctx, "bob", "correct-horse"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials for unknown user, got %v", err)
+	}
+}
+
+func TestRegisterValidatesInput(t *testing.T) {
+	svc := NewService(NewMemoryStore())
+	if _, err := svc.Register(context.Background(), "a!", "correct-horse"); !errors.Is(err, ErrInvalidUsername) {
+		t.Fatalf("expected ErrInvalidUsername, got %v", err)
+	}
+	if _, err := svc.Register(context.Background(), "alice", "short"); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("expected ErrInvalidPassword, got %v", err)
+	}
+}
+
+func TestRotateRefreshTokenRevokesFamilyOnReuse(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore())
+
+	first, err := svc.IssueRefreshToken(ctx, "usr-1")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	userID, second, err := svc.RotateRefreshToken(ctx, first)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if userID != "usr-1" || second == first {
+		t.Fatalf("unexpected rotation result %q %q", userID, second)
+	}
+
+	if _, _, err := svc.RotateRefreshToken(ctx, first); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected reused token to be rejected, got %v", err)
+	}
+	if _, _, err := svc.RotateRefreshToken(ctx, second); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected family to be revoked after reuse, got %v", err)
+	}
+}
+
+func TestRotateRefreshTokenRejectsExpiredAndUnknown(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore())
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	token, err := svc.IssueRefreshToken(ctx, "usr-1")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	now = now.Add(defaultRefreshTokenTTL)
+	if _, _, err := svc.RotateRefreshToken(ctx, token); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected expired token to be rejected, got %v", err)
+	}
+	if _, _, err := svc.RotateRefreshToken(ctx, "unknown"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected unknown token to be rejected, got %v", err)
+	}
+}
+
+func TestRevokeRefreshTokenEndsSession(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore())
+
+	first, _ := svc.IssueRefreshToken(ctx, "usr-1")
+	_, second, err := svc.RotateRefreshToken(ctx, first)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	other, _ := svc.IssueRefreshToken(ctx, "usr-1")
+
+	if err := svc.RevokeRefreshToken(ctx, second); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, _, err := svc.RotateRefreshToken(ctx, second); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected revoked token to be rejected, got %v", err)
+	}
+	if _, _, err := svc.RotateRefreshToken(ctx, other); err != nil {
+		t.Fatalf("expected other session to survive logout, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package accounts
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps accounts in process memory. It is meant for tests and
+// local runs without PostgreSQL; everything is lost on restart.
+type MemoryStore struct {
+	mu      sync.Mutex
+	users   map[string]User
+	tokens  map[string]RefreshToken
+	revoked map[string]time.Time
+	subs    map[string][]SubAccount
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:   map[string]User{},
+		tokens:  map[string]RefreshToken{},
+		revoked: map[string]time.Time{},
+		subs:    map[string][]SubAccount{},
+	}
+}
+
+func (m *MemoryStore) CreateUser(_ context.Context, user User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.users[user.Username]; exists {
+		return ErrUsernameTaken
+	}
+	m.users[user.Username] = user
+	return nil
+}
+
+func (m *MemoryStore) UserByUsername(_ context.Context, username string) (User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	user, ok := m.users[username]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (m *MemoryStore) UserByID(_ context.Context, userID string) (User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, user := range m.users {
+		if user.ID == userID {
+			return user, nil
+		}
+	}
+	return User{}, ErrUserNotFound
+}
+
+func (m *MemoryStore) SetRole(_ context.Context, userID, role string) (User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for username, user := range m.users {
+		if user.ID == userID {
+			user.Role = role
+			m.users[username] = user
+			return user, nil
+		}
+	}
+	return User{}, ErrUserNotFound
+}
+
+func (m *MemoryStore) CreateRefreshToken(_ context.Context, token RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[token.Hash] = token
+	return nil
+}
+
+func (m *MemoryStore) ConsumeRefreshToken(_ context.Context, hash string, now time.Time) (RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[hash]
+	if !ok {
+		return RefreshToken{}, ErrInvalidRefreshToken
+	}
+	if _, revoked := m.revoked[hash]; revoked {
+		return token, errRefreshTokenConsumed
+	}
+	m.revoked[hash] = now
+	return token, nil
+}
+
+func (m *MemoryStore) RevokeRefreshTokenFamily(_ context.Context, familyID string, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, token := range m.tokens {
+		if _, revoked := m.revoked[hash]; token.FamilyID == familyID && !revoked {
+			m.revoked[hash] = now
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) CreateSubAccount(_ context.Context, account SubAccount) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.subs[account.UserID] {
+		if existing.Name == account.Name {
+			return ErrSubAccountNameTaken
+		}
+	}
+	m.subs[account.UserID] = append(m.subs[account.UserID], account)
+	return nil
+}
+
+func (m *MemoryStore) SubAccounts(_ context.Context, userID string) ([]SubAccount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]SubAccount{}, m.subs[userID]...), nil
+}
+
+func (m *MemoryStore) SubAccount(_ context.Context, userID, accountID string) (SubAccount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, account := range m.subs[userID] {
+		if account.ID == accountID {
+			return account, nil
+		}
+	}
+	return SubAccount{}, ErrSubAccountNotFound
+}
@@ -0,0 +1,166 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	uniqueViolation = "23505"
+	userColumns     = `user_id, username, password_hash, role, created_at`
+)
+
+// PostgresStore keeps accounts in the users, refresh_tokens and sub_accounts
+// tables created by docker/postgres-init.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+	pool, err := pgxpool.New(ctx, strings.TrimSpace(dsn))
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return &PostgresStore{pool: pool}, nil
+}
+
+func (s *PostgresStore) Close() {
+	if s.pool != nil {
+		s.pool.Close()
+	}
+}
+
+func (s *PostgresStore) CreateUser(ctx context.Context, user User) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO users (user_id, username, password_hash, role, created_at)
+		VALUES ($1,$2,$3,$4,$5)
+	`, user.ID, user.Username, user.PasswordHash, user.Role, user.CreatedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrUsernameTaken
+	}
+	return err
+}
+
+func (s *PostgresStore) UserByUsername(ctx context.Context, username string) (User, error) {
+	return s.queryUser(ctx, `SELECT `+userColumns+` FROM users WHERE username = $1`, username)
+}
+
+func (s *PostgresStore) UserByID(ctx context.Context, userID string) (User, error) {
+	return s.queryUser(ctx, `SELECT `+userColumns+` FROM users WHERE user_id = $1`, userID)
+}
+
+func (s *PostgresStore) SetRole(ctx context.Context, userID, role string) (User, error) {
+	return s.queryUser(ctx, `UPDATE users SET role = $2 WHERE user_id = $1 RETURNING `+userColumns, userID, role)
+}
+
+func (s *PostgresStore) queryUser(ctx context.Context, query string, args ...any) (User, error) {
+	var user User
+	err := s.pool.QueryRow(ctx, query, args...).Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Role, &user.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return User{}, ErrUserNotFound
+	}
+	return user, err
+}
+
+func (s *PostgresStore) CreateRefreshToken(ctx context.Context, token RefreshToken) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO refresh_tokens (token_hash, user_id, family_id, expires_at, created_at)
+		VALUES ($1,$2,$3,$4,$5)
+	`, token.Hash, token.UserID, token.FamilyID, token.ExpiresAt, token.CreatedAt)
+	return err
+}
+
+func (s *PostgresStore) ConsumeRefreshToken(ctx context.Context, hash string, now time.Time) (RefreshToken, error) {
+	token := RefreshToken{Hash: hash}
+	err := s.pool.QueryRow(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $2
+		WHERE token_hash = $1 AND revoked_at IS NULL
+		RETURNING user_id, family_id, expires_at, created_at
+	`, hash, now).Scan(&token.UserID, &token.FamilyID, &token.ExpiresAt, &token.CreatedAt)
+	if err == nil {
+		return token, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return RefreshToken{}, err
+	}
+
+	err = s.pool.QueryRow(ctx, `
+		SELECT user_id, family_id, expires_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`, hash).Scan(&token.UserID, &token.FamilyID, &token.ExpiresAt, &token.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return RefreshToken{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return RefreshToken{}, err
+	}
+	return token, errRefreshTokenConsumed
+}
+
+func (s *PostgresStore) RevokeRefreshTokenFamily(ctx context.Context, familyID string, now time.Time) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $2
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`, familyID, now)
+	return err
+}
+
+func (s *PostgresStore) CreateSubAccount(ctx context.Context, account SubAccount) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO sub_accounts (account_id, user_id, name, created_at)
+		VALUES ($1,$2,$3,$4)
+	`, account.ID, account.UserID, account.Name, account.CreatedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrSubAccountNameTaken
+	}
+	return err
+}
+
+func (s *PostgresStore) SubAccounts(ctx context.Context, userID string) ([]SubAccount, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT account_id, user_id, name, created_at
+		FROM sub_accounts
+		WHERE user_id = $1
+		ORDER BY created_at, account_id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []SubAccount{}
+	for rows.Next() {
+		var account SubAccount
+		if err := rows.Scan(&account.ID, &account.UserID, &account.Name, &account.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, account)
+	}
+	return out, rows.Err()
+}
+
+func (s *PostgresStore) SubAccount(ctx context.Context, userID, accountID string) (SubAccount, error) {
+	var account SubAccount
+	err := s.pool.QueryRow(ctx, `
+		SELECT account_id, user_id, name, created_at
+		FROM sub_accounts
+		WHERE user_id = $1 AND account_id = $2
+	`, userID, accountID).Scan(&account.ID, &account.UserID, &account.Name, &account.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return SubAccount{}, ErrSubAccountNotFound
+	}
+	return account, err
+}
@@ -0,0 +1,74 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+const maxSubAccounts = 20
+
+var (
+	ErrSubAccountNotFound    = errors.New("sub-account not found")
+	ErrSubAccountNameTaken   = errors.New("sub-account name is already taken")
+	ErrInvalidSubAccountName = errors.New("sub-account name must be 1 to 64 characters")
+	ErrTooManySubAccounts    = errors.New("a user can have at most 20 sub-accounts")
+)
+
+// SubAccount has its own wallet and open orders on the matching engine,
+// which keys them by TradingUserID.
+type SubAccount struct {
+	ID        string
+	UserID    string
+	Name      string
+	CreatedAt time.Time
+}
+
+// TradingUserID is the user ID the matching engine knows an account by: the
+// user ID for the main account and "{userID}:{accountID}" for a sub-account.
+func TradingUserID(userID, accountID string) string {
+	if accountID == "" {
+		return userID
+	}
+	return userID + ":" + accountID
+}
+
+// ParentUserID is the user a trading user ID belongs to.
+func ParentUserID(tradingUserID string) string {
+	userID, _, _ := strings.Cut(tradingUserID, ":")
+	return userID
+}
+
+func (s *Service) CreateSubAccount(ctx context.Context, userID, name string) (SubAccount, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 64 {
+		return SubAccount{}, ErrInvalidSubAccountName
+	}
+	existing, err := s.store.SubAccounts(ctx, userID)
+	if err != nil {
+		return SubAccount{}, err
+	}
+	if len(existing) >= maxSubAccounts {
+		return SubAccount{}, ErrTooManySubAccounts
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return SubAccount{}, err
+	}
+	account := SubAccount{ID: "sub-" + id, UserID: userID, Name: name, CreatedAt: s.now()}
+	if err := s.store.CreateSubAccount(ctx, account); err != nil {
+		return SubAccount{}, err
+	}
+	return account, nil
+}
+
+func (s *Service) SubAccounts(ctx context.Context, userID string) ([]SubAccount, error) {
+	return s.store.SubAccounts(ctx, userID)
+}
+
+// SubAccount returns ErrSubAccountNotFound unless accountID belongs to userID.
+func (s *Service) SubAccount(ctx context.Context, userID, accountID string) (SubAccount, error) {
+	return s.store.SubAccount(ctx, userID, strings.TrimSpace(accountID))
+}
@@ -0,0 +1,39 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSubAccountsBelongToTheirUser(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore())
+
+	account, err := svc.CreateSubAccount(ctx, "usr-1", " market-making ")
+	if err != nil {
+		t.Fatalf("create sub-account: %v", err)
+	}
+	if account.Name != "market-making" || !strings.HasPrefix(account.ID, "sub-") {
+		t.Fatalf("unexpected sub-account %+v", account)
+	}
+	if _, err := svc.CreateSubAccount(ctx, "usr-1", "market-making"); !errors.Is(err, ErrSubAccountNameTaken) {
+		t.Fatalf("expected ErrSubAccountNameTaken, got %v", err)
+	}
+	if _, err := svc.CreateSubAccount(ctx, "usr-1", " "); !errors.Is(err, ErrInvalidSubAccountName) {
+		t.Fatalf("expected ErrInvalidSubAccountName, got %v", err)
+	}
+
+	if _, err := svc.SubAccount(ctx, "usr-1", account.ID); err != nil {
+		t.Fatalf("lookup own sub-account: %v", err)
+	}
+	if _, err := svc.SubAccount(ctx, "usr-2", account.ID); !errors.Is(err, ErrSubAccountNotFound) {
+		t.Fatalf("expected ErrSubAccountNotFound for another user, got %v", err)
+	}
+
+	tradingUserID := TradingUserID("usr-1", account.ID)
+	if tradingUserID != "usr-1:"+account.ID || ParentUserID(tradingUserID) != "usr-1" || TradingUserID("usr-1", "") != "usr-1" {
+		t.Fatalf("unexpected trading user id %q", tradingUserID)
+	}
+}
@@ -0,0 +1,397 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"kalency/apps/gateway-api/signing"
+)
+
+// Read and trade are capabilities; operator and admin additionally grant the
+// gateway role of the same name.
+const (
+	ScopeRead     = "read"
+	ScopeTrade    = "trade"
+	ScopeOperator = "operator"
+	ScopeAdmin    = "admin"
+
+	keyPrefix        = "kk_"
+	defaultCacheTTL  = 30 * time.Second
+	lastUsedInterval = time.Minute
+	maxKeysPerUser   = 50
+)
+
+var (
+	ErrKeyNotFound      = errors.New("API key not found")
+	ErrStoreUnavailable = errors.New("API key store unavailable")
+	ErrInvalidKey       = errors.New("invalid API key")
+	ErrKeyExpired       = errors.New("API key expired")
+	ErrIPNotAllowed     = errors.New("API key is not allowed from this IP")
+	ErrTooManyKeys      = errors.New("API key limit reached")
+	ErrInvalidScope     = errors.New("scopes must be read, trade, operator or admin")
+	ErrInvalidIP        = errors.New("allowedIps entries must be IP addresses or CIDR prefixes")
+	ErrInvalidExpires   = errors.New("expiresAt must be in the future")
+	ErrCannotSign       = errors.New("API key has no usable signing secret; create a new key")
+	ErrEncryptionKey    = errors.New("API key encryption key must be 32 bytes")
+)
+
+// Key is the stored form of an API key. Only the SHA-256 of the secret is
+// kept; the secret itself is returned once, from Create. SigningSecret is the
+// HMAC key for signed requests sealed with the service's encryption key, so
+// the stored row alone can neither authenticate nor sign. A key with an
+// AccountID trades only that sub-account.
+type Key struct {
+	ID            string
+	UserID        string
+	AccountID     string
+	Name          string
+	Hash          string
+	SigningSecret []byte
+	Scopes        []string
+	AllowedIPs    []string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+	LastUsedAt    time.Time
+	RevokedAt     time.Time
+}
+
+func (k Key) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+type Store interface {
+	CreateKey(ctx context.Context, key Key) error
+	// KeyByHash returns ErrKeyNotFound when no key has the hash.
+	KeyByHash(ctx context.Context, hash string) (Key, error)
+	// KeyByID returns ErrKeyNotFound when no key has the id.
+	KeyByID(ctx context.Context, keyID string) (Key, error)
+	ListKeys(ctx context.Context, userID string) ([]Key, error)
+	// RevokeKey returns ErrKeyNotFound unless the user owns an unrevoked key
+	// with the id.
+	RevokeKey(ctx context.Context, userID, keyID string, now time.Time) (Key, error)
+	TouchKey(ctx context.Context, keyID string, now time.Time) error
+}
+
+type CreateRequest struct {
+	AccountID  string
+	Name       string
+	Scopes     []string
+	AllowedIPs []string
+	ExpiresAt  time.Time
+}
+
+type cachedKey struct {
+	key       Key
+	fetchedAt time.Time
+}
+
+// Service creates keys and authenticates requests against them. Lookups go
+// through an in-process cache, so a key revoked on another gateway instance
+// keeps working here for up to the cache TTL.
+type Service struct {
+	store    Store
+	cacheTTL time.Duration
+	now      func() time.Time
+	sealer   cipher.AEAD
+
+	mu    sync.Mutex
+	cache map[string]cachedKey
+}
+
+// NewService seals signing secrets with a random key until SetEncryptionKey
+// is called, so they do not outlive the process.
+func NewService(store Store) *Service {
+	s := &Service{
+		store:    store,
+		cacheTTL: defaultCacheTTL,
+		now:      func() time.Time { return time.Now().UTC() },
+		cache:    map[string]cachedKey{},
+	}
+	ephemeral := make([]byte, 32)
+	if _, err := rand.Read(ephemeral); err == nil {
+		_ = s.SetEncryptionKey(ephemeral)
+	}
+	return s
+}
+
+// SetEncryptionKey sets the 32-byte AES key that seals signing secrets. It is
+// server configuration and must never be stored next to the keys.
+func (s *Service) SetEncryptionKey(key []byte) error {
+	if len(key) != 32 {
+		return ErrEncryptionKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	sealer, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	s.sealer = sealer
+	return nil
+}
+
+// SigningKey opens the HMAC key a signed request for key must be signed with.
+func (s *Service) SigningKey(key Key) ([]byte, error) {
+	size := s.sealer.NonceSize()
+	if len(key.SigningSecret) <= size {
+		return nil, ErrCannotSign
+	}
+	nonce, sealed := key.SigningSecret[:size], key.SigningSecret[size:]
+	opened, err := s.sealer.Open(nil, nonce, sealed, []byte(key.ID))
+	if err != nil {
+		return nil, ErrCannotSign
+	}
+	return opened, nil
+}
+
+// sealSigningKey binds the sealed secret to the key id so it cannot be
+// copied onto another key's row.
+func (s *Service) sealSigningKey(keyID string, signingKey []byte) ([]byte, error) {
+	nonce := make([]byte, s.sealer.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return s.sealer.Seal(nonce, nonce, signingKey, []byte(keyID)), nil
+}
+
+// Create stores a new key for the user and returns it with its secret.
+func (s *Service) Create(ctx context.Context, userID string, req CreateRequest) (Key, string, error) {
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return Key{}, "", err
+	}
+	allowedIPs, err := normalizeAllowedIPs(req.AllowedIPs)
+	if err != nil {
+		return Key{}, "", err
+	}
+	now := s.now()
+	if !req.ExpiresAt.IsZero() && !req.ExpiresAt.After(now) {
+		return Key{}, "", ErrInvalidExpires
+	}
+
+	existing, err := s.store.ListKeys(ctx, userID)
+	if err != nil {
+		return Key{}, "", err
+	}
+	active := 0
+	for _, key := range existing {
+		if key.RevokedAt.IsZero() {
+			active++
+		}
+	}
+	if active >= maxKeysPerUser {
+		return Key{}, "", ErrTooManyKeys
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return Key{}, "", err
+	}
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return Key{}, "", err
+	}
+	secret := keyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	sealed, err := s.sealSigningKey("key-"+id, signing.SigningKey(secret))
+	if err != nil {
+		return Key{}, "", err
+	}
+
+	key := Key{
+		ID:            "key-" + id,
+		UserID:        userID,
+		AccountID:     strings.TrimSpace(req.AccountID),
+		Name:          strings.TrimSpace(req.Name),
+		Hash:          hashSecret(secret),
+		SigningSecret: sealed,
+		Scopes:        scopes,
+		AllowedIPs:    allowedIPs,
+		ExpiresAt:     req.ExpiresAt.UTC(),
+		CreatedAt:     now,
+	}
+	if err := s.store.CreateKey(ctx, key); err != nil {
+		return Key{}, "", err
+	}
+	return key, secret, nil
+}
+
+func (s *Service) List(ctx context.Context, userID string) ([]Key, error) {
+	return s.store.ListKeys(ctx, userID)
+}
+
+func (s *Service) Revoke(ctx context.Context, userID, keyID string) (Key, error) {
+	key, err := s.store.RevokeKey(ctx, userID, keyID, s.now())
+	if err != nil {
+		return Key{}, err
+	}
+	s.mu.Lock()
+	delete(s.cache, key.Hash)
+	delete(s.cache, "id:"+key.ID)
+	s.mu.Unlock()
+	return key, nil
+}
+
+// Authenticate resolves a presented secret to its key, checking revocation,
+// expiry and the IP allowlist. Last-used timestamps are written at most once
+// a minute per key.
+func (s *Service) Authenticate(ctx context.Context, secret, remoteIP string) (Key, error) {
+	if !strings.HasPrefix(secret, keyPrefix) {
+		return Key{}, ErrInvalidKey
+	}
+	hash := hashSecret(secret)
+	return s.authenticate(ctx, hash, remoteIP, func() (Key, error) {
+		return s.store.KeyByHash(ctx, hash)
+	})
+}
+
+// AuthenticateID is Authenticate for signed requests, which name the key by
+// id instead of sending the secret. The caller must still check the
+// signature with SigningKey.
+func (s *Service) AuthenticateID(ctx context.Context, keyID, remoteIP string) (Key, error) {
+	if keyID == "" {
+		return Key{}, ErrInvalidKey
+	}
+	return s.authenticate(ctx, "id:"+keyID, remoteIP, func() (Key, error) {
+		return s.store.KeyByID(ctx, keyID)
+	})
+}
+
+func (s *Service) authenticate(ctx context.Context, cacheKey, remoteIP string, load func() (Key, error)) (Key, error) {
+	now := s.now()
+	key, err := s.lookup(cacheKey, now, load)
+	if errors.Is(err, ErrKeyNotFound) {
+		return Key{}, ErrInvalidKey
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("%w: %v", ErrStoreUnavailable, err)
+	}
+	if !key.RevokedAt.IsZero() {
+		return Key{}, ErrInvalidKey
+	}
+	if !key.ExpiresAt.IsZero() && !now.Before(key.ExpiresAt) {
+		return Key{}, ErrKeyExpired
+	}
+	if !ipAllowed(key.AllowedIPs, remoteIP) {
+		return Key{}, ErrIPNotAllowed
+	}
+
+	if now.Sub(key.LastUsedAt) >= lastUsedInterval {
+		if err := s.store.TouchKey(ctx, key.ID, now); err == nil {
+			key.LastUsedAt = now
+			s.mu.Lock()
+			if cached, ok := s.cache[cacheKey]; ok {
+				cached.key.LastUsedAt = now
+				s.cache[cacheKey] = cached
+			}
+			s.mu.Unlock()
+		}
+	}
+	return key, nil
+}
+
+func (s *Service) lookup(cacheKey string, now time.Time, load func() (Key, error)) (Key, error) {
+	s.mu.Lock()
+	cached, ok := s.cache[cacheKey]
+	s.mu.Unlock()
+	if ok && now.Sub(cached.fetchedAt) < s.cacheTTL {
+		return cached.key, nil
+	}
+
+	key, err := load()
+	if err != nil {
+		return Key{}, err
+	}
+	s.mu.Lock()
+	s.cache[cacheKey] = cachedKey{key: key, fetchedAt: now}
+	s.mu.Unlock()
+	return key, nil
+}
+
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return []string{ScopeRead}, nil
+	}
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		switch scope {
+		case ScopeRead, ScopeTrade, ScopeOperator, ScopeAdmin:
+		default:
+			return nil, ErrInvalidScope
+		}
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
+
+func normalizeAllowedIPs(entries []string) ([]string, error) {
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			result = append(result, prefix.Masked().String())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, ErrInvalidIP
+		}
+		result = append(result, addr.Unmap().String())
+	}
+	return result, nil
+}
+
+func ipAllowed(allowed []string, remoteIP string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(strings.TrimSpace(remoteIP))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, entry := range allowed {
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			if prefix.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if other, err := netip.ParseAddr(entry); err == nil && other == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,129 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type countingStore struct {
+	*MemoryStore
+	lookups int
+	touches int
+}
+
+func (c *countingStore) KeyByHash(ctx context.Context, hash string) (Key, error) {
+	c.lookups++
+	return c.MemoryStore.KeyByHash(ctx, hash)
+}
+
+func (c *countingStore) TouchKey(ctx context.Context, keyID string, now time.Time) error {
+	c.touches++
+	return c.MemoryStore.TouchKey(ctx, keyID, now)
+}
+
+func TestCreateStoresOnlyHashAndAuthenticates(t *testing.T) {
+	ctx := context.Background()
+	store := &countingStore{MemoryStore: NewMemoryStore()}
+	svc := NewService(store)
+
+	key, secret, err := svc.Create(ctx, "u1", CreateRequest{Name: "bot", Scopes: []string{"Trade", "read", "trade"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !strings.HasPrefix(secret, keyPrefix) || key.Hash == secret || strings.Contains(key.Hash, secret) {
+		t.Fatalf("unexpected secret %q / hash %q", secret, key.Hash)
+	}
+	if len(key.Scopes) != 2 || !key.HasScope(ScopeTrade) || !key.HasScope(ScopeRead) {
+		t.Fatalf("unexpected scopes %v", key.Scopes)
+	}
+
+	for i := 0; i < 3; i++ {
+		got, err := svc.Authenticate(ctx, secret, "10.0.0.1")
+		if err != nil {
+			t.Fatalf("authenticate: %v", err)
+		}
+		if got.UserID != "u1" || got.ID != key.ID {
+			t.Fatalf("unexpected key %+v", got)
+		}
+	}
+	if store.lookups != 1 {
+		t.Fatalf("expected cached lookups, got %d store reads", store.lookups)
+	}
+	if store.touches != 1 {
+		t.Fatalf("expected last-used to be written once, got %d", store.touches)
+	}
+
+	if _, err := svc.Authenticate(ctx, "kk_unknown", "10.0.0.1"); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+}
+
+func TestRevokeInvalidatesCachedKey(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore())
+	key, secret, _ := svc.Create(ctx, "u1", CreateRequest{})
+	if _, err := svc.Authenticate(ctx, secret, "127.0.0.1"); err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+
+	if _, err := svc.Revoke(ctx, "u2", key.ID); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected other users to be unable to revoke, got %v", err)
+	}
+	if _, err := svc.Revoke(ctx, "u1", key.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, secret, "127.0.0.1"); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected revoked key to be rejected, got %v", err)
+	}
+
+	keys, _ := svc.List(ctx, "u1")
+	if len(keys) != 1 || keys[0].RevokedAt.IsZero() {
+		t.Fatalf("expected revoked key in listing, got %+v", keys)
+	}
+}
+
+func TestAuthenticateChecksExpiryAndAllowlist(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore())
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	_, secret, err := svc.Create(ctx, "u1", CreateRequest{
+		AllowedIPs: []string{"10.1.0.0/16", "192.168.1.7"},
+		ExpiresAt:  now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	for _, ip := range []string{"10.1.2.3", "192.168.1.7", "::ffff:10.1.9.9"} {
+		if _, err := svc.Authenticate(ctx, secret, ip); err != nil {
+			t.Fatalf("expected %s to be allowed, got %v", ip, err)
+		}
+	}
+	if _, err := svc.Authenticate(ctx, secret, "10.2.0.1"); !errors.Is(err, ErrIPNotAllowed) {
+		t.Fatalf("expected ErrIPNotAllowed, got %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := svc.Authenticate(ctx, secret, "10.1.2.3"); !errors.Is(err, ErrKeyExpired) {
+		t.Fatalf("expected ErrKeyExpired, got %v", err)
+	}
+}
+
+func TestCreateValidatesRequest(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore())
+	if _, _, err := svc.Create(ctx, "u1", CreateRequest{Scopes: []string{"withdraw"}}); !errors.Is(err, ErrInvalidScope) {
+		t.Fatalf("expected ErrInvalidScope, got %v", err)
+	}
+	if _, _, err := svc.Create(ctx, "u1", CreateRequest{AllowedIPs: []string{"not-an-ip"}}); !errors.Is(err, ErrInvalidIP) {
+		t.Fatalf("expected ErrInvalidIP, got %v", err)
+	}
+	if _, _, err := svc.Create(ctx, "u1", CreateRequest{ExpiresAt: time.Now().Add(-time.Minute)}); !errors.Is(err, ErrInvalidExpires) {
+		t.Fatalf("expected ErrInvalidExpires, got %v", err)
+	}
+}
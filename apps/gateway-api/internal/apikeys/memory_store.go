@@ -0,0 +1,98 @@
+package apikeys
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps API keys in process memory for tests and local runs
+// without PostgreSQL.
+type MemoryStore struct {
+	mu     sync.Mutex
+	byID   map[string]Key
+	byHash map[string]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		byID:   map[string]Key{},
+		byHash: map[string]string{},
+	}
+}
+
+func (m *MemoryStore) CreateKey(_ context.Context, key Key) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.byID[key.ID] = cloneKey(key)
+	m.byHash[key.Hash] = key.ID
+	return nil
+}
+
+func (m *MemoryStore) KeyByHash(_ context.Context, hash string) (Key, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id, ok := m.byHash[hash]
+	if !ok {
+		return Key{}, ErrKeyNotFound
+	}
+	return cloneKey(m.byID[id]), nil
+}
+
+func (m *MemoryStore) KeyByID(_ context.Context, keyID string) (Key, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key, ok := m.byID[keyID]
+	if !ok {
+		return Key{}, ErrKeyNotFound
+	}
+	return cloneKey(key), nil
+}
+
+func (m *MemoryStore) ListKeys(_ context.Context, userID string) ([]Key, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]Key, 0)
+	for _, key := range m.byID {
+		if key.UserID == userID {
+			keys = append(keys, cloneKey(key))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+func (m *MemoryStore) RevokeKey(_ context.Context, userID, keyID string, now time.Time) (Key, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key, ok := m.byID[keyID]
+	if !ok || key.UserID != userID || !key.RevokedAt.IsZero() {
+		return Key{}, ErrKeyNotFound
+	}
+	key.RevokedAt = now
+	m.byID[keyID] = key
+	return cloneKey(key), nil
+}
+
+func (m *MemoryStore) TouchKey(_ context.Context, keyID string, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if key, ok := m.byID[keyID]; ok {
+		key.LastUsedAt = now
+		m.byID[keyID] = key
+	}
+	return nil
+}
+
+func cloneKey(key Key) Key {
+	key.Scopes = slices.Clone(key.Scopes)
+	key.AllowedIPs = slices.Clone(key.AllowedIPs)
+	return key
+}
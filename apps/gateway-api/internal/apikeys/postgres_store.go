@@ -0,0 +1,144 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const keyColumns = `key_id, user_id, account_id, name, key_hash, signing_secret, scopes, allowed_ips, expires_at, created_at, last_used_at, revoked_at`
+
+// PostgresStore keeps API keys in the api_keys table created by
+// docker/postgres-init.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+	pool, err := pgxpool.New(ctx, strings.TrimSpace(dsn))
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return &PostgresStore{pool: pool}, nil
+}
+
+func (s *PostgresStore) Close() {
+	if s.pool != nil {
+		s.pool.Close()
+	}
+}
+
+func (s *PostgresStore) CreateKey(ctx context.Context, key Key) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO api_keys (key_id, user_id, account_id, name, key_hash, signing_secret, scopes, allowed_ips, expires_at, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+	`, key.ID, key.UserID, key.AccountID, key.Name, key.Hash, key.SigningSecret, key.Scopes, key.AllowedIPs, nullTime(key.ExpiresAt), key.CreatedAt)
+	return err
+}
+
+func (s *PostgresStore) KeyByHash(ctx context.Context, hash string) (Key, error) {
+	row := s.pool.QueryRow(ctx, `SELECT `+keyColumns+` FROM api_keys WHERE key_hash = $1`, hash)
+	key, err := scanKey(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Key{}, ErrKeyNotFound
+	}
+	return key, err
+}
+
+func (s *PostgresStore) KeyByID(ctx context.Context, keyID string) (Key, error) {
+	row := s.pool.QueryRow(ctx, `SELECT `+keyColumns+` FROM api_keys WHERE key_id = $1`, keyID)
+	key, err := scanKey(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Key{}, ErrKeyNotFound
+	}
+	return key, err
+}
+
+func (s *PostgresStore) ListKeys(ctx context.Context, userID string) ([]Key, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+keyColumns+` FROM api_keys
+		WHERE user_id = $1
+		ORDER BY created_at, key_id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]Key, 0)
+	for rows.Next() {
+		key, err := scanKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (s *PostgresStore) RevokeKey(ctx context.Context, userID, keyID string, now time.Time) (Key, error) {
+	row := s.pool.QueryRow(ctx, `
+		UPDATE api_keys SET revoked_at = $3
+		WHERE key_id = $1 AND user_id = $2 AND revoked_at IS NULL
+		RETURNING `+keyColumns, keyID, userID, now)
+	key, err := scanKey(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Key{}, ErrKeyNotFound
+	}
+	return key, err
+}
+
+func (s *PostgresStore) TouchKey(ctx context.Context, keyID string, now time.Time) error {
+	_, err := s.pool.Exec(ctx, `UPDATE api_keys SET last_used_at = $2 WHERE key_id = $1`, keyID, now)
+	return err
+}
+
+func scanKey(row pgx.Row) (Key, error) {
+	var (
+		key                              Key
+		expiresAt, lastUsedAt, revokedAt *time.Time
+	)
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.AccountID,
+		&key.Name,
+		&key.Hash,
+		&key.SigningSecret,
+		&key.Scopes,
+		&key.AllowedIPs,
+		&expiresAt,
+		&key.CreatedAt,
+		&lastUsedAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return Key{}, err
+	}
+	key.ExpiresAt = valueOrZero(expiresAt)
+	key.LastUsedAt = valueOrZero(lastUsedAt)
+	key.RevokedAt = valueOrZero(revokedAt)
+	return key, nil
+}
+
+func nullTime(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+	return &value
+}
+
+func valueOrZero(value *time.Time) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	return value.UTC()
+}
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memoryLogCapacity = 1000
+
+// Entry records one admin action. Status is the HTTP status the gateway
+// answered with, so refused attempts are logged too.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	UserID   string    `json:"userId"`
+	Role     string    `json:"role"`
+	KeyID    string    `json:"keyId,omitempty"`
+	Method   string    `json:"method"`
+	Path     string    `json:"path"`
+	Status   int       `json:"status"`
+	RemoteIP string    `json:"remoteIp"`
+}
+
+type Log interface {
+	Record(ctx context.Context, entry Entry) error
+	// Recent returns up to limit entries, newest first.
+	Recent(ctx context.Context, limit int) ([]Entry, error)
+}
+
+// MemoryLog keeps the most recent entries in process memory.
+type MemoryLog struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+func NewMemoryLog() *MemoryLog {
+	return &MemoryLog{}
+}
+
+func (m *MemoryLog) Record(_ context.Context, entry Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, entry)
+	if len(m.entries) > memoryLogCapacity {
+		m.entries = append([]Entry(nil), m.entries[len(m.entries)-memoryLogCapacity:]...)
+	}
+	return nil
+}
+
+func (m *MemoryLog) Recent(_ context.Context, limit int) ([]Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if limit <= 0 || limit > len(m.entries) {
+		limit = len(m.entries)
+	}
+	result := make([]Entry, 0, limit)
+	for i := len(m.entries) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, m.entries[i])
+	}
+	return result, nil
+}
@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryLogReturnsNewestFirstAndCaps(t *testing.T) {
+	log := NewMemoryLog()
+	for i := 0; i < memoryLogCapacity+5; i++ {
+		_ = log.Record(context.Background(), Entry{Status: i})
+	}
+
+	recent, _ := log.Recent(context.Background(), 2)
+	if len(recent) != 2 || recent[0].Status != memoryLogCapacity+4 || recent[1].Status != memoryLogCapacity+3 {
+		t.Fatalf("unexpected recent entries %+v", recent)
+	}
+	all, _ := log.Recent(context.Background(), 0)
+	if len(all) != memoryLogCapacity || all[len(all)-1].Status != 5 {
+		t.Fatalf("expected %d retained entries ending at 5, got %d", memoryLogCapacity, len(all))
+	}
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresLog appends entries to the audit_log table created by
+// docker/postgres-init.
+type PostgresLog struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresLog(ctx context.Context, dsn string) (*PostgresLog, error) {
+	pool, err := pgxpool.New(ctx, strings.TrimSpace(dsn))
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return &PostgresLog{pool: pool}, nil
+}
+
+func (p *PostgresLog) Close() {
+	if p.pool != nil {
+		p.pool.Close()
+	}
+}
+
+func (p *PostgresLog) Record(ctx context.Context, entry Entry) error {
+	_, err := p.pool.Exec(ctx, `
+		INSERT INTO audit_log (occurred_at, user_id, role, key_id, method, path, status, remote_ip)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+	`, entry.Time, entry.UserID, entry.Role, entry.KeyID, entry.Method, entry.Path, entry.Status, entry.RemoteIP)
+	return err
+}
+
+func (p *PostgresLog) Recent(ctx context.Context, limit int) ([]Entry, error) {
+	rows, err := p.pool.Query(ctx, `
+		SELECT occurred_at, user_id, role, key_id, method, path, status, remote_ip
+		FROM audit_log
+		ORDER BY audit_id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]Entry, 0)
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(&entry.Time, &entry.UserID, &entry.Role, &entry.KeyID, &entry.Method, &entry.Path, &entry.Status, &entry.RemoteIP); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
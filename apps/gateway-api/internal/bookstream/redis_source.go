@@ -0,0 +1,125 @@
+package bookstream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/streamhub"
+)
+
+// RedisBookStreamSource reads the level deltas the matching engine appends to
+// {prefix}:stream:book:{symbol}.
+type RedisBookStreamSource struct {
+	client redis.UniversalClient
+	symbol string
+	stream string
+}
+
+func NewRedisBookStreamSource(client redis.UniversalClient, prefix, symbol string) *RedisBookStreamSource {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		prefix = "kalency:v1"
+	}
+	return &RedisBookStreamSource{
+		client: client,
+		symbol: symbol,
+		stream: fmt.Sprintf("%s:stream:book:%s", prefix, symbol),
+	}
+}
+
+func (s *RedisBookStreamSource) Read(ctx context.Context, lastID string, count int, block time.Duration) ([]streamhub.Message, string, error) {
+	if strings.TrimSpace(lastID) == "" {
+		lastID = "$"
+	}
+	if count <= 0 {
+		count = 250
+	}
+	if block < 0 {
+		block = 0
+	}
+
+	streamData, err := s.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{s.stream, lastID},
+		Count:   int64(count),
+		Block:   block,
+	}).Result()
+	if err == redis.Nil {
+		return []streamhub.Message{}, lastID, nil
+	}
+	if err != nil {
+		return nil, lastID, err
+	}
+
+	result := make([]streamhub.Message, 0)
+	nextID := lastID
+	for _, stream := range streamData {
+		for _, entry := range stream.Messages {
+			nextID = entry.ID
+			delta, err := s.decodeDelta(entry.Values)
+			if err != nil {
+				continue
+			}
+			result = append(result, streamhub.Message{ID: entry.ID, Key: s.symbol, Data: delta})
+		}
+	}
+	return result, nextID, nil
+}
+
+func (s *RedisBookStreamSource) ReadAfter(ctx context.Context, afterID string, count int) ([]streamhub.Message, error) {
+	entries, err := s.client.XRangeN(ctx, s.stream, "("+afterID, "+", int64(count)).Result()
+	if err == redis.Nil {
+		return []streamhub.Message{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]streamhub.Message, 0, len(entries))
+	for _, entry := range entries {
+		message := streamhub.Message{ID: entry.ID}
+		if delta, err := s.decodeDelta(entry.Values); err == nil {
+			message.Key = s.symbol
+			message.Data = delta
+		}
+		result = append(result, message)
+	}
+	return result, nil
+}
+
+func (s *RedisBookStreamSource) decodeDelta(values map[string]any) (contracts.BookDelta, error) {
+	seq, err := strconv.ParseInt(fmt.Sprint(values["seq"]), 10, 64)
+	if err != nil {
+		return contracts.BookDelta{}, err
+	}
+	price, err := strconv.ParseInt(fmt.Sprint(values["price"]), 10, 64)
+	if err != nil {
+		return contracts.BookDelta{}, err
+	}
+	qty, err := strconv.ParseInt(fmt.Sprint(values["qty"]), 10, 64)
+	if err != nil {
+		return contracts.BookDelta{}, err
+	}
+	orders, err := strconv.Atoi(fmt.Sprint(values["orders"]))
+	if err != nil {
+		return contracts.BookDelta{}, err
+	}
+	ts, err := time.Parse(time.RFC3339Nano, fmt.Sprint(values["ts"]))
+	if err != nil {
+		ts = time.Time{}
+	}
+
+	return contracts.BookDelta{
+		Symbol: s.symbol,
+		Seq:    seq,
+		Side:   contracts.Side(fmt.Sprint(values["side"])),
+		Price:  price,
+		Qty:    qty,
+		Orders: orders,
+		TS:     ts,
+	}, nil
+}
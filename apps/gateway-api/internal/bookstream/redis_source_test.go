@@ -0,0 +1,60 @@
+package bookstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+func TestRedisBookStreamSourceDecodesDeltas(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	for seq := 1; seq <= 2; seq++ {
+		if err := client.XAdd(ctx, &redis.XAddArgs{
+			Stream: "kalency:v1:stream:book:BTC-USD",
+			Values: map[string]any{
+				"seq":    seq,
+				"side":   "SELL",
+				"price":  101,
+				"qty":    seq * 3,
+				"orders": seq,
+				"ts":     time.Unix(10, 0).UTC().Format(time.RFC3339Nano),
+			},
+		}).Err(); err != nil {
+			t.Fatalf("xadd failed: %v", err)
+		}
+	}
+
+	source := NewRedisBookStreamSource(client, "", "BTC-USD")
+	messages, _, err := source.Read(ctx, "0", 10, 0)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 deltas, got %d", len(messages))
+	}
+	delta, ok := messages[1].Data.(contracts.BookDelta)
+	if !ok || messages[1].Key != "BTC-USD" || delta.Seq != 2 || delta.Side != contracts.SideSell || delta.Qty != 6 || delta.Orders != 2 {
+		t.Fatalf("unexpected delta %+v", messages[1])
+	}
+
+	after, err := source.ReadAfter(ctx, messages[0].ID, 10)
+	if err != nil {
+		t.Fatalf("read after failed: %v", err)
+	}
+	if len(after) != 1 || after[0].ID != messages[1].ID {
+		t.Fatalf("unexpected messages after %s: %+v", messages[0].ID, after)
+	}
+}
@@ -0,0 +1,129 @@
+package candlestream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/streamhub"
+)
+
+// RedisCandleStreamSource reads candle-aggregator updates as hub messages
+// keyed by Key(symbol, timeframe).
+type RedisCandleStreamSource struct {
+	client redis.UniversalClient
+	stream string
+}
+
+func NewRedisCandleStreamSource(client redis.UniversalClient, stream string) *RedisCandleStreamSource {
+	stream = strings.TrimSpace(stream)
+	if stream == "" {
+		stream = "kalency:v1:stream:candles"
+	}
+	return &RedisCandleStreamSource{client: client, stream: stream}
+}
+
+// Key is the hub key of the candles of symbol in timeframe.
+func Key(symbol, timeframe string) string {
+	return symbol + "." + timeframe
+}
+
+func (s *RedisCandleStreamSource) Read(ctx context.Context, lastID string, count int, block time.Duration) ([]streamhub.Message, string, error) {
+	if strings.TrimSpace(lastID) == "" {
+		lastID = "$"
+	}
+	if count <= 0 {
+		count = 250
+	}
+	if block < 0 {
+		block = 0
+	}
+
+	streamData, err := s.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{s.stream, lastID},
+		Count:   int64(count),
+		Block:   block,
+	}).Result()
+	if err == redis.Nil {
+		return []streamhub.Message{}, lastID, nil
+	}
+	if err != nil {
+		return nil, lastID, err
+	}
+
+	result := make([]streamhub.Message, 0)
+	nextID := lastID
+	for _, stream := range streamData {
+		for _, entry := range stream.Messages {
+			nextID = entry.ID
+			if message, ok := decodeMessage(entry); ok {
+				result = append(result, message)
+			}
+		}
+	}
+	return result, nextID, nil
+}
+
+func (s *RedisCandleStreamSource) ReadAfter(ctx context.Context, afterID string, count int) ([]streamhub.Message, error) {
+	entries, err := s.client.XRangeN(ctx, s.stream, "("+afterID, "+", int64(count)).Result()
+	if err == redis.Nil {
+		return []streamhub.Message{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]streamhub.Message, 0, len(entries))
+	for _, entry := range entries {
+		message, ok := decodeMessage(entry)
+		if !ok {
+			message = streamhub.Message{ID: entry.ID}
+		}
+		result = append(result, message)
+	}
+	return result, nil
+}
+
+func decodeMessage(entry redis.XMessage) (streamhub.Message, bool) {
+	update, err := decodeCandleUpdate(entry.Values)
+	if err != nil {
+		return streamhub.Message{}, false
+	}
+	return streamhub.Message{ID: entry.ID, Key: Key(update.Symbol, update.Timeframe), Data: update}, true
+}
+
+func decodeCandleUpdate(values map[string]any) (contracts.CandleUpdate, error) {
+	symbol := strings.TrimSpace(fmt.Sprint(values["symbol"]))
+	timeframe := strings.TrimSpace(fmt.Sprint(values["timeframe"]))
+	if symbol == "" || timeframe == "" {
+		return contracts.CandleUpdate{}, fmt.Errorf("missing symbol or timeframe")
+	}
+	bucketStart, err := time.Parse(time.RFC3339, fmt.Sprint(values["bucket_start"]))
+	if err != nil {
+		return contracts.CandleUpdate{}, err
+	}
+
+	update := contracts.CandleUpdate{
+		Candle: contracts.Candle{Symbol: symbol, Timeframe: timeframe, BucketStart: bucketStart.UTC()},
+		Final:  fmt.Sprint(values["final"]) == "true",
+	}
+	fields := map[string]*float64{
+		"open":   &update.Open,
+		"high":   &update.High,
+		"low":    &update.Low,
+		"close":  &update.Close,
+		"volume": &update.Volume,
+	}
+	for name, field := range fields {
+		parsed, err := strconv.ParseFloat(fmt.Sprint(values[name]), 64)
+		if err != nil {
+			return contracts.CandleUpdate{}, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		*field = parsed
+	}
+	return update, nil
+}
@@ -0,0 +1,56 @@
+package candlestream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+func TestRedisCandleStreamSourceKeysBySymbolAndTimeframe(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	stream := "kalency:v1:stream:candles"
+	ids := make([]string, 0, 2)
+	for _, values := range []map[string]any{
+		{"symbol": "BTC-USD", "timeframe": "1m", "bucket_start": "2026-02-14T12:34:00Z", "open": "100", "high": "110", "low": "99", "close": "105", "volume": "5", "final": "false"},
+		{"symbol": "BTC-USD", "timeframe": "5s", "bucket_start": "2026-02-14T12:34:05Z", "open": "105", "high": "105", "low": "105", "close": "105", "volume": "1", "final": "true"},
+	} {
+		id, err := client.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: values}).Result()
+		if err != nil {
+			t.Fatalf("xadd failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	source := NewRedisCandleStreamSource(client, stream)
+	messages, nextID, err := source.Read(ctx, "0", 10, 0)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if len(messages) != 2 || nextID != ids[1] {
+		t.Fatalf("expected 2 messages ending at %s, got %+v ending at %s", ids[1], messages, nextID)
+	}
+	update, ok := messages[0].Data.(contracts.CandleUpdate)
+	if !ok || messages[0].Key != "BTC-USD.1m" || update.High != 110 || update.Volume != 5 || update.Final {
+		t.Fatalf("unexpected first message %+v", messages[0])
+	}
+
+	after, err := source.ReadAfter(ctx, ids[0], 10)
+	if err != nil {
+		t.Fatalf("read after failed: %v", err)
+	}
+	if len(after) != 1 || after[0].Key != "BTC-USD.5s" || !after[0].Data.(contracts.CandleUpdate).Final {
+		t.Fatalf("unexpected read-after result %+v", after)
+	}
+}
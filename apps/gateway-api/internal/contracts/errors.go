@@ -0,0 +1,115 @@
+package contracts
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode is the machine-readable code of an API error. It mirrors the
+// matching engine's codes and adds the ones only the gateway answers with.
+type ErrorCode string
+
+const (
+	ErrorInvalidRequest    ErrorCode = "INVALID_REQUEST"
+	ErrorValidationFailed  ErrorCode = "VALIDATION_FAILED"
+	ErrorInsufficientFunds ErrorCode = "INSUFFICIENT_FUNDS"
+	ErrorNoLiquidity       ErrorCode = "NO_LIQUIDITY"
+	ErrorRiskLimitExceeded ErrorCode = "RISK_LIMIT_EXCEEDED"
+	ErrorFundingLimit      ErrorCode = "FUNDING_LIMIT_EXCEEDED"
+	ErrorOrderNotFound     ErrorCode = "ORDER_NOT_FOUND"
+	ErrorOrderListNotFound ErrorCode = "ORDER_LIST_NOT_FOUND"
+	ErrorOrderNotAmendable ErrorCode = "ORDER_NOT_AMENDABLE"
+	ErrorTradingDisabled   ErrorCode = "TRADING_DISABLED"
+	ErrorSymbolHalted      ErrorCode = "SYMBOL_HALTED"
+	ErrorNotFound          ErrorCode = "NOT_FOUND"
+	ErrorUnauthorized      ErrorCode = "UNAUTHORIZED"
+	ErrorForbidden         ErrorCode = "FORBIDDEN"
+	ErrorMethodNotAllowed  ErrorCode = "METHOD_NOT_ALLOWED"
+	ErrorConflict          ErrorCode = "CONFLICT"
+	ErrorRateLimited       ErrorCode = "RATE_LIMITED"
+	ErrorUnavailable       ErrorCode = "UNAVAILABLE"
+	ErrorInternal          ErrorCode = "INTERNAL"
+)
+
+func (c ErrorCode) HTTPStatus() int {
+	switch c {
+	case ErrorInvalidRequest, ErrorValidationFailed:
+		return http.StatusBadRequest
+	case ErrorUnauthorized:
+		return http.StatusUnauthorized
+	case ErrorForbidden, ErrorTradingDisabled:
+		return http.StatusForbidden
+	case ErrorOrderNotFound, ErrorOrderListNotFound, ErrorNotFound:
+		return http.StatusNotFound
+	case ErrorMethodNotAllowed:
+		return http.StatusMethodNotAllowed
+	case ErrorConflict, ErrorOrderNotAmendable:
+		return http.StatusConflict
+	case ErrorInsufficientFunds, ErrorNoLiquidity, ErrorRiskLimitExceeded, ErrorFundingLimit:
+		return http.StatusUnprocessableEntity
+	case ErrorRateLimited:
+		return http.StatusTooManyRequests
+	case ErrorSymbolHalted, ErrorUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ErrorCodeForStatus is the code for an error that only has an HTTP status.
+func ErrorCodeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorInvalidRequest
+	case http.StatusUnauthorized:
+		return ErrorUnauthorized
+	case http.StatusForbidden:
+		return ErrorForbidden
+	case http.StatusNotFound:
+		return ErrorNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrorMethodNotAllowed
+	case http.StatusConflict:
+		return ErrorConflict
+	case http.StatusUnprocessableEntity:
+		return ErrorValidationFailed
+	case http.StatusTooManyRequests:
+		return ErrorRateLimited
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrorUnavailable
+	default:
+		return ErrorInternal
+	}
+}
+
+// APIError is the body of every error response: {code, message, details}.
+type APIError struct {
+	Code    ErrorCode         `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+func (e *APIError) HTTPStatus() int {
+	return e.Code.HTTPStatus()
+}
+
+// ParseAPIError reads an upstream error response. Bodies that are not an
+// APIError, such as plain text from older services, keep their text as the
+// message and take their code from the status.
+func ParseAPIError(status int, body []byte) *APIError {
+	var apiErr APIError
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Code != "" {
+		return &apiErr
+	}
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = fmt.Sprintf("request failed: %d %s", status, http.StatusText(status))
+	}
+	return &APIError{Code: ErrorCodeForStatus(status), Message: message}
+}
@@ -12,13 +12,16 @@ const (
 type OrderType string
 
 const (
-	OrderTypeMarket OrderType = "MARKET"
-	OrderTypeLimit  OrderType = "LIMIT"
+	OrderTypeMarket     OrderType = "MARKET"
+	OrderTypeLimit      OrderType = "LIMIT"
+	OrderTypeStopMarket OrderType = "STOP_MARKET"
+	OrderTypeStopLimit  OrderType = "STOP_LIMIT"
 )
 
 type OrderStatus string
 
 const (
+	OrderStatusPending       OrderStatus = "PENDING"
 	OrderStatusAccepted      OrderStatus = "ACCEPTED"
 	OrderStatusPartiallyFill OrderStatus = "PARTIALLY_FILLED"
 	OrderStatusFilled        OrderStatus = "FILLED"
@@ -26,13 +29,17 @@ const (
 	OrderStatusRejected      OrderStatus = "REJECTED"
 )
 
+// PlaceOrderRequest.AccountID picks the sub-account that trades; the gateway
+// resolves it into UserID before the order reaches the engine.
 type PlaceOrderRequest struct {
 	ClientOrderID string    `json:"clientOrderId"`
 	UserID        string    `json:"userId"`
+	AccountID     string    `json:"accountId,omitempty"`
 	Symbol        string    `json:"symbol"`
 	Side          Side      `json:"side"`
 	Type          OrderType `json:"type"`
 	Price         int64     `json:"price,omitempty"`
+	StopPrice     int64     `json:"stopPrice,omitempty"`
 	Qty           int64     `json:"qty"`
 }
 
@@ -44,6 +51,9 @@ type OrderAck struct {
 	AvgPrice      int64       `json:"avgPrice"`
 	ClientOrderID string      `json:"clientOrderId,omitempty"`
 	Symbol        string      `json:"symbol,omitempty"`
+	ListID        string      `json:"listId,omitempty"`
+	RejectCode    string      `json:"rejectCode,omitempty"`
+	RejectReason  string      `json:"rejectReason,omitempty"`
 	TS            time.Time   `json:"ts"`
 }
 
@@ -55,11 +65,90 @@ type Order struct {
 	Side          Side      `json:"side"`
 	Type          OrderType `json:"type"`
 	Price         int64     `json:"price"`
+	StopPrice     int64     `json:"stopPrice,omitempty"`
+	Triggered     bool      `json:"triggered,omitempty"`
 	Qty           int64     `json:"qty"`
 	RemainingQty  int64     `json:"remainingQty"`
+	ListID        string    `json:"listId,omitempty"`
+	ListRole      string    `json:"listRole,omitempty"`
+	ListStatus    string    `json:"listStatus,omitempty"`
 	CreatedAt     time.Time `json:"createdAt"`
 }
 
+type OrderListType string
+
+const (
+	OrderListTypeOCO     OrderListType = "OCO"
+	OrderListTypeBracket OrderListType = "BRACKET"
+)
+
+type PlaceOrderBatchRequest struct {
+	Orders []PlaceOrderRequest `json:"orders"`
+}
+
+type CancelOrderBatchRequest struct {
+	UserID   string   `json:"userId,omitempty"`
+	OrderIDs []string `json:"orderIds"`
+}
+
+// BatchResult is one entry of a batch response, in request order.
+type BatchResult struct {
+	Ack       *OrderAck `json:"ack,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	ErrorCode ErrorCode `json:"errorCode,omitempty"`
+}
+
+type OrderBatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+type OrderLeg struct {
+	ClientOrderID string    `json:"clientOrderId"`
+	Type          OrderType `json:"type"`
+	Price         int64     `json:"price,omitempty"`
+	StopPrice     int64     `json:"stopPrice,omitempty"`
+}
+
+type PlaceOrderListRequest struct {
+	ClientListID string        `json:"clientListId"`
+	UserID       string        `json:"userId"`
+	AccountID    string        `json:"accountId,omitempty"`
+	Symbol       string        `json:"symbol"`
+	Type         OrderListType `json:"type"`
+	Side         Side          `json:"side"`
+	Qty          int64         `json:"qty"`
+	Entry        *OrderLeg     `json:"entry,omitempty"`
+	TakeProfit   OrderLeg      `json:"takeProfit"`
+	StopLoss     OrderLeg      `json:"stopLoss"`
+}
+
+// KillSwitch stops trading for a user, a symbol or, with the GLOBAL scope,
+// everyone.
+type KillSwitch struct {
+	Scope     string    `json:"scope"`
+	Target    string    `json:"target,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// KillSwitchResult is an activated kill switch and the orders the engine
+// canceled for it.
+type KillSwitchResult struct {
+	KillSwitch KillSwitch `json:"killSwitch"`
+	Canceled   []OrderAck `json:"canceled"`
+}
+
+type OrderListAck struct {
+	ListID       string        `json:"listId"`
+	ClientListID string        `json:"clientListId,omitempty"`
+	Type         OrderListType `json:"type"`
+	Status       string        `json:"status"`
+	Symbol       string        `json:"symbol"`
+	Orders       []OrderAck    `json:"orders"`
+	TS           time.Time     `json:"ts"`
+}
+
 type Wallet struct {
 	UserID    string           `json:"userId"`
 	Available map[string]int64 `json:"available"`
@@ -67,6 +156,57 @@ type Wallet struct {
 	UpdatedAt time.Time        `json:"updatedAt"`
 }
 
+// TransferRequest moves available balance between a user's main account and
+// its sub-accounts. From and To are engine user IDs.
+type TransferRequest struct {
+	RequestID  string `json:"requestId,omitempty"`
+	FromUserID string `json:"fromUserId"`
+	ToUserID   string `json:"toUserId"`
+	Asset      string `json:"asset"`
+	Amount     int64  `json:"amount"`
+}
+
+type TransferResult struct {
+	Operation FundingOperation `json:"operation"`
+	From      Wallet           `json:"from"`
+	To        Wallet           `json:"to"`
+}
+
+const (
+	FundingDeposit    = "DEPOSIT"
+	FundingWithdrawal = "WITHDRAWAL"
+	FundingTransfer   = "TRANSFER"
+
+	FundingPending   = "PENDING"
+	FundingCompleted = "COMPLETED"
+	FundingRejected  = "REJECTED"
+)
+
+// FundingRequest deposits to or withdraws from an engine user ID. The engine
+// returns the first operation again when a RequestID is retried.
+type FundingRequest struct {
+	RequestID string `json:"requestId"`
+	UserID    string `json:"userId"`
+	Asset     string `json:"asset"`
+	Amount    int64  `json:"amount"`
+}
+
+// FundingOperation is a deposit, withdrawal or transfer with its status.
+// ToUserID is only set for transfers.
+type FundingOperation struct {
+	ID        string    `json:"id"`
+	RequestID string    `json:"requestId,omitempty"`
+	Type      string    `json:"type"`
+	Status    string    `json:"status"`
+	UserID    string    `json:"userId"`
+	ToUserID  string    `json:"toUserId,omitempty"`
+	Asset     string    `json:"asset"`
+	Amount    int64     `json:"amount"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
 type Execution struct {
 	TradeID      string    `json:"tradeId"`
 	Symbol       string    `json:"symbol"`
@@ -87,11 +227,42 @@ type BookLevel struct {
 
 type OrderBookSnapshot struct {
 	Symbol string      `json:"symbol"`
+	Seq    int64       `json:"seq"`
 	Bids   []BookLevel `json:"bids"`
 	Asks   []BookLevel `json:"asks"`
 	TS     time.Time   `json:"ts"`
 }
 
+// BookDelta is the new aggregate state of one price level; Qty 0 removes it.
+type BookDelta struct {
+	Symbol string    `json:"symbol"`
+	Seq    int64     `json:"seq"`
+	Side   Side      `json:"side"`
+	Price  int64     `json:"price"`
+	Qty    int64     `json:"qty"`
+	Orders int       `json:"orders"`
+	TS     time.Time `json:"ts"`
+}
+
+type Ticker struct {
+	Symbol           string    `json:"symbol"`
+	LastPrice        int64     `json:"lastPrice"`
+	LastQty          int64     `json:"lastQty"`
+	BestBid          int64     `json:"bestBid"`
+	BestBidQty       int64     `json:"bestBidQty"`
+	BestAsk          int64     `json:"bestAsk"`
+	BestAskQty       int64     `json:"bestAskQty"`
+	Open24h          int64     `json:"open24h"`
+	High24h          int64     `json:"high24h"`
+	Low24h           int64     `json:"low24h"`
+	Volume24h        int64     `json:"volume24h"`
+	QuoteVolume24h   int64     `json:"quoteVolume24h"`
+	Trades24h        int64     `json:"trades24h"`
+	Change24h        int64     `json:"change24h"`
+	ChangePercent24h float64   `json:"changePercent24h"`
+	TS               time.Time `json:"ts"`
+}
+
 type Candle struct {
 	Symbol      string    `json:"symbol"`
 	Timeframe   string    `json:"timeframe"`
@@ -102,3 +273,9 @@ type Candle struct {
 	Close       float64   `json:"close"`
 	Volume      float64   `json:"volume"`
 }
+
+// CandleUpdate is a pushed candle; Final is set once the bucket has closed.
+type CandleUpdate struct {
+	Candle
+	Final bool `json:"final"`
+}
@@ -0,0 +1,161 @@
+package gatewayapi
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/apikeys"
+)
+
+type createAPIKeyRequest struct {
+	AccountID  string    `json:"accountId"`
+	Name       string    `json:"name"`
+	Scopes     []string  `json:"scopes"`
+	AllowedIPs []string  `json:"allowedIps"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+type apiKeyResponse struct {
+	KeyID      string     `json:"keyId"`
+	AccountID  string     `json:"accountId,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	Scopes     []string   `json:"scopes"`
+	AllowedIPs []string   `json:"allowedIps"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// createAPIKeyResponse is the only place the secret is ever returned.
+type createAPIKeyResponse struct {
+	apiKeyResponse
+	Secret string `json:"secret"`
+}
+
+func registerAPIKeyRoutes(protected fiber.Router, keys *apikeys.Service, users *accounts.Service) {
+	protected.Post("/api-keys", func(c *fiber.Ctx) error {
+		if keys == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "API key store is not configured")
+		}
+		identity := c.Locals(authLocalKey).(authIdentity)
+
+		var req createAPIKeyRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+		// A key can do no more than its creator: role scopes need that role,
+		// and a scoped key may only mint keys within its own scopes.
+		for _, scope := range req.Scopes {
+			scope = strings.ToLower(strings.TrimSpace(scope))
+			roleScope := scope == apikeys.ScopeOperator || scope == apikeys.ScopeAdmin
+			if !identity.allows(scope) || (roleScope && accounts.RoleRank(identity.Role) < accounts.RoleRank(scope)) {
+				return fiber.NewError(fiber.StatusForbidden, "cannot grant the "+scope+" scope")
+			}
+		}
+
+		// A key scoped to a sub-account trades only that sub-account, and a
+		// scoped identity can only mint keys for its own.
+		if _, err := resolveAccount(c, users, identity, req.AccountID); err != nil {
+			return err
+		}
+		accountID := strings.TrimSpace(req.AccountID)
+		switch {
+		case identity.AccountID != "":
+			accountID = identity.AccountID
+		case accountID == mainAccountName:
+			accountID = ""
+		}
+
+		key, secret, err := keys.Create(c.UserContext(), identity.UserID, apikeys.CreateRequest{
+			AccountID:  accountID,
+			Name:       req.Name,
+			Scopes:     req.Scopes,
+			AllowedIPs: req.AllowedIPs,
+			ExpiresAt:  req.ExpiresAt,
+		})
+		if err != nil {
+			return apiKeyError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(createAPIKeyResponse{apiKeyResponse: toAPIKeyResponse(key), Secret: secret})
+	})
+
+	protected.Get("/api-keys", func(c *fiber.Ctx) error {
+		if keys == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "API key store is not configured")
+		}
+		identity := c.Locals(authLocalKey).(authIdentity)
+		if err := requireUnscoped(identity); err != nil {
+			return err
+		}
+
+		list, err := keys.List(c.UserContext(), identity.UserID)
+		if err != nil {
+			return apiKeyError(err)
+		}
+		response := make([]apiKeyResponse, 0, len(list))
+		for _, key := range list {
+			response = append(response, toAPIKeyResponse(key))
+		}
+		return c.JSON(response)
+	})
+
+	protected.Delete("/api-keys/:keyId", func(c *fiber.Ctx) error {
+		if keys == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "API key store is not configured")
+		}
+		identity := c.Locals(authLocalKey).(authIdentity)
+		if err := requireUnscoped(identity); err != nil {
+			return err
+		}
+		// A stored key could otherwise revoke keys with more privileges than
+		// it has, so revoking needs a role scope.
+		if !identity.allows(apikeys.ScopeOperator) && !identity.allows(apikeys.ScopeAdmin) {
+			return fiber.NewError(fiber.StatusForbidden, "revoking keys needs the operator or admin scope")
+		}
+
+		key, err := keys.Revoke(c.UserContext(), identity.UserID, c.Params("keyId"))
+		if err != nil {
+			return apiKeyError(err)
+		}
+		return c.JSON(toAPIKeyResponse(key))
+	})
+}
+
+func toAPIKeyResponse(key apikeys.Key) apiKeyResponse {
+	return apiKeyResponse{
+		KeyID:      key.ID,
+		AccountID:  key.AccountID,
+		Name:       key.Name,
+		Scopes:     key.Scopes,
+		AllowedIPs: key.AllowedIPs,
+		ExpiresAt:  optionalTime(key.ExpiresAt),
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: optionalTime(key.LastUsedAt),
+		RevokedAt:  optionalTime(key.RevokedAt),
+	}
+}
+
+func optionalTime(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+	return &value
+}
+
+func apiKeyError(err error) error {
+	switch {
+	case errors.Is(err, apikeys.ErrInvalidScope),
+		errors.Is(err, apikeys.ErrInvalidIP),
+		errors.Is(err, apikeys.ErrInvalidExpires),
+		errors.Is(err, apikeys.ErrTooManyKeys):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, apikeys.ErrKeyNotFound):
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	default:
+		return fiber.NewError(fiber.StatusServiceUnavailable, apikeys.ErrStoreUnavailable.Error())
+	}
+}
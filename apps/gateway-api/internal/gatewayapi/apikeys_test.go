@@ -0,0 +1,142 @@
+package gatewayapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/apikeys"
+)
+
+func apiKeyRequest(t *testing.T, app *fiber.App, method, path, key string, body any) *http.Response {
+	t.Helper()
+	var raw []byte
+	if body != nil {
+		raw, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, path, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", key)
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	return res
+}
+
+func TestAPIKeyLifecycleAndScopes(t *testing.T) {
+	svc := &fakeTradingService{}
+	app := NewServer(Config{
+		JWTSecret:     "secret",
+		APIKeys:       map[string]string{"root-key": "u1"},
+		APIKeyService: apikeys.NewService(apikeys.NewMemoryStore()),
+	}, svc)
+
+	res := apiKeyRequest(t, app, http.MethodPost, "/v1/api-keys", "root-key", createAPIKeyRequest{Name: "reader", Scopes: []string{"read"}})
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("expected create status 201, got %d", res.StatusCode)
+	}
+	var created createAPIKeyResponse
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode create response failed: %v", err)
+	}
+	if created.Secret == "" || created.KeyID == "" {
+		t.Fatalf("unexpected create response %+v", created)
+	}
+
+	if res := apiKeyRequest(t, app, http.MethodGet, "/v1/wallet", created.Secret, nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected read-scoped wallet status 200, got %d", res.StatusCode)
+	}
+	order := map[string]any{"symbol": "BTC-USD", "side": "BUY", "type": "MARKET", "qty": 1}
+	if res := apiKeyRequest(t, app, http.MethodPost, "/v1/orders", created.Secret, order); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected read-scoped order status 403, got %d", res.StatusCode)
+	}
+	if res := apiKeyRequest(t, app, http.MethodPost, "/v1/api-keys", created.Secret, createAPIKeyRequest{}); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected read-scoped key creation status 403, got %d", res.StatusCode)
+	}
+
+	res = apiKeyRequest(t, app, http.MethodGet, "/v1/api-keys", "root-key", nil)
+	var listed []apiKeyResponse
+	if err := json.NewDecoder(res.Body).Decode(&listed); err != nil {
+		t.Fatalf("decode list response failed: %v", err)
+	}
+	if len(listed) != 1 || listed[0].KeyID != created.KeyID || listed[0].LastUsedAt == nil {
+		t.Fatalf("unexpected listing %+v", listed)
+	}
+
+	if res := apiKeyRequest(t, app, http.MethodDelete, "/v1/api-keys/"+created.KeyID, "root-key", nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected revoke status 200, got %d", res.StatusCode)
+	}
+	if res := apiKeyRequest(t, app, http.MethodGet, "/v1/wallet", created.Secret, nil); res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected revoked key status 401, got %d", res.StatusCode)
+	}
+}
+
+func TestAPIKeyAllowlistRejectsOtherIPs(t *testing.T) {
+	app := NewServer(Config{
+		JWTSecret:     "secret",
+		APIKeys:       map[string]string{"root-key": "u1"},
+		APIKeyService: apikeys.NewService(apikeys.NewMemoryStore()),
+	}, &fakeTradingService{})
+
+	res := apiKeyRequest(t, app, http.MethodPost, "/v1/api-keys", "root-key", createAPIKeyRequest{AllowedIPs: []string{"10.0.0.0/8"}})
+	var created createAPIKeyResponse
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode create response failed: %v", err)
+	}
+	if res := apiKeyRequest(t, app, http.MethodGet, "/v1/wallet", created.Secret, nil); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected allowlist status 403, got %d", res.StatusCode)
+	}
+}
+
+func TestAPIKeyManagementNeedsUnscopedCredentials(t *testing.T) {
+	app := NewServer(Config{
+		JWTSecret:     "secret",
+		APIKeys:       map[string]string{"root-key": "u1"},
+		APIKeyService: apikeys.NewService(apikeys.NewMemoryStore()),
+	}, &fakeTradingService{})
+
+	res := apiKeyRequest(t, app, http.MethodPost, "/v1/api-keys", "root-key", createAPIKeyRequest{Name: "main"})
+	var created createAPIKeyResponse
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode create response failed: %v", err)
+	}
+
+	scoped, _ := signAccessToken("secret", "u1", "sub-1", "trader", time.Hour)
+	if res := bearerRequest(t, app, http.MethodGet, "/v1/api-keys", scoped, nil); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected scoped list status 403, got %d", res.StatusCode)
+	}
+	if res := bearerRequest(t, app, http.MethodDelete, "/v1/api-keys/"+created.KeyID, scoped, nil); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected scoped revoke status 403, got %d", res.StatusCode)
+	}
+}
+
+func TestTradeScopedAPIKeyCannotRevokeKeys(t *testing.T) {
+	app := NewServer(Config{
+		JWTSecret:     "secret",
+		APIKeys:       map[string]string{"root-key": "u1"},
+		APIKeyService: apikeys.NewService(apikeys.NewMemoryStore()),
+	}, &fakeTradingService{})
+
+	operator, _ := signAccessToken("secret", "u1", "", "operator", time.Hour)
+	res := bearerRequest(t, app, http.MethodPost, "/v1/api-keys", operator, createAPIKeyRequest{Name: "ops", Scopes: []string{"read", "trade", "operator"}})
+	var privileged createAPIKeyResponse
+	if err := json.NewDecoder(res.Body).Decode(&privileged); err != nil {
+		t.Fatalf("decode create response failed: %v", err)
+	}
+	res = apiKeyRequest(t, app, http.MethodPost, "/v1/api-keys", "root-key", createAPIKeyRequest{Name: "bot", Scopes: []string{"trade"}})
+	var trader createAPIKeyResponse
+	if err := json.NewDecoder(res.Body).Decode(&trader); err != nil {
+		t.Fatalf("decode create response failed: %v", err)
+	}
+
+	if res := apiKeyRequest(t, app, http.MethodDelete, "/v1/api-keys/"+privileged.KeyID, trader.Secret, nil); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected trade-scoped revoke status 403, got %d", res.StatusCode)
+	}
+	if res := apiKeyRequest(t, app, http.MethodDelete, "/v1/api-keys/"+trader.KeyID, privileged.Secret, nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected operator-scoped revoke status 200, got %d", res.StatusCode)
+	}
+}
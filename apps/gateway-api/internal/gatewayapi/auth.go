@@ -0,0 +1,193 @@
+package gatewayapi
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"kalency/apps/gateway-api/internal/accounts"
+)
+
+const defaultAccessTokenTTL = 15 * time.Minute
+
+type credentialsRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// tokenRequest is credentialsRequest plus the bare userId and role that are
+// only honoured when the gateway runs with DevAuth.
+type tokenRequest struct {
+	UserID   string `json:"userId"`
+	Role     string `json:"role"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type tokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	ExpiresIn    int64  `json:"expiresIn"`
+}
+
+type registerResponse struct {
+	UserID   string `json:"userId"`
+	Username string `json:"username"`
+}
+
+func registerAuthRoutes(app *fiber.App, cfg Config, secret string) {
+	users := cfg.Accounts
+	accessTTL := accessTokenTTL(cfg)
+
+	issueTokens := func(c *fiber.Ctx, userID, role, refreshToken string) error {
+		signed, err := signAccessToken(secret, userID, "", role, accessTTL)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to sign token")
+		}
+		return c.JSON(tokenResponse{
+			Token:        signed,
+			RefreshToken: refreshToken,
+			ExpiresIn:    int64(accessTTL / time.Second),
+		})
+	}
+
+	login := func(c *fiber.Ctx, username, password string) error {
+		if users == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "accounts are not configured")
+		}
+		user, err := users.Login(c.UserContext(), username, password)
+		if err != nil {
+			return accountsError(err)
+		}
+		refreshToken, err := users.IssueRefreshToken(c.UserContext(), user.ID)
+		if err != nil {
+			return accountsError(err)
+		}
+		return issueTokens(c, user.ID, user.Role, refreshToken)
+	}
+
+	app.Post("/v1/auth/register", func(c *fiber.Ctx) error {
+		if users == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "accounts are not configured")
+		}
+		var req credentialsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+		user, err := users.Register(c.UserContext(), req.Username, req.Password)
+		if err != nil {
+			return accountsError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(registerResponse{UserID: user.ID, Username: user.Username})
+	})
+
+	app.Post("/v1/auth/login", func(c *fiber.Ctx) error {
+		var req credentialsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+		return login(c, req.Username, req.Password)
+	})
+
+	app.Post("/v1/auth/token", func(c *fiber.Ctx) error {
+		var req tokenRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+		if strings.TrimSpace(req.Username) != "" {
+			return login(c, req.Username, req.Password)
+		}
+
+		req.UserID = strings.TrimSpace(req.UserID)
+		if req.UserID == "" {
+			return fiber.NewError(fiber.StatusBadRequest, "username and password are required")
+		}
+		if !cfg.DevAuth {
+			return fiber.NewError(fiber.StatusUnauthorized, "userId tokens are only issued in dev auth mode")
+		}
+		role := strings.ToLower(strings.TrimSpace(req.Role))
+		if role == "" {
+			role = accounts.RoleTrader
+		}
+		if accounts.RoleRank(role) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, accounts.ErrInvalidRole.Error())
+		}
+		return issueTokens(c, req.UserID, role, "")
+	})
+
+	app.Post("/v1/auth/refresh", func(c *fiber.Ctx) error {
+		if users == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "accounts are not configured")
+		}
+		var req refreshRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+		userID, next, err := users.RotateRefreshToken(c.UserContext(), strings.TrimSpace(req.RefreshToken))
+		if err != nil {
+			return accountsError(err)
+		}
+		// Re-read the role so promotions and demotions apply on refresh.
+		user, err := users.User(c.UserContext(), userID)
+		if errors.Is(err, accounts.ErrUserNotFound) {
+			return fiber.NewError(fiber.StatusUnauthorized, accounts.ErrInvalidRefreshToken.Error())
+		}
+		if err != nil {
+			return accountsError(err)
+		}
+		return issueTokens(c, user.ID, user.Role, next)
+	})
+
+	app.Post("/v1/auth/logout", func(c *fiber.Ctx) error {
+		if users == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "accounts are not configured")
+		}
+		var req refreshRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+		if err := users.RevokeRefreshToken(c.UserContext(), strings.TrimSpace(req.RefreshToken)); err != nil {
+			return accountsError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+}
+
+func accessTokenTTL(cfg Config) time.Duration {
+	if cfg.AccessTokenTTL > 0 {
+		return cfg.AccessTokenTTL
+	}
+	return defaultAccessTokenTTL
+}
+
+// signAccessToken adds an accountId claim for tokens scoped to a sub-account.
+func signAccessToken(secret, userID, accountID, role string, ttl time.Duration) (string, error) {
+	claims := jwt.MapClaims{
+		"sub":  userID,
+		"role": role,
+		"exp":  time.Now().Add(ttl).Unix(),
+	}
+	if accountID != "" {
+		claims["accountId"] = accountID
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+func accountsError(err error) error {
+	switch {
+	case errors.Is(err, accounts.ErrInvalidUsername), errors.Is(err, accounts.ErrInvalidPassword):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, accounts.ErrUsernameTaken):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	case errors.Is(err, accounts.ErrInvalidCredentials), errors.Is(err, accounts.ErrInvalidRefreshToken):
+		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+	default:
+		return fiber.NewError(fiber.StatusInternalServerError, "account store unavailable")
+	}
+}
@@ -0,0 +1,101 @@
+package gatewayapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+func postJSON(t *testing.T, app *fiber.App, path string, body any) *http.Response {
+	t.Helper()
+	raw, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("POST %s failed: %v", path, err)
+	}
+	return res
+}
+
+func TestTokenEndpointRejectsBareUserIDOutsideDevAuth(t *testing.T) {
+	app := NewServer(Config{JWTSecret: "secret", Accounts: accounts.NewService(accounts.NewMemoryStore())}, &fakeTradingService{})
+
+	res := postJSON(t, app, "/v1/auth/token", map[string]string{"userId": "u1"})
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", res.StatusCode)
+	}
+}
+
+func TestRegisterLoginRefreshAndLogout(t *testing.T) {
+	svc := &fakeTradingService{walletByUser: map[string]contracts.Wallet{}}
+	app := NewServer(Config{JWTSecret: "secret", Accounts: accounts.NewService(accounts.NewMemoryStore())}, svc)
+	creds := map[string]string{"username": "alice", "password": "correct-horse"}
+
+	res := postJSON(t, app, "/v1/auth/register", creds)
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("expected register status 201, got %d", res.StatusCode)
+	}
+	var registered registerResponse
+	if err := json.NewDecoder(res.Body).Decode(&registered); err != nil {
+		t.Fatalf("decode register response failed: %v", err)
+	}
+	if res := postJSON(t, app, "/v1/auth/register", creds); res.StatusCode != http.StatusConflict {
+		t.Fatalf("expected duplicate register status 409, got %d", res.StatusCode)
+	}
+
+	bad := map[string]string{"username": "alice", "password": "wrong-password"}
+	if res := postJSON(t, app, "/v1/auth/login", bad); res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected bad login status 401, got %d", res.StatusCode)
+	}
+
+	res = postJSON(t, app, "/v1/auth/token", creds)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected login status 200, got %d", res.StatusCode)
+	}
+	var login tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&login); err != nil {
+		t.Fatalf("decode login response failed: %v", err)
+	}
+	if login.Token == "" || login.RefreshToken == "" || login.ExpiresIn != 900 {
+		t.Fatalf("unexpected login response %+v", login)
+	}
+
+	walletReq, _ := http.NewRequest(http.MethodGet, "/v1/wallet", nil)
+	walletReq.Header.Set("Authorization", "Bearer "+login.Token)
+	walletRes, err := app.Test(walletReq)
+	if err != nil {
+		t.Fatalf("wallet request failed: %v", err)
+	}
+	var wallet contracts.Wallet
+	if err := json.NewDecoder(walletRes.Body).Decode(&wallet); err != nil {
+		t.Fatalf("decode wallet failed: %v", err)
+	}
+	if wallet.UserID != registered.UserID {
+		t.Fatalf("expected wallet for %s, got %s", registered.UserID, wallet.UserID)
+	}
+
+	res = postJSON(t, app, "/v1/auth/refresh", refreshRequest{RefreshToken: login.RefreshToken})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected refresh status 200, got %d", res.StatusCode)
+	}
+	var refreshed tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&refreshed); err != nil {
+		t.Fatalf("decode refresh response failed: %v", err)
+	}
+	if refreshed.RefreshToken == "" || refreshed.RefreshToken == login.RefreshToken {
+		t.Fatalf("expected a rotated refresh token, got %+v", refreshed)
+	}
+
+	if res := postJSON(t, app, "/v1/auth/logout", refreshRequest{RefreshToken: refreshed.RefreshToken}); res.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected logout status 204, got %d", res.StatusCode)
+	}
+	if res := postJSON(t, app, "/v1/auth/refresh", refreshRequest{RefreshToken: refreshed.RefreshToken}); res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected refresh after logout status 401, got %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,52 @@
+package gatewayapi
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/killswitch"
+)
+
+// errorHandler answers every error as {code, message, details}. Errors from
+// the engine or simulator keep their code; plain fiber errors get the code
+// for their status.
+func errorHandler(c *fiber.Ctx, err error) error {
+	var apiErr *contracts.APIError
+	if !errors.As(err, &apiErr) {
+		status := fiber.StatusInternalServerError
+		message := err.Error()
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			status = fiberErr.Code
+			message = fiberErr.Message
+		}
+		apiErr = &contracts.APIError{Code: contracts.ErrorCodeForStatus(status), Message: message}
+		return c.Status(status).JSON(apiErr)
+	}
+	return c.Status(apiErr.HTTPStatus()).JSON(apiErr)
+}
+
+// upstreamError passes on errors from the engine or simulator. The clients
+// give every upstream failure a code, so an error without one is a gateway
+// fault rather than the caller's.
+func upstreamError(err error) error {
+	var apiErr *contracts.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return &contracts.APIError{Code: contracts.ErrorInternal, Message: err.Error()}
+}
+
+// killSwitchError stops an order list the way the engine would.
+func killSwitchError(killSwitch contracts.KillSwitch) error {
+	code := contracts.ErrorSymbolHalted
+	if killSwitch.Scope == killswitch.ScopeUser {
+		code = contracts.ErrorTradingDisabled
+	}
+	return &contracts.APIError{
+		Code:    code,
+		Message: killswitch.Message(killSwitch),
+		Details: map[string]string{"rejectCode": rejectKillSwitch},
+	}
+}
@@ -0,0 +1,121 @@
+package gatewayapi
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+// FundingAdmin settles deposits and withdrawals the matching engine holds
+// for review.
+type FundingAdmin interface {
+	PendingFunding() ([]contracts.FundingOperation, error)
+	CompleteFunding(id string) (contracts.FundingOperation, error)
+	RejectFunding(id, reason string) (contracts.FundingOperation, error)
+}
+
+// fundingRequest names the account by accountId like orders do; an empty one
+// is the main account.
+type fundingRequest struct {
+	RequestID string `json:"requestId"`
+	AccountID string `json:"accountId"`
+	Asset     string `json:"asset"`
+	Amount    int64  `json:"amount"`
+}
+
+type rejectFundingRequest struct {
+	Reason string `json:"reason"`
+}
+
+func requireRequestID(requestID string) error {
+	if strings.TrimSpace(requestID) == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "requestId is required")
+	}
+	return nil
+}
+
+func registerFundingRoutes(protected fiber.Router, cfg Config, trading TradingService) {
+	fund := func(request func(contracts.FundingRequest) (contracts.FundingOperation, error)) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			var req fundingRequest
+			if err := c.BodyParser(&req); err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+			}
+			if err := requireRequestID(req.RequestID); err != nil {
+				return err
+			}
+			identity := c.Locals(authLocalKey).(authIdentity)
+			userID, err := resolveAccount(c, cfg.Accounts, identity, req.AccountID)
+			if err != nil {
+				return err
+			}
+
+			op, err := request(contracts.FundingRequest{RequestID: req.RequestID, UserID: userID, Asset: req.Asset, Amount: req.Amount})
+			if err != nil {
+				return upstreamError(err)
+			}
+			return c.JSON(op)
+		}
+	}
+	protected.Post("/wallet/deposits", fund(trading.Deposit))
+	protected.Post("/wallet/withdrawals", fund(trading.Withdraw))
+
+	protected.Get("/wallet/history", func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+		userID, err := resolveAccount(c, cfg.Accounts, identity, c.Query("accountId"))
+		if err != nil {
+			return err
+		}
+		limit := c.QueryInt("limit", 100)
+		if limit <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "limit must be positive")
+		}
+		history, err := trading.FundingHistory(userID, limit)
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(history)
+	})
+}
+
+func registerFundingAdminRoutes(admin fiber.Router, engine FundingAdmin) {
+	admin.Get("/funding/pending", func(c *fiber.Ctx) error {
+		if engine == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "matching engine admin API is not configured")
+		}
+		pending, err := engine.PendingFunding()
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(pending)
+	})
+
+	admin.Post("/funding/:id/complete", func(c *fiber.Ctx) error {
+		if engine == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "matching engine admin API is not configured")
+		}
+		op, err := engine.CompleteFunding(c.Params("id"))
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(op)
+	})
+
+	admin.Post("/funding/:id/reject", func(c *fiber.Ctx) error {
+		if engine == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "matching engine admin API is not configured")
+		}
+		var req rejectFundingRequest
+		if len(c.Body()) > 0 {
+			if err := c.BodyParser(&req); err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+			}
+		}
+		op, err := engine.RejectFunding(c.Params("id"), req.Reason)
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(op)
+	})
+}
@@ -0,0 +1,85 @@
+package gatewayapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+type fakeFundingAdmin struct {
+	completed []string
+	rejected  map[string]string
+}
+
+func (f *fakeFundingAdmin) PendingFunding() ([]contracts.FundingOperation, error) {
+	return []contracts.FundingOperation{{ID: "fnd-2", Type: contracts.FundingWithdrawal, Status: contracts.FundingPending}}, nil
+}
+
+func (f *fakeFundingAdmin) CompleteFunding(id string) (contracts.FundingOperation, error) {
+	f.completed = append(f.completed, id)
+	return contracts.FundingOperation{ID: id, Status: contracts.FundingCompleted}, nil
+}
+
+func (f *fakeFundingAdmin) RejectFunding(id, reason string) (contracts.FundingOperation, error) {
+	f.rejected[id] = reason
+	return contracts.FundingOperation{ID: id, Status: contracts.FundingRejected, Reason: reason}, nil
+}
+
+func TestFundingRoutesResolveAccountsAndRequireRequestIDs(t *testing.T) {
+	svc := &fakeTradingService{}
+	app := NewServer(Config{JWTSecret: "secret", DevAuth: true, Accounts: accounts.NewService(accounts.NewMemoryStore())}, svc)
+	token := devToken(t, app, "u1", "trader")
+
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/wallet/deposits", token, fundingRequest{Asset: "USD", Amount: 10}); res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 without requestId, got %d", res.StatusCode)
+	}
+
+	res := bearerRequest(t, app, http.MethodPost, "/v1/wallet/withdrawals", token, fundingRequest{RequestID: "w1", Asset: "USD", Amount: 10})
+	if res.StatusCode != http.StatusOK || svc.lastFunding.UserID != "u1" || svc.lastFunding.RequestID != "w1" {
+		t.Fatalf("expected withdrawal for u1, got %d %+v", res.StatusCode, svc.lastFunding)
+	}
+	var op contracts.FundingOperation
+	if err := json.NewDecoder(res.Body).Decode(&op); err != nil || op.Type != contracts.FundingWithdrawal {
+		t.Fatalf("unexpected operation %+v %v", op, err)
+	}
+
+	svc.fundingErr = &contracts.APIError{Code: contracts.ErrorFundingLimit, Message: "deposit of 10 USD is above the limit of 5"}
+	res = bearerRequest(t, app, http.MethodPost, "/v1/wallet/deposits", token, fundingRequest{RequestID: "d1", Asset: "USD", Amount: 10})
+	var apiErr contracts.APIError
+	if err := json.NewDecoder(res.Body).Decode(&apiErr); err != nil || res.StatusCode != http.StatusUnprocessableEntity || apiErr.Code != contracts.ErrorFundingLimit {
+		t.Fatalf("expected 422 FUNDING_LIMIT_EXCEEDED, got %d %+v", res.StatusCode, apiErr)
+	}
+
+	if res := bearerRequest(t, app, http.MethodGet, "/v1/wallet/history?accountId=sub-missing", token, nil); res.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown sub-account, got %d", res.StatusCode)
+	}
+	res = bearerRequest(t, app, http.MethodGet, "/v1/wallet/history", token, nil)
+	var history []contracts.FundingOperation
+	if err := json.NewDecoder(res.Body).Decode(&history); err != nil || len(history) != 1 || svc.historyUser != "u1" {
+		t.Fatalf("unexpected history %+v %v", history, err)
+	}
+}
+
+func TestFundingAdminRoutesNeedOperators(t *testing.T) {
+	engine := &fakeFundingAdmin{rejected: map[string]string{}}
+	app := NewServer(Config{JWTSecret: "secret", DevAuth: true, FundingAdmin: engine}, &fakeTradingService{})
+	operator := devToken(t, app, "ops1", "operator")
+	trader := devToken(t, app, "u1", "")
+
+	if res := bearerRequest(t, app, http.MethodGet, "/v1/admin/funding/pending", trader, nil); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected trader status 403, got %d", res.StatusCode)
+	}
+	if res := bearerRequest(t, app, http.MethodGet, "/v1/admin/funding/pending", operator, nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected pending status 200, got %d", res.StatusCode)
+	}
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/admin/funding/fnd-2/complete", operator, nil); res.StatusCode != http.StatusOK || len(engine.completed) != 1 {
+		t.Fatalf("expected completion, got %d %v", res.StatusCode, engine.completed)
+	}
+	res := bearerRequest(t, app, http.MethodPost, "/v1/admin/funding/fnd-3/reject", operator, rejectFundingRequest{Reason: "suspicious"})
+	if res.StatusCode != http.StatusOK || engine.rejected["fnd-3"] != "suspicious" {
+		t.Fatalf("expected rejection with reason, got %d %v", res.StatusCode, engine.rejected)
+	}
+}
@@ -0,0 +1,161 @@
+package gatewayapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/killswitch"
+)
+
+const rejectKillSwitch = "KILL_SWITCH"
+
+// KillSwitchEngine applies kill switches on the matching engine, which
+// cancels the resting orders they cover.
+type KillSwitchEngine interface {
+	ActivateKillSwitch(killSwitch contracts.KillSwitch) (contracts.KillSwitchResult, error)
+	ReleaseKillSwitch(scope, target string) error
+}
+
+type killSwitchRequest struct {
+	Scope  string `json:"scope"`
+	Target string `json:"target"`
+	Reason string `json:"reason"`
+}
+
+// killSwitchAck answers an order stopped at the gateway the way the engine
+// answers one it rejects.
+func killSwitchAck(killSwitch contracts.KillSwitch, req contracts.PlaceOrderRequest) contracts.OrderAck {
+	return contracts.OrderAck{
+		Status:        contracts.OrderStatusRejected,
+		ClientOrderID: req.ClientOrderID,
+		Symbol:        req.Symbol,
+		RejectCode:    rejectKillSwitch,
+		RejectReason:  killswitch.Message(killSwitch),
+		TS:            time.Now().UTC(),
+	}
+}
+
+// placeOrdersUnlessKilled sends the batch orders no kill switch covers to the
+// engine and answers the rest with rejections, keeping request order.
+func placeOrdersUnlessKilled(trading TradingService, killSwitches *killswitch.Service, reqs []contracts.PlaceOrderRequest) ([]contracts.BatchResult, error) {
+	if killSwitches == nil {
+		return trading.PlaceOrders(reqs)
+	}
+
+	results := make([]contracts.BatchResult, len(reqs))
+	allowed := make([]contracts.PlaceOrderRequest, 0, len(reqs))
+	allowedAt := make([]int, 0, len(reqs))
+	for i, req := range reqs {
+		if killSwitch, ok := killSwitches.Check(req.UserID, req.Symbol); ok {
+			ack := killSwitchAck(killSwitch, req)
+			results[i] = contracts.BatchResult{Ack: &ack}
+			continue
+		}
+		allowed = append(allowed, req)
+		allowedAt = append(allowedAt, i)
+	}
+	if len(allowed) == 0 {
+		return results, nil
+	}
+
+	placed, err := trading.PlaceOrders(allowed)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range placed {
+		if i < len(allowedAt) {
+			results[allowedAt[i]] = result
+		}
+	}
+	return results, nil
+}
+
+// registerKillSwitchRoutes stores kill switches at the gateway first, so
+// orders through it stop even if the engine cannot be reached, and then has
+// the engine cancel resting orders.
+func registerKillSwitchRoutes(admin fiber.Router, killSwitches *killswitch.Service, engine KillSwitchEngine) {
+	admin.Get("/kill-switches", func(c *fiber.Ctx) error {
+		if killSwitches == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "kill switches are not configured")
+		}
+		return c.JSON(killSwitches.List())
+	})
+
+	admin.Post("/kill-switches", func(c *fiber.Ctx) error {
+		if killSwitches == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "kill switches are not configured")
+		}
+		var req killSwitchRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+
+		identity := c.Locals(authLocalKey).(authIdentity)
+		ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+		defer cancel()
+		killSwitch, err := killSwitches.Activate(ctx, contracts.KillSwitch{
+			Scope:     req.Scope,
+			Target:    req.Target,
+			Reason:    req.Reason,
+			CreatedBy: identity.UserID,
+		})
+		switch {
+		case errors.Is(err, killswitch.ErrInvalidScope), errors.Is(err, killswitch.ErrTargetRequired):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case err != nil:
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to store kill switch")
+		}
+
+		if engine == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "kill switch stored but the matching engine admin API is not configured; resting orders were not canceled")
+		}
+		result, err := engine.ActivateKillSwitch(killSwitch)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadGateway, "kill switch stored but the matching engine did not apply it: "+err.Error())
+		}
+		return c.JSON(contracts.KillSwitchResult{KillSwitch: killSwitch, Canceled: result.Canceled})
+	})
+
+	admin.Delete("/kill-switches", func(c *fiber.Ctx) error {
+		if killSwitches == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "kill switches are not configured")
+		}
+		scope, target, err := killswitch.Normalize(c.Query("scope"), c.Query("target"))
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		// Release on the engine first so a failure leaves the switch in
+		// place everywhere rather than only at the gateway.
+		if engine != nil {
+			if err := engine.ReleaseKillSwitch(scope, target); err != nil {
+				return fiber.NewError(fiber.StatusBadGateway, "matching engine did not release the kill switch: "+err.Error())
+			}
+		}
+		ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+		defer cancel()
+		err = killSwitches.Release(ctx, scope, target)
+		switch {
+		case errors.Is(err, killswitch.ErrNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err != nil:
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to release kill switch")
+		}
+		return c.JSON(killSwitches.List())
+	})
+}
+
+// SyncKillSwitches re-applies the stored kill switches on the engine, which
+// may have lost them if it runs without Redis.
+func SyncKillSwitches(killSwitches *killswitch.Service, engine KillSwitchEngine) error {
+	var errs []error
+	for _, killSwitch := range killSwitches.List() {
+		if _, err := engine.ActivateKillSwitch(killSwitch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,97 @@
+package gatewayapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/killswitch"
+)
+
+type fakeKillSwitchEngine struct {
+	activated []contracts.KillSwitch
+	released  []string
+}
+
+func (f *fakeKillSwitchEngine) ActivateKillSwitch(killSwitch contracts.KillSwitch) (contracts.KillSwitchResult, error) {
+	f.activated = append(f.activated, killSwitch)
+	return contracts.KillSwitchResult{
+		KillSwitch: killSwitch,
+		Canceled:   []contracts.OrderAck{{OrderID: "ord-1", Status: contracts.OrderStatusCanceled}},
+	}, nil
+}
+
+func (f *fakeKillSwitchEngine) ReleaseKillSwitch(scope, target string) error {
+	f.released = append(f.released, scope+":"+target)
+	return nil
+}
+
+func TestKillSwitchStopsOrdersUntilReleased(t *testing.T) {
+	killSwitches, err := killswitch.NewService(context.Background(), killswitch.NewMemoryStore())
+	if err != nil {
+		t.Fatalf("new service: %v", err)
+	}
+	engine := &fakeKillSwitchEngine{}
+	trading := &fakeTradingService{}
+	app := NewServer(Config{
+		JWTSecret:        "secret",
+		DevAuth:          true,
+		KillSwitches:     killSwitches,
+		KillSwitchEngine: engine,
+	}, trading)
+
+	operator := devToken(t, app, "ops1", "operator")
+	trader := devToken(t, app, "bot", "")
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/admin/kill-switches", trader, map[string]string{"scope": "USER", "target": "bot"}); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected trader status 403, got %d", res.StatusCode)
+	}
+
+	res := bearerRequest(t, app, http.MethodPost, "/v1/admin/kill-switches", operator, map[string]string{"scope": "user", "target": "bot", "reason": "runaway"})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", res.StatusCode)
+	}
+	var result contracts.KillSwitchResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if result.KillSwitch.Scope != killswitch.ScopeUser || result.KillSwitch.CreatedBy != "ops1" || len(result.Canceled) != 1 {
+		t.Fatalf("unexpected kill switch result %+v", result)
+	}
+	if len(engine.activated) != 1 || engine.activated[0].Target != "bot" {
+		t.Fatalf("expected the engine to apply the kill switch, got %+v", engine.activated)
+	}
+
+	order := map[string]any{"symbol": "BTC-USD", "side": "BUY", "type": "LIMIT", "price": 10, "qty": 1}
+	res = bearerRequest(t, app, http.MethodPost, "/v1/orders", trader, order)
+	var ack contracts.OrderAck
+	if err := json.NewDecoder(res.Body).Decode(&ack); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if res.StatusCode != http.StatusOK || ack.RejectCode != "KILL_SWITCH" || ack.RejectReason != "trading is disabled for bot: runaway" {
+		t.Fatalf("expected KILL_SWITCH rejection, got %d %+v", res.StatusCode, ack)
+	}
+	if trading.lastPlaceReq.UserID != "" {
+		t.Fatal("expected the order not to reach the engine")
+	}
+
+	res = bearerRequest(t, app, http.MethodPost, "/v1/orders/batch", trader, map[string]any{"orders": []any{order}})
+	var batch contracts.OrderBatchResponse
+	if err := json.NewDecoder(res.Body).Decode(&batch); err != nil || len(batch.Results) != 1 || batch.Results[0].Ack.RejectCode != "KILL_SWITCH" {
+		t.Fatalf("expected a rejected batch result, got %+v err=%v", batch, err)
+	}
+
+	if res := bearerRequest(t, app, http.MethodDelete, "/v1/admin/kill-switches?scope=USER&target=bot", operator, nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected release status 200, got %d", res.StatusCode)
+	}
+	if len(engine.released) != 1 || engine.released[0] != "USER:bot" {
+		t.Fatalf("expected the engine to release the kill switch, got %v", engine.released)
+	}
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/orders", trader, order); res.StatusCode != http.StatusCreated {
+		t.Fatalf("expected order accepted after release, got %d", res.StatusCode)
+	}
+	if res := bearerRequest(t, app, http.MethodDelete, "/v1/admin/kill-switches?scope=USER&target=bot", operator, nil); res.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for an inactive kill switch, got %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,103 @@
+package gatewayapi
+
+import (
+	"sync/atomic"
+
+	"kalency/apps/gateway-api/internal/streamhub"
+)
+
+// connMetrics counts WebSocket connections on one endpoint.
+type connMetrics struct {
+	active        atomic.Int64
+	opened        atomic.Int64
+	slowConsumers atomic.Int64
+}
+
+type ConnStats struct {
+	Active        int64 `json:"active"`
+	Opened        int64 `json:"opened"`
+	SlowConsumers int64 `json:"slowConsumers"`
+}
+
+// open records a new connection and returns the func that records its close.
+func (m *connMetrics) open() func() {
+	m.opened.Add(1)
+	m.active.Add(1)
+	return func() { m.active.Add(-1) }
+}
+
+func (m *connMetrics) slowConsumer() {
+	m.slowConsumers.Add(1)
+}
+
+func (m *connMetrics) stats() ConnStats {
+	return ConnStats{
+		Active:        m.active.Load(),
+		Opened:        m.opened.Load(),
+		SlowConsumers: m.slowConsumers.Load(),
+	}
+}
+
+type streamMetrics struct {
+	ws     connMetrics
+	trades connMetrics
+	ticks  connMetrics
+	sse    connMetrics
+}
+
+// StreamStats is the body of GET /v1/admin/streams.
+type StreamStats struct {
+	Connections map[string]ConnStats       `json:"connections"`
+	Hubs        map[string]streamhub.Stats `json:"hubs"`
+}
+
+func (m *streamMetrics) snapshot(cfg Config) StreamStats {
+	out := StreamStats{
+		Connections: map[string]ConnStats{
+			"ws":     m.ws.stats(),
+			"trades": m.trades.stats(),
+			"ticks":  m.ticks.stats(),
+			"sse":    m.sse.stats(),
+		},
+		Hubs: map[string]streamhub.Stats{},
+	}
+	if cfg.TickHub != nil {
+		out.Hubs["ticks"] = cfg.TickHub.Stats()
+	}
+	if cfg.TradeHub != nil {
+		out.Hubs["trades"] = cfg.TradeHub.Stats()
+	}
+	if cfg.BookHubs != nil {
+		out.Hubs["book"] = cfg.BookHubs.Stats()
+	}
+	if cfg.CandleHub != nil {
+		out.Hubs["candles"] = cfg.CandleHub.Stats()
+	}
+	if cfg.UserEventHub != nil {
+		out.Hubs["private"] = cfg.UserEventHub.Stats()
+	}
+	return out
+}
+
+// rateLimitMetrics counts rate limit decisions across route classes.
+type rateLimitMetrics struct {
+	allowed  atomic.Int64
+	denied   atomic.Int64
+	failOpen atomic.Int64
+}
+
+// RateLimitStats is the body of GET /v1/admin/rate-limits. FailOpen counts
+// requests let through because the limiter could not be reached.
+type RateLimitStats struct {
+	Allowed  int64 `json:"allowed"`
+	Denied   int64 `json:"denied"`
+	FailOpen int64 `json:"failOpen"`
+}
+
+func (m *rateLimitMetrics) stats() RateLimitStats {
+	return RateLimitStats{
+		Allowed:  m.allowed.Load(),
+		Denied:   m.denied.Load(),
+		FailOpen: m.failOpen.Load(),
+	}
+}
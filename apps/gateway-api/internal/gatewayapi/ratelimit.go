@@ -0,0 +1,106 @@
+package gatewayapi
+
+import (
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/ratelimit"
+)
+
+// Route classes with their own rate limits.
+const (
+	RouteClassOrders     = "orders"
+	RouteClassMarketData = "market-data"
+	RouteClassAuth       = "auth"
+)
+
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error)
+}
+
+// DefaultRateLimits apply to route classes missing from Config.RateLimits.
+func DefaultRateLimits() map[string]ratelimit.Limit {
+	return map[string]ratelimit.Limit{
+		RouteClassOrders:     {Requests: 50, Window: time.Second},
+		RouteClassMarketData: {Requests: 100, Window: time.Second},
+		RouteClassAuth:       {Requests: 10, Window: time.Minute},
+	}
+}
+
+func routeClass(path string) string {
+	switch {
+	case strings.HasPrefix(path, "/v1/auth/"):
+		return RouteClassAuth
+	case path == "/v1/orders", strings.HasPrefix(path, "/v1/orders/"):
+		return RouteClassOrders
+	case strings.HasPrefix(path, "/v1/markets/"), path == "/v1/tickers":
+		return RouteClassMarketData
+	default:
+		return ""
+	}
+}
+
+// rateLimit counts requests per subject and route class, and a request must
+// fit every subject's bucket. Routes outside a class are not limited, and
+// requests are let through when the limiter fails so a Redis outage does not
+// take trading down with it; those are logged and counted as fail-opens.
+func rateLimit(limiter RateLimiter, limits map[string]ratelimit.Limit, subjects func(*fiber.Ctx) []string, metrics *rateLimitMetrics) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		class := routeClass(c.Path())
+		limit, ok := limits[class]
+		if limiter == nil || !ok {
+			return c.Next()
+		}
+
+		var tightest ratelimit.Result
+		for i, subject := range subjects(c) {
+			ctx, cancel := context.WithTimeout(c.UserContext(), 500*time.Millisecond)
+			result, err := limiter.Allow(ctx, subject+":"+class, limit)
+			cancel()
+			if err != nil {
+				metrics.failOpen.Add(1)
+				log.Printf("rate limiter unavailable, allowing request: %v", err)
+				return c.Next()
+			}
+			if i == 0 || !result.Allowed || result.Remaining < tightest.Remaining {
+				tightest = result
+			}
+			if !result.Allowed {
+				break
+			}
+		}
+
+		reset := strconv.Itoa(int(math.Ceil(tightest.ResetAfter.Seconds())))
+		c.Set("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		c.Set("RateLimit-Reset", reset)
+		if !tightest.Allowed {
+			metrics.denied.Add(1)
+			c.Set(fiber.HeaderRetryAfter, reset)
+			return fiber.NewError(fiber.StatusTooManyRequests, "rate limit exceeded for "+class)
+		}
+		metrics.allowed.Add(1)
+		return c.Next()
+	}
+}
+
+// identitySubjects limits everyone per user, and store-backed API keys
+// individually as well, so minting more keys does not raise a user's quota.
+// The user bucket is charged first so a denied request costs no key quota.
+func identitySubjects(c *fiber.Ctx) []string {
+	identity := c.Locals(authLocalKey).(authIdentity)
+	subjects := []string{"user:" + identity.UserID}
+	if identity.KeyID != "" {
+		subjects = append(subjects, "key:"+identity.KeyID)
+	}
+	return subjects
+}
+
+func ipSubject(c *fiber.Ctx) []string {
+	return []string{"ip:" + c.IP()}
+}
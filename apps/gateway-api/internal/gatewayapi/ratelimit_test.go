@@ -0,0 +1,144 @@
+package gatewayapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/apikeys"
+	"kalency/apps/gateway-api/internal/ratelimit"
+)
+
+func newRateLimitedServer(t *testing.T, limits map[string]ratelimit.Limit) *fiber.App {
+	t.Helper()
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mini.Close)
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewServer(Config{
+		JWTSecret:   "secret",
+		DevAuth:     true,
+		APIKeys:     map[string]string{"k1": "u1", "k2": "u2"},
+		RateLimiter: ratelimit.NewRedisLimiter(client, "v1"),
+		RateLimits:  limits,
+	}, &fakeTradingService{})
+}
+
+func TestRateLimitPerUserAndRouteClass(t *testing.T) {
+	app := newRateLimitedServer(t, map[string]ratelimit.Limit{
+		RouteClassOrders: {Requests: 2, Window: time.Minute},
+	})
+
+	for i := 0; i < 2; i++ {
+		res := apiKeyRequest(t, app, http.MethodGet, "/v1/orders/open", "k1", nil)
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: expected status 200, got %d", i, res.StatusCode)
+		}
+		if res.Header.Get("RateLimit-Limit") != "2" {
+			t.Fatalf("expected RateLimit-Limit 2, got %q", res.Header.Get("RateLimit-Limit"))
+		}
+	}
+
+	res := apiKeyRequest(t, app, http.MethodGet, "/v1/orders/open", "k1", nil)
+	if res.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", res.StatusCode)
+	}
+	if res.Header.Get("RateLimit-Remaining") != "0" || res.Header.Get("Retry-After") == "" {
+		t.Fatalf("unexpected rate limit headers %v", res.Header)
+	}
+
+	if res := apiKeyRequest(t, app, http.MethodGet, "/v1/orders/open", "k2", nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected other user status 200, got %d", res.StatusCode)
+	}
+	if res := apiKeyRequest(t, app, http.MethodGet, "/v1/markets/BTC-USD/book", "k1", nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected market data status 200, got %d", res.StatusCode)
+	}
+	if res := apiKeyRequest(t, app, http.MethodGet, "/v1/wallet", "k1", nil); res.StatusCode != http.StatusOK || res.Header.Get("RateLimit-Limit") != "" {
+		t.Fatalf("expected unclassified route to be unlimited, got %d %v", res.StatusCode, res.Header)
+	}
+}
+
+func TestRateLimitAuthRoutesPerIP(t *testing.T) {
+	app := newRateLimitedServer(t, map[string]ratelimit.Limit{
+		RouteClassAuth: {Requests: 1, Window: time.Minute},
+	})
+
+	if res := postJSON(t, app, "/v1/auth/token", map[string]string{"userId": "u1"}); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected first token status 200, got %d", res.StatusCode)
+	}
+	if res := postJSON(t, app, "/v1/auth/token", map[string]string{"userId": "u2"}); res.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected second token status 429, got %d", res.StatusCode)
+	}
+}
+
+func TestRateLimitChargesTheUserAcrossStoredKeys(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mini.Close)
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	app := NewServer(Config{
+		APIKeys:       map[string]string{"root-key": "u1"},
+		APIKeyService: apikeys.NewService(apikeys.NewMemoryStore()),
+		RateLimiter:   ratelimit.NewRedisLimiter(client, "v1"),
+		RateLimits:    map[string]ratelimit.Limit{RouteClassOrders: {Requests: 2, Window: time.Minute}},
+	}, &fakeTradingService{})
+
+	secrets := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		res := apiKeyRequest(t, app, http.MethodPost, "/v1/api-keys", "root-key", createAPIKeyRequest{Scopes: []string{"read"}})
+		var created createAPIKeyResponse
+		if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+			t.Fatalf("decode create response failed: %v", err)
+		}
+		secrets = append(secrets, created.Secret)
+	}
+
+	for i, secret := range secrets {
+		if res := apiKeyRequest(t, app, http.MethodGet, "/v1/orders/open", secret, nil); res.StatusCode != http.StatusOK {
+			t.Fatalf("key %d: expected status 200, got %d", i, res.StatusCode)
+		}
+	}
+	if res := apiKeyRequest(t, app, http.MethodGet, "/v1/orders/open", "root-key", nil); res.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the shared user bucket to be spent, got %d", res.StatusCode)
+	}
+}
+
+func TestRateLimitCountsFailOpens(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	mini.Close()
+	app := NewServer(Config{
+		JWTSecret:   "secret",
+		APIKeys:     map[string]string{"k1": "u1"},
+		RateLimiter: ratelimit.NewRedisLimiter(client, "v1"),
+		RateLimits:  map[string]ratelimit.Limit{RouteClassOrders: {Requests: 1, Window: time.Minute}},
+	}, &fakeTradingService{})
+
+	if res := apiKeyRequest(t, app, http.MethodGet, "/v1/orders/open", "k1", nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected fail-open status 200, got %d", res.StatusCode)
+	}
+	operator, _ := signAccessToken("secret", "ops1", "", "operator", time.Hour)
+	res := bearerRequest(t, app, http.MethodGet, "/v1/admin/rate-limits", operator, nil)
+	var stats RateLimitStats
+	if err := json.NewDecoder(res.Body).Decode(&stats); err != nil {
+		t.Fatalf("decode stats failed: %v", err)
+	}
+	if stats.FailOpen != 1 || stats.Allowed != 0 {
+		t.Fatalf("expected one fail-open request, got %+v", stats)
+	}
+}
@@ -0,0 +1,113 @@
+package gatewayapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/audit"
+)
+
+type setRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type userResponse struct {
+	UserID   string `json:"userId"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func requireRole(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+		if accounts.RoleRank(identity.Role) < accounts.RoleRank(role) {
+			return fiber.NewError(fiber.StatusForbidden, "requires the "+role+" role")
+		}
+		return c.Next()
+	}
+}
+
+// recordAudit writes every non-GET request it wraps to the audit log,
+// including ones refused further down the chain. A failing log does not fail
+// the request.
+func recordAudit(auditLog audit.Log) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+			return c.Next()
+		}
+
+		err := c.Next()
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		// Fiber reuses request buffers, so strings kept past the handler are copied.
+		identity := c.Locals(authLocalKey).(authIdentity)
+		entry := audit.Entry{
+			Time:     time.Now().UTC(),
+			UserID:   identity.UserID,
+			Role:     identity.Role,
+			KeyID:    identity.KeyID,
+			Method:   strings.Clone(c.Method()),
+			Path:     strings.Clone(c.Path()),
+			Status:   status,
+			RemoteIP: strings.Clone(c.IP()),
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if recordErr := auditLog.Record(ctx, entry); recordErr != nil {
+			log.Printf("audit log write failed: %v (entry=%+v)", recordErr, entry)
+		}
+		return err
+	}
+}
+
+func registerAdminRoutes(admin fiber.Router, users *accounts.Service, auditLog audit.Log) {
+	admin.Put("/users/:userId/role", requireRole(accounts.RoleAdmin), func(c *fiber.Ctx) error {
+		if users == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "accounts are not configured")
+		}
+		var req setRoleRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+
+		user, err := users.SetRole(c.UserContext(), c.Params("userId"), strings.ToLower(strings.TrimSpace(req.Role)))
+		switch {
+		case errors.Is(err, accounts.ErrInvalidRole):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, accounts.ErrUserNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err != nil:
+			return accountsError(err)
+		}
+		return c.JSON(userResponse{UserID: user.ID, Username: user.Username, Role: user.Role})
+	})
+
+	admin.Get("/audit", requireRole(accounts.RoleAdmin), func(c *fiber.Ctx) error {
+		limit := 100
+		if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed <= 0 || parsed > 1000 {
+				return fiber.NewError(fiber.StatusBadRequest, "limit must be between 1 and 1000")
+			}
+			limit = parsed
+		}
+		entries, err := auditLog.Recent(c.UserContext(), limit)
+		if err != nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "audit log unavailable")
+		}
+		return c.JSON(entries)
+	})
+}
@@ -0,0 +1,134 @@
+package gatewayapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/audit"
+)
+
+func devToken(t *testing.T, app *fiber.App, userID, role string) string {
+	t.Helper()
+	res := postJSON(t, app, "/v1/auth/token", map[string]string{"userId": userID, "role": role})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected dev token status 200, got %d", res.StatusCode)
+	}
+	var token tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+		t.Fatalf("decode token failed: %v", err)
+	}
+	return token.Token
+}
+
+func bearerRequest(t *testing.T, app *fiber.App, method, path, token string, body any) *http.Response {
+	t.Helper()
+	var raw []byte
+	if body != nil {
+		raw, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, path, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	return res
+}
+
+func TestAdminRoutesRequireOperatorRoleAndAreAudited(t *testing.T) {
+	auditLog := audit.NewMemoryLog()
+	app := NewServer(Config{
+		JWTSecret:    "secret",
+		DevAuth:      true,
+		AdminService: &fakeAdminService{},
+		AuditLog:     auditLog,
+	}, &fakeTradingService{})
+
+	trader := devToken(t, app, "u1", "")
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/admin/sim/start", trader, nil); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected trader status 403, got %d", res.StatusCode)
+	}
+	operator := devToken(t, app, "ops1", "operator")
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/admin/sim/start", operator, nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected operator status 200, got %d", res.StatusCode)
+	}
+	if res := bearerRequest(t, app, http.MethodGet, "/v1/admin/audit", operator, nil); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected operator audit read status 403, got %d", res.StatusCode)
+	}
+
+	entries, _ := auditLog.Recent(context.Background(), 10)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %+v", entries)
+	}
+	if entries[0].UserID != "ops1" || entries[0].Role != "operator" || entries[0].Status != http.StatusOK || entries[0].Path != "/v1/admin/sim/start" {
+		t.Fatalf("unexpected operator entry %+v", entries[0])
+	}
+	if entries[1].UserID != "u1" || entries[1].Status != http.StatusForbidden {
+		t.Fatalf("unexpected trader entry %+v", entries[1])
+	}
+
+	admin := devToken(t, app, "root", "admin")
+	res := bearerRequest(t, app, http.MethodGet, "/v1/admin/audit?limit=1", admin, nil)
+	var recent []audit.Entry
+	if err := json.NewDecoder(res.Body).Decode(&recent); err != nil {
+		t.Fatalf("decode audit failed: %v", err)
+	}
+	if len(recent) != 1 || recent[0].UserID != "ops1" {
+		t.Fatalf("unexpected audit response %+v", recent)
+	}
+}
+
+func TestAdminCanAssignRoles(t *testing.T) {
+	users := accounts.NewService(accounts.NewMemoryStore())
+	app := NewServer(Config{JWTSecret: "secret", DevAuth: true, Accounts: users}, &fakeTradingService{})
+	user, err := users.Register(context.Background(), "alice", "correct-horse")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	operator := devToken(t, app, "ops1", "operator")
+	if res := bearerRequest(t, app, http.MethodPut, "/v1/admin/users/"+user.ID+"/role", operator, setRoleRequest{Role: "admin"}); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected operator role change status 403, got %d", res.StatusCode)
+	}
+
+	admin := devToken(t, app, "root", "admin")
+	if res := bearerRequest(t, app, http.MethodPut, "/v1/admin/users/"+user.ID+"/role", admin, setRoleRequest{Role: "operator"}); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected role change status 200, got %d", res.StatusCode)
+	}
+
+	res := postJSON(t, app, "/v1/auth/login", map[string]string{"username": "alice", "password": "correct-horse"})
+	var login tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&login); err != nil {
+		t.Fatalf("decode login failed: %v", err)
+	}
+	identity, err := parseToken(login.Token, "secret")
+	if err != nil {
+		t.Fatalf("parse token: %v", err)
+	}
+	if identity.Role != accounts.RoleOperator {
+		t.Fatalf("expected operator role claim, got %q", identity.Role)
+	}
+}
+
+func TestStaticAPIKeysCannotReachAdminRoutes(t *testing.T) {
+	app := NewServer(Config{
+		APIKeys:      map[string]string{"k1": "ops1"},
+		AdminService: &fakeAdminService{},
+	}, &fakeTradingService{})
+
+	req, _ := http.NewRequest(http.MethodPost, "/v1/admin/sim/start", nil)
+	req.Header.Set("X-API-Key", "k1")
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected static key status 403, got %d", res.StatusCode)
+	}
+}
@@ -3,34 +3,50 @@ package gatewayapi
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/websocket/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/apikeys"
+	"kalency/apps/gateway-api/internal/audit"
+	"kalency/apps/gateway-api/internal/candlestream"
 	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/killswitch"
+	"kalency/apps/gateway-api/internal/ratelimit"
+	"kalency/apps/gateway-api/internal/streamhub"
 	"kalency/apps/gateway-api/internal/tickstream"
+	"kalency/apps/gateway-api/signing"
 )
 
 type TradingService interface {
 	PlaceOrder(req contracts.PlaceOrderRequest) (contracts.OrderAck, error)
 	CancelOrder(userID, orderID string) (contracts.OrderAck, error)
+	PlaceOrders(reqs []contracts.PlaceOrderRequest) ([]contracts.BatchResult, error)
+	CancelOrders(userID string, orderIDs []string) ([]contracts.BatchResult, error)
+	PlaceOrderList(req contracts.PlaceOrderListRequest) (contracts.OrderListAck, error)
+	CancelOrderList(userID, listID string) (contracts.OrderListAck, error)
 	OpenOrders(userID string) ([]contracts.Order, error)
 	Wallet(userID string) (contracts.Wallet, error)
+	Transfer(req contracts.TransferRequest) (contracts.TransferResult, error)
+	Deposit(req contracts.FundingRequest) (contracts.FundingOperation, error)
+	Withdraw(req contracts.FundingRequest) (contracts.FundingOperation, error)
+	FundingHistory(userID string, limit int) ([]contracts.FundingOperation, error)
 	ListExecutions(symbol string, limit int) ([]contracts.Execution, error)
 	ListOrderBook(symbol string, depth int) (contracts.OrderBookSnapshot, error)
+	Ticker(symbol string) (contracts.Ticker, error)
+	Tickers() ([]contracts.Ticker, error)
 }
 
 type CandleService interface {
 	ListCandles(symbol, timeframe string, from, to time.Time) ([]contracts.Candle, error)
 }
 
-type TickSource interface {
-	Read(ctx context.Context, lastID string, count int, block time.Duration) ([]tickstream.Tick, string, error)
-}
-
 type AdminService interface {
 	StartSimulator() (map[string]any, error)
 	StopSimulator() (map[string]any, error)
@@ -41,74 +57,207 @@ type AdminService interface {
 }
 
 type Config struct {
-	JWTSecret     string
+	JWTSecret string
+	// AccessTokenTTL is the lifetime of issued JWTs; it defaults to 15m.
+	AccessTokenTTL time.Duration
+	// DevAuth lets POST /v1/auth/token mint a token for a bare userId
+	// without credentials. Never enable it outside local development.
+	DevAuth  bool
+	Accounts *accounts.Service
+	// APIKeys are plaintext key:user pairs from API_KEYS for bootstrapping.
+	// They always act as traders: operator and admin access goes through
+	// the hashed, scoped keys in APIKeyService or account JWTs.
 	APIKeys       map[string]string
-	CandleService CandleService
-	AdminService  AdminService
-	TickSource    TickSource
+	APIKeyService *apikeys.Service
+	// AuditLog records admin actions; it defaults to an in-memory log.
+	AuditLog audit.Log
+	// SignatureWindow is how far a signed request's timestamp may be from
+	// the gateway clock; it defaults to 30s.
+	SignatureWindow time.Duration
+	CandleService   CandleService
+	AdminService    AdminService
+	TickHub         *streamhub.Hub
+	TradeHub        *streamhub.Hub
+	BookHubs        *streamhub.Registry
+	UserEventHub    *streamhub.Hub
+	CandleHub       *streamhub.Hub
+
+	// RateLimiter enforces RateLimits, keyed by route class and merged over
+	// DefaultRateLimits. Without a limiter nothing is limited.
+	RateLimiter RateLimiter
+	RateLimits  map[string]ratelimit.Limit
+
+	// KillSwitches stops orders at the gateway; KillSwitchEngine applies
+	// them on the matching engine.
+	KillSwitches     *killswitch.Service
+	KillSwitchEngine KillSwitchEngine
+
+	// FundingAdmin settles deposits and withdrawals held for review.
+	FundingAdmin FundingAdmin
 }
 
-type tokenRequest struct {
-	UserID string `json:"userId"`
+type authIdentity struct {
+	UserID string
+	Role   string
+	// AccountID is set for JWTs and API keys scoped to one sub-account.
+	AccountID string
+	// KeyID and Scopes are set for keys from the API key store. Nil Scopes
+	// means unrestricted: JWTs and API_KEYS entries.
+	KeyID  string
+	Scopes []string
 }
 
-type tokenResponse struct {
-	Token string `json:"token"`
+func (i authIdentity) allows(scope string) bool {
+	return i.Scopes == nil || slices.Contains(i.Scopes, scope)
 }
 
-type authIdentity struct {
-	UserID string
+// keyIdentity carries a stored key's role in its operator or admin scope.
+func keyIdentity(key apikeys.Key) authIdentity {
+	role := accounts.RoleTrader
+	switch {
+	case key.HasScope(apikeys.ScopeAdmin):
+		role = accounts.RoleAdmin
+	case key.HasScope(apikeys.ScopeOperator):
+		role = accounts.RoleOperator
+	}
+	return authIdentity{UserID: key.UserID, Role: role, AccountID: key.AccountID, KeyID: key.ID, Scopes: key.Scopes}
+}
+
+// requiredScope maps a route to the API key scope it needs: reads need read
+// and writes need trade. Admin routes are gated by role instead.
+func requiredScope(method, path string) string {
+	switch {
+	case strings.HasPrefix(path, "/v1/admin/"):
+		return ""
+	case method == fiber.MethodGet || method == fiber.MethodHead:
+		return apikeys.ScopeRead
+	default:
+		return apikeys.ScopeTrade
+	}
 }
 
 const authLocalKey = "auth.identity"
 
+const maxStreamReplay = 1000
+
 func NewServer(cfg Config, trading TradingService) *fiber.App {
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: errorHandler})
 	secret := cfg.JWTSecret
 	if secret == "" {
 		secret = "dev-secret"
 	}
 	candleService := cfg.CandleService
 	adminService := cfg.AdminService
-	tickSource := cfg.TickSource
+	tickHub := cfg.TickHub
+	tradeHub := cfg.TradeHub
+	metrics := &streamMetrics{}
+	limitMetrics := &rateLimitMetrics{}
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Authorization,Content-Type,X-API-Key",
-		AllowMethods: "GET,POST,DELETE,OPTIONS",
+		AllowOrigins:  "*",
+		AllowHeaders:  "Authorization,Content-Type,X-API-Key,X-API-Key-Id,X-API-Timestamp,X-API-Nonce,X-API-Signature,Last-Event-ID",
+		AllowMethods:  "GET,POST,PUT,DELETE,OPTIONS",
+		ExposeHeaders: "RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,Retry-After",
 	}))
 
 	app.Get("/healthz", func(c *fiber.Ctx) error {
 		return c.SendString("ok")
 	})
 
-	app.Post("/v1/auth/token", func(c *fiber.Ctx) error {
-		var req tokenRequest
+	rateLimits := DefaultRateLimits()
+	for class, limit := range cfg.RateLimits {
+		rateLimits[class] = limit
+	}
+	app.Use("/v1/auth", rateLimit(cfg.RateLimiter, rateLimits, ipSubject, limitMetrics))
+	registerAuthRoutes(app, cfg, secret)
+	auth := newAuthenticator(cfg, secret)
+
+	protected := app.Group("/v1", requireAuth(auth), rateLimit(cfg.RateLimiter, rateLimits, identitySubjects, limitMetrics))
+
+	protected.Post("/orders", func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+
+		var req contracts.PlaceOrderRequest
 		if err := c.BodyParser(&req); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
 		}
+
 		req.UserID = strings.TrimSpace(req.UserID)
-		if req.UserID == "" {
-			return fiber.NewError(fiber.StatusBadRequest, "userId is required")
+		if req.UserID != "" && req.UserID != identity.UserID {
+			return fiber.NewError(fiber.StatusForbidden, "userId does not match authenticated identity")
+		}
+		userID, err := resolveAccount(c, cfg.Accounts, identity, req.AccountID)
+		if err != nil {
+			return err
+		}
+		req.UserID = userID
+		if cfg.KillSwitches != nil {
+			if killSwitch, ok := cfg.KillSwitches.Check(req.UserID, req.Symbol); ok {
+				return c.JSON(killSwitchAck(killSwitch, req))
+			}
 		}
 
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-			"sub": req.UserID,
-			"exp": time.Now().Add(24 * time.Hour).Unix(),
-		})
-		signed, err := token.SignedString([]byte(secret))
+		ack, err := trading.PlaceOrder(req)
 		if err != nil {
-			return fiber.NewError(fiber.StatusInternalServerError, "failed to sign token")
+			return upstreamError(err)
+		}
+		// Risk rejections come back as REJECTED acks with a rejectCode.
+		if ack.Status == contracts.OrderStatusRejected {
+			return c.JSON(ack)
 		}
-		return c.JSON(tokenResponse{Token: signed})
+		return c.Status(fiber.StatusCreated).JSON(ack)
 	})
 
-	protected := app.Group("/v1", requireAuth(secret, cfg.APIKeys))
+	protected.Post("/orders/batch", func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+
+		var req contracts.PlaceOrderBatchRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
 
-	protected.Post("/orders", func(c *fiber.Ctx) error {
+		for i := range req.Orders {
+			userID := strings.TrimSpace(req.Orders[i].UserID)
+			if userID != "" && userID != identity.UserID {
+				return fiber.NewError(fiber.StatusForbidden, "userId does not match authenticated identity")
+			}
+			userID, err := resolveAccount(c, cfg.Accounts, identity, req.Orders[i].AccountID)
+			if err != nil {
+				return err
+			}
+			req.Orders[i].UserID = userID
+		}
+
+		results, err := placeOrdersUnlessKilled(trading, cfg.KillSwitches, req.Orders)
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(contracts.OrderBatchResponse{Results: results})
+	})
+
+	protected.Delete("/orders/batch", func(c *fiber.Ctx) error {
 		identity := c.Locals(authLocalKey).(authIdentity)
 
-		var req contracts.PlaceOrderRequest
+		var req contracts.CancelOrderBatchRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+
+		userID, err := resolveAccount(c, cfg.Accounts, identity, c.Query("accountId"))
+		if err != nil {
+			return err
+		}
+		results, err := trading.CancelOrders(userID, req.OrderIDs)
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(contracts.OrderBatchResponse{Results: results})
+	})
+
+	protected.Post("/orders/lists", func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+
+		var req contracts.PlaceOrderListRequest
 		if err := c.BodyParser(&req); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
 		}
@@ -117,15 +266,42 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 		if req.UserID != "" && req.UserID != identity.UserID {
 			return fiber.NewError(fiber.StatusForbidden, "userId does not match authenticated identity")
 		}
-		req.UserID = identity.UserID
+		userID, err := resolveAccount(c, cfg.Accounts, identity, req.AccountID)
+		if err != nil {
+			return err
+		}
+		req.UserID = userID
+		if cfg.KillSwitches != nil {
+			if killSwitch, ok := cfg.KillSwitches.Check(req.UserID, req.Symbol); ok {
+				return killSwitchError(killSwitch)
+			}
+		}
 
-		ack, err := trading.PlaceOrder(req)
+		ack, err := trading.PlaceOrderList(req)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.Status(fiber.StatusCreated).JSON(ack)
 	})
 
+	protected.Delete("/orders/lists/:listId", func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+		listID := strings.TrimSpace(c.Params("listId"))
+		if listID == "" {
+			return fiber.NewError(fiber.StatusBadRequest, "listId is required")
+		}
+
+		userID, err := resolveAccount(c, cfg.Accounts, identity, c.Query("accountId"))
+		if err != nil {
+			return err
+		}
+		ack, err := trading.CancelOrderList(userID, listID)
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(ack)
+	})
+
 	protected.Delete("/orders/:orderId", func(c *fiber.Ctx) error {
 		identity := c.Locals(authLocalKey).(authIdentity)
 		orderID := strings.TrimSpace(c.Params("orderId"))
@@ -133,54 +309,87 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 			return fiber.NewError(fiber.StatusBadRequest, "orderId is required")
 		}
 
-		ack, err := trading.CancelOrder(identity.UserID, orderID)
+		userID, err := resolveAccount(c, cfg.Accounts, identity, c.Query("accountId"))
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return err
+		}
+		ack, err := trading.CancelOrder(userID, orderID)
+		if err != nil {
+			return upstreamError(err)
 		}
 		return c.JSON(ack)
 	})
 
 	protected.Get("/orders/open", func(c *fiber.Ctx) error {
 		identity := c.Locals(authLocalKey).(authIdentity)
-		orders, err := trading.OpenOrders(identity.UserID)
+		userID, err := resolveAccount(c, cfg.Accounts, identity, c.Query("accountId"))
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return err
+		}
+		orders, err := trading.OpenOrders(userID)
+		if err != nil {
+			return upstreamError(err)
 		}
 		return c.JSON(orders)
 	})
 
+	registerAPIKeyRoutes(protected, cfg.APIKeyService, cfg.Accounts)
+	registerSubAccountRoutes(protected, cfg, trading, secret)
+
 	protected.Get("/wallet", func(c *fiber.Ctx) error {
 		identity := c.Locals(authLocalKey).(authIdentity)
-		wallet, err := trading.Wallet(identity.UserID)
+		userID, err := resolveAccount(c, cfg.Accounts, identity, c.Query("accountId"))
+		if err != nil {
+			return err
+		}
+		wallet, err := trading.Wallet(userID)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(wallet)
 	})
+	registerFundingRoutes(protected, cfg, trading)
 
-	protected.Post("/admin/sim/start", func(c *fiber.Ctx) error {
+	auditLog := cfg.AuditLog
+	if auditLog == nil {
+		auditLog = audit.NewMemoryLog()
+	}
+	admin := protected.Group("/admin", recordAudit(auditLog), requireRole(accounts.RoleOperator))
+	registerAdminRoutes(admin, cfg.Accounts, auditLog)
+	registerKillSwitchRoutes(admin, cfg.KillSwitches, cfg.KillSwitchEngine)
+	registerFundingAdminRoutes(admin, cfg.FundingAdmin)
+
+	admin.Get("/streams", func(c *fiber.Ctx) error {
+		return c.JSON(metrics.snapshot(cfg))
+	})
+
+	admin.Get("/rate-limits", func(c *fiber.Ctx) error {
+		return c.JSON(limitMetrics.stats())
+	})
+
+	admin.Post("/sim/start", func(c *fiber.Ctx) error {
 		if adminService == nil {
 			return fiber.NewError(fiber.StatusServiceUnavailable, "admin service unavailable")
 		}
 		out, err := adminService.StartSimulator()
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(out)
 	})
 
-	protected.Post("/admin/sim/stop", func(c *fiber.Ctx) error {
+	admin.Post("/sim/stop", func(c *fiber.Ctx) error {
 		if adminService == nil {
 			return fiber.NewError(fiber.StatusServiceUnavailable, "admin service unavailable")
 		}
 		out, err := adminService.StopSimulator()
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(out)
 	})
 
-	protected.Post("/admin/sim/volatility-profile", func(c *fiber.Ctx) error {
+	admin.Post("/sim/volatility-profile", func(c *fiber.Ctx) error {
 		if adminService == nil {
 			return fiber.NewError(fiber.StatusServiceUnavailable, "admin service unavailable")
 		}
@@ -192,12 +401,12 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 		}
 		out, err := adminService.SetVolatility(req.Volatility)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(out)
 	})
 
-	protected.Post("/admin/symbols/:symbol/pause", func(c *fiber.Ctx) error {
+	admin.Post("/symbols/:symbol/pause", func(c *fiber.Ctx) error {
 		if adminService == nil {
 			return fiber.NewError(fiber.StatusServiceUnavailable, "admin service unavailable")
 		}
@@ -207,12 +416,12 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 		}
 		out, err := adminService.PauseSymbol(symbol)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(out)
 	})
 
-	protected.Post("/admin/symbols/:symbol/resume", func(c *fiber.Ctx) error {
+	admin.Post("/symbols/:symbol/resume", func(c *fiber.Ctx) error {
 		if adminService == nil {
 			return fiber.NewError(fiber.StatusServiceUnavailable, "admin service unavailable")
 		}
@@ -222,12 +431,12 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 		}
 		out, err := adminService.ResumeSymbol(symbol)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(out)
 	})
 
-	protected.Post("/admin/symbols/:symbol/ensure", func(c *fiber.Ctx) error {
+	admin.Post("/symbols/:symbol/ensure", func(c *fiber.Ctx) error {
 		if adminService == nil {
 			return fiber.NewError(fiber.StatusServiceUnavailable, "admin service unavailable")
 		}
@@ -237,7 +446,7 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 		}
 		out, err := adminService.EnsureSymbol(symbol)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(out)
 	})
@@ -254,7 +463,7 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 
 		trades, err := trading.ListExecutions(symbol, limit)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(trades)
 	})
@@ -271,11 +480,32 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 
 		snapshot, err := trading.ListOrderBook(symbol, depth)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(snapshot)
 	})
 
+	app.Get("/v1/markets/:symbol/ticker", func(c *fiber.Ctx) error {
+		symbol := strings.TrimSpace(c.Params("symbol"))
+		if symbol == "" {
+			return fiber.NewError(fiber.StatusBadRequest, "symbol is required")
+		}
+
+		ticker, err := trading.Ticker(symbol)
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(ticker)
+	})
+
+	app.Get("/v1/tickers", func(c *fiber.Ctx) error {
+		tickers, err := trading.Tickers()
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(tickers)
+	})
+
 	app.Get("/v1/markets/:symbol/candles", func(c *fiber.Ctx) error {
 		if candleService == nil {
 			return fiber.NewError(fiber.StatusServiceUnavailable, "candle service unavailable")
@@ -302,12 +532,42 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 
 		candles, err := candleService.ListCandles(symbol, timeframe, from, to)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return upstreamError(err)
 		}
 		return c.JSON(candles)
 	})
 
+	app.Get("/v1/markets/:symbol/trades/stream", sseStream(tradeHub, "trade", &metrics.sse, func(c *fiber.Ctx) (string, error) {
+		return requiredSymbol(c)
+	}))
+
+	app.Get("/v1/markets/:symbol/ticks/stream", sseStream(tickHub, "tick", &metrics.sse, func(c *fiber.Ctx) (string, error) {
+		symbol, err := requiredSymbol(c)
+		return tickstream.Key(symbol), err
+	}))
+
+	app.Get("/v1/markets/:symbol/candles/stream", sseStream(cfg.CandleHub, "candle", &metrics.sse, func(c *fiber.Ctx) (string, error) {
+		symbol, err := requiredSymbol(c)
+		if err != nil {
+			return "", err
+		}
+		timeframe := normalizeTimeframe(c.Query("tf", "1m"))
+		if !isSupportedTimeframe(timeframe) {
+			return "", fiber.NewError(fiber.StatusBadRequest, "unsupported timeframe")
+		}
+		return candlestream.Key(symbol, timeframe), nil
+	}))
+
+	feeds := &wsFeeds{trading: trading, trades: tradeHub, books: cfg.BookHubs, private: cfg.UserEventHub, candles: cfg.CandleHub, metrics: &metrics.ws}
+	app.Get("/ws", websocketAuth(auth), websocket.New(feeds.handler(secret)))
+
 	app.Get("/ws/trades/:symbol", websocket.New(func(conn *websocket.Conn) {
+		if tradeHub == nil {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"error":"trade stream not enabled"}`))
+			_ = conn.Close()
+			return
+		}
+
 		symbol := strings.TrimSpace(conn.Params("symbol"))
 		if symbol == "" {
 			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"error":"symbol is required"}`))
@@ -315,31 +575,63 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 			return
 		}
 
-		ticker := time.NewTicker(1 * time.Second)
-		defer ticker.Stop()
+		defer metrics.trades.open()()
+		sub := tradeHub.Subscribe(symbol)
+		defer sub.Close()
+		var disconnected atomic.Bool
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					disconnected.Store(true)
+					sub.Close()
+					return
+				}
+			}
+		}()
 
-		sentTradeIDs := map[string]struct{}{}
-		for range ticker.C {
-			trades, err := trading.ListExecutions(symbol, 20)
+		lastID := ""
+		if from := strings.TrimSpace(conn.Query("from")); from != "" {
+			backlog, resumeFrom, err := tradeHub.Replay(context.Background(), symbol, from, maxStreamReplay)
 			if err != nil {
 				_ = conn.WriteJSON(map[string]any{"type": "error", "message": err.Error()})
-				continue
+				_ = conn.Close()
+				return
 			}
-			for _, trade := range trades {
-				if _, seen := sentTradeIDs[trade.TradeID]; seen {
-					continue
-				}
-				if err := conn.WriteJSON(map[string]any{"type": "trade", "data": trade}); err != nil {
+			for _, message := range backlog {
+				if err := conn.WriteJSON(map[string]any{"type": "trade", "id": message.ID, "data": message.Data}); err != nil {
 					_ = conn.Close()
 					return
 				}
-				sentTradeIDs[trade.TradeID] = struct{}{}
+				lastID = message.ID
+			}
+			// Going live now would skip everything after the replay window.
+			if resumeFrom != "" {
+				_ = conn.WriteJSON(map[string]any{"type": "resync", "id": resumeFrom, "message": "replay truncated, reconnect with ?from=" + resumeFrom})
+				_ = conn.Close()
+				return
+			}
+		}
+
+		for message := range sub.Messages() {
+			if lastID != "" && streamhub.CompareIDs(message.ID, lastID) <= 0 {
+				continue
+			}
+			if err := conn.WriteJSON(map[string]any{"type": "trade", "id": message.ID, "data": message.Data}); err != nil {
+				_ = conn.Close()
+				return
 			}
+			lastID = message.ID
 		}
+
+		if !disconnected.Load() {
+			metrics.trades.slowConsumer()
+		}
+		_ = conn.WriteJSON(map[string]any{"type": "error", "message": "subscription closed, reconnect with ?from=" + lastID})
+		_ = conn.Close()
 	}))
 
 	app.Get("/ws/ticks/:symbol", websocket.New(func(conn *websocket.Conn) {
-		if tickSource == nil {
+		if tickHub == nil {
 			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"error":"tick stream not enabled"}`))
 			_ = conn.Close()
 			return
@@ -352,31 +644,49 @@ func NewServer(cfg Config, trading TradingService) *fiber.App {
 			return
 		}
 
-		lastID := "$"
-		for {
-			ticks, nextID, err := tickSource.Read(context.Background(), lastID, 250, 2*time.Second)
-			if err != nil {
-				_ = conn.WriteJSON(map[string]any{"type": "error", "message": err.Error()})
-				lastID = nextID
-				continue
+		defer metrics.ticks.open()()
+		sub := tickHub.Subscribe(tickstream.Key(symbol))
+		defer sub.Close()
+		var disconnected atomic.Bool
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					disconnected.Store(true)
+					sub.Close()
+					return
+				}
 			}
-			lastID = nextID
+		}()
 
-			for _, tick := range ticks {
-				if !strings.EqualFold(strings.TrimSpace(tick.Symbol), symbol) {
-					continue
-				}
-				if err := conn.WriteJSON(map[string]any{"type": "tick", "data": tick}); err != nil {
-					_ = conn.Close()
-					return
+		for message := range sub.Messages() {
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteJSON(map[string]any{"type": "tick", "id": message.ID, "data": message.Data}); err != nil {
+				if !disconnected.Load() {
+					metrics.ticks.slowConsumer()
 				}
+				_ = conn.Close()
+				return
 			}
 		}
+
+		if !disconnected.Load() {
+			metrics.ticks.slowConsumer()
+			_ = conn.WriteJSON(map[string]any{"type": "error", "message": "slow consumer, reconnect"})
+		}
+		_ = conn.Close()
 	}))
 
 	return app
 }
 
+func requiredSymbol(c *fiber.Ctx) (string, error) {
+	symbol := strings.TrimSpace(c.Params("symbol"))
+	if symbol == "" {
+		return "", fiber.NewError(fiber.StatusBadRequest, "symbol is required")
+	}
+	return symbol, nil
+}
+
 func normalizeTimeframe(value string) string {
 	return strings.TrimSpace(strings.ToLower(value))
 }
@@ -402,24 +712,63 @@ func parseOptionalTime(raw string) (time.Time, error) {
 	return parsed.UTC(), nil
 }
 
-func requireAuth(jwtSecret string, apiKeys map[string]string) fiber.Handler {
+// authenticator resolves X-API-Key and bearer JWT credentials. Keys from
+// API_KEYS are checked before the hashed key store and carry no scope limits.
+type authenticator struct {
+	jwtSecret       string
+	staticKeys      map[string]string
+	apiKeys         *apikeys.Service
+	signatureWindow time.Duration
+	nonces          *nonceCache
+	now             func() time.Time
+}
+
+func newAuthenticator(cfg Config, jwtSecret string) *authenticator {
+	window := cfg.SignatureWindow
+	if window <= 0 {
+		window = defaultSignatureWindow
+	}
+	return &authenticator{
+		jwtSecret:       jwtSecret,
+		staticKeys:      cfg.APIKeys,
+		apiKeys:         cfg.APIKeyService,
+		signatureWindow: window,
+		nonces:          newNonceCache(window),
+		now:             time.Now,
+	}
+}
+
+func requireAuth(auth *authenticator) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		identity, err := authenticate(c, jwtSecret, apiKeys)
+		identity, err := auth.authenticate(c)
 		if err != nil {
-			return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+			return authError(err)
+		}
+		if scope := requiredScope(c.Method(), c.Path()); scope != "" && !identity.allows(scope) {
+			return fiber.NewError(fiber.StatusForbidden, "API key lacks the "+scope+" scope")
 		}
 		c.Locals(authLocalKey, identity)
 		return c.Next()
 	}
 }
 
-func authenticate(c *fiber.Ctx, jwtSecret string, apiKeys map[string]string) (authIdentity, error) {
+func (a *authenticator) authenticate(c *fiber.Ctx) (authIdentity, error) {
+	if c.Get(signing.HeaderKeyID) != "" {
+		return a.authenticateSigned(c)
+	}
 	apiKey := strings.TrimSpace(c.Get("X-API-Key"))
 	if apiKey != "" {
-		if userID, ok := apiKeys[apiKey]; ok && strings.TrimSpace(userID) != "" {
-			return authIdentity{UserID: strings.TrimSpace(userID)}, nil
+		if userID, ok := a.staticKeys[apiKey]; ok && strings.TrimSpace(userID) != "" {
+			return authIdentity{UserID: strings.TrimSpace(userID), Role: accounts.RoleTrader}, nil
 		}
-		return authIdentity{}, errors.New("invalid API key")
+		if a.apiKeys == nil {
+			return authIdentity{}, apikeys.ErrInvalidKey
+		}
+		key, err := a.apiKeys.Authenticate(c.UserContext(), apiKey, c.IP())
+		if err != nil {
+			return authIdentity{}, err
+		}
+		return keyIdentity(key), nil
 	}
 
 	authorization := strings.TrimSpace(c.Get("Authorization"))
@@ -430,7 +779,22 @@ func authenticate(c *fiber.Ctx, jwtSecret string, apiKeys map[string]string) (au
 	if rawToken == "" {
 		return authIdentity{}, errors.New("missing bearer token")
 	}
+	return parseToken(rawToken, a.jwtSecret)
+}
 
+// authError reports credential failures as 401 and a failing key store as 503.
+func authError(err error) error {
+	switch {
+	case errors.Is(err, apikeys.ErrIPNotAllowed):
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apikeys.ErrStoreUnavailable):
+		return fiber.NewError(fiber.StatusServiceUnavailable, apikeys.ErrStoreUnavailable.Error())
+	default:
+		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+	}
+}
+
+func parseToken(rawToken, jwtSecret string) (authIdentity, error) {
 	parsed, err := jwt.Parse(rawToken, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -453,5 +817,11 @@ func authenticate(c *fiber.Ctx, jwtSecret string, apiKeys map[string]string) (au
 	if subject == "" {
 		return authIdentity{}, errors.New("token subject missing")
 	}
-	return authIdentity{UserID: subject}, nil
+	// Tokens minted before roles existed carry no role claim.
+	role, _ := claims["role"].(string)
+	if accounts.RoleRank(role) == 0 {
+		role = accounts.RoleTrader
+	}
+	accountID, _ := claims["accountId"].(string)
+	return authIdentity{UserID: subject, Role: role, AccountID: accountID}, nil
 }
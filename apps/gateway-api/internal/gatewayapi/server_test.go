@@ -3,6 +3,7 @@ package gatewayapi
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 	"time"
@@ -11,13 +12,26 @@ import (
 )
 
 type fakeTradingService struct {
-	lastPlaceReq contracts.PlaceOrderRequest
-	walletByUser map[string]contracts.Wallet
-	bookBySymbol map[string]contracts.OrderBookSnapshot
+	lastPlaceReq     contracts.PlaceOrderRequest
+	lastListReq      contracts.PlaceOrderListRequest
+	lastCanceledList string
+	lastBatchReqs    []contracts.PlaceOrderRequest
+	lastBatchCancel  []string
+	walletByUser     map[string]contracts.Wallet
+	bookBySymbol     map[string]contracts.OrderBookSnapshot
+	tickers          []contracts.Ticker
+	placeErr         error
+	lastTransfer     contracts.TransferRequest
+	lastFunding      contracts.FundingRequest
+	fundingErr       error
+	historyUser      string
 }
 
 func (f *fakeTradingService) PlaceOrder(req contracts.PlaceOrderRequest) (contracts.OrderAck, error) {
 	f.lastPlaceReq = req
+	if f.placeErr != nil {
+		return contracts.OrderAck{}, f.placeErr
+	}
 	return contracts.OrderAck{OrderID: "ord-1", Status: contracts.OrderStatusAccepted}, nil
 }
 
@@ -25,6 +39,34 @@ func (f *fakeTradingService) CancelOrder(userID, orderID string) (contracts.Orde
 	return contracts.OrderAck{OrderID: orderID, Status: contracts.OrderStatusCanceled}, nil
 }
 
+func (f *fakeTradingService) PlaceOrders(reqs []contracts.PlaceOrderRequest) ([]contracts.BatchResult, error) {
+	f.lastBatchReqs = reqs
+	results := make([]contracts.BatchResult, 0, len(reqs))
+	for _, req := range reqs {
+		results = append(results, contracts.BatchResult{Ack: &contracts.OrderAck{ClientOrderID: req.ClientOrderID, Status: contracts.OrderStatusAccepted}})
+	}
+	return results, nil
+}
+
+func (f *fakeTradingService) CancelOrders(userID string, orderIDs []string) ([]contracts.BatchResult, error) {
+	f.lastBatchCancel = orderIDs
+	results := make([]contracts.BatchResult, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		results = append(results, contracts.BatchResult{Error: "order not found: " + orderID + " for " + userID})
+	}
+	return results, nil
+}
+
+func (f *fakeTradingService) PlaceOrderList(req contracts.PlaceOrderListRequest) (contracts.OrderListAck, error) {
+	f.lastListReq = req
+	return contracts.OrderListAck{ListID: "lst-1", Type: req.Type, Status: "ACTIVE"}, nil
+}
+
+func (f *fakeTradingService) CancelOrderList(userID, listID string) (contracts.OrderListAck, error) {
+	f.lastCanceledList = listID
+	return contracts.OrderListAck{ListID: listID, Status: "ALL_DONE"}, nil
+}
+
 func (f *fakeTradingService) OpenOrders(userID string) ([]contracts.Order, error) {
 	return []contracts.Order{}, nil
 }
@@ -36,6 +78,34 @@ func (f *fakeTradingService) Wallet(userID string) (contracts.Wallet, error) {
 	return contracts.Wallet{UserID: userID, Available: map[string]int64{"USD": 100000}, Reserved: map[string]int64{}}, nil
 }
 
+func (f *fakeTradingService) Transfer(req contracts.TransferRequest) (contracts.TransferResult, error) {
+	f.lastTransfer = req
+	from, _ := f.Wallet(req.FromUserID)
+	to, _ := f.Wallet(req.ToUserID)
+	return contracts.TransferResult{From: from, To: to}, nil
+}
+
+func (f *fakeTradingService) Deposit(req contracts.FundingRequest) (contracts.FundingOperation, error) {
+	return f.fund(contracts.FundingDeposit, req)
+}
+
+func (f *fakeTradingService) Withdraw(req contracts.FundingRequest) (contracts.FundingOperation, error) {
+	return f.fund(contracts.FundingWithdrawal, req)
+}
+
+func (f *fakeTradingService) fund(kind string, req contracts.FundingRequest) (contracts.FundingOperation, error) {
+	f.lastFunding = req
+	if f.fundingErr != nil {
+		return contracts.FundingOperation{}, f.fundingErr
+	}
+	return contracts.FundingOperation{ID: "fnd-1", RequestID: req.RequestID, Type: kind, Status: contracts.FundingCompleted, UserID: req.UserID, Asset: req.Asset, Amount: req.Amount}, nil
+}
+
+func (f *fakeTradingService) FundingHistory(userID string, limit int) ([]contracts.FundingOperation, error) {
+	f.historyUser = userID
+	return []contracts.FundingOperation{{ID: "fnd-1", Type: contracts.FundingDeposit, Status: contracts.FundingCompleted, UserID: userID}}, nil
+}
+
 func (f *fakeTradingService) ListExecutions(symbol string, limit int) ([]contracts.Execution, error) {
 	return []contracts.Execution{}, nil
 }
@@ -47,6 +117,19 @@ func (f *fakeTradingService) ListOrderBook(symbol string, depth int) (contracts.
 	return contracts.OrderBookSnapshot{Symbol: symbol}, nil
 }
 
+func (f *fakeTradingService) Ticker(symbol string) (contracts.Ticker, error) {
+	for _, ticker := range f.tickers {
+		if ticker.Symbol == symbol {
+			return ticker, nil
+		}
+	}
+	return contracts.Ticker{Symbol: symbol}, nil
+}
+
+func (f *fakeTradingService) Tickers() ([]contracts.Ticker, error) {
+	return f.tickers, nil
+}
+
 func TestWalletEndpointRequiresAuth(t *testing.T) {
 	svc := &fakeTradingService{walletByUser: map[string]contracts.Wallet{}}
 	app := NewServer(Config{JWTSecret: "secret", APIKeys: map[string]string{}}, svc)
@@ -65,7 +148,7 @@ func TestJWTTokenAndWalletFlow(t *testing.T) {
 	svc := &fakeTradingService{walletByUser: map[string]contracts.Wallet{
 		"u1": {UserID: "u1", Available: map[string]int64{"USD": 999}, Reserved: map[string]int64{}},
 	}}
-	app := NewServer(Config{JWTSecret: "secret", APIKeys: map[string]string{}}, svc)
+	app := NewServer(Config{JWTSecret: "secret", DevAuth: true, APIKeys: map[string]string{}}, svc)
 
 	body, _ := json.Marshal(map[string]string{"userId": "u1"})
 	tokenReq, _ := http.NewRequest(http.MethodPost, "/v1/auth/token", bytes.NewReader(body))
@@ -128,6 +211,161 @@ func TestPlaceOrderUsesAPIKeyIdentity(t *testing.T) {
 	}
 }
 
+func TestErrorsAnswerWithCodes(t *testing.T) {
+	svc := &fakeTradingService{placeErr: &contracts.APIError{
+		Code:    contracts.ErrorInsufficientFunds,
+		Message: "insufficient buy balance",
+		Details: map[string]string{"asset": "USD"},
+	}}
+	app := NewServer(Config{JWTSecret: "secret", APIKeys: map[string]string{"demo-key": "u1"}}, svc)
+
+	body := []byte(`{"symbol":"BTC-USD","side":"BUY","type":"LIMIT","price":100,"qty":1}`)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/orders", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", "demo-key")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("place order request failed: %v", err)
+	}
+	var apiErr contracts.APIError
+	if err := json.NewDecoder(res.Body).Decode(&apiErr); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+	if res.StatusCode != http.StatusUnprocessableEntity || apiErr.Code != contracts.ErrorInsufficientFunds || apiErr.Details["asset"] != "USD" {
+		t.Fatalf("expected 422 INSUFFICIENT_FUNDS for USD, got %d %+v", res.StatusCode, apiErr)
+	}
+
+	walletReq, _ := http.NewRequest(http.MethodGet, "/v1/wallet", nil)
+	res, err = app.Test(walletReq)
+	if err != nil {
+		t.Fatalf("wallet request failed: %v", err)
+	}
+	apiErr = contracts.APIError{}
+	if err := json.NewDecoder(res.Body).Decode(&apiErr); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+	if res.StatusCode != http.StatusUnauthorized || apiErr.Code != contracts.ErrorUnauthorized || apiErr.Message == "" {
+		t.Fatalf("expected 401 UNAUTHORIZED, got %d %+v", res.StatusCode, apiErr)
+	}
+}
+
+func TestUncodedUpstreamErrorsAreInternal(t *testing.T) {
+	svc := &fakeTradingService{placeErr: errors.New("unexpected EOF")}
+	app := NewServer(Config{JWTSecret: "secret", APIKeys: map[string]string{"demo-key": "u1"}}, svc)
+
+	body := []byte(`{"symbol":"BTC-USD","side":"BUY","type":"LIMIT","price":100,"qty":1}`)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/orders", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", "demo-key")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("place order request failed: %v", err)
+	}
+	var apiErr contracts.APIError
+	if err := json.NewDecoder(res.Body).Decode(&apiErr); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+	if res.StatusCode != http.StatusInternalServerError || apiErr.Code != contracts.ErrorInternal {
+		t.Fatalf("expected 500 INTERNAL, got %d %+v", res.StatusCode, apiErr)
+	}
+}
+
+func TestPlaceOrderListUsesAuthenticatedIdentity(t *testing.T) {
+	svc := &fakeTradingService{walletByUser: map[string]contracts.Wallet{}}
+	app := NewServer(Config{JWTSecret: "secret", APIKeys: map[string]string{"demo-key": "u1"}}, svc)
+
+	body := []byte(`{"type":"BRACKET","symbol":"BTC-USD","side":"BUY","qty":2,"entry":{"type":"LIMIT","price":100},"takeProfit":{"type":"LIMIT","price":110},"stopLoss":{"type":"STOP_MARKET","stopPrice":95}}`)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/orders/lists", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", "demo-key")
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("place order list request failed: %v", err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", res.StatusCode)
+	}
+	if svc.lastListReq.UserID != "u1" {
+		t.Fatalf("expected userId u1 from API key, got %s", svc.lastListReq.UserID)
+	}
+	if svc.lastListReq.Entry == nil || svc.lastListReq.Entry.Price != 100 {
+		t.Fatalf("expected entry leg to be forwarded, got %+v", svc.lastListReq.Entry)
+	}
+
+	cancelReq, _ := http.NewRequest(http.MethodDelete, "/v1/orders/lists/lst-1", nil)
+	cancelReq.Header.Set("X-API-Key", "demo-key")
+	cancelRes, err := app.Test(cancelReq)
+	if err != nil {
+		t.Fatalf("cancel order list request failed: %v", err)
+	}
+	if cancelRes.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", cancelRes.StatusCode)
+	}
+	if svc.lastCanceledList != "lst-1" {
+		t.Fatalf("expected list lst-1 to be canceled, got %q", svc.lastCanceledList)
+	}
+}
+
+func TestOrderBatchEndpointsUseAuthenticatedIdentity(t *testing.T) {
+	svc := &fakeTradingService{walletByUser: map[string]contracts.Wallet{}}
+	app := NewServer(Config{JWTSecret: "secret", APIKeys: map[string]string{"demo-key": "u1"}}, svc)
+
+	body := []byte(`{"orders":[{"clientOrderId":"b-1","symbol":"BTC-USD","side":"BUY","type":"LIMIT","price":99,"qty":1},{"clientOrderId":"b-2","userId":"u1","symbol":"BTC-USD","side":"BUY","type":"LIMIT","price":98,"qty":1}]}`)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/orders/batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", "demo-key")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("batch place request failed: %v", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", res.StatusCode)
+	}
+	var placed contracts.OrderBatchResponse
+	if err := json.NewDecoder(res.Body).Decode(&placed); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(placed.Results) != 2 || placed.Results[1].Ack == nil || placed.Results[1].Ack.ClientOrderID != "b-2" {
+		t.Fatalf("unexpected results: %+v", placed.Results)
+	}
+	for _, order := range svc.lastBatchReqs {
+		if order.UserID != "u1" {
+			t.Fatalf("expected every order to carry userId u1, got %+v", svc.lastBatchReqs)
+		}
+	}
+
+	cancelReq, _ := http.NewRequest(http.MethodDelete, "/v1/orders/batch", bytes.NewReader([]byte(`{"orderIds":["ord-1","ord-2"]}`)))
+	cancelReq.Header.Set("Content-Type", "application/json")
+	cancelReq.Header.Set("X-API-Key", "demo-key")
+	cancelRes, err := app.Test(cancelReq)
+	if err != nil {
+		t.Fatalf("batch cancel request failed: %v", err)
+	}
+	if cancelRes.StatusCode != http.StatusOK || len(svc.lastBatchCancel) != 2 {
+		t.Fatalf("expected batch cancel to reach the engine, got status %d and %v", cancelRes.StatusCode, svc.lastBatchCancel)
+	}
+	var canceled contracts.OrderBatchResponse
+	if err := json.NewDecoder(cancelRes.Body).Decode(&canceled); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if canceled.Results[0].Error != "order not found: ord-1 for u1" {
+		t.Fatalf("expected cancel scoped to u1, got %+v", canceled.Results)
+	}
+
+	foreign := []byte(`{"orders":[{"userId":"u2","symbol":"BTC-USD","side":"BUY","type":"LIMIT","price":99,"qty":1}]}`)
+	foreignReq, _ := http.NewRequest(http.MethodPost, "/v1/orders/batch", bytes.NewReader(foreign))
+	foreignReq.Header.Set("Content-Type", "application/json")
+	foreignReq.Header.Set("X-API-Key", "demo-key")
+	foreignRes, err := app.Test(foreignReq)
+	if err != nil {
+		t.Fatalf("foreign batch request failed: %v", err)
+	}
+	if foreignRes.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403 for another user's order, got %d", foreignRes.StatusCode)
+	}
+}
+
 type fakeCandleService struct {
 	candles []contracts.Candle
 }
@@ -238,6 +476,48 @@ func TestOrderBookEndpoint(t *testing.T) {
 	}
 }
 
+func TestTickerEndpoints(t *testing.T) {
+	svc := &fakeTradingService{
+		walletByUser: map[string]contracts.Wallet{},
+		tickers: []contracts.Ticker{
+			{Symbol: "BTC-USD", LastPrice: 105, BestBid: 104, BestAsk: 106, Open24h: 100, Change24h: 5, ChangePercent24h: 5},
+			{Symbol: "ETH-USD", LastPrice: 20},
+		},
+	}
+	app := NewServer(Config{JWTSecret: "secret", APIKeys: map[string]string{"demo-key": "u1"}}, svc)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/markets/BTC-USD/ticker", nil)
+	req.Header.Set("X-API-Key", "demo-key")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("ticker request failed: %v", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.StatusCode)
+	}
+	var ticker contracts.Ticker
+	if err := json.NewDecoder(res.Body).Decode(&ticker); err != nil {
+		t.Fatalf("decode ticker failed: %v", err)
+	}
+	if ticker.LastPrice != 105 || ticker.Change24h != 5 {
+		t.Fatalf("unexpected ticker: %+v", ticker)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/tickers", nil)
+	req.Header.Set("X-API-Key", "demo-key")
+	res, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("tickers request failed: %v", err)
+	}
+	var tickers []contracts.Ticker
+	if err := json.NewDecoder(res.Body).Decode(&tickers); err != nil {
+		t.Fatalf("decode tickers failed: %v", err)
+	}
+	if len(tickers) != 2 {
+		t.Fatalf("expected 2 tickers, got %+v", tickers)
+	}
+}
+
 func TestAdminSimulatorEndpoints(t *testing.T) {
 	adminSvc := &fakeAdminService{}
 	app := NewServer(Config{
@@ -245,9 +525,10 @@ func TestAdminSimulatorEndpoints(t *testing.T) {
 		APIKeys:      map[string]string{"demo-key": "u1"},
 		AdminService: adminSvc,
 	}, &fakeTradingService{walletByUser: map[string]contracts.Wallet{}})
+	operator, _ := signAccessToken("secret", "ops1", "", "operator", time.Hour)
 
 	startReq, _ := http.NewRequest(http.MethodPost, "/v1/admin/sim/start", nil)
-	startReq.Header.Set("X-API-Key", "demo-key")
+	startReq.Header.Set("Authorization", "Bearer "+operator)
 	startRes, err := app.Test(startReq)
 	if err != nil {
 		t.Fatalf("admin start request failed: %v", err)
@@ -261,7 +542,7 @@ func TestAdminSimulatorEndpoints(t *testing.T) {
 
 	volReq, _ := http.NewRequest(http.MethodPost, "/v1/admin/sim/volatility-profile", bytes.NewReader([]byte(`{"volatility":0.05}`)))
 	volReq.Header.Set("Content-Type", "application/json")
-	volReq.Header.Set("X-API-Key", "demo-key")
+	volReq.Header.Set("Authorization", "Bearer "+operator)
 	volRes, err := app.Test(volReq)
 	if err != nil {
 		t.Fatalf("admin volatility request failed: %v", err)
@@ -281,9 +562,10 @@ func TestAdminSymbolPauseResumeEndpoints(t *testing.T) {
 		APIKeys:      map[string]string{"demo-key": "u1"},
 		AdminService: adminSvc,
 	}, &fakeTradingService{walletByUser: map[string]contracts.Wallet{}})
+	operator, _ := signAccessToken("secret", "ops1", "", "operator", time.Hour)
 
 	pauseReq, _ := http.NewRequest(http.MethodPost, "/v1/admin/symbols/BTC-USD/pause", nil)
-	pauseReq.Header.Set("X-API-Key", "demo-key")
+	pauseReq.Header.Set("Authorization", "Bearer "+operator)
 	pauseRes, err := app.Test(pauseReq)
 	if err != nil {
 		t.Fatalf("pause request failed: %v", err)
@@ -296,7 +578,7 @@ func TestAdminSymbolPauseResumeEndpoints(t *testing.T) {
 	}
 
 	resumeReq, _ := http.NewRequest(http.MethodPost, "/v1/admin/symbols/BTC-USD/resume", nil)
-	resumeReq.Header.Set("X-API-Key", "demo-key")
+	resumeReq.Header.Set("Authorization", "Bearer "+operator)
 	resumeRes, err := app.Test(resumeReq)
 	if err != nil {
 		t.Fatalf("resume request failed: %v", err)
@@ -316,9 +598,10 @@ func TestAdminSymbolEnsureEndpoint(t *testing.T) {
 		APIKeys:      map[string]string{"demo-key": "u1"},
 		AdminService: adminSvc,
 	}, &fakeTradingService{walletByUser: map[string]contracts.Wallet{}})
+	operator, _ := signAccessToken("secret", "ops1", "", "operator", time.Hour)
 
 	ensureReq, _ := http.NewRequest(http.MethodPost, "/v1/admin/symbols/SOL-USD/ensure", nil)
-	ensureReq.Header.Set("X-API-Key", "demo-key")
+	ensureReq.Header.Set("Authorization", "Bearer "+operator)
 	ensureRes, err := app.Test(ensureReq)
 	if err != nil {
 		t.Fatalf("ensure request failed: %v", err)
@@ -0,0 +1,91 @@
+package gatewayapi
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"kalency/apps/gateway-api/internal/apikeys"
+	"kalency/apps/gateway-api/signing"
+)
+
+func TestSignedRequestsAndReplayProtection(t *testing.T) {
+	keys := apikeys.NewService(apikeys.NewMemoryStore())
+	key, secret, err := keys.Create(context.Background(), "u1", apikeys.CreateRequest{Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	app := NewServer(Config{JWTSecret: "secret", APIKeyService: keys}, &fakeTradingService{})
+	signer := signing.NewSigner(key.ID, secret)
+
+	signed, _ := http.NewRequest(http.MethodGet, "/v1/wallet", nil)
+	if err := signer.Sign(signed); err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	replay := signed.Clone(context.Background())
+
+	res, err := app.Test(signed)
+	if err != nil {
+		t.Fatalf("signed request failed: %v", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected signed request status 200, got %d", res.StatusCode)
+	}
+
+	res, _ = app.Test(replay)
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected replayed request status 401, got %d", res.StatusCode)
+	}
+
+	stale, _ := http.NewRequest(http.MethodGet, "/v1/wallet", nil)
+	_ = signer.Sign(stale)
+	timestamp := strconv.FormatInt(time.Now().Add(-time.Minute).UnixMilli(), 10)
+	stale.Header.Set(signing.HeaderTimestamp, timestamp)
+	stale.Header.Set(signing.HeaderSignature, signing.Signature(signing.SigningKey(secret), http.MethodGet, "/v1/wallet", timestamp, stale.Header.Get(signing.HeaderNonce), nil))
+	res, _ = app.Test(stale)
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected stale request status 401, got %d", res.StatusCode)
+	}
+
+	forged, _ := http.NewRequest(http.MethodGet, "/v1/wallet", nil)
+	_ = signing.NewSigner(key.ID, "kk_wrong").Sign(forged)
+	res, _ = app.Test(forged)
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected wrongly signed request status 401, got %d", res.StatusCode)
+	}
+}
+
+func TestStoredKeyHashCannotSignRequests(t *testing.T) {
+	keys := apikeys.NewService(apikeys.NewMemoryStore())
+	key, secret, err := keys.Create(context.Background(), "u1", apikeys.CreateRequest{Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	app := NewServer(Config{JWTSecret: "secret", APIKeyService: keys}, &fakeTradingService{})
+
+	stored, _ := hex.DecodeString(key.Hash)
+	forged, _ := http.NewRequest(http.MethodGet, "/v1/wallet", nil)
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	forged.Header.Set(signing.HeaderKeyID, key.ID)
+	forged.Header.Set(signing.HeaderTimestamp, timestamp)
+	forged.Header.Set(signing.HeaderNonce, "n1")
+	forged.Header.Set(signing.HeaderSignature, signing.Signature(stored, http.MethodGet, "/v1/wallet", timestamp, "n1", nil))
+	res, _ := app.Test(forged)
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected a signature keyed with the stored hash to get 401, got %d", res.StatusCode)
+	}
+
+	signed, _ := http.NewRequest(http.MethodGet, "/v1/wallet", nil)
+	_ = signing.NewSigner(key.ID, secret).Sign(signed)
+	if res, _ := app.Test(signed); res.StatusCode != http.StatusOK {
+		t.Fatalf("expected the secret holder's signature to get 200, got %d", res.StatusCode)
+	}
+
+	other := apikeys.NewService(apikeys.NewMemoryStore())
+	if _, err := other.SigningKey(key); err != apikeys.ErrCannotSign {
+		t.Fatalf("expected a different encryption key to fail to open the signing secret, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package gatewayapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/streamhub"
+)
+
+const sseRetry = 2 * time.Second
+
+// sseStream serves one hub key as text/event-stream. Every event carries its
+// Redis stream ID, so a reconnecting client's Last-Event-ID (or ?lastEventId=)
+// replays what it missed before going live. A replay cut short by the cap
+// ends in a resync event carrying the ID to resume from, and the stream closes
+// so EventSource reconnects from there.
+func sseStream(hub *streamhub.Hub, event string, metrics *connMetrics, key func(c *fiber.Ctx) (string, error)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if hub == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, event+" stream not enabled")
+		}
+		streamKey, err := key(c)
+		if err != nil {
+			return err
+		}
+
+		lastEventID := strings.TrimSpace(c.Get("Last-Event-ID"))
+		if lastEventID == "" {
+			lastEventID = strings.TrimSpace(c.Query("lastEventId"))
+		}
+
+		sub := hub.Subscribe(streamKey)
+		var backlog []streamhub.Message
+		resumeFrom := ""
+		if lastEventID != "" {
+			backlog, resumeFrom, err = hub.Replay(context.Background(), streamKey, lastEventID, maxStreamReplay)
+			if err != nil {
+				sub.Close()
+				return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+			}
+		}
+
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		c.Set(fiber.HeaderConnection, "keep-alive")
+		c.Set("X-Accel-Buffering", "no")
+
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer metrics.open()()
+			defer sub.Close()
+
+			fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds())
+			lastID := ""
+			for _, message := range backlog {
+				writeSSE(w, event, message)
+				lastID = message.ID
+			}
+			if resumeFrom != "" {
+				fmt.Fprintf(w, "id: %s\nevent: resync\ndata: {\"lastEventId\":%q}\n\n", resumeFrom, resumeFrom)
+				_ = w.Flush()
+				return
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+
+			heartbeat := time.NewTicker(wsHeartbeatInterval)
+			defer heartbeat.Stop()
+			for {
+				select {
+				case message, ok := <-sub.Messages():
+					if !ok {
+						metrics.slowConsumer()
+						fmt.Fprint(w, "event: error\ndata: {\"message\":\"slow consumer, reconnect with Last-Event-ID\"}\n\n")
+						_ = w.Flush()
+						return
+					}
+					if lastID != "" && streamhub.CompareIDs(message.ID, lastID) <= 0 {
+						continue
+					}
+					writeSSE(w, event, message)
+					lastID = message.ID
+				case <-heartbeat.C:
+					fmt.Fprint(w, ": heartbeat\n\n")
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		})
+		return nil
+	}
+}
+
+func writeSSE(w *bufio.Writer, event string, message streamhub.Message) {
+	payload, err := json.Marshal(message.Data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", message.ID, event, payload)
+}
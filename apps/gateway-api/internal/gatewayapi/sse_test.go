@@ -0,0 +1,156 @@
+package gatewayapi
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/streamhub"
+)
+
+type storedSource struct {
+	idleSource
+	stored []streamhub.Message
+}
+
+func (s storedSource) ReadAfter(_ context.Context, afterID string, count int) ([]streamhub.Message, error) {
+	out := []streamhub.Message{}
+	for _, message := range s.stored {
+		if streamhub.CompareIDs(message.ID, afterID) > 0 && len(out) < count {
+			out = append(out, message)
+		}
+	}
+	return out, nil
+}
+
+// readSSEEvent returns the fields of the next event, skipping comments and
+// the retry preamble.
+func readSSEEvent(t *testing.T, reader *bufio.Reader) map[string]string {
+	t.Helper()
+	fields := map[string]string{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			if _, ok := fields["event"]; ok {
+				return fields
+			}
+			fields = map[string]string{}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		name, value, _ := strings.Cut(line, ": ")
+		fields[name] = value
+	}
+}
+
+func TestTradeSSEResumesFromLastEventID(t *testing.T) {
+	hub := streamhub.NewHub(storedSource{stored: []streamhub.Message{
+		{ID: "1-0", Key: "BTC-USD", Data: contracts.Execution{TradeID: "trd-1"}},
+		{ID: "2-0", Key: "ETH-USD", Data: contracts.Execution{TradeID: "trd-2"}},
+		{ID: "3-0", Key: "BTC-USD", Data: contracts.Execution{TradeID: "trd-3"}},
+	}}, 0)
+	base := strings.Replace(strings.TrimSuffix(startWSServer(t, Config{APIKeys: map[string]string{"k1": "u1"}, TradeHub: hub}, &fakeTradingService{}), "/ws"), "ws://", "http://", 1)
+
+	req, _ := http.NewRequest(http.MethodGet, base+"/v1/markets/BTC-USD/trades/stream", nil)
+	req.Header.Set("Last-Event-ID", "1-0")
+	req.Header.Set("X-API-Key", "k1")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("stream request failed: %v", err)
+	}
+	if got := res.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("expected event stream, got %q", got)
+	}
+	reader := bufio.NewReader(res.Body)
+
+	replayed := readSSEEvent(t, reader)
+	if replayed["id"] != "3-0" || replayed["event"] != "trade" || !strings.Contains(replayed["data"], `"tradeId":"trd-3"`) {
+		t.Fatalf("unexpected replayed event %v", replayed)
+	}
+
+	hub.Broadcast([]streamhub.Message{
+		{ID: "3-0", Key: "BTC-USD", Data: contracts.Execution{TradeID: "trd-3"}},
+		{ID: "4-0", Key: "BTC-USD", Data: contracts.Execution{TradeID: "trd-4"}},
+	})
+	if live := readSSEEvent(t, reader); live["id"] != "4-0" {
+		t.Fatalf("expected live event 4-0 after deduped replay, got %v", live)
+	}
+
+	_ = res.Body.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for seq := 5; hub.Subscribers("BTC-USD") != 0; seq++ {
+		if time.Now().After(deadline) {
+			t.Fatal("expected subscription to close after the client disconnected")
+		}
+		hub.Broadcast([]streamhub.Message{{ID: fmt.Sprintf("%d-0", seq), Key: "BTC-USD", Data: contracts.Execution{}}})
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCandleSSERejectsUnsupportedTimeframe(t *testing.T) {
+	hub := streamhub.NewHub(idleSource{}, 0)
+	app := NewServer(Config{APIKeys: map[string]string{"k1": "u1"}, CandleHub: hub}, &fakeTradingService{})
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/markets/BTC-USD/candles/stream?tf=2m", nil)
+	req.Header.Set("X-API-Key", "k1")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", res.StatusCode)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/markets/BTC-USD/ticks/stream", nil)
+	req.Header.Set("X-API-Key", "k1")
+	res, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 without a tick hub, got %d", res.StatusCode)
+	}
+}
+
+func TestTradeSSEResyncsWhenReplayIsTruncated(t *testing.T) {
+	stored := make([]streamhub.Message, 0, maxStreamReplay+5)
+	for i := 1; i <= maxStreamReplay+5; i++ {
+		stored = append(stored, streamhub.Message{ID: fmt.Sprintf("%d-0", i), Key: "BTC-USD", Data: contracts.Execution{TradeID: fmt.Sprintf("trd-%d", i)}})
+	}
+	hub := streamhub.NewHub(storedSource{stored: stored}, 0)
+	base := strings.Replace(strings.TrimSuffix(startWSServer(t, Config{APIKeys: map[string]string{"k1": "u1"}, TradeHub: hub}, &fakeTradingService{}), "/ws"), "ws://", "http://", 1)
+
+	req, _ := http.NewRequest(http.MethodGet, base+"/v1/markets/BTC-USD/trades/stream", nil)
+	req.Header.Set("Last-Event-ID", "0-0")
+	req.Header.Set("X-API-Key", "k1")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("stream request failed: %v", err)
+	}
+	defer res.Body.Close()
+	reader := bufio.NewReader(res.Body)
+
+	for i := 1; i <= maxStreamReplay; i++ {
+		if event := readSSEEvent(t, reader); event["event"] != "trade" || event["id"] != fmt.Sprintf("%d-0", i) {
+			t.Fatalf("expected replayed trade %d-0, got %v", i, event)
+		}
+	}
+	want := fmt.Sprintf("%d-0", maxStreamReplay)
+	if resync := readSSEEvent(t, reader); resync["event"] != "resync" || resync["id"] != want {
+		t.Fatalf("expected resync from %s, got %v", want, resync)
+	}
+	if _, err := reader.ReadString('\n'); err == nil {
+		t.Fatal("expected the stream to end after the resync event")
+	}
+}
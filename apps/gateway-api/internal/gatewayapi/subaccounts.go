@@ -0,0 +1,192 @@
+package gatewayapi
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+type createSubAccountRequest struct {
+	Name string `json:"name"`
+}
+
+type subAccountResponse struct {
+	AccountID string     `json:"accountId"`
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// transferRequest names accounts by accountId; an empty one is the main
+// account.
+type transferRequest struct {
+	RequestID     string `json:"requestId"`
+	FromAccountID string `json:"fromAccountId"`
+	ToAccountID   string `json:"toAccountId"`
+	Asset         string `json:"asset"`
+	Amount        int64  `json:"amount"`
+}
+
+const mainAccountName = "main"
+
+// resolveAccount turns the accountId of a request into the user ID the
+// matching engine trades it under. Credentials scoped to a sub-account always
+// trade it; others pick any of the user's accounts, the main one by default.
+func resolveAccount(c *fiber.Ctx, users *accounts.Service, identity authIdentity, accountID string) (string, error) {
+	accountID = strings.TrimSpace(accountID)
+	if identity.AccountID != "" {
+		if accountID != "" && accountID != identity.AccountID {
+			return "", fiber.NewError(fiber.StatusForbidden, "credentials are scoped to sub-account "+identity.AccountID)
+		}
+		return accounts.TradingUserID(identity.UserID, identity.AccountID), nil
+	}
+	if accountID == "" || accountID == mainAccountName {
+		return identity.UserID, nil
+	}
+	if users == nil {
+		return "", fiber.NewError(fiber.StatusServiceUnavailable, "accounts are not configured")
+	}
+	if _, err := users.SubAccount(c.UserContext(), identity.UserID, accountID); err != nil {
+		return "", subAccountError(err)
+	}
+	return accounts.TradingUserID(identity.UserID, accountID), nil
+}
+
+// ownsTradingUser reports whether the identity may follow the orders and
+// wallet of an engine user ID: its own account or, when unscoped, any of its
+// sub-accounts.
+func (i authIdentity) ownsTradingUser(tradingUserID string) bool {
+	if i.AccountID != "" {
+		return tradingUserID == accounts.TradingUserID(i.UserID, i.AccountID)
+	}
+	return accounts.ParentUserID(tradingUserID) == i.UserID
+}
+
+// requireUnscoped stops credentials scoped to one sub-account from managing
+// the others.
+func requireUnscoped(identity authIdentity) error {
+	if identity.AccountID != "" {
+		return fiber.NewError(fiber.StatusForbidden, "credentials scoped to a sub-account cannot manage accounts")
+	}
+	return nil
+}
+
+func registerSubAccountRoutes(protected fiber.Router, cfg Config, trading TradingService, secret string) {
+	users := cfg.Accounts
+	accessTTL := accessTokenTTL(cfg)
+
+	protected.Get("/accounts", func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+		if identity.AccountID != "" {
+			if users == nil {
+				return fiber.NewError(fiber.StatusServiceUnavailable, "accounts are not configured")
+			}
+			account, err := users.SubAccount(c.UserContext(), identity.UserID, identity.AccountID)
+			if err != nil {
+				return subAccountError(err)
+			}
+			return c.JSON([]subAccountResponse{toSubAccountResponse(account)})
+		}
+
+		out := []subAccountResponse{{AccountID: "", Name: mainAccountName}}
+		if users == nil {
+			return c.JSON(out)
+		}
+		subs, err := users.SubAccounts(c.UserContext(), identity.UserID)
+		if err != nil {
+			return subAccountError(err)
+		}
+		for _, account := range subs {
+			out = append(out, toSubAccountResponse(account))
+		}
+		return c.JSON(out)
+	})
+
+	protected.Post("/accounts", func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+		if err := requireUnscoped(identity); err != nil {
+			return err
+		}
+		if users == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "accounts are not configured")
+		}
+		var req createSubAccountRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+		account, err := users.CreateSubAccount(c.UserContext(), identity.UserID, req.Name)
+		if err != nil {
+			return subAccountError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(toSubAccountResponse(account))
+	})
+
+	protected.Post("/accounts/transfers", func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+		if err := requireUnscoped(identity); err != nil {
+			return err
+		}
+		var req transferRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid JSON body")
+		}
+		if err := requireRequestID(req.RequestID); err != nil {
+			return err
+		}
+		from, err := resolveAccount(c, users, identity, req.FromAccountID)
+		if err != nil {
+			return err
+		}
+		to, err := resolveAccount(c, users, identity, req.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		result, err := trading.Transfer(contracts.TransferRequest{RequestID: req.RequestID, FromUserID: from, ToUserID: to, Asset: req.Asset, Amount: req.Amount})
+		if err != nil {
+			return upstreamError(err)
+		}
+		return c.JSON(result)
+	})
+
+	// A sub-account token is an access token like any other, limited to one
+	// sub-account. It is not refreshable; ask for a new one instead.
+	protected.Post("/accounts/:accountId/token", func(c *fiber.Ctx) error {
+		identity := c.Locals(authLocalKey).(authIdentity)
+		if err := requireUnscoped(identity); err != nil {
+			return err
+		}
+		if users == nil {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "accounts are not configured")
+		}
+		account, err := users.SubAccount(c.UserContext(), identity.UserID, c.Params("accountId"))
+		if err != nil {
+			return subAccountError(err)
+		}
+		signed, err := signAccessToken(secret, identity.UserID, account.ID, identity.Role, accessTTL)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to sign token")
+		}
+		return c.JSON(tokenResponse{Token: signed, ExpiresIn: int64(accessTTL / time.Second)})
+	})
+}
+
+func toSubAccountResponse(account accounts.SubAccount) subAccountResponse {
+	return subAccountResponse{AccountID: account.ID, Name: account.Name, CreatedAt: &account.CreatedAt}
+}
+
+func subAccountError(err error) error {
+	switch {
+	case errors.Is(err, accounts.ErrInvalidSubAccountName), errors.Is(err, accounts.ErrTooManySubAccounts):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, accounts.ErrSubAccountNameTaken):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	case errors.Is(err, accounts.ErrSubAccountNotFound):
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	default:
+		return fiber.NewError(fiber.StatusInternalServerError, "account store unavailable")
+	}
+}
@@ -0,0 +1,70 @@
+package gatewayapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+func TestSubAccountsTradeUnderTheirOwnEngineUser(t *testing.T) {
+	svc := &fakeTradingService{walletByUser: map[string]contracts.Wallet{}}
+	app := NewServer(Config{JWTSecret: "secret", DevAuth: true, Accounts: accounts.NewService(accounts.NewMemoryStore())}, svc)
+	token := devToken(t, app, "u1", "trader")
+
+	res := bearerRequest(t, app, http.MethodPost, "/v1/accounts", token, map[string]string{"name": "arb"})
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("expected create status 201, got %d", res.StatusCode)
+	}
+	var created subAccountResponse
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode sub-account: %v", err)
+	}
+	subUserID := "u1:" + created.AccountID
+
+	res = bearerRequest(t, app, http.MethodPost, "/v1/accounts/transfers", token, transferRequest{RequestID: "t1", ToAccountID: created.AccountID, Asset: "USD", Amount: 100})
+	if res.StatusCode != http.StatusOK || svc.lastTransfer.FromUserID != "u1" || svc.lastTransfer.ToUserID != subUserID {
+		t.Fatalf("expected transfer from u1 to %s, got %d %+v", subUserID, res.StatusCode, svc.lastTransfer)
+	}
+
+	order := map[string]any{"accountId": created.AccountID, "symbol": "BTC-USD", "side": "BUY", "type": "LIMIT", "price": 100, "qty": 1}
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/orders", token, order); res.StatusCode != http.StatusCreated || svc.lastPlaceReq.UserID != subUserID {
+		t.Fatalf("expected order on %s, got %d %s", subUserID, res.StatusCode, svc.lastPlaceReq.UserID)
+	}
+
+	other := devToken(t, app, "u2", "trader")
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/orders", other, order); res.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for another user's sub-account, got %d", res.StatusCode)
+	}
+}
+
+func TestSubAccountTokenIsScopedToItsAccount(t *testing.T) {
+	svc := &fakeTradingService{walletByUser: map[string]contracts.Wallet{}}
+	app := NewServer(Config{JWTSecret: "secret", DevAuth: true, Accounts: accounts.NewService(accounts.NewMemoryStore())}, svc)
+	token := devToken(t, app, "u1", "trader")
+
+	res := bearerRequest(t, app, http.MethodPost, "/v1/accounts", token, map[string]string{"name": "arb"})
+	var created subAccountResponse
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode sub-account: %v", err)
+	}
+	res = bearerRequest(t, app, http.MethodPost, "/v1/accounts/"+created.AccountID+"/token", token, nil)
+	var scoped tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&scoped); err != nil || scoped.Token == "" {
+		t.Fatalf("expected a scoped token, got %d %v", res.StatusCode, err)
+	}
+
+	order := map[string]any{"symbol": "BTC-USD", "side": "BUY", "type": "LIMIT", "price": 100, "qty": 1}
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/orders", scoped.Token, order); res.StatusCode != http.StatusCreated || svc.lastPlaceReq.UserID != "u1:"+created.AccountID {
+		t.Fatalf("expected scoped order on the sub-account, got %d %s", res.StatusCode, svc.lastPlaceReq.UserID)
+	}
+	order["accountId"] = "main"
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/orders", scoped.Token, order); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for the main account, got %d", res.StatusCode)
+	}
+	if res := bearerRequest(t, app, http.MethodPost, "/v1/accounts/transfers", scoped.Token, transferRequest{Asset: "USD", Amount: 1}); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for transfers with a scoped token, got %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,441 @@
+package gatewayapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"kalency/apps/gateway-api/internal/apikeys"
+	"kalency/apps/gateway-api/internal/candlestream"
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/streamhub"
+	"kalency/apps/gateway-api/signing"
+)
+
+const (
+	wsHeartbeatInterval = 15 * time.Second
+	wsReadTimeout       = 3 * wsHeartbeatInterval
+	wsWriteTimeout      = 10 * time.Second
+	wsSendBuffer        = 512
+	wsBookDepth         = 50
+)
+
+var errChannelUnavailable = errors.New("channel not available")
+
+// wsRequest is a client frame on /ws.
+type wsRequest struct {
+	Op      string `json:"op"`
+	Channel string `json:"channel,omitempty"`
+	From    string `json:"from,omitempty"`
+	Token   string `json:"token,omitempty"`
+}
+
+// wsEnvelope is every server frame on /ws.
+type wsEnvelope struct {
+	Type    string    `json:"type"`
+	Channel string    `json:"channel,omitempty"`
+	ID      string    `json:"id,omitempty"`
+	Data    any       `json:"data,omitempty"`
+	Message string    `json:"message,omitempty"`
+	TS      time.Time `json:"ts"`
+}
+
+type wsChannel struct {
+	Name      string
+	Kind      string
+	Symbol    string
+	Timeframe string
+	UserID    string
+}
+
+func parseWSChannel(name string) (wsChannel, error) {
+	name = strings.TrimSpace(name)
+	kind, rest, ok := strings.Cut(name, ".")
+	if !ok || rest == "" {
+		return wsChannel{}, errors.New("invalid channel")
+	}
+
+	channel := wsChannel{Name: name, Kind: kind}
+	switch kind {
+	case "book", "trades":
+		channel.Symbol = rest
+	case "candles":
+		idx := strings.LastIndex(rest, ".")
+		if idx <= 0 || idx == len(rest)-1 {
+			return wsChannel{}, errors.New("candles channel must be candles.{symbol}.{tf}")
+		}
+		channel.Symbol = rest[:idx]
+		channel.Timeframe = normalizeTimeframe(rest[idx+1:])
+		if !isSupportedTimeframe(channel.Timeframe) {
+			return wsChannel{}, errors.New("unsupported timeframe")
+		}
+	case "orders", "wallet":
+		channel.UserID = rest
+	default:
+		return wsChannel{}, errors.New("unknown channel")
+	}
+	return channel, nil
+}
+
+func (c wsChannel) private() bool {
+	return c.Kind == "orders" || c.Kind == "wallet"
+}
+
+type wsFeeds struct {
+	trading TradingService
+	trades  *streamhub.Hub
+	books   *streamhub.Registry
+	private *streamhub.Hub
+	candles *streamhub.Hub
+	metrics *connMetrics
+}
+
+// wsFeed is an open channel subscription: backlog is sent first, then every
+// hub message that event accepts. A feed without sub only has a truncated
+// replay ending in a resync frame.
+type wsFeed struct {
+	sub     *streamhub.Subscription
+	backlog []wsEnvelope
+	event   func(streamhub.Message) (wsEnvelope, bool)
+}
+
+func (f *wsFeeds) open(channel wsChannel, from string) (wsFeed, error) {
+	switch channel.Kind {
+	case "trades":
+		if f.trades == nil {
+			return wsFeed{}, errChannelUnavailable
+		}
+		return streamFeed(f.trades, channel.Symbol, channel, from)
+	case "candles":
+		if f.candles == nil {
+			return wsFeed{}, errChannelUnavailable
+		}
+		return streamFeed(f.candles, candlestream.Key(channel.Symbol, channel.Timeframe), channel, from)
+	case "orders", "wallet":
+		if f.private == nil {
+			return wsFeed{}, errChannelUnavailable
+		}
+		return streamFeed(f.private, channel.Name, channel, from)
+	case "book":
+		if f.books == nil {
+			return wsFeed{}, errChannelUnavailable
+		}
+		sub := f.books.Hub(channel.Symbol).Subscribe(channel.Symbol)
+		snapshot, err := f.trading.ListOrderBook(channel.Symbol, wsBookDepth)
+		if err != nil {
+			sub.Close()
+			return wsFeed{}, err
+		}
+		return wsFeed{
+			sub:     sub,
+			backlog: []wsEnvelope{{Type: "snapshot", Channel: channel.Name, Data: snapshot}},
+			event: func(message streamhub.Message) (wsEnvelope, bool) {
+				delta, ok := message.Data.(contracts.BookDelta)
+				if !ok || delta.Seq <= snapshot.Seq {
+					return wsEnvelope{}, false
+				}
+				return wsEnvelope{Type: "event", Channel: channel.Name, ID: message.ID, Data: delta}, true
+			},
+		}, nil
+	default:
+		return wsFeed{}, errChannelUnavailable
+	}
+}
+
+// streamFeed subscribes to key on hub, replaying entries after from first.
+func streamFeed(hub *streamhub.Hub, key string, channel wsChannel, from string) (wsFeed, error) {
+	sub := hub.Subscribe(key)
+	feed := wsFeed{sub: sub}
+	lastID := ""
+	if from != "" {
+		backlog, resumeFrom, err := hub.Replay(context.Background(), key, from, maxStreamReplay)
+		if err != nil {
+			sub.Close()
+			return wsFeed{}, err
+		}
+		for _, message := range backlog {
+			feed.backlog = append(feed.backlog, wsEnvelope{Type: "event", Channel: channel.Name, ID: message.ID, Data: message.Data})
+			lastID = message.ID
+		}
+		if resumeFrom != "" {
+			sub.Close()
+			feed.sub = nil
+			feed.backlog = append(feed.backlog, wsEnvelope{Type: "resync", Channel: channel.Name, ID: resumeFrom, Message: "replay truncated, resubscribe with from"})
+			return feed, nil
+		}
+	}
+	feed.event = func(message streamhub.Message) (wsEnvelope, bool) {
+		if lastID != "" && streamhub.CompareIDs(message.ID, lastID) <= 0 {
+			return wsEnvelope{}, false
+		}
+		return wsEnvelope{Type: "event", Channel: channel.Name, ID: message.ID, Data: message.Data}, true
+	}
+	return feed, nil
+}
+
+type wsSession struct {
+	conn      *websocket.Conn
+	feeds     *wsFeeds
+	jwtSecret string
+	identity  *authIdentity
+	send      chan wsEnvelope
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu   sync.Mutex
+	subs map[string]*streamhub.Subscription
+}
+
+func websocketAuth(auth *authenticator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+
+		if token := strings.TrimSpace(c.Query("token")); token != "" {
+			identity, err := parseToken(token, auth.jwtSecret)
+			if err != nil {
+				return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+			}
+			c.Locals(authLocalKey, identity)
+		} else if c.Get("X-API-Key") != "" || c.Get(signing.HeaderKeyID) != "" || c.Get("Authorization") != "" {
+			identity, err := auth.authenticate(c)
+			if err != nil {
+				return authError(err)
+			}
+			if !identity.allows(apikeys.ScopeRead) {
+				return fiber.NewError(fiber.StatusForbidden, "API key lacks the read scope")
+			}
+			c.Locals(authLocalKey, identity)
+		}
+		return c.Next()
+	}
+}
+
+func (f *wsFeeds) handler(jwtSecret string) func(*websocket.Conn) {
+	return func(conn *websocket.Conn) {
+		defer f.metrics.open()()
+		session := &wsSession{
+			conn:      conn,
+			feeds:     f,
+			jwtSecret: jwtSecret,
+			send:      make(chan wsEnvelope, wsSendBuffer),
+			done:      make(chan struct{}),
+			subs:      make(map[string]*streamhub.Subscription),
+		}
+		if identity, ok := conn.Locals(authLocalKey).(authIdentity); ok {
+			session.identity = &identity
+		}
+
+		var writer sync.WaitGroup
+		writer.Add(1)
+		go func() {
+			defer writer.Done()
+			session.writeLoop()
+		}()
+
+		session.readLoop()
+		session.close()
+		writer.Wait()
+		session.unsubscribeAll()
+	}
+}
+
+func (s *wsSession) readLoop() {
+	_ = s.conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	})
+
+	for {
+		_, payload, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = s.conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+
+		var req wsRequest
+		if err := json.Unmarshal(payload, &req); err != nil {
+			s.enqueue(wsEnvelope{Type: "error", Message: "invalid JSON frame"})
+			continue
+		}
+
+		switch req.Op {
+		case "subscribe":
+			s.subscribe(req)
+		case "unsubscribe":
+			s.unsubscribe(strings.TrimSpace(req.Channel))
+		case "auth":
+			identity, err := parseToken(strings.TrimSpace(req.Token), s.jwtSecret)
+			if err != nil {
+				s.enqueue(wsEnvelope{Type: "error", Message: err.Error()})
+				continue
+			}
+			s.identity = &identity
+			s.enqueue(wsEnvelope{Type: "authenticated", Data: map[string]string{"userId": identity.UserID}})
+		case "ping":
+			s.enqueue(wsEnvelope{Type: "pong"})
+		default:
+			s.enqueue(wsEnvelope{Type: "error", Message: "unknown op"})
+		}
+	}
+}
+
+func (s *wsSession) writeLoop() {
+	heartbeat := time.NewTicker(wsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case envelope := <-s.send:
+			_ = s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := s.conn.WriteJSON(envelope); err != nil {
+				s.close()
+				return
+			}
+		case now := <-heartbeat.C:
+			_ = s.conn.SetWriteDeadline(now.Add(wsWriteTimeout))
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, now.Add(wsHeartbeatInterval)); err != nil {
+				s.close()
+				return
+			}
+			if err := s.conn.WriteJSON(wsEnvelope{Type: "heartbeat", TS: now.UTC()}); err != nil {
+				s.close()
+				return
+			}
+		}
+	}
+}
+
+func (s *wsSession) subscribe(req wsRequest) {
+	channel, err := parseWSChannel(req.Channel)
+	if err != nil {
+		s.enqueue(wsEnvelope{Type: "error", Channel: req.Channel, Message: err.Error()})
+		return
+	}
+	if channel.private() {
+		if s.identity == nil {
+			s.enqueue(wsEnvelope{Type: "error", Channel: channel.Name, Message: "authentication required"})
+			return
+		}
+		if !s.identity.ownsTradingUser(channel.UserID) {
+			s.enqueue(wsEnvelope{Type: "error", Channel: channel.Name, Message: "forbidden"})
+			return
+		}
+	}
+
+	s.mu.Lock()
+	_, exists := s.subs[channel.Name]
+	s.mu.Unlock()
+	if exists {
+		s.enqueue(wsEnvelope{Type: "subscribed", Channel: channel.Name})
+		return
+	}
+
+	feed, err := s.feeds.open(channel, strings.TrimSpace(req.From))
+	if err != nil {
+		s.enqueue(wsEnvelope{Type: "error", Channel: channel.Name, Message: err.Error()})
+		return
+	}
+
+	if feed.sub == nil {
+		for _, envelope := range feed.backlog {
+			s.enqueue(envelope)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	s.subs[channel.Name] = feed.sub
+	s.mu.Unlock()
+
+	s.enqueue(wsEnvelope{Type: "subscribed", Channel: channel.Name})
+	for _, envelope := range feed.backlog {
+		s.enqueue(envelope)
+	}
+	go s.forward(channel, feed)
+}
+
+func (s *wsSession) forward(channel wsChannel, feed wsFeed) {
+	for message := range feed.sub.Messages() {
+		envelope, ok := feed.event(message)
+		if !ok {
+			continue
+		}
+		if !s.enqueue(envelope) {
+			return
+		}
+	}
+
+	s.mu.Lock()
+	current, dropped := s.subs[channel.Name]
+	dropped = dropped && current == feed.sub
+	if dropped {
+		delete(s.subs, channel.Name)
+	}
+	s.mu.Unlock()
+
+	if dropped {
+		s.enqueue(wsEnvelope{Type: "error", Channel: channel.Name, Message: "subscription dropped, resubscribe with from"})
+	}
+}
+
+func (s *wsSession) unsubscribe(name string) {
+	s.mu.Lock()
+	sub, ok := s.subs[name]
+	delete(s.subs, name)
+	s.mu.Unlock()
+
+	if ok {
+		sub.Close()
+	}
+	s.enqueue(wsEnvelope{Type: "unsubscribed", Channel: name})
+}
+
+func (s *wsSession) unsubscribeAll() {
+	s.mu.Lock()
+	subs := s.subs
+	s.subs = make(map[string]*streamhub.Subscription)
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
+
+// enqueue queues a frame for the writer. A session whose queue is full is a
+// slow consumer and is closed.
+func (s *wsSession) enqueue(envelope wsEnvelope) bool {
+	if envelope.TS.IsZero() {
+		envelope.TS = time.Now().UTC()
+	}
+
+	select {
+	case <-s.done:
+		return false
+	default:
+	}
+
+	select {
+	case s.send <- envelope:
+		return true
+	default:
+		s.feeds.metrics.slowConsumer()
+		s.close()
+		return false
+	}
+}
+
+func (s *wsSession) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		_ = s.conn.Close()
+	})
+}
@@ -0,0 +1,289 @@
+package gatewayapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	fastws "github.com/fasthttp/websocket"
+	"github.com/golang-jwt/jwt/v5"
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/streamhub"
+	"kalency/apps/gateway-api/internal/tickstream"
+)
+
+type idleSource struct{}
+
+func (idleSource) Read(ctx context.Context, lastID string, count int, block time.Duration) ([]streamhub.Message, string, error) {
+	<-ctx.Done()
+	return nil, lastID, ctx.Err()
+}
+
+func (idleSource) ReadAfter(context.Context, string, int) ([]streamhub.Message, error) {
+	return []streamhub.Message{}, nil
+}
+
+func startWSServer(t *testing.T, cfg Config, trading TradingService) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	app := NewServer(cfg, trading)
+	go func() { _ = app.Listener(listener) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+	return "ws://" + listener.Addr().String() + "/ws"
+}
+
+func dialWS(t *testing.T, url string, header http.Header) *fastws.Conn {
+	t.Helper()
+	conn, _, err := fastws.DefaultDialer.Dial(url, header)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+func readEnvelope(t *testing.T, conn *fastws.Conn) map[string]any {
+	t.Helper()
+	var envelope map[string]any
+	if err := conn.ReadJSON(&envelope); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	return envelope
+}
+
+func waitForSubscribers(t *testing.T, hub *streamhub.Hub, key string, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.Subscribers(key) != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d subscribers for %s, got %d", want, key, hub.Subscribers(key))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWebSocketTradesSubscription(t *testing.T) {
+	hub := streamhub.NewHub(idleSource{}, 0)
+	url := startWSServer(t, Config{JWTSecret: "secret", TradeHub: hub}, &fakeTradingService{})
+	conn := dialWS(t, url, nil)
+
+	if err := conn.WriteJSON(map[string]any{"op": "subscribe", "channel": "trades.BTC-USD"}); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	if got := readEnvelope(t, conn); got["type"] != "subscribed" || got["channel"] != "trades.BTC-USD" {
+		t.Fatalf("expected subscribed ack, got %v", got)
+	}
+	waitForSubscribers(t, hub, "BTC-USD", 1)
+
+	hub.Broadcast([]streamhub.Message{
+		{ID: "1-0", Key: "ETH-USD", Data: contracts.Execution{TradeID: "trd-1", Symbol: "ETH-USD"}},
+		{ID: "2-0", Key: "BTC-USD", Data: contracts.Execution{TradeID: "trd-2", Symbol: "BTC-USD"}},
+	})
+	event := readEnvelope(t, conn)
+	if event["type"] != "event" || event["channel"] != "trades.BTC-USD" || event["id"] != "2-0" {
+		t.Fatalf("unexpected event %v", event)
+	}
+	if data, _ := event["data"].(map[string]any); data["tradeId"] != "trd-2" {
+		t.Fatalf("unexpected event data %v", event["data"])
+	}
+
+	if err := conn.WriteJSON(map[string]any{"op": "unsubscribe", "channel": "trades.BTC-USD"}); err != nil {
+		t.Fatalf("unsubscribe failed: %v", err)
+	}
+	if got := readEnvelope(t, conn); got["type"] != "unsubscribed" {
+		t.Fatalf("expected unsubscribed ack, got %v", got)
+	}
+	waitForSubscribers(t, hub, "BTC-USD", 0)
+}
+
+func TestWebSocketPrivateChannelRequiresMatchingUser(t *testing.T) {
+	url := startWSServer(t, Config{JWTSecret: "secret"}, &fakeTradingService{})
+	conn := dialWS(t, url, nil)
+
+	if err := conn.WriteJSON(map[string]any{"op": "subscribe", "channel": "orders.u1"}); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	if got := readEnvelope(t, conn); got["type"] != "error" || got["message"] != "authentication required" {
+		t.Fatalf("expected authentication error, got %v", got)
+	}
+
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "u2",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	if err := conn.WriteJSON(map[string]any{"op": "auth", "token": token}); err != nil {
+		t.Fatalf("auth failed: %v", err)
+	}
+	if got := readEnvelope(t, conn); got["type"] != "authenticated" {
+		t.Fatalf("expected authenticated, got %v", got)
+	}
+
+	if err := conn.WriteJSON(map[string]any{"op": "subscribe", "channel": "orders.u1"}); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	if got := readEnvelope(t, conn); got["type"] != "error" || got["message"] != "forbidden" {
+		t.Fatalf("expected forbidden, got %v", got)
+	}
+
+	if err := conn.WriteJSON(map[string]any{"op": "ping"}); err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	if got := readEnvelope(t, conn); got["type"] != "pong" {
+		t.Fatalf("expected pong, got %v", got)
+	}
+}
+
+func TestWebSocketRejectsInvalidToken(t *testing.T) {
+	url := startWSServer(t, Config{JWTSecret: "secret"}, &fakeTradingService{})
+	_, res, err := fastws.DefaultDialer.Dial(url+"?token=bogus", nil)
+	if err == nil {
+		t.Fatal("expected dial with invalid token to fail")
+	}
+	if res == nil || res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %+v", res)
+	}
+}
+
+func TestParseWSChannel(t *testing.T) {
+	channel, err := parseWSChannel("candles.BTC-USD.1M")
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if channel.Symbol != "BTC-USD" || channel.Timeframe != "1m" {
+		t.Fatalf("unexpected channel %+v", channel)
+	}
+	for _, name := range []string{"book", "candles.BTC-USD", "candles.BTC-USD.2m", "quotes.BTC-USD"} {
+		if _, err := parseWSChannel(name); err == nil {
+			t.Fatalf("expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestWebSocketDeliversPrivateOrderUpdates(t *testing.T) {
+	hub := streamhub.NewHub(idleSource{}, 0)
+	url := startWSServer(t, Config{JWTSecret: "secret", UserEventHub: hub}, &fakeTradingService{})
+
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "u1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	conn := dialWS(t, url+"?token="+token, nil)
+
+	if err := conn.WriteJSON(map[string]any{"op": "subscribe", "channel": "orders.u1"}); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	if got := readEnvelope(t, conn); got["type"] != "subscribed" {
+		t.Fatalf("expected subscribed ack, got %v", got)
+	}
+	waitForSubscribers(t, hub, "orders.u1", 1)
+
+	hub.Broadcast([]streamhub.Message{
+		{ID: "1-0", Key: "orders.u2", Data: json.RawMessage(`{"orderId":"ord-9"}`)},
+		{ID: "2-0", Key: "orders.u1", Data: json.RawMessage(`{"orderId":"ord-1","status":"FILLED"}`)},
+	})
+	event := readEnvelope(t, conn)
+	data, _ := event["data"].(map[string]any)
+	if event["channel"] != "orders.u1" || data["orderId"] != "ord-1" || data["status"] != "FILLED" {
+		t.Fatalf("unexpected private event %v", event)
+	}
+}
+
+func TestTickWebSocketSharesHubAndReportsMetrics(t *testing.T) {
+	hub := streamhub.NewHub(idleSource{}, 0)
+	hub.SetSlowConsumerPolicy(streamhub.ConflateSlowConsumer)
+	url := startWSServer(t, Config{JWTSecret: "secret", TickHub: hub}, &fakeTradingService{})
+	base := strings.TrimSuffix(url, "/ws")
+
+	first := dialWS(t, base+"/ws/ticks/btc-usd", nil)
+	second := dialWS(t, base+"/ws/ticks/BTC-USD", nil)
+	waitForSubscribers(t, hub, "BTC-USD", 2)
+
+	hub.Broadcast([]streamhub.Message{
+		{ID: "1-0", Key: "ETH-USD", Data: tickstream.Tick{Symbol: "ETH-USD", Price: 3000}},
+		{ID: "2-0", Key: "BTC-USD", Data: tickstream.Tick{Symbol: "BTC-USD", Price: 101}},
+	})
+	for _, conn := range []*fastws.Conn{first, second} {
+		tick := readEnvelope(t, conn)
+		if tick["type"] != "tick" || tick["id"] != "2-0" {
+			t.Fatalf("unexpected tick %v", tick)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, strings.Replace(base, "ws://", "http://", 1)+"/v1/admin/streams", nil)
+	operator, _ := signAccessToken("secret", "ops1", "", "operator", time.Hour)
+	req.Header.Set("Authorization", "Bearer "+operator)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("stats request failed: %v", err)
+	}
+	defer res.Body.Close()
+	var stats StreamStats
+	if err := json.NewDecoder(res.Body).Decode(&stats); err != nil {
+		t.Fatalf("decode stats failed: %v", err)
+	}
+	if got := stats.Connections["ticks"]; got.Active != 2 || got.Opened != 2 {
+		t.Fatalf("unexpected tick connection stats %+v", got)
+	}
+	if got := stats.Hubs["ticks"]; got.Subscribers != 2 || got.Delivered != 2 {
+		t.Fatalf("unexpected tick hub stats %+v", got)
+	}
+
+	_ = first.Close()
+	waitForSubscribers(t, hub, "BTC-USD", 1)
+}
+
+func TestWebSocketCandleSubscription(t *testing.T) {
+	hub := streamhub.NewHub(idleSource{}, 0)
+	url := startWSServer(t, Config{JWTSecret: "secret", CandleHub: hub}, &fakeTradingService{})
+	conn := dialWS(t, url, nil)
+
+	if err := conn.WriteJSON(map[string]any{"op": "subscribe", "channel": "candles.BTC-USD.1m"}); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	if got := readEnvelope(t, conn); got["type"] != "subscribed" || got["channel"] != "candles.BTC-USD.1m" {
+		t.Fatalf("expected subscribed ack, got %v", got)
+	}
+	waitForSubscribers(t, hub, "BTC-USD.1m", 1)
+
+	bucket := time.Date(2026, 2, 14, 12, 34, 0, 0, time.UTC)
+	hub.Broadcast([]streamhub.Message{
+		{ID: "1-0", Key: "BTC-USD.5s", Data: contracts.CandleUpdate{Candle: contracts.Candle{Symbol: "BTC-USD", Timeframe: "5s"}}},
+		{ID: "2-0", Key: "BTC-USD.1m", Data: contracts.CandleUpdate{Candle: contracts.Candle{Symbol: "BTC-USD", Timeframe: "1m", BucketStart: bucket, Close: 105}, Final: true}},
+	})
+	event := readEnvelope(t, conn)
+	if event["type"] != "event" || event["id"] != "2-0" {
+		t.Fatalf("unexpected event %v", event)
+	}
+	if data, _ := event["data"].(map[string]any); data["timeframe"] != "1m" || data["close"] != 105.0 || data["final"] != true {
+		t.Fatalf("unexpected candle data %v", event["data"])
+	}
+}
+
+func TestTradeWebSocketResumeSignalsTruncatedReplay(t *testing.T) {
+	stored := make([]streamhub.Message, 0, maxStreamReplay+1)
+	for i := 1; i <= maxStreamReplay+1; i++ {
+		stored = append(stored, streamhub.Message{ID: fmt.Sprintf("%d-0", i), Key: "BTC-USD", Data: contracts.Execution{TradeID: fmt.Sprintf("trd-%d", i)}})
+	}
+	hub := streamhub.NewHub(storedSource{stored: stored}, 0)
+	url := startWSServer(t, Config{TradeHub: hub}, &fakeTradingService{})
+	conn := dialWS(t, url+"/trades/BTC-USD?from=0-0", nil)
+
+	for i := 1; i <= maxStreamReplay; i++ {
+		if got := readEnvelope(t, conn); got["type"] != "trade" || got["id"] != fmt.Sprintf("%d-0", i) {
+			t.Fatalf("expected replayed trade %d-0, got %v", i, got)
+		}
+	}
+	want := fmt.Sprintf("%d-0", maxStreamReplay)
+	if got := readEnvelope(t, conn); got["type"] != "resync" || got["id"] != want {
+		t.Fatalf("expected resync from %s, got %v", want, got)
+	}
+}
@@ -0,0 +1,232 @@
+package killswitch
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"kalency/apps/gateway-api/internal/accounts"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+const (
+	ScopeUser   = "USER"
+	ScopeSymbol = "SYMBOL"
+	ScopeGlobal = "GLOBAL"
+)
+
+var (
+	ErrNotFound       = errors.New("kill switch not found")
+	ErrInvalidScope   = errors.New("scope must be USER, SYMBOL or GLOBAL")
+	ErrTargetRequired = errors.New("target is required for USER and SYMBOL kill switches")
+)
+
+type Store interface {
+	Put(ctx context.Context, killSwitch contracts.KillSwitch) error
+	// Delete returns ErrNotFound when no kill switch has the scope and target.
+	Delete(ctx context.Context, scope, target string) error
+	List(ctx context.Context) ([]contracts.KillSwitch, error)
+}
+
+// Normalize upper-cases the scope and checks the target it needs; GLOBAL
+// kill switches have no target.
+func Normalize(scope, target string) (string, string, error) {
+	scope = strings.ToUpper(strings.TrimSpace(scope))
+	target = strings.TrimSpace(target)
+	switch scope {
+	case ScopeGlobal:
+		return scope, "", nil
+	case ScopeUser, ScopeSymbol:
+		if target == "" {
+			return "", "", ErrTargetRequired
+		}
+		return scope, target, nil
+	default:
+		return "", "", ErrInvalidScope
+	}
+}
+
+func key(scope, target string) string {
+	return scope + ":" + target
+}
+
+// Service keeps the active kill switches in memory, so checking an order
+// does not touch the store, and writes every change through to the store.
+// Run reloads them so changes made through other gateway replicas show up.
+type Service struct {
+	store  Store
+	mu     sync.RWMutex
+	active map[string]contracts.KillSwitch
+}
+
+// NewService loads the kill switches already in store.
+func NewService(ctx context.Context, store Store) (*Service, error) {
+	stored, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{store: store}
+	s.replace(stored)
+	return s, nil
+}
+
+// Refresh replaces the in-memory kill switches with the stored ones.
+func (s *Service) Refresh(ctx context.Context) error {
+	stored, err := s.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	s.replace(stored)
+	return nil
+}
+
+// Run refreshes every interval until ctx is done. A failed refresh keeps the
+// last loaded kill switches.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		refreshCtx, cancel := context.WithTimeout(ctx, interval)
+		if err := s.Refresh(refreshCtx); err != nil && ctx.Err() == nil {
+			log.Printf("kill switch refresh failed: %v", err)
+		}
+		cancel()
+	}
+}
+
+func (s *Service) replace(stored []contracts.KillSwitch) {
+	active := make(map[string]contracts.KillSwitch, len(stored))
+	for _, killSwitch := range stored {
+		active[key(killSwitch.Scope, killSwitch.Target)] = killSwitch
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = active
+}
+
+func (s *Service) Activate(ctx context.Context, killSwitch contracts.KillSwitch) (contracts.KillSwitch, error) {
+	scope, target, err := Normalize(killSwitch.Scope, killSwitch.Target)
+	if err != nil {
+		return contracts.KillSwitch{}, err
+	}
+	killSwitch.Scope = scope
+	killSwitch.Target = target
+	killSwitch.Reason = strings.TrimSpace(killSwitch.Reason)
+	if killSwitch.CreatedAt.IsZero() {
+		killSwitch.CreatedAt = time.Now().UTC()
+	}
+	if err := s.store.Put(ctx, killSwitch); err != nil {
+		return contracts.KillSwitch{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active[key(scope, target)] = killSwitch
+	return killSwitch, nil
+}
+
+func (s *Service) Release(ctx context.Context, scope, target string) error {
+	scope, target, err := Normalize(scope, target)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Delete(ctx, scope, target); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.active, key(scope, target))
+	return nil
+}
+
+func (s *Service) List() []contracts.KillSwitch {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]contracts.KillSwitch, 0, len(s.active))
+	for _, killSwitch := range s.active {
+		out = append(out, killSwitch)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return key(out[i].Scope, out[i].Target) < key(out[j].Scope, out[j].Target)
+	})
+	return out
+}
+
+// Check returns the kill switch that stops userID trading symbol, the
+// broadest scope first. A user's kill switch covers its sub-accounts.
+func (s *Service) Check(userID, symbol string) (contracts.KillSwitch, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := []string{key(ScopeGlobal, ""), key(ScopeSymbol, symbol), key(ScopeUser, accounts.ParentUserID(userID)), key(ScopeUser, userID)}
+	for _, k := range keys {
+		if killSwitch, ok := s.active[k]; ok {
+			return killSwitch, true
+		}
+	}
+	return contracts.KillSwitch{}, false
+}
+
+// Message is the rejection text for an order stopped by killSwitch.
+func Message(killSwitch contracts.KillSwitch) string {
+	message := "trading is disabled"
+	switch killSwitch.Scope {
+	case ScopeUser:
+		message = "trading is disabled for " + killSwitch.Target
+	case ScopeSymbol:
+		message = "trading is disabled on " + killSwitch.Target
+	}
+	if killSwitch.Reason != "" {
+		message += ": " + killSwitch.Reason
+	}
+	return message
+}
+
+// MemoryStore keeps kill switches in process memory for tests and local runs
+// without PostgreSQL.
+type MemoryStore struct {
+	mu    sync.Mutex
+	byKey map[string]contracts.KillSwitch
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{byKey: map[string]contracts.KillSwitch{}}
+}
+
+func (m *MemoryStore) Put(_ context.Context, killSwitch contracts.KillSwitch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.byKey[key(killSwitch.Scope, killSwitch.Target)] = killSwitch
+	return nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, scope, target string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byKey[key(scope, target)]; !ok {
+		return ErrNotFound
+	}
+	delete(m.byKey, key(scope, target))
+	return nil
+}
+
+func (m *MemoryStore) List(_ context.Context) ([]contracts.KillSwitch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]contracts.KillSwitch, 0, len(m.byKey))
+	for _, killSwitch := range m.byKey {
+		out = append(out, killSwitch)
+	}
+	return out, nil
+}
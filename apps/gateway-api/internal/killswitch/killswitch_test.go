@@ -0,0 +1,104 @@
+package killswitch
+
+import (
+	"context"
+	"testing"
+
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+func TestServiceChecksScopesAndReloadsFromStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	service, err := NewService(ctx, store)
+	if err != nil {
+		t.Fatalf("new service: %v", err)
+	}
+
+	if _, err := service.Activate(ctx, contracts.KillSwitch{Scope: "user"}); err != ErrTargetRequired {
+		t.Fatalf("expected ErrTargetRequired, got %v", err)
+	}
+	if _, err := service.Activate(ctx, contracts.KillSwitch{Scope: "account", Target: "x"}); err != ErrInvalidScope {
+		t.Fatalf("expected ErrInvalidScope, got %v", err)
+	}
+
+	if _, err := service.Activate(ctx, contracts.KillSwitch{Scope: "symbol", Target: "ETH-USD", Reason: " halted "}); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	if _, ok := service.Check("u1", "BTC-USD"); ok {
+		t.Fatal("expected BTC-USD to trade")
+	}
+	killSwitch, ok := service.Check("u1", "ETH-USD")
+	if !ok || Message(killSwitch) != "trading is disabled on ETH-USD: halted" {
+		t.Fatalf("expected ETH-USD to be stopped, got %+v", killSwitch)
+	}
+
+	// A restarted gateway loads the kill switch back from the store.
+	reloaded, err := NewService(ctx, store)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if _, ok := reloaded.Check("u2", "ETH-USD"); !ok {
+		t.Fatal("expected the kill switch to survive a restart")
+	}
+
+	if _, err := reloaded.Activate(ctx, contracts.KillSwitch{Scope: ScopeGlobal, Target: "ignored"}); err != nil {
+		t.Fatalf("activate global: %v", err)
+	}
+	if killSwitch, ok := reloaded.Check("u2", "BTC-USD"); !ok || killSwitch.Scope != ScopeGlobal || killSwitch.Target != "" {
+		t.Fatalf("expected the global kill switch, got %+v", killSwitch)
+	}
+	if err := reloaded.Release(ctx, "global", ""); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if err := reloaded.Release(ctx, ScopeGlobal, ""); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if got := reloaded.List(); len(got) != 1 || got[0].Scope != ScopeSymbol {
+		t.Fatalf("unexpected kill switches %+v", got)
+	}
+
+	// A user's kill switch also stops its sub-accounts.
+	if _, err := reloaded.Activate(ctx, contracts.KillSwitch{Scope: ScopeUser, Target: "u3"}); err != nil {
+		t.Fatalf("activate user: %v", err)
+	}
+	if killSwitch, ok := reloaded.Check("u3:sub-1", "BTC-USD"); !ok || killSwitch.Target != "u3" {
+		t.Fatalf("expected the user kill switch to cover its sub-account, got %+v", killSwitch)
+	}
+}
+
+func TestServiceRefreshSeesOtherReplicasChanges(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	local, err := NewService(ctx, store)
+	if err != nil {
+		t.Fatalf("new service: %v", err)
+	}
+	other, err := NewService(ctx, store)
+	if err != nil {
+		t.Fatalf("new service: %v", err)
+	}
+
+	if _, err := other.Activate(ctx, contracts.KillSwitch{Scope: ScopeGlobal}); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	if _, ok := local.Check("u1", "BTC-USD"); ok {
+		t.Fatal("expected the change to be unseen before a refresh")
+	}
+	if err := local.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if _, ok := local.Check("u1", "BTC-USD"); !ok {
+		t.Fatal("expected the refreshed service to see the global kill switch")
+	}
+
+	if err := other.Release(ctx, ScopeGlobal, ""); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if err := local.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if len(local.List()) != 0 {
+		t.Fatalf("expected the release to be seen, got %+v", local.List())
+	}
+}
@@ -0,0 +1,76 @@
+package killswitch
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+// PostgresStore keeps kill switches in the kill_switches table created by
+// docker/postgres-init.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+	pool, err := pgxpool.New(ctx, strings.TrimSpace(dsn))
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return &PostgresStore{pool: pool}, nil
+}
+
+func (s *PostgresStore) Close() {
+	if s.pool != nil {
+		s.pool.Close()
+	}
+}
+
+func (s *PostgresStore) Put(ctx context.Context, killSwitch contracts.KillSwitch) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO kill_switches (scope, target, reason, created_by, created_at)
+		VALUES ($1,$2,$3,$4,$5)
+		ON CONFLICT (scope, target) DO UPDATE
+		SET reason = EXCLUDED.reason, created_by = EXCLUDED.created_by, created_at = EXCLUDED.created_at
+	`, killSwitch.Scope, killSwitch.Target, killSwitch.Reason, killSwitch.CreatedBy, killSwitch.CreatedAt)
+	return err
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, scope, target string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM kill_switches WHERE scope = $1 AND target = $2`, scope, target)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStore) List(ctx context.Context) ([]contracts.KillSwitch, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT scope, target, reason, created_by, created_at
+		FROM kill_switches
+		ORDER BY scope, target
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]contracts.KillSwitch, 0)
+	for rows.Next() {
+		var killSwitch contracts.KillSwitch
+		if err := rows.Scan(&killSwitch.Scope, &killSwitch.Target, &killSwitch.Reason, &killSwitch.CreatedBy, &killSwitch.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, killSwitch)
+	}
+	return out, rows.Err()
+}
@@ -3,13 +3,14 @@ package marketsimclient
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"kalency/apps/gateway-api/internal/contracts"
 )
 
 type HTTPClient struct {
@@ -90,24 +91,20 @@ func (h *HTTPClient) doJSON(method, path string, body any, out any) error {
 
 	res, err := h.client.Do(req)
 	if err != nil {
-		return err
+		return &contracts.APIError{Code: contracts.ErrorUnavailable, Message: "market simulator unavailable: " + err.Error()}
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		message, _ := io.ReadAll(res.Body)
-		trimmed := strings.TrimSpace(string(message))
-		if trimmed == "" {
-			trimmed = fmt.Sprintf("request failed: %s", res.Status)
-		}
-		return errors.New(trimmed)
+		body, _ := io.ReadAll(res.Body)
+		return contracts.ParseAPIError(res.StatusCode, body)
 	}
 
 	if out == nil {
 		return nil
 	}
 	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
-		return err
+		return &contracts.APIError{Code: contracts.ErrorUnavailable, Message: "invalid market simulator response: " + err.Error()}
 	}
 	return nil
 }
@@ -1,9 +1,12 @@
 package marketsimclient
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"kalency/apps/gateway-api/internal/contracts"
 )
 
 func TestPauseSymbolForwardsRequest(t *testing.T) {
@@ -60,3 +63,17 @@ func TestEnsureSymbolForwardsRequest(t *testing.T) {
 		t.Fatalf("unexpected path %q", calledPath)
 	}
 }
+
+func TestUndecodableResponsesAreUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`<html>bad gateway</html>`))
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPClient(server.URL).PauseSymbol("BTC-USD")
+	var apiErr *contracts.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != contracts.ErrorUnavailable {
+		t.Fatalf("expected UNAVAILABLE, got %v", err)
+	}
+}
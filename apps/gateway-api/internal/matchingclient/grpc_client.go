@@ -0,0 +1,537 @@
+package matchingclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/matching-engine/matchingpb"
+)
+
+const grpcCallTimeout = 5 * time.Second
+
+// GRPCClient talks to the matching engine's gRPC API. Engine rejections come
+// back as plain errors carrying the engine's message, like HTTPClient.
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	client matchingpb.MatchingEngineClient
+}
+
+// NewGRPCClient connects lazily; the first call dials addr.
+func NewGRPCClient(addr string, opts ...grpc.DialOption) (*GRPCClient, error) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		addr = "localhost:9081"
+	}
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GRPCClient{conn: conn, client: matchingpb.NewMatchingEngineClient(conn)}, nil
+}
+
+func (g *GRPCClient) Close() error {
+	return g.conn.Close()
+}
+
+func (g *GRPCClient) PlaceOrder(req contracts.PlaceOrderRequest) (contracts.OrderAck, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	ack, err := g.client.PlaceOrder(ctx, placeOrderRequestPB(req))
+	if err != nil {
+		return contracts.OrderAck{}, grpcError(err)
+	}
+	return orderAck(ack), nil
+}
+
+func (g *GRPCClient) CancelOrder(userID, orderID string) (contracts.OrderAck, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	ack, err := g.client.CancelOrder(ctx, &matchingpb.CancelOrderRequest{UserId: userID, OrderId: orderID})
+	if err != nil {
+		return contracts.OrderAck{}, grpcError(err)
+	}
+	return orderAck(ack), nil
+}
+
+func (g *GRPCClient) PlaceOrders(reqs []contracts.PlaceOrderRequest) ([]contracts.BatchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	orders := make([]*matchingpb.PlaceOrderRequest, 0, len(reqs))
+	for _, req := range reqs {
+		orders = append(orders, placeOrderRequestPB(req))
+	}
+	res, err := g.client.PlaceOrderBatch(ctx, &matchingpb.PlaceOrderBatchRequest{Orders: orders})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return batchResults(res), nil
+}
+
+func (g *GRPCClient) CancelOrders(userID string, orderIDs []string) ([]contracts.BatchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	res, err := g.client.CancelOrderBatch(ctx, &matchingpb.CancelOrderBatchRequest{UserId: userID, OrderIds: orderIDs})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return batchResults(res), nil
+}
+
+// AmendOrder changes a resting limit order's price and/or total quantity;
+// zero keeps the current value. It is only available over gRPC.
+func (g *GRPCClient) AmendOrder(userID, orderID string, price, qty int64) (contracts.OrderAck, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	ack, err := g.client.AmendOrder(ctx, &matchingpb.AmendOrderRequest{UserId: userID, OrderId: orderID, Price: price, Qty: qty})
+	if err != nil {
+		return contracts.OrderAck{}, grpcError(err)
+	}
+	return orderAck(ack), nil
+}
+
+func (g *GRPCClient) PlaceOrderList(req contracts.PlaceOrderListRequest) (contracts.OrderListAck, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	pbReq := &matchingpb.PlaceOrderListRequest{
+		ClientListId: req.ClientListID,
+		UserId:       req.UserID,
+		Symbol:       req.Symbol,
+		Type:         orderListTypePB(req.Type),
+		Side:         sidePB(req.Side),
+		Qty:          req.Qty,
+		TakeProfit:   orderLegPB(req.TakeProfit),
+		StopLoss:     orderLegPB(req.StopLoss),
+	}
+	if req.Entry != nil {
+		pbReq.Entry = orderLegPB(*req.Entry)
+	}
+	ack, err := g.client.PlaceOrderList(ctx, pbReq)
+	if err != nil {
+		return contracts.OrderListAck{}, grpcError(err)
+	}
+	return orderListAck(ack), nil
+}
+
+func (g *GRPCClient) CancelOrderList(userID, listID string) (contracts.OrderListAck, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	ack, err := g.client.CancelOrderList(ctx, &matchingpb.CancelOrderListRequest{UserId: userID, ListId: listID})
+	if err != nil {
+		return contracts.OrderListAck{}, grpcError(err)
+	}
+	return orderListAck(ack), nil
+}
+
+func (g *GRPCClient) OpenOrders(userID string) ([]contracts.Order, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	res, err := g.client.OpenOrders(ctx, &matchingpb.OpenOrdersRequest{UserId: userID})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	orders := make([]contracts.Order, 0, len(res.GetOrders()))
+	for _, order := range res.GetOrders() {
+		orders = append(orders, contracts.Order{
+			OrderID:       order.GetOrderId(),
+			ClientOrderID: order.GetClientOrderId(),
+			UserID:        order.GetUserId(),
+			Symbol:        order.GetSymbol(),
+			Side:          contracts.Side(enumName(order.GetSide().String(), "SIDE_")),
+			Type:          contracts.OrderType(enumName(order.GetType().String(), "ORDER_TYPE_")),
+			Price:         order.GetPrice(),
+			StopPrice:     order.GetStopPrice(),
+			Triggered:     order.GetTriggered(),
+			Qty:           order.GetQty(),
+			RemainingQty:  order.GetRemainingQty(),
+			ListID:        order.GetListId(),
+			ListRole:      enumName(order.GetListRole().String(), "ORDER_LIST_ROLE_"),
+			ListStatus:    enumName(order.GetListStatus().String(), "ORDER_LIST_STATUS_"),
+			CreatedAt:     timeFromPB(order.GetCreatedAt()),
+		})
+	}
+	return orders, nil
+}
+
+func (g *GRPCClient) Wallet(userID string) (contracts.Wallet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	wallet, err := g.client.GetWallet(ctx, &matchingpb.GetWalletRequest{UserId: userID})
+	if err != nil {
+		return contracts.Wallet{}, grpcError(err)
+	}
+	return walletFromPB(wallet), nil
+}
+
+func (g *GRPCClient) Transfer(req contracts.TransferRequest) (contracts.TransferResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	res, err := g.client.Transfer(ctx, &matchingpb.TransferRequest{
+		RequestId:  req.RequestID,
+		FromUserId: req.FromUserID,
+		ToUserId:   req.ToUserID,
+		Asset:      req.Asset,
+		Amount:     req.Amount,
+	})
+	if err != nil {
+		return contracts.TransferResult{}, grpcError(err)
+	}
+	return contracts.TransferResult{
+		Operation: fundingOperationFromPB(res.GetOperation()),
+		From:      walletFromPB(res.GetFrom()),
+		To:        walletFromPB(res.GetTo()),
+	}, nil
+}
+
+func (g *GRPCClient) Deposit(req contracts.FundingRequest) (contracts.FundingOperation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	op, err := g.client.Deposit(ctx, fundingRequestPB(req))
+	if err != nil {
+		return contracts.FundingOperation{}, grpcError(err)
+	}
+	return fundingOperationFromPB(op), nil
+}
+
+func (g *GRPCClient) Withdraw(req contracts.FundingRequest) (contracts.FundingOperation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	op, err := g.client.Withdraw(ctx, fundingRequestPB(req))
+	if err != nil {
+		return contracts.FundingOperation{}, grpcError(err)
+	}
+	return fundingOperationFromPB(op), nil
+}
+
+func (g *GRPCClient) FundingHistory(userID string, limit int) ([]contracts.FundingOperation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	res, err := g.client.ListFundingHistory(ctx, &matchingpb.ListFundingHistoryRequest{UserId: userID, Limit: int32(limit)})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	history := make([]contracts.FundingOperation, 0, len(res.GetOperations()))
+	for _, op := range res.GetOperations() {
+		history = append(history, fundingOperationFromPB(op))
+	}
+	return history, nil
+}
+
+func fundingRequestPB(req contracts.FundingRequest) *matchingpb.FundingRequest {
+	return &matchingpb.FundingRequest{
+		RequestId: req.RequestID,
+		UserId:    req.UserID,
+		Asset:     req.Asset,
+		Amount:    req.Amount,
+	}
+}
+
+func fundingOperationFromPB(op *matchingpb.FundingOperation) contracts.FundingOperation {
+	return contracts.FundingOperation{
+		ID:        op.GetId(),
+		RequestID: op.GetRequestId(),
+		Type:      enumName(op.GetType().String(), "FUNDING_TYPE_"),
+		Status:    enumName(op.GetStatus().String(), "FUNDING_STATUS_"),
+		UserID:    op.GetUserId(),
+		ToUserID:  op.GetToUserId(),
+		Asset:     op.GetAsset(),
+		Amount:    op.GetAmount(),
+		Reason:    op.GetReason(),
+		CreatedAt: timeFromPB(op.GetCreatedAt()),
+		UpdatedAt: timeFromPB(op.GetUpdatedAt()),
+	}
+}
+
+func walletFromPB(wallet *matchingpb.Wallet) contracts.Wallet {
+	out := contracts.Wallet{
+		UserID:    wallet.GetUserId(),
+		Available: wallet.GetAvailable(),
+		Reserved:  wallet.GetReserved(),
+		UpdatedAt: timeFromPB(wallet.GetUpdatedAt()),
+	}
+	if out.Available == nil {
+		out.Available = map[string]int64{}
+	}
+	if out.Reserved == nil {
+		out.Reserved = map[string]int64{}
+	}
+	return out
+}
+
+func (g *GRPCClient) ListExecutions(symbol string, limit int) ([]contracts.Execution, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	res, err := g.client.ListTrades(ctx, &matchingpb.ListTradesRequest{Symbol: symbol, Limit: int32(limit)})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	executions := make([]contracts.Execution, 0, len(res.GetTrades()))
+	for _, trade := range res.GetTrades() {
+		executions = append(executions, execution(trade))
+	}
+	return executions, nil
+}
+
+func (g *GRPCClient) ListOrderBook(symbol string, depth int) (contracts.OrderBookSnapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	book, err := g.client.GetOrderBook(ctx, &matchingpb.GetOrderBookRequest{Symbol: symbol, Depth: int32(depth)})
+	if err != nil {
+		return contracts.OrderBookSnapshot{}, grpcError(err)
+	}
+	return contracts.OrderBookSnapshot{
+		Symbol: book.GetSymbol(),
+		Seq:    book.GetSeq(),
+		Bids:   bookLevels(book.GetBids()),
+		Asks:   bookLevels(book.GetAsks()),
+		TS:     timeFromPB(book.GetTs()),
+	}, nil
+}
+
+func (g *GRPCClient) Ticker(symbol string) (contracts.Ticker, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	ticker, err := g.client.GetTicker(ctx, &matchingpb.GetTickerRequest{Symbol: symbol})
+	if err != nil {
+		return contracts.Ticker{}, grpcError(err)
+	}
+	return tickerFromPB(ticker), nil
+}
+
+func (g *GRPCClient) Tickers() ([]contracts.Ticker, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	res, err := g.client.ListTickers(ctx, &matchingpb.ListTickersRequest{})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	tickers := make([]contracts.Ticker, 0, len(res.GetTickers()))
+	for _, ticker := range res.GetTickers() {
+		tickers = append(tickers, tickerFromPB(ticker))
+	}
+	return tickers, nil
+}
+
+// StreamExecutions calls fn for each live execution matching the optional
+// symbol and user filters until ctx ends or the engine closes the stream.
+func (g *GRPCClient) StreamExecutions(ctx context.Context, symbol, userID string, fn func(contracts.Execution)) error {
+	stream, err := g.client.StreamExecutions(ctx, &matchingpb.StreamExecutionsRequest{Symbol: symbol, UserId: userID})
+	if err != nil {
+		return grpcError(err)
+	}
+	for {
+		trade, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return grpcError(err)
+		}
+		fn(execution(trade))
+	}
+}
+
+// grpcError turns a status from the engine into an APIError, taking the code
+// from its ErrorDetail when the engine sent one.
+func grpcError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return &contracts.APIError{Code: contracts.ErrorUnavailable, Message: "matching engine unavailable: " + err.Error()}
+	}
+	for _, detail := range st.Details() {
+		if detail, ok := detail.(*matchingpb.ErrorDetail); ok {
+			return &contracts.APIError{
+				Code:    contracts.ErrorCode(detail.GetCode()),
+				Message: detail.GetMessage(),
+				Details: detail.GetDetails(),
+			}
+		}
+	}
+	return &contracts.APIError{Code: grpcErrorCode(st.Code()), Message: st.Message()}
+}
+
+func grpcErrorCode(code codes.Code) contracts.ErrorCode {
+	switch code {
+	case codes.InvalidArgument:
+		return contracts.ErrorInvalidRequest
+	case codes.NotFound:
+		return contracts.ErrorNotFound
+	case codes.FailedPrecondition:
+		return contracts.ErrorValidationFailed
+	case codes.PermissionDenied:
+		return contracts.ErrorForbidden
+	case codes.Unauthenticated:
+		return contracts.ErrorUnauthorized
+	case codes.ResourceExhausted:
+		return contracts.ErrorRateLimited
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return contracts.ErrorUnavailable
+	default:
+		return contracts.ErrorInternal
+	}
+}
+
+func enumName(name, prefix string) string {
+	if !strings.HasPrefix(name, prefix) || strings.HasSuffix(name, "_UNSPECIFIED") {
+		return ""
+	}
+	return strings.TrimPrefix(name, prefix)
+}
+
+func sidePB(side contracts.Side) matchingpb.Side {
+	return matchingpb.Side(matchingpb.Side_value["SIDE_"+string(side)])
+}
+
+func orderTypePB(orderType contracts.OrderType) matchingpb.OrderType {
+	return matchingpb.OrderType(matchingpb.OrderType_value["ORDER_TYPE_"+string(orderType)])
+}
+
+func orderListTypePB(listType contracts.OrderListType) matchingpb.OrderListType {
+	return matchingpb.OrderListType(matchingpb.OrderListType_value["ORDER_LIST_TYPE_"+string(listType)])
+}
+
+func placeOrderRequestPB(req contracts.PlaceOrderRequest) *matchingpb.PlaceOrderRequest {
+	return &matchingpb.PlaceOrderRequest{
+		ClientOrderId: req.ClientOrderID,
+		UserId:        req.UserID,
+		Symbol:        req.Symbol,
+		Side:          sidePB(req.Side),
+		Type:          orderTypePB(req.Type),
+		Price:         req.Price,
+		StopPrice:     req.StopPrice,
+		Qty:           req.Qty,
+	}
+}
+
+func orderLegPB(leg contracts.OrderLeg) *matchingpb.OrderLeg {
+	return &matchingpb.OrderLeg{
+		ClientOrderId: leg.ClientOrderID,
+		Type:          orderTypePB(leg.Type),
+		Price:         leg.Price,
+		StopPrice:     leg.StopPrice,
+	}
+}
+
+func timeFromPB(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func orderAck(ack *matchingpb.OrderAck) contracts.OrderAck {
+	return contracts.OrderAck{
+		OrderID:       ack.GetOrderId(),
+		Status:        contracts.OrderStatus(enumName(ack.GetStatus().String(), "ORDER_STATUS_")),
+		FilledQty:     ack.GetFilledQty(),
+		RemainingQty:  ack.GetRemainingQty(),
+		AvgPrice:      ack.GetAvgPrice(),
+		ClientOrderID: ack.GetClientOrderId(),
+		Symbol:        ack.GetSymbol(),
+		ListID:        ack.GetListId(),
+		RejectCode:    ack.GetRejectCode(),
+		RejectReason:  ack.GetRejectReason(),
+		TS:            timeFromPB(ack.GetTs()),
+	}
+}
+
+func batchResults(res *matchingpb.OrderBatchResponse) []contracts.BatchResult {
+	out := make([]contracts.BatchResult, 0, len(res.GetResults()))
+	for _, result := range res.GetResults() {
+		entry := contracts.BatchResult{Error: result.GetError(), ErrorCode: contracts.ErrorCode(result.GetErrorCode())}
+		if result.GetAck() != nil {
+			ack := orderAck(result.GetAck())
+			entry.Ack = &ack
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func orderListAck(ack *matchingpb.OrderListAck) contracts.OrderListAck {
+	out := contracts.OrderListAck{
+		ListID:       ack.GetListId(),
+		ClientListID: ack.GetClientListId(),
+		Type:         contracts.OrderListType(enumName(ack.GetType().String(), "ORDER_LIST_TYPE_")),
+		Status:       enumName(ack.GetStatus().String(), "ORDER_LIST_STATUS_"),
+		Symbol:       ack.GetSymbol(),
+		Orders:       make([]contracts.OrderAck, 0, len(ack.GetOrders())),
+		TS:           timeFromPB(ack.GetTs()),
+	}
+	for _, order := range ack.GetOrders() {
+		out.Orders = append(out.Orders, orderAck(order))
+	}
+	return out
+}
+
+func execution(trade *matchingpb.Execution) contracts.Execution {
+	return contracts.Execution{
+		TradeID:      trade.GetTradeId(),
+		Symbol:       trade.GetSymbol(),
+		Price:        trade.GetPrice(),
+		Qty:          trade.GetQty(),
+		MakerOrderID: trade.GetMakerOrderId(),
+		MakerUserID:  trade.GetMakerUserId(),
+		TakerOrderID: trade.GetTakerOrderId(),
+		TakerUserID:  trade.GetTakerUserId(),
+		TS:           timeFromPB(trade.GetTs()),
+	}
+}
+
+func bookLevels(levels []*matchingpb.BookLevel) []contracts.BookLevel {
+	out := make([]contracts.BookLevel, 0, len(levels))
+	for _, level := range levels {
+		out = append(out, contracts.BookLevel{Price: level.GetPrice(), Qty: level.GetQty(), Orders: int(level.GetOrders())})
+	}
+	return out
+}
+
+func tickerFromPB(ticker *matchingpb.Ticker) contracts.Ticker {
+	return contracts.Ticker{
+		Symbol:           ticker.GetSymbol(),
+		LastPrice:        ticker.GetLastPrice(),
+		LastQty:          ticker.GetLastQty(),
+		BestBid:          ticker.GetBestBid(),
+		BestBidQty:       ticker.GetBestBidQty(),
+		BestAsk:          ticker.GetBestAsk(),
+		BestAskQty:       ticker.GetBestAskQty(),
+		Open24h:          ticker.GetOpen_24H(),
+		High24h:          ticker.GetHigh_24H(),
+		Low24h:           ticker.GetLow_24H(),
+		Volume24h:        ticker.GetVolume_24H(),
+		QuoteVolume24h:   ticker.GetQuoteVolume_24H(),
+		Trades24h:        ticker.GetTrades_24H(),
+		Change24h:        ticker.GetChange_24H(),
+		ChangePercent24h: ticker.GetChangePercent_24H(),
+		TS:               timeFromPB(ticker.GetTs()),
+	}
+}
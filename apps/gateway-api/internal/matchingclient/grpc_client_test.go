@@ -0,0 +1,157 @@
+package matchingclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/gatewayapi"
+	"kalency/apps/matching-engine/matchingpb"
+)
+
+var _ gatewayapi.TradingService = (*GRPCClient)(nil)
+
+type fakeEngine struct {
+	matchingpb.UnimplementedMatchingEngineServer
+	placed *matchingpb.PlaceOrderRequest
+}
+
+func (f *fakeEngine) PlaceOrder(_ context.Context, req *matchingpb.PlaceOrderRequest) (*matchingpb.OrderAck, error) {
+	if req.GetQty() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "qty must be positive")
+	}
+	f.placed = req
+	return &matchingpb.OrderAck{
+		OrderId:       "ord-1",
+		Status:        matchingpb.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED,
+		FilledQty:     1,
+		RemainingQty:  req.GetQty() - 1,
+		AvgPrice:      req.GetPrice(),
+		ClientOrderId: req.GetClientOrderId(),
+		Symbol:        req.GetSymbol(),
+		Ts:            timestamppb.New(time.Unix(1700000000, 0)),
+	}, nil
+}
+
+func (f *fakeEngine) PlaceOrderBatch(_ context.Context, req *matchingpb.PlaceOrderBatchRequest) (*matchingpb.OrderBatchResponse, error) {
+	return &matchingpb.OrderBatchResponse{Results: []*matchingpb.BatchResult{
+		{Ack: &matchingpb.OrderAck{OrderId: "ord-1", ClientOrderId: req.GetOrders()[0].GetClientOrderId(), Status: matchingpb.OrderStatus_ORDER_STATUS_ACCEPTED}},
+		{Error: "price must be positive for LIMIT order"},
+	}}, nil
+}
+
+func (f *fakeEngine) GetWallet(_ context.Context, req *matchingpb.GetWalletRequest) (*matchingpb.Wallet, error) {
+	return &matchingpb.Wallet{UserId: req.GetUserId(), Available: map[string]int64{"USD": 900}}, nil
+}
+
+func (f *fakeEngine) StreamExecutions(req *matchingpb.StreamExecutionsRequest, stream grpc.ServerStreamingServer[matchingpb.Execution]) error {
+	return stream.Send(&matchingpb.Execution{TradeId: "trd-1", Symbol: req.GetSymbol(), Price: 100, Qty: 2, TakerUserId: req.GetUserId()})
+}
+
+func newFakeEngineClient(t *testing.T, engine *fakeEngine) *GRPCClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	matchingpb.RegisterMatchingEngineServer(server, engine)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	client, err := NewGRPCClient("passthrough:///bufconn", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatalf("new client failed: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestGRPCClientPlaceOrderMapsContracts(t *testing.T) {
+	engine := &fakeEngine{}
+	client := newFakeEngineClient(t, engine)
+
+	ack, err := client.PlaceOrder(contracts.PlaceOrderRequest{
+		ClientOrderID: "c-1",
+		UserID:        "user-1",
+		Symbol:        "BTC-USD",
+		Side:          contracts.SideSell,
+		Type:          contracts.OrderTypeStopLimit,
+		Price:         100,
+		StopPrice:     95,
+		Qty:           3,
+	})
+	if err != nil {
+		t.Fatalf("place failed: %v", err)
+	}
+	if engine.placed.GetSide() != matchingpb.Side_SIDE_SELL || engine.placed.GetType() != matchingpb.OrderType_ORDER_TYPE_STOP_LIMIT || engine.placed.GetStopPrice() != 95 {
+		t.Fatalf("unexpected request sent: %+v", engine.placed)
+	}
+	if ack.Status != contracts.OrderStatusPartiallyFill || ack.RemainingQty != 2 || ack.ClientOrderID != "c-1" || !ack.TS.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+}
+
+func TestGRPCClientReturnsEngineMessage(t *testing.T) {
+	client := newFakeEngineClient(t, &fakeEngine{})
+
+	_, err := client.PlaceOrder(contracts.PlaceOrderRequest{UserID: "user-1", Symbol: "BTC-USD", Side: contracts.SideBuy, Type: contracts.OrderTypeMarket})
+	if err == nil || err.Error() != "qty must be positive" {
+		t.Fatalf("expected engine message, got %v", err)
+	}
+	var apiErr *contracts.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != contracts.ErrorInvalidRequest {
+		t.Fatalf("expected INVALID_REQUEST, got %#v", err)
+	}
+
+	wallet, err := client.Wallet("user-1")
+	if err != nil {
+		t.Fatalf("wallet failed: %v", err)
+	}
+	if wallet.Available["USD"] != 900 || wallet.Reserved == nil {
+		t.Fatalf("unexpected wallet: %+v", wallet)
+	}
+}
+
+func TestGRPCClientStreamsExecutions(t *testing.T) {
+	client := newFakeEngineClient(t, &fakeEngine{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var got []contracts.Execution
+	err := client.StreamExecutions(ctx, "BTC-USD", "user-1", func(execution contracts.Execution) {
+		got = append(got, execution)
+	})
+	if err != nil {
+		t.Fatalf("expected stream to end cleanly, got %v", err)
+	}
+	if len(got) != 1 || got[0].TradeID != "trd-1" || got[0].TakerUserID != "user-1" {
+		t.Fatalf("unexpected executions: %+v", got)
+	}
+}
+
+func TestGRPCClientPlaceOrdersMapsResults(t *testing.T) {
+	client := newFakeEngineClient(t, &fakeEngine{})
+
+	results, err := client.PlaceOrders([]contracts.PlaceOrderRequest{
+		{ClientOrderID: "b-1", UserID: "u1", Symbol: "BTC-USD", Side: contracts.SideBuy, Type: contracts.OrderTypeLimit, Price: 99, Qty: 1},
+		{ClientOrderID: "b-2", UserID: "u1", Symbol: "BTC-USD", Side: contracts.SideBuy, Type: contracts.OrderTypeLimit, Qty: 1},
+	})
+	if err != nil {
+		t.Fatalf("batch place failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Ack == nil || results[0].Ack.ClientOrderID != "b-1" || results[0].Ack.Status != contracts.OrderStatusAccepted {
+		t.Fatalf("unexpected first result: %+v", results)
+	}
+	if results[1].Ack != nil || results[1].Error != "price must be positive for LIMIT order" {
+		t.Fatalf("unexpected second result: %+v", results[1])
+	}
+}
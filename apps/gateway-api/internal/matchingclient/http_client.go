@@ -15,8 +15,9 @@ import (
 )
 
 type HTTPClient struct {
-	baseURL string
-	client  *http.Client
+	baseURL    string
+	client     *http.Client
+	adminToken string
 }
 
 func NewHTTPClient(baseURL string) *HTTPClient {
@@ -32,6 +33,11 @@ func NewHTTPClient(baseURL string) *HTTPClient {
 	}
 }
 
+// SetAdminToken sets the engine's ADMIN_TOKEN, sent on /v1/admin/ requests.
+func (h *HTTPClient) SetAdminToken(token string) {
+	h.adminToken = strings.TrimSpace(token)
+}
+
 func (h *HTTPClient) PlaceOrder(req contracts.PlaceOrderRequest) (contracts.OrderAck, error) {
 	var ack contracts.OrderAck
 	err := h.doJSON(http.MethodPost, "/v1/orders", req, &ack)
@@ -45,6 +51,31 @@ func (h *HTTPClient) CancelOrder(userID, orderID string) (contracts.OrderAck, er
 	return ack, err
 }
 
+func (h *HTTPClient) PlaceOrders(reqs []contracts.PlaceOrderRequest) ([]contracts.BatchResult, error) {
+	var res contracts.OrderBatchResponse
+	err := h.doJSON(http.MethodPost, "/v1/orders/batch", contracts.PlaceOrderBatchRequest{Orders: reqs}, &res)
+	return res.Results, err
+}
+
+func (h *HTTPClient) CancelOrders(userID string, orderIDs []string) ([]contracts.BatchResult, error) {
+	var res contracts.OrderBatchResponse
+	err := h.doJSON(http.MethodDelete, "/v1/orders/batch", contracts.CancelOrderBatchRequest{UserID: userID, OrderIDs: orderIDs}, &res)
+	return res.Results, err
+}
+
+func (h *HTTPClient) PlaceOrderList(req contracts.PlaceOrderListRequest) (contracts.OrderListAck, error) {
+	var ack contracts.OrderListAck
+	err := h.doJSON(http.MethodPost, "/v1/orders/lists", req, &ack)
+	return ack, err
+}
+
+func (h *HTTPClient) CancelOrderList(userID, listID string) (contracts.OrderListAck, error) {
+	var ack contracts.OrderListAck
+	path := fmt.Sprintf("/v1/orders/lists/%s?userId=%s", url.PathEscape(listID), url.QueryEscape(userID))
+	err := h.doJSON(http.MethodDelete, path, nil, &ack)
+	return ack, err
+}
+
 func (h *HTTPClient) OpenOrders(userID string) ([]contracts.Order, error) {
 	var orders []contracts.Order
 	err := h.doJSON(http.MethodGet, "/v1/orders/open/"+url.PathEscape(userID), nil, &orders)
@@ -60,6 +91,34 @@ func (h *HTTPClient) Wallet(userID string) (contracts.Wallet, error) {
 	return wallet, err
 }
 
+func (h *HTTPClient) Transfer(req contracts.TransferRequest) (contracts.TransferResult, error) {
+	var result contracts.TransferResult
+	err := h.doJSON(http.MethodPost, "/v1/transfers", req, &result)
+	return result, err
+}
+
+func (h *HTTPClient) Deposit(req contracts.FundingRequest) (contracts.FundingOperation, error) {
+	var op contracts.FundingOperation
+	err := h.doJSON(http.MethodPost, "/v1/funding/deposits", req, &op)
+	return op, err
+}
+
+func (h *HTTPClient) Withdraw(req contracts.FundingRequest) (contracts.FundingOperation, error) {
+	var op contracts.FundingOperation
+	err := h.doJSON(http.MethodPost, "/v1/funding/withdrawals", req, &op)
+	return op, err
+}
+
+func (h *HTTPClient) FundingHistory(userID string, limit int) ([]contracts.FundingOperation, error) {
+	var history []contracts.FundingOperation
+	path := fmt.Sprintf("/v1/funding/history/%s?limit=%d", url.PathEscape(userID), limit)
+	err := h.doJSON(http.MethodGet, path, nil, &history)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
 func (h *HTTPClient) ListExecutions(symbol string, limit int) ([]contracts.Execution, error) {
 	var executions []contracts.Execution
 	path := fmt.Sprintf("/v1/markets/%s/trades?limit=%d", url.PathEscape(symbol), limit)
@@ -77,6 +136,61 @@ func (h *HTTPClient) ListOrderBook(symbol string, depth int) (contracts.OrderBoo
 	return snapshot, err
 }
 
+func (h *HTTPClient) Ticker(symbol string) (contracts.Ticker, error) {
+	var ticker contracts.Ticker
+	path := fmt.Sprintf("/v1/markets/%s/ticker", url.PathEscape(symbol))
+	err := h.doJSON(http.MethodGet, path, nil, &ticker)
+	return ticker, err
+}
+
+func (h *HTTPClient) Tickers() ([]contracts.Ticker, error) {
+	var tickers []contracts.Ticker
+	err := h.doJSON(http.MethodGet, "/v1/tickers", nil, &tickers)
+	return tickers, err
+}
+
+// ActivateKillSwitch has the engine cancel the orders killSwitch covers and
+// reject new ones.
+func (h *HTTPClient) ActivateKillSwitch(killSwitch contracts.KillSwitch) (contracts.KillSwitchResult, error) {
+	var out contracts.KillSwitchResult
+	err := h.doJSON(http.MethodPost, "/v1/admin/kill-switches", killSwitch, &out)
+	return out, err
+}
+
+// ReleaseKillSwitch succeeds when the engine no longer has the kill switch,
+// including when it never had it.
+func (h *HTTPClient) ReleaseKillSwitch(scope, target string) error {
+	query := url.Values{}
+	query.Set("scope", scope)
+	query.Set("target", target)
+	err := h.doJSON(http.MethodDelete, "/v1/admin/kill-switches?"+query.Encode(), nil, nil)
+	var apiErr *contracts.APIError
+	if errors.As(err, &apiErr) && apiErr.HTTPStatus() == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// PendingFunding lists the deposits and withdrawals waiting for review.
+func (h *HTTPClient) PendingFunding() ([]contracts.FundingOperation, error) {
+	var pending []contracts.FundingOperation
+	err := h.doJSON(http.MethodGet, "/v1/admin/funding/pending", nil, &pending)
+	return pending, err
+}
+
+func (h *HTTPClient) CompleteFunding(id string) (contracts.FundingOperation, error) {
+	var op contracts.FundingOperation
+	err := h.doJSON(http.MethodPost, "/v1/admin/funding/operations/"+url.PathEscape(id)+"/complete", nil, &op)
+	return op, err
+}
+
+func (h *HTTPClient) RejectFunding(id, reason string) (contracts.FundingOperation, error) {
+	var op contracts.FundingOperation
+	body := map[string]string{"reason": reason}
+	err := h.doJSON(http.MethodPost, "/v1/admin/funding/operations/"+url.PathEscape(id)+"/reject", body, &op)
+	return op, err
+}
+
 func (h *HTTPClient) doJSON(method, path string, body any, out any) error {
 	var bodyReader io.Reader
 	if body != nil {
@@ -94,27 +208,26 @@ func (h *HTTPClient) doJSON(method, path string, body any, out any) error {
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if h.adminToken != "" && strings.HasPrefix(path, "/v1/admin/") {
+		req.Header.Set("Authorization", "Bearer "+h.adminToken)
+	}
 
 	res, err := h.client.Do(req)
 	if err != nil {
-		return err
+		return &contracts.APIError{Code: contracts.ErrorUnavailable, Message: "matching engine unavailable: " + err.Error()}
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		message, _ := io.ReadAll(res.Body)
-		trimmed := strings.TrimSpace(string(message))
-		if trimmed == "" {
-			trimmed = fmt.Sprintf("request failed: %s", res.Status)
-		}
-		return errors.New(trimmed)
+		body, _ := io.ReadAll(res.Body)
+		return contracts.ParseAPIError(res.StatusCode, body)
 	}
 
 	if out == nil {
 		return nil
 	}
 	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
-		return err
+		return &contracts.APIError{Code: contracts.ErrorUnavailable, Message: "invalid matching engine response: " + err.Error()}
 	}
 	return nil
 }
@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrInvalidLimit = errors.New("rate limit must look like 50/1s")
+
+// Limit allows Requests per sliding Window.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// ParseLimit reads limits written as requests/window, e.g. 50/1s or 10/1m.
+func ParseLimit(raw string) (Limit, error) {
+	requests, window, ok := strings.Cut(strings.TrimSpace(raw), "/")
+	if !ok {
+		return Limit{}, ErrInvalidLimit
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n <= 0 {
+		return Limit{}, ErrInvalidLimit
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d < time.Millisecond {
+		return Limit{}, ErrInvalidLimit
+	}
+	return Limit{Requests: n, Window: d}, nil
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is when the current window ends and its count starts to
+	// decay out of the sliding window.
+	ResetAfter time.Duration
+}
+
+// slidingWindowScript approximates a sliding window from two fixed window
+// counters: the previous window counts by the share of it still inside the
+// sliding window. Denied requests are not counted.
+var slidingWindowScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+local limit = tonumber(ARGV[1])
+local used = math.floor(previous * tonumber(ARGV[2])) + current
+if used >= limit then
+  return {0, used}
+end
+redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return {1, used + 1}
+`)
+
+// RedisLimiter keeps counters in Redis as {prefix}:ratelimit:{key}:{window},
+// so every gateway replica shares them. The window is the index of the fixed
+// window since the Unix epoch. The key is written in literal braces, a Redis
+// Cluster hash tag that keeps both counters the script reads in one slot.
+type RedisLimiter struct {
+	client redis.UniversalClient
+	prefix string
+	now    func() time.Time
+}
+
+func NewRedisLimiter(client redis.UniversalClient, prefix string) *RedisLimiter {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		prefix = "v1"
+	}
+	return &RedisLimiter{client: client, prefix: prefix, now: time.Now}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := l.now()
+	window := now.UnixMilli() / limit.Window.Milliseconds()
+	elapsed := time.Duration(now.UnixMilli()-window*limit.Window.Milliseconds()) * time.Millisecond
+	weight := 1 - float64(elapsed)/float64(limit.Window)
+
+	keys := []string{
+		fmt.Sprintf("%s:ratelimit:{%s}:%d", l.prefix, key, window),
+		fmt.Sprintf("%s:ratelimit:{%s}:%d", l.prefix, key, window-1),
+	}
+	// Counters must outlive their own window to weigh in on the next one.
+	ttl := 2 * limit.Window.Milliseconds()
+	values, err := slidingWindowScript.Run(ctx, l.client, keys, limit.Requests, strconv.FormatFloat(weight, 'f', 6, 64), ttl).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(values) != 2 {
+		return Result{}, errors.New("unexpected rate limit script reply")
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit.Requests,
+		Remaining:  max(limit.Requests-int(values[1]), 0),
+		ResetAfter: limit.Window - elapsed,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestLimiter(t *testing.T) (*RedisLimiter, *miniredis.Miniredis, *time.Time) {
+	t.Helper()
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mini.Close)
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	now := time.UnixMilli(1_700_000_000_000)
+	limiter := NewRedisLimiter(client, "v1")
+	limiter.now = func() time.Time { return now }
+	return limiter, mini, &now
+}
+
+func TestRedisLimiterDeniesOverLimit(t *testing.T) {
+	limiter, mini, _ := newTestLimiter(t)
+	limit := Limit{Requests: 3, Window: time.Second}
+
+	for i := 0; i < 3; i++ {
+		result, err := limiter.Allow(context.Background(), "key:k1:orders", limit)
+		if err != nil || !result.Allowed {
+			t.Fatalf("request %d: expected allowed, got %+v err=%v", i, result, err)
+		}
+		if result.Remaining != 2-i {
+			t.Fatalf("request %d: expected remaining %d, got %d", i, 2-i, result.Remaining)
+		}
+	}
+	result, err := limiter.Allow(context.Background(), "key:k1:orders", limit)
+	if err != nil || result.Allowed || result.Remaining != 0 {
+		t.Fatalf("expected denied, got %+v err=%v", result, err)
+	}
+	if result.ResetAfter != time.Second {
+		t.Fatalf("expected reset after 1s, got %s", result.ResetAfter)
+	}
+
+	other, err := limiter.Allow(context.Background(), "key:k2:orders", limit)
+	if err != nil || !other.Allowed {
+		t.Fatalf("expected other key to be allowed, got %+v err=%v", other, err)
+	}
+	if got, _ := mini.Get("v1:ratelimit:{key:k1:orders}:1700000000"); got != "3" {
+		t.Fatalf("expected counter of 3, got %q", got)
+	}
+}
+
+func TestRedisLimiterSlidesPreviousWindow(t *testing.T) {
+	limiter, _, now := newTestLimiter(t)
+	limit := Limit{Requests: 4, Window: time.Second}
+
+	for i := 0; i < 4; i++ {
+		if result, _ := limiter.Allow(context.Background(), "user:u1:orders", limit); !result.Allowed {
+			t.Fatalf("request %d: expected allowed", i)
+		}
+	}
+
+	// A quarter into the next window, three quarters of the previous four
+	// requests still count.
+	*now = now.Add(1250 * time.Millisecond)
+	result, err := limiter.Allow(context.Background(), "user:u1:orders", limit)
+	if err != nil || !result.Allowed || result.Remaining != 0 {
+		t.Fatalf("expected one more request, got %+v err=%v", result, err)
+	}
+	if result, _ := limiter.Allow(context.Background(), "user:u1:orders", limit); result.Allowed {
+		t.Fatalf("expected denied, got %+v", result)
+	}
+	if result.ResetAfter != 750*time.Millisecond {
+		t.Fatalf("expected reset after 750ms, got %s", result.ResetAfter)
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit(" 50/1s ")
+	if err != nil || limit != (Limit{Requests: 50, Window: time.Second}) {
+		t.Fatalf("unexpected limit %+v err=%v", limit, err)
+	}
+	for _, raw := range []string{"", "50", "0/1s", "50/x", "-1/1m"} {
+		if _, err := ParseLimit(raw); err != ErrInvalidLimit {
+			t.Fatalf("expected ErrInvalidLimit for %q, got %v", raw, err)
+		}
+	}
+}
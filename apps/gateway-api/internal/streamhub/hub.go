@@ -0,0 +1,262 @@
+package streamhub
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBufferSize = 256
+	readBatchSize     = 250
+	replayPageSize    = 500
+	replayScanLimit   = 100 * replayPageSize
+)
+
+// Message is one stream entry. ID is the Redis stream ID, which clients hand
+// back to resume; Key selects the subscribers it is delivered to.
+type Message struct {
+	ID   string
+	Key  string
+	Data any
+}
+
+// SlowConsumerPolicy decides what happens to a subscriber whose buffer is
+// full when a new message arrives.
+type SlowConsumerPolicy int
+
+const (
+	// DropSlowConsumer closes the subscription. Use it for feeds where every
+	// message matters and the client resumes by ID.
+	DropSlowConsumer SlowConsumerPolicy = iota
+	// ConflateSlowConsumer discards the oldest buffered message to make room,
+	// for feeds where only the latest value matters.
+	ConflateSlowConsumer
+)
+
+// Stats is a point-in-time view of a hub's subscribers and delivery counters.
+type Stats struct {
+	Keys        int    `json:"keys"`
+	Subscribers int    `json:"subscribers"`
+	Broadcast   uint64 `json:"broadcast"`
+	Delivered   uint64 `json:"delivered"`
+	Conflated   uint64 `json:"conflated"`
+	Dropped     uint64 `json:"dropped"`
+	ReadErrors  uint64 `json:"readErrors"`
+}
+
+type Source interface {
+	Read(ctx context.Context, lastID string, count int, block time.Duration) ([]Message, string, error)
+	ReadAfter(ctx context.Context, afterID string, count int) ([]Message, error)
+}
+
+// Hub tails a Source once and fans each message out to the subscribers of
+// its key. A subscriber whose buffer is full never blocks the others: by
+// default it is dropped and can reconnect and resume from the last ID it
+// received, or with ConflateSlowConsumer it loses its oldest buffered message.
+type Hub struct {
+	source     Source
+	bufferSize int
+
+	mu          sync.Mutex
+	policy      SlowConsumerPolicy
+	subscribers map[string]map[*Subscription]struct{}
+	stats       Stats
+}
+
+type Subscription struct {
+	hub      *Hub
+	key      string
+	messages chan Message
+	once     sync.Once
+}
+
+func NewHub(source Source, bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Hub{
+		source:      source,
+		bufferSize:  bufferSize,
+		subscribers: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+func (h *Hub) Run(ctx context.Context) {
+	lastID := "$"
+	for ctx.Err() == nil {
+		messages, nextID, err := h.source.Read(ctx, lastID, readBatchSize, 2*time.Second)
+		if err != nil {
+			h.mu.Lock()
+			h.stats.ReadErrors++
+			h.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		lastID = nextID
+		h.Broadcast(messages)
+	}
+}
+
+func (h *Hub) Broadcast(messages []Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, message := range messages {
+		h.stats.Broadcast++
+		for sub := range h.subscribers[message.Key] {
+			h.deliverLocked(sub, message)
+		}
+	}
+}
+
+func (h *Hub) deliverLocked(sub *Subscription, message Message) {
+	select {
+	case sub.messages <- message:
+		h.stats.Delivered++
+		return
+	default:
+	}
+
+	if h.policy == ConflateSlowConsumer {
+		select {
+		case <-sub.messages:
+			h.stats.Conflated++
+		default:
+		}
+		select {
+		case sub.messages <- message:
+			h.stats.Delivered++
+			return
+		default:
+		}
+	}
+	h.stats.Dropped++
+	h.removeLocked(sub)
+}
+
+// SetSlowConsumerPolicy changes how subscribers with a full buffer are
+// handled. The default is DropSlowConsumer.
+func (h *Hub) SetSlowConsumerPolicy(policy SlowConsumerPolicy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.policy = policy
+}
+
+func (h *Hub) Subscribe(key string) *Subscription {
+	sub := &Subscription{hub: h, key: key, messages: make(chan Message, h.bufferSize)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[key] == nil {
+		h.subscribers[key] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[key][sub] = struct{}{}
+	return sub
+}
+
+// Replay returns up to limit messages for key stored after afterID, oldest
+// first, scanning at most replayScanLimit stream entries. When it stops early
+// resumeFrom is the last ID it covered and later entries were not read, so the
+// client must resume from there rather than go live.
+func (h *Hub) Replay(ctx context.Context, key, afterID string, limit int) (messages []Message, resumeFrom string, err error) {
+	out := make([]Message, 0)
+	scanned := 0
+	for {
+		page, err := h.source.ReadAfter(ctx, afterID, replayPageSize)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, message := range page {
+			afterID = message.ID
+			scanned++
+			if message.Key == key {
+				out = append(out, message)
+				if len(out) >= limit {
+					return out, afterID, nil
+				}
+			}
+			if scanned >= replayScanLimit {
+				return out, afterID, nil
+			}
+		}
+		if len(page) < replayPageSize {
+			return out, "", nil
+		}
+	}
+}
+
+func (h *Hub) Subscribers(key string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[key])
+}
+
+func (h *Hub) Stats() Stats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats := h.stats
+	stats.Keys = len(h.subscribers)
+	for _, subs := range h.subscribers {
+		stats.Subscribers += len(subs)
+	}
+	return stats
+}
+
+func (h *Hub) removeLocked(sub *Subscription) {
+	subs := h.subscribers[sub.key]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.key)
+	}
+	close(sub.messages)
+}
+
+// Messages is closed when the subscription is closed or dropped as a slow
+// consumer.
+func (s *Subscription) Messages() <-chan Message {
+	return s.messages
+}
+
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.mu.Lock()
+		defer s.hub.mu.Unlock()
+		s.hub.removeLocked(s)
+	})
+}
+
+// CompareIDs orders two Redis stream IDs ("ms-seq").
+func CompareIDs(a, b string) int {
+	aMS, aSeq := splitID(a)
+	bMS, bSeq := splitID(b)
+	switch {
+	case aMS < bMS:
+		return -1
+	case aMS > bMS:
+		return 1
+	case aSeq < bSeq:
+		return -1
+	case aSeq > bSeq:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func splitID(id string) (uint64, uint64) {
+	msPart, seqPart, _ := strings.Cut(strings.TrimSpace(id), "-")
+	ms, _ := strconv.ParseUint(msPart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
@@ -0,0 +1,191 @@
+package streamhub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fakeSource struct {
+	stored []Message
+}
+
+func (f *fakeSource) Read(ctx context.Context, lastID string, count int, block time.Duration) ([]Message, string, error) {
+	<-ctx.Done()
+	return nil, lastID, ctx.Err()
+}
+
+func (f *fakeSource) ReadAfter(_ context.Context, afterID string, count int) ([]Message, error) {
+	out := []Message{}
+	for _, message := range f.stored {
+		if CompareIDs(message.ID, afterID) > 0 && len(out) < count {
+			out = append(out, message)
+		}
+	}
+	return out, nil
+}
+
+func TestHubFansOutByKey(t *testing.T) {
+	hub := NewHub(&fakeSource{}, 4)
+	btc := hub.Subscribe("BTC-USD")
+	defer btc.Close()
+	eth := hub.Subscribe("ETH-USD")
+	defer eth.Close()
+
+	hub.Broadcast([]Message{
+		{ID: "1-0", Key: "BTC-USD", Data: 1},
+		{ID: "2-0", Key: "ETH-USD", Data: 2},
+		{ID: "3-0", Key: "BTC-USD", Data: 3},
+	})
+
+	for _, want := range []string{"1-0", "3-0"} {
+		if got := (<-btc.Messages()).ID; got != want {
+			t.Fatalf("expected BTC message %s, got %s", want, got)
+		}
+	}
+	if got := (<-eth.Messages()).ID; got != "2-0" {
+		t.Fatalf("expected ETH message 2-0, got %s", got)
+	}
+}
+
+func TestHubDropsSlowConsumer(t *testing.T) {
+	hub := NewHub(&fakeSource{}, 1)
+	slow := hub.Subscribe("BTC-USD")
+	fast := hub.Subscribe("BTC-USD")
+
+	hub.Broadcast([]Message{{ID: "1-0", Key: "BTC-USD"}})
+	<-fast.Messages()
+	hub.Broadcast([]Message{{ID: "2-0", Key: "BTC-USD"}})
+
+	<-slow.Messages()
+	if _, open := <-slow.Messages(); open {
+		t.Fatal("expected slow subscription to be closed")
+	}
+	if got := (<-fast.Messages()).ID; got != "2-0" {
+		t.Fatalf("expected fast subscriber to keep receiving, got %s", got)
+	}
+	if got := hub.Subscribers("BTC-USD"); got != 1 {
+		t.Fatalf("expected 1 remaining subscriber, got %d", got)
+	}
+
+	fast.Close()
+	fast.Close()
+	if got := hub.Subscribers("BTC-USD"); got != 0 {
+		t.Fatalf("expected no subscribers after close, got %d", got)
+	}
+}
+
+func TestHubReplayFiltersByKeyAfterID(t *testing.T) {
+	source := &fakeSource{stored: []Message{
+		{ID: "5-0", Key: "BTC-USD"},
+		{ID: "5-1", Key: "ETH-USD"},
+		{ID: "6-0", Key: "BTC-USD"},
+		{ID: "10-0", Key: "BTC-USD"},
+	}}
+	hub := NewHub(source, 0)
+
+	replayed, resumeFrom, err := hub.Replay(context.Background(), "BTC-USD", "5-0", 10)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if len(replayed) != 2 || replayed[0].ID != "6-0" || replayed[1].ID != "10-0" || resumeFrom != "" {
+		t.Fatalf("unexpected replay %+v resume %q", replayed, resumeFrom)
+	}
+}
+
+func TestHubReplayReportsWhereItStopped(t *testing.T) {
+	source := &fakeSource{}
+	for i := 1; i <= 5; i++ {
+		source.stored = append(source.stored, Message{ID: fmt.Sprintf("%d-0", i), Key: "BTC-USD"})
+	}
+	hub := NewHub(source, 0)
+
+	replayed, resumeFrom, err := hub.Replay(context.Background(), "BTC-USD", "0-0", 3)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if len(replayed) != 3 || resumeFrom != "3-0" {
+		t.Fatalf("expected 3 messages and resume from 3-0, got %d and %q", len(replayed), resumeFrom)
+	}
+
+	source.stored = nil
+	for i := 1; i <= replayScanLimit+1; i++ {
+		source.stored = append(source.stored, Message{ID: fmt.Sprintf("%d-0", i), Key: "ETH-USD"})
+	}
+	replayed, resumeFrom, err = hub.Replay(context.Background(), "BTC-USD", "0-0", 3)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if len(replayed) != 0 || resumeFrom != fmt.Sprintf("%d-0", replayScanLimit) {
+		t.Fatalf("expected the scan to stop at %d entries, got %d and %q", replayScanLimit, len(replayed), resumeFrom)
+	}
+}
+
+func TestCompareIDs(t *testing.T) {
+	if CompareIDs("9-0", "10-0") >= 0 {
+		t.Fatal("expected 9-0 before 10-0")
+	}
+	if CompareIDs("10-2", "10-10") >= 0 {
+		t.Fatal("expected 10-2 before 10-10")
+	}
+	if CompareIDs("10-1", "10-1") != 0 {
+		t.Fatal("expected equal ids")
+	}
+}
+
+func TestRegistryReusesHubPerKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	created := 0
+	registry := NewRegistry(ctx, func(key string) Source {
+		created++
+		return &fakeSource{}
+	}, 0)
+
+	if registry.Hub("BTC-USD") != registry.Hub("BTC-USD") {
+		t.Fatal("expected the same hub for one key")
+	}
+	registry.Hub("ETH-USD")
+	if created != 2 {
+		t.Fatalf("expected 2 sources, got %d", created)
+	}
+}
+
+func TestHubConflatesSlowConsumer(t *testing.T) {
+	hub := NewHub(&fakeSource{}, 2)
+	hub.SetSlowConsumerPolicy(ConflateSlowConsumer)
+	sub := hub.Subscribe("BTC-USD")
+	defer sub.Close()
+
+	hub.Broadcast([]Message{
+		{ID: "1-0", Key: "BTC-USD"},
+		{ID: "2-0", Key: "BTC-USD"},
+		{ID: "3-0", Key: "BTC-USD"},
+		{ID: "4-0", Key: "BTC-USD"},
+	})
+
+	for _, want := range []string{"3-0", "4-0"} {
+		if got := (<-sub.Messages()).ID; got != want {
+			t.Fatalf("expected conflated message %s, got %s", want, got)
+		}
+	}
+
+	stats := hub.Stats()
+	if stats.Subscribers != 1 || stats.Broadcast != 4 || stats.Delivered != 4 || stats.Conflated != 2 || stats.Dropped != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestHubStatsCountDroppedSubscribers(t *testing.T) {
+	hub := NewHub(&fakeSource{}, 1)
+	hub.Subscribe("BTC-USD")
+
+	hub.Broadcast([]Message{{ID: "1-0", Key: "BTC-USD"}, {ID: "2-0", Key: "BTC-USD"}})
+
+	stats := hub.Stats()
+	if stats.Subscribers != 0 || stats.Keys != 0 || stats.Delivered != 1 || stats.Dropped != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
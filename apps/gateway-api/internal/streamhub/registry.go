@@ -0,0 +1,71 @@
+package streamhub
+
+import (
+	"context"
+	"sync"
+)
+
+// Registry lazily starts one Hub per key, for feeds that live on a stream per
+// symbol.
+type Registry struct {
+	ctx        context.Context
+	newSource  func(key string) Source
+	bufferSize int
+
+	mu     sync.Mutex
+	policy SlowConsumerPolicy
+	hubs   map[string]*Hub
+}
+
+func NewRegistry(ctx context.Context, newSource func(key string) Source, bufferSize int) *Registry {
+	return &Registry{
+		ctx:        ctx,
+		newSource:  newSource,
+		bufferSize: bufferSize,
+		hubs:       make(map[string]*Hub),
+	}
+}
+
+func (r *Registry) Hub(key string) *Hub {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if hub, ok := r.hubs[key]; ok {
+		return hub
+	}
+	hub := NewHub(r.newSource(key), r.bufferSize)
+	hub.SetSlowConsumerPolicy(r.policy)
+	r.hubs[key] = hub
+	go hub.Run(r.ctx)
+	return hub
+}
+
+// SetSlowConsumerPolicy applies to hubs created after the call.
+func (r *Registry) SetSlowConsumerPolicy(policy SlowConsumerPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = policy
+}
+
+// Stats sums the stats of every hub in the registry.
+func (r *Registry) Stats() Stats {
+	r.mu.Lock()
+	hubs := make([]*Hub, 0, len(r.hubs))
+	for _, hub := range r.hubs {
+		hubs = append(hubs, hub)
+	}
+	r.mu.Unlock()
+
+	total := Stats{}
+	for _, hub := range hubs {
+		stats := hub.Stats()
+		total.Keys += stats.Keys
+		total.Subscribers += stats.Subscribers
+		total.Broadcast += stats.Broadcast
+		total.Delivered += stats.Delivered
+		total.Conflated += stats.Conflated
+		total.Dropped += stats.Dropped
+		total.ReadErrors += stats.ReadErrors
+	}
+	return total
+}
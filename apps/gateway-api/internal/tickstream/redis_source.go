@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/streamhub"
 )
 
 type Tick struct {
@@ -17,6 +18,8 @@ type Tick struct {
 	TS     time.Time `json:"ts"`
 }
 
+// RedisTickStreamSource reads simulator ticks as hub messages keyed by
+// upper-cased symbol.
 type RedisTickStreamSource struct {
 	client redis.UniversalClient
 	stream string
@@ -30,7 +33,7 @@ func NewRedisTickStreamSource(client redis.UniversalClient, stream string) *Redi
 	return &RedisTickStreamSource{client: client, stream: stream}
 }
 
-func (s *RedisTickStreamSource) Read(ctx context.Context, lastID string, count int, block time.Duration) ([]Tick, string, error) {
+func (s *RedisTickStreamSource) Read(ctx context.Context, lastID string, count int, block time.Duration) ([]streamhub.Message, string, error) {
 	if strings.TrimSpace(lastID) == "" {
 		lastID = "$"
 	}
@@ -47,28 +50,59 @@ func (s *RedisTickStreamSource) Read(ctx context.Context, lastID string, count i
 		Block:   block,
 	}).Result()
 	if err == redis.Nil {
-		return []Tick{}, lastID, nil
+		return []streamhub.Message{}, lastID, nil
 	}
 	if err != nil {
 		return nil, lastID, err
 	}
 
-	result := make([]Tick, 0)
+	result := make([]streamhub.Message, 0)
 	nextID := lastID
 	for _, stream := range streamData {
-		for _, message := range stream.Messages {
-			nextID = message.ID
-			tick, decodeErr := decodeTick(message.Values)
-			if decodeErr != nil {
-				continue
+		for _, entry := range stream.Messages {
+			nextID = entry.ID
+			if message, ok := decodeMessage(entry); ok {
+				result = append(result, message)
 			}
-			result = append(result, tick)
 		}
 	}
 
 	return result, nextID, nil
 }
 
+func (s *RedisTickStreamSource) ReadAfter(ctx context.Context, afterID string, count int) ([]streamhub.Message, error) {
+	entries, err := s.client.XRangeN(ctx, s.stream, "("+afterID, "+", int64(count)).Result()
+	if err == redis.Nil {
+		return []streamhub.Message{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]streamhub.Message, 0, len(entries))
+	for _, entry := range entries {
+		message, ok := decodeMessage(entry)
+		if !ok {
+			message = streamhub.Message{ID: entry.ID}
+		}
+		result = append(result, message)
+	}
+	return result, nil
+}
+
+// Key is the hub key ticks for symbol are published under.
+func Key(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+func decodeMessage(entry redis.XMessage) (streamhub.Message, bool) {
+	tick, err := decodeTick(entry.Values)
+	if err != nil {
+		return streamhub.Message{}, false
+	}
+	return streamhub.Message{ID: entry.ID, Key: Key(tick.Symbol), Data: tick}, true
+}
+
 func decodeTick(values map[string]any) (Tick, error) {
 	symbol := strings.TrimSpace(fmt.Sprint(values["symbol"]))
 	if symbol == "" {
@@ -118,4 +152,3 @@ func parseFloat(value any) (float64, error) {
 		return strconv.ParseFloat(strings.TrimSpace(fmt.Sprint(value)), 64)
 	}
 }
-
@@ -0,0 +1,57 @@
+package tickstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisTickStreamSourceKeysTicksBySymbol(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	stream := "kalency:v1:stream:ticks"
+	ids := make([]string, 0, 3)
+	for _, values := range []map[string]any{
+		{"symbol": "btc-usd", "price": "101.5", "qty": "2", "ts": time.Unix(10, 0).UTC().Format(time.RFC3339Nano)},
+		{"symbol": "BTC-USD", "price": "bad"},
+		{"symbol": "ETH-USD", "price": "3000"},
+	} {
+		id, err := client.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: values}).Result()
+		if err != nil {
+			t.Fatalf("xadd failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	source := NewRedisTickStreamSource(client, stream)
+	messages, nextID, err := source.Read(ctx, "0", 10, 0)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if len(messages) != 2 || nextID != ids[2] {
+		t.Fatalf("expected 2 decodable ticks ending at %s, got %+v ending at %s", ids[2], messages, nextID)
+	}
+	tick, ok := messages[0].Data.(Tick)
+	if !ok || messages[0].Key != "BTC-USD" || tick.Price != 101.5 || tick.Volume != 2 {
+		t.Fatalf("unexpected first message %+v", messages[0])
+	}
+
+	after, err := source.ReadAfter(ctx, ids[0], 10)
+	if err != nil {
+		t.Fatalf("read after failed: %v", err)
+	}
+	if len(after) != 2 || after[0].ID != ids[1] || after[0].Key != "" || after[1].Key != "ETH-USD" {
+		t.Fatalf("unexpected read-after result %+v", after)
+	}
+}
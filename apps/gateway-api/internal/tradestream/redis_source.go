@@ -0,0 +1,124 @@
+package tradestream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/contracts"
+	"kalency/apps/gateway-api/internal/streamhub"
+)
+
+// RedisTradeStreamSource reads matching-engine executions as hub messages
+// keyed by symbol.
+type RedisTradeStreamSource struct {
+	client redis.UniversalClient
+	stream string
+}
+
+func NewRedisTradeStreamSource(client redis.UniversalClient, stream string) *RedisTradeStreamSource {
+	stream = strings.TrimSpace(stream)
+	if stream == "" {
+		stream = "kalency:v1:stream:executions"
+	}
+	return &RedisTradeStreamSource{client: client, stream: stream}
+}
+
+func (s *RedisTradeStreamSource) Read(ctx context.Context, lastID string, count int, block time.Duration) ([]streamhub.Message, string, error) {
+	if strings.TrimSpace(lastID) == "" {
+		lastID = "$"
+	}
+	if count <= 0 {
+		count = 250
+	}
+	if block < 0 {
+		block = 0
+	}
+
+	streamData, err := s.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{s.stream, lastID},
+		Count:   int64(count),
+		Block:   block,
+	}).Result()
+	if err == redis.Nil {
+		return []streamhub.Message{}, lastID, nil
+	}
+	if err != nil {
+		return nil, lastID, err
+	}
+
+	result := make([]streamhub.Message, 0)
+	nextID := lastID
+	for _, stream := range streamData {
+		for _, entry := range stream.Messages {
+			nextID = entry.ID
+			if message, ok := decodeMessage(entry); ok {
+				result = append(result, message)
+			}
+		}
+	}
+	return result, nextID, nil
+}
+
+func (s *RedisTradeStreamSource) ReadAfter(ctx context.Context, afterID string, count int) ([]streamhub.Message, error) {
+	entries, err := s.client.XRangeN(ctx, s.stream, "("+afterID, "+", int64(count)).Result()
+	if err == redis.Nil {
+		return []streamhub.Message{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]streamhub.Message, 0, len(entries))
+	for _, entry := range entries {
+		message, ok := decodeMessage(entry)
+		if !ok {
+			// Keep the ID so Replay pages past entries it cannot decode.
+			message = streamhub.Message{ID: entry.ID}
+		}
+		result = append(result, message)
+	}
+	return result, nil
+}
+
+func decodeMessage(entry redis.XMessage) (streamhub.Message, bool) {
+	execution, err := decodeExecution(entry.Values)
+	if err != nil {
+		return streamhub.Message{}, false
+	}
+	return streamhub.Message{ID: entry.ID, Key: execution.Symbol, Data: execution}, true
+}
+
+func decodeExecution(values map[string]any) (contracts.Execution, error) {
+	symbol := strings.TrimSpace(fmt.Sprint(values["symbol"]))
+	if symbol == "" {
+		return contracts.Execution{}, fmt.Errorf("missing symbol")
+	}
+	price, err := strconv.ParseInt(fmt.Sprint(values["price"]), 10, 64)
+	if err != nil {
+		return contracts.Execution{}, err
+	}
+	qty, err := strconv.ParseInt(fmt.Sprint(values["qty"]), 10, 64)
+	if err != nil {
+		return contracts.Execution{}, err
+	}
+	ts, err := time.Parse(time.RFC3339Nano, fmt.Sprint(values["ts"]))
+	if err != nil {
+		ts = time.Time{}
+	}
+
+	return contracts.Execution{
+		TradeID:      fmt.Sprint(values["trade_id"]),
+		Symbol:       symbol,
+		Price:        price,
+		Qty:          qty,
+		MakerOrderID: fmt.Sprint(values["maker_order_id"]),
+		MakerUserID:  fmt.Sprint(values["maker_user_id"]),
+		TakerOrderID: fmt.Sprint(values["taker_order_id"]),
+		TakerUserID:  fmt.Sprint(values["taker_user_id"]),
+		TS:           ts,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package tradestream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/contracts"
+)
+
+func TestRedisTradeStreamSourceReadsExecutions(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	stream := "kalency:v1:stream:executions"
+	ids := make([]string, 0, 3)
+	for i, symbol := range []string{"BTC-USD", "ETH-USD", "BTC-USD"} {
+		id, err := client.XAdd(ctx, &redis.XAddArgs{
+			Stream: stream,
+			Values: map[string]any{
+				"trade_id": fmt.Sprintf("trd-%d", i+1),
+				"symbol":   symbol,
+				"price":    100 + i,
+				"qty":      1,
+				"ts":       time.Unix(10, 0).UTC().Format(time.RFC3339Nano),
+			},
+		}).Result()
+		if err != nil {
+			t.Fatalf("xadd failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	source := NewRedisTradeStreamSource(client, stream)
+	messages, nextID, err := source.Read(ctx, "0", 10, 0)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if len(messages) != 3 || nextID != ids[2] {
+		t.Fatalf("expected 3 messages ending at %s, got %d ending at %s", ids[2], len(messages), nextID)
+	}
+	execution, ok := messages[0].Data.(contracts.Execution)
+	if !ok || messages[0].Key != "BTC-USD" || execution.TradeID != "trd-1" || execution.Price != 100 {
+		t.Fatalf("unexpected first message %+v", messages[0])
+	}
+
+	after, err := source.ReadAfter(ctx, ids[0], 10)
+	if err != nil {
+		t.Fatalf("read after failed: %v", err)
+	}
+	if len(after) != 2 || after[0].ID != ids[1] || after[1].Key != "BTC-USD" {
+		t.Fatalf("unexpected messages after %s: %+v", ids[0], after)
+	}
+}
@@ -0,0 +1,92 @@
+package userstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/gateway-api/internal/streamhub"
+)
+
+// RedisUserEventSource reads the engine's private order and wallet updates
+// as hub messages keyed by channel name (orders.{userId}, wallet.{userId}).
+type RedisUserEventSource struct {
+	client redis.UniversalClient
+	stream string
+}
+
+func NewRedisUserEventSource(client redis.UniversalClient, stream string) *RedisUserEventSource {
+	stream = strings.TrimSpace(stream)
+	if stream == "" {
+		stream = "kalency:v1:stream:user-events"
+	}
+	return &RedisUserEventSource{client: client, stream: stream}
+}
+
+func (s *RedisUserEventSource) Read(ctx context.Context, lastID string, count int, block time.Duration) ([]streamhub.Message, string, error) {
+	if strings.TrimSpace(lastID) == "" {
+		lastID = "$"
+	}
+	if count <= 0 {
+		count = 250
+	}
+	if block < 0 {
+		block = 0
+	}
+
+	streamData, err := s.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{s.stream, lastID},
+		Count:   int64(count),
+		Block:   block,
+	}).Result()
+	if err == redis.Nil {
+		return []streamhub.Message{}, lastID, nil
+	}
+	if err != nil {
+		return nil, lastID, err
+	}
+
+	result := make([]streamhub.Message, 0)
+	nextID := lastID
+	for _, stream := range streamData {
+		for _, entry := range stream.Messages {
+			nextID = entry.ID
+			if message, ok := decodeMessage(entry); ok {
+				result = append(result, message)
+			}
+		}
+	}
+	return result, nextID, nil
+}
+
+func (s *RedisUserEventSource) ReadAfter(ctx context.Context, afterID string, count int) ([]streamhub.Message, error) {
+	entries, err := s.client.XRangeN(ctx, s.stream, "("+afterID, "+", int64(count)).Result()
+	if err == redis.Nil {
+		return []streamhub.Message{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]streamhub.Message, 0, len(entries))
+	for _, entry := range entries {
+		message, ok := decodeMessage(entry)
+		if !ok {
+			message = streamhub.Message{ID: entry.ID}
+		}
+		result = append(result, message)
+	}
+	return result, nil
+}
+
+func decodeMessage(entry redis.XMessage) (streamhub.Message, bool) {
+	channel := strings.TrimSpace(fmt.Sprint(entry.Values["channel"]))
+	payload := fmt.Sprint(entry.Values["payload"])
+	if channel == "" || !json.Valid([]byte(payload)) {
+		return streamhub.Message{}, false
+	}
+	return streamhub.Message{ID: entry.ID, Key: channel, Data: json.RawMessage(payload)}, true
+}
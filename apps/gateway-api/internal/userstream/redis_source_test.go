@@ -0,0 +1,53 @@
+package userstream
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisUserEventSourceKeysByChannel(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	stream := "kalency:v1:stream:user-events"
+	for _, values := range []map[string]any{
+		{"channel": "orders.u1", "user_id": "u1", "payload": `{"orderId":"ord-1","status":"FILLED"}`},
+		{"channel": "wallet.u1", "user_id": "u1", "payload": `not-json`},
+		{"channel": "wallet.u1", "user_id": "u1", "payload": `{"userId":"u1"}`},
+	} {
+		if err := client.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: values}).Err(); err != nil {
+			t.Fatalf("xadd failed: %v", err)
+		}
+	}
+
+	source := NewRedisUserEventSource(client, stream)
+	messages, _, err := source.Read(ctx, "0", 10, 0)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected malformed entry to be skipped, got %d messages", len(messages))
+	}
+	if messages[0].Key != "orders.u1" || messages[1].Key != "wallet.u1" {
+		t.Fatalf("unexpected keys %s, %s", messages[0].Key, messages[1].Key)
+	}
+
+	var update map[string]any
+	if err := json.Unmarshal(messages[0].Data.(json.RawMessage), &update); err != nil {
+		t.Fatalf("decode payload failed: %v", err)
+	}
+	if update["status"] != "FILLED" {
+		t.Fatalf("unexpected payload %v", update)
+	}
+}
@@ -0,0 +1,60 @@
+package signing
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSignerSignsAndPreservesBody(t *testing.T) {
+	var verified bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"qty":1}` {
+			t.Errorf("unexpected body %q", body)
+		}
+		if r.Header.Get(HeaderKeyID) != "key-1" {
+			t.Errorf("unexpected key id %q", r.Header.Get(HeaderKeyID))
+		}
+		verified = Verify(
+			SigningKey("kk_secret"),
+			r.Method,
+			r.URL.RequestURI(),
+			r.Header.Get(HeaderTimestamp),
+			r.Header.Get(HeaderNonce),
+			body,
+			r.Header.Get(HeaderSignature),
+		)
+	}))
+	defer server.Close()
+
+	client := NewClient("key-1", "kk_secret")
+	res, err := client.Post(server.URL+"/v1/orders?dryRun=1", "application/json", strings.NewReader(`{"qty":1}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = res.Body.Close()
+	if !verified {
+		t.Fatal("expected signature to verify")
+	}
+}
+
+func TestVerifyRejectsTamperedRequest(t *testing.T) {
+	key := SigningKey("kk_secret")
+	signature := Signature(key, "POST", "/v1/orders", "1700000000000", "nonce-123", []byte(`{"qty":1}`))
+
+	if !Verify(key, "post", "/v1/orders", "1700000000000", "nonce-123", []byte(`{"qty":1}`), signature) {
+		t.Fatal("expected original request to verify")
+	}
+	if Verify(key, "POST", "/v1/orders", "1700000000000", "nonce-123", []byte(`{"qty":9}`), signature) {
+		t.Fatal("expected tampered body to fail")
+	}
+	if Verify(key, "DELETE", "/v1/orders", "1700000000000", "nonce-123", []byte(`{"qty":1}`), signature) {
+		t.Fatal("expected different method to fail")
+	}
+	if Verify(SigningKey("kk_other"), "POST", "/v1/orders", "1700000000000", "nonce-123", []byte(`{"qty":1}`), signature) {
+		t.Fatal("expected different key to fail")
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"kalency/apps/market-sim/internal/httpapi"
+	"kalency/apps/market-sim/internal/orderentry"
 	"kalency/apps/market-sim/internal/sim"
 	"kalency/apps/market-sim/internal/store"
 )
@@ -21,6 +22,8 @@ func main() {
 	redisAddr := strings.TrimSpace(os.Getenv("REDIS_ADDR"))
 	streamKey := getEnv("SIM_STREAM_KEY", "kalency:v1:stream:ticks")
 	matchingEngineURL := getEnv("MATCHING_ENGINE_URL", "http://localhost:8081")
+	orderEntryAddr := strings.TrimSpace(os.Getenv("MATCHING_ENGINE_ORDER_ENTRY_ADDR"))
+	adminToken := strings.TrimSpace(os.Getenv("MATCHING_ENGINE_ADMIN_TOKEN"))
 	symbols := parseSymbols(getEnv("SIM_SYMBOLS", "BTC-USD,ETH-USD"))
 	initialPrice := getEnvFloat("SIM_INITIAL_PRICE", 100)
 	volatility := getEnvFloat("SIM_VOLATILITY", 0.005)
@@ -33,7 +36,7 @@ func main() {
 		log.Printf("invalid SIM_SELL_BIAS value; using default 0.65: %v", err)
 		_ = generator.SetSellBias(0.65)
 	}
-	sink, closeSink := newSink(mode, redisAddr, streamKey, matchingEngineURL)
+	sink, closeSink := newSink(mode, redisAddr, streamKey, matchingEngineURL, orderEntryAddr, adminToken)
 	defer closeSink()
 
 	publisher := sim.NewPublisher(generator, sink, time.Duration(intervalMS)*time.Millisecond)
@@ -62,19 +65,30 @@ func main() {
 	}
 }
 
-func newSink(mode, redisAddr, streamKey, matchingEngineURL string) (sim.TickSink, func()) {
+func newSink(mode, redisAddr, streamKey, matchingEngineURL, orderEntryAddr, adminToken string) (sim.TickSink, func()) {
 	mode = strings.ToLower(strings.TrimSpace(mode))
 
 	if mode == "bot-orders" {
 		orderSink := store.NewMatchingOrderSink(matchingEngineURL)
+		orderSink.SetAdminToken(adminToken)
+		closeOrders := func() {}
+		if orderEntryAddr != "" {
+			client := orderentry.NewClient(orderEntryAddr)
+			orderSink.SetOrderEntryClient(client)
+			closeOrders = func() { _ = client.Close() }
+			log.Printf("bot orders sent over binary order entry at %s", orderEntryAddr)
+		}
 		if redisAddr == "" {
 			log.Printf("bot-orders mode enabled (redis tick stream disabled)")
-			return orderSink, func() {}
+			return orderSink, closeOrders
 		}
 
 		redisSink, closeRedis := newRedisSink(redisAddr, streamKey)
 		log.Printf("bot-orders mode enabled (matching=%s)", matchingEngineURL)
-		return multiTickSink{orderSink, redisSink}, closeRedis
+		return multiTickSink{orderSink, redisSink}, func() {
+			closeOrders()
+			closeRedis()
+		}
 	}
 
 	if mode != "ticks" {
@@ -1,10 +1,11 @@
 module kalency/apps/market-sim
 
-go 1.25
+go 1.25.0
 
 require (
 	github.com/alicebob/miniredis/v2 v2.36.1
 	github.com/redis/go-redis/v9 v9.17.3
+	kalency/apps/matching-engine v0.0.0
 )
 
 require (
@@ -12,3 +13,5 @@ require (
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/yuin/gopher-lua v1.1.1 // indirect
 )
+
+replace kalency/apps/matching-engine => ../matching-engine
@@ -46,7 +46,7 @@ func (s *Server) routes() {
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -55,11 +55,11 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 	if err := s.controller.Start(); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]bool{"running": s.controller.Running()})
@@ -67,11 +67,11 @@ func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleStop(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 	if err := s.controller.Stop(); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]bool{"running": s.controller.Running()})
@@ -79,18 +79,18 @@ func (s *Server) handleStop(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleVolatility(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 	var req struct {
 		Volatility float64 `json:"volatility"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid JSON body")
 		return
 	}
 	if err := s.controller.SetVolatility(req.Volatility); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "VALIDATION_FAILED", err.Error())
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]bool{"running": s.controller.Running()})
@@ -98,21 +98,21 @@ func (s *Server) handleVolatility(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleSymbols(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 
 	path := strings.TrimPrefix(r.URL.Path, "/v1/admin/symbols/")
 	parts := strings.Split(path, "/")
 	if len(parts) != 2 {
-		http.NotFound(w, r)
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "not found")
 		return
 	}
 
 	symbol := strings.TrimSpace(parts[0])
 	action := strings.TrimSpace(parts[1])
 	if symbol == "" {
-		http.Error(w, "symbol is required", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "symbol is required")
 		return
 	}
 
@@ -129,12 +129,12 @@ func (s *Server) handleSymbols(w http.ResponseWriter, r *http.Request) {
 		ensured = true
 		err = s.controller.EnsureSymbol(symbol)
 	default:
-		http.NotFound(w, r)
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "not found")
 		return
 	}
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "VALIDATION_FAILED", err.Error())
 		return
 	}
 
@@ -152,6 +152,12 @@ func writeJSON(w http.ResponseWriter, status int, payload any) {
 	_ = json.NewEncoder(w).Encode(payload)
 }
 
+// writeError answers with the {code, message} body every service uses for
+// errors.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{"code": code, "message": message})
+}
+
 func setCORSHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET,POST,OPTIONS")
@@ -0,0 +1,237 @@
+package orderentry
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"kalency/apps/matching-engine/sbe"
+)
+
+const (
+	dialTimeout  = 5 * time.Second
+	writeTimeout = 5 * time.Second
+)
+
+var errConnectionClosed = errors.New("order entry connection closed")
+
+// Client sends orders to the matching engine's binary order entry port. It
+// dials lazily and redials after the connection drops, so a restarted engine
+// only fails the requests that were in flight.
+type Client struct {
+	addr   string
+	nextID atomic.Uint64
+
+	mu     sync.Mutex
+	conn   *clientConn
+	onFill func(sbe.Fill)
+	closed bool
+}
+
+func NewClient(addr string) *Client {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		addr = "localhost:9082"
+	}
+	return &Client{addr: addr}
+}
+
+// SetFillHandler registers a callback for fills on orders placed through the
+// client. It runs on the connection's read goroutine and must not block.
+func (c *Client) SetFillHandler(fn func(sbe.Fill)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onFill = fn
+}
+
+func (c *Client) PlaceOrder(ctx context.Context, order sbe.NewOrder) (sbe.OrderAck, error) {
+	order.RequestID = c.nextID.Add(1)
+	return c.roundTrip(ctx, order.RequestID, order)
+}
+
+func (c *Client) CancelOrder(ctx context.Context, userID, orderID string) (sbe.OrderAck, error) {
+	requestID := c.nextID.Add(1)
+	return c.roundTrip(ctx, requestID, sbe.CancelOrder{RequestID: requestID, UserID: userID, OrderID: orderID})
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	conn := c.conn
+	c.conn = nil
+	c.mu.Unlock()
+
+	if conn != nil {
+		conn.close(errConnectionClosed)
+	}
+	return nil
+}
+
+func (c *Client) roundTrip(ctx context.Context, requestID uint64, msg any) (sbe.OrderAck, error) {
+	frame, err := sbe.Encode(msg)
+	if err != nil {
+		return sbe.OrderAck{}, err
+	}
+	conn, err := c.connection(ctx)
+	if err != nil {
+		return sbe.OrderAck{}, err
+	}
+
+	reply := make(chan any, 1)
+	if err := conn.send(requestID, frame, reply); err != nil {
+		return sbe.OrderAck{}, err
+	}
+
+	select {
+	case msg := <-reply:
+		return replyAck(msg)
+	case <-conn.done:
+		// The reply may have arrived just before the connection dropped.
+		select {
+		case msg := <-reply:
+			return replyAck(msg)
+		default:
+			return sbe.OrderAck{}, conn.err
+		}
+	case <-ctx.Done():
+		conn.forget(requestID)
+		return sbe.OrderAck{}, ctx.Err()
+	}
+}
+
+func replyAck(msg any) (sbe.OrderAck, error) {
+	if reject, ok := msg.(sbe.OrderReject); ok {
+		return sbe.OrderAck{}, errors.New(reject.Reason)
+	}
+	return msg.(sbe.OrderAck), nil
+}
+
+func (c *Client) connection(ctx context.Context) (*clientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, errConnectionClosed
+	}
+	if c.conn != nil {
+		select {
+		case <-c.conn.done:
+			c.conn = nil
+		default:
+			return c.conn, nil
+		}
+	}
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := &clientConn{
+		netConn: netConn,
+		pending: map[uint64]chan any{},
+		done:    make(chan struct{}),
+	}
+	go conn.readLoop(c.fillHandler)
+	c.conn = conn
+	return conn, nil
+}
+
+func (c *Client) fillHandler() func(sbe.Fill) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.onFill
+}
+
+// clientConn is one TCP connection. Replies are matched to requests by
+// RequestID, so callers can share it concurrently.
+type clientConn struct {
+	netConn net.Conn
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[uint64]chan any
+
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+func (c *clientConn) send(requestID uint64, frame []byte, reply chan any) error {
+	c.mu.Lock()
+	select {
+	case <-c.done:
+		c.mu.Unlock()
+		return c.err
+	default:
+	}
+	c.pending[requestID] = reply
+	c.mu.Unlock()
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.netConn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := c.netConn.Write(frame); err != nil {
+		c.close(err)
+		return err
+	}
+	return nil
+}
+
+func (c *clientConn) forget(requestID uint64) {
+	c.mu.Lock()
+	delete(c.pending, requestID)
+	c.mu.Unlock()
+}
+
+func (c *clientConn) readLoop(fillHandler func() func(sbe.Fill)) {
+	reader := bufio.NewReader(c.netConn)
+	for {
+		msg, err := sbe.ReadMessage(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errConnectionClosed
+			}
+			c.close(err)
+			return
+		}
+
+		var requestID uint64
+		switch m := msg.(type) {
+		case sbe.OrderAck:
+			requestID = m.RequestID
+		case sbe.OrderReject:
+			requestID = m.RequestID
+		case sbe.Fill:
+			if fn := fillHandler(); fn != nil {
+				fn(m)
+			}
+			continue
+		default:
+			continue
+		}
+
+		c.mu.Lock()
+		reply, ok := c.pending[requestID]
+		delete(c.pending, requestID)
+		c.mu.Unlock()
+		if ok {
+			reply <- msg
+		}
+	}
+}
+
+func (c *clientConn) close(err error) {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.err = err
+		c.mu.Unlock()
+		close(c.done)
+		_ = c.netConn.Close()
+	})
+}
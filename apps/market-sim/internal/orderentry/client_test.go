@@ -0,0 +1,108 @@
+package orderentry
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"kalency/apps/matching-engine/sbe"
+)
+
+// fakeEngine answers every NewOrder with a fill followed by an ack, rejects
+// cancels, and hangs up after closeAfter requests when set.
+func fakeEngine(t *testing.T, closeAfter int) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for handled := 0; closeAfter == 0 || handled < closeAfter; handled++ {
+					msg, err := sbe.ReadMessage(reader)
+					if err != nil {
+						return
+					}
+					var replies []any
+					switch m := msg.(type) {
+					case sbe.NewOrder:
+						replies = []any{
+							sbe.Fill{OrderID: "ord-1", TradeID: "trd-1", UserID: m.UserID, Symbol: m.Symbol, Liquidity: sbe.LiquidityTaker, Price: 100, Qty: m.Qty},
+							sbe.OrderAck{RequestID: m.RequestID, OrderID: "ord-1", ClientOrderID: m.ClientOrderID, Status: "FILLED", FilledQty: m.Qty},
+						}
+					case sbe.CancelOrder:
+						replies = []any{sbe.OrderReject{RequestID: m.RequestID, Reason: "order not found"}}
+					}
+					for _, reply := range replies {
+						frame, _ := sbe.Encode(reply)
+						if _, err := conn.Write(frame); err != nil {
+							return
+						}
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestClientPlacesOrdersAndReceivesFills(t *testing.T) {
+	client := NewClient(fakeEngine(t, 0))
+	defer client.Close()
+
+	fills := make(chan sbe.Fill, 1)
+	client.SetFillHandler(func(fill sbe.Fill) { fills <- fill })
+
+	ack, err := client.PlaceOrder(context.Background(), sbe.NewOrder{ClientOrderID: "sim-1", UserID: "sim-taker-BTC-USD", Symbol: "BTC-USD", Side: "BUY", Type: "MARKET", Qty: 3})
+	if err != nil {
+		t.Fatalf("place failed: %v", err)
+	}
+	if ack.Status != "FILLED" || ack.ClientOrderID != "sim-1" || ack.FilledQty != 3 {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+	select {
+	case fill := <-fills:
+		if fill.OrderID != "ord-1" || fill.Qty != 3 {
+			t.Fatalf("unexpected fill: %+v", fill)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected fill")
+	}
+
+	if _, err := client.CancelOrder(context.Background(), "sim-taker-BTC-USD", "ord-1"); err == nil || err.Error() != "order not found" {
+		t.Fatalf("expected reject reason as error, got %v", err)
+	}
+}
+
+func TestClientRedialsAfterDisconnect(t *testing.T) {
+	client := NewClient(fakeEngine(t, 1))
+	defer client.Close()
+	order := sbe.NewOrder{UserID: "u1", Symbol: "BTC-USD", Side: "BUY", Type: "MARKET", Qty: 1}
+
+	if _, err := client.PlaceOrder(context.Background(), order); err != nil {
+		t.Fatalf("first place failed: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		_, err := client.PlaceOrder(context.Background(), order)
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("client never recovered: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -13,14 +13,19 @@ import (
 	"sync"
 	"time"
 
+	"kalency/apps/market-sim/internal/orderentry"
 	"kalency/apps/market-sim/internal/sim"
+
+	"kalency/apps/matching-engine/sbe"
 )
 
 const initialBotFunding = int64(1_000_000)
 
 type MatchingOrderSink struct {
-	baseURL string
-	client  *http.Client
+	baseURL    string
+	client     *http.Client
+	orderEntry *orderentry.Client
+	adminToken string
 
 	mu     sync.Mutex
 	seq    int64
@@ -41,6 +46,17 @@ func NewMatchingOrderSink(baseURL string) *MatchingOrderSink {
 	}
 }
 
+// SetOrderEntryClient sends bot orders over the engine's binary order entry
+// port instead of HTTP. Wallet funding still goes over HTTP.
+func (s *MatchingOrderSink) SetOrderEntryClient(client *orderentry.Client) {
+	s.orderEntry = client
+}
+
+// SetAdminToken authenticates wallet funding against the engine's admin routes.
+func (s *MatchingOrderSink) SetAdminToken(token string) {
+	s.adminToken = strings.TrimSpace(token)
+}
+
 func (s *MatchingOrderSink) PublishTick(ctx context.Context, tick sim.Tick) error {
 	symbol := strings.ToUpper(strings.TrimSpace(tick.Symbol))
 	baseAsset, quoteAsset, err := parseSymbol(symbol)
@@ -88,13 +104,26 @@ func (s *MatchingOrderSink) PublishTick(ctx context.Context, tick sim.Tick) erro
 		takerOrder.Side = "BUY"
 	}
 
-	if err := s.doJSON(ctx, http.MethodPost, "/v1/orders", makerOrder, nil); err != nil {
+	if err := s.placeOrder(ctx, makerOrder); err != nil {
 		return err
 	}
-	if err := s.doJSON(ctx, http.MethodPost, "/v1/orders", takerOrder, nil); err != nil {
-		return err
-	}
-	return nil
+	return s.placeOrder(ctx, takerOrder)
+}
+
+func (s *MatchingOrderSink) placeOrder(ctx context.Context, order orderPayload) error {
+	if s.orderEntry == nil {
+		return s.doJSON(ctx, http.MethodPost, "/v1/orders", order, nil)
+	}
+	_, err := s.orderEntry.PlaceOrder(ctx, sbe.NewOrder{
+		ClientOrderID: order.ClientOrderID,
+		UserID:        order.UserID,
+		Symbol:        order.Symbol,
+		Side:          order.Side,
+		Type:          order.Type,
+		Price:         order.Price,
+		Qty:           order.Qty,
+	})
+	return err
 }
 
 func (s *MatchingOrderSink) ensureFunding(ctx context.Context, symbol, makerUserID, takerUserID, baseAsset, quoteAsset string) error {
@@ -147,6 +176,9 @@ func (s *MatchingOrderSink) doJSON(ctx context.Context, method, path string, bod
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if s.adminToken != "" && strings.HasPrefix(path, "/v1/admin/") {
+		req.Header.Set("Authorization", "Bearer "+s.adminToken)
+	}
 
 	res, err := s.client.Do(req)
 	if err != nil {
@@ -1,19 +1,26 @@
 package store
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"kalency/apps/market-sim/internal/orderentry"
 	"kalency/apps/market-sim/internal/sim"
+
+	"kalency/apps/matching-engine/sbe"
 )
 
 type capturedRequest struct {
 	Path string
+	Auth string
 	Body map[string]any
 }
 
@@ -27,7 +34,7 @@ func TestMatchingOrderSinkPublishesFundingAndOrders(t *testing.T) {
 		_ = json.NewDecoder(r.Body).Decode(&body)
 
 		mu.Lock()
-		requests = append(requests, capturedRequest{Path: r.URL.Path, Body: body})
+		requests = append(requests, capturedRequest{Path: r.URL.Path, Auth: r.Header.Get("Authorization"), Body: body})
 		mu.Unlock()
 
 		w.Header().Set("Content-Type", "application/json")
@@ -36,6 +43,7 @@ func TestMatchingOrderSinkPublishesFundingAndOrders(t *testing.T) {
 	defer srv.Close()
 
 	sink := NewMatchingOrderSink(srv.URL)
+	sink.SetAdminToken("admin-token")
 	tick := sim.Tick{Symbol: "BTC-USD", Price: 101.7, Volume: 1.9, TS: time.Now().UTC()}
 
 	if err := sink.PublishTick(context.Background(), tick); err != nil {
@@ -50,6 +58,9 @@ func TestMatchingOrderSinkPublishesFundingAndOrders(t *testing.T) {
 	if requests[0].Path != "/v1/admin/wallets/fund" {
 		t.Fatalf("expected first request to fund endpoint, got %s", requests[0].Path)
 	}
+	if requests[0].Auth != "Bearer admin-token" || requests[4].Auth != "" {
+		t.Fatalf("expected admin token on funding only, got %q and %q", requests[0].Auth, requests[4].Auth)
+	}
 	if requests[4].Path != "/v1/orders" || requests[5].Path != "/v1/orders" {
 		t.Fatalf("expected order requests to /v1/orders, got %s and %s", requests[4].Path, requests[5].Path)
 	}
@@ -57,3 +68,58 @@ func TestMatchingOrderSinkPublishesFundingAndOrders(t *testing.T) {
 		t.Fatalf("expected LIMIT then MARKET order types, got %v then %v", requests[4].Body["type"], requests[5].Body["type"])
 	}
 }
+
+func TestMatchingOrderSinkSendsOrdersOverOrderEntry(t *testing.T) {
+	var httpRequests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpRequests.Add(1)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	defer listener.Close()
+	orders := make(chan sbe.NewOrder, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			msg, err := sbe.ReadMessage(reader)
+			if err != nil {
+				return
+			}
+			order := msg.(sbe.NewOrder)
+			orders <- order
+			frame, _ := sbe.Encode(sbe.OrderAck{RequestID: order.RequestID, Status: "ACCEPTED"})
+			_, _ = conn.Write(frame)
+		}
+	}()
+
+	client := orderentry.NewClient(listener.Addr().String())
+	defer client.Close()
+	sink := NewMatchingOrderSink(srv.URL)
+	sink.SetOrderEntryClient(client)
+
+	tick := sim.Tick{Symbol: "BTC-USD", Price: 101.7, Volume: 1.9, Delta: -1, TS: time.Now().UTC()}
+	if err := sink.PublishTick(context.Background(), tick); err != nil {
+		t.Fatalf("publish tick failed: %v", err)
+	}
+
+	if got := httpRequests.Load(); got != 4 {
+		t.Fatalf("expected only the 4 funding requests over HTTP, got %d", got)
+	}
+	maker, taker := <-orders, <-orders
+	if maker.Type != "LIMIT" || maker.Side != "BUY" || maker.Price != 102 || maker.Qty != 2 {
+		t.Fatalf("unexpected maker order: %+v", maker)
+	}
+	if taker.Type != "MARKET" || taker.Side != "SELL" || taker.UserID != "sim-taker-BTC-USD" {
+		t.Fatalf("unexpected taker order: %+v", taker)
+	}
+}
@@ -3,13 +3,20 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"kalency/apps/matching-engine/internal/fix"
+	"kalency/apps/matching-engine/internal/grpcapi"
 	"kalency/apps/matching-engine/internal/httpapi"
 	"kalency/apps/matching-engine/internal/matching"
+	"kalency/apps/matching-engine/internal/orderentry"
 	"kalency/apps/matching-engine/internal/store"
 )
 
@@ -19,8 +26,52 @@ func main() {
 		port = "8081"
 	}
 
-	engine, tradeSource := newRuntime()
+	engine, tradeSource, client := newRuntime()
+	executionSinks := matching.ExecutionSinks{}
+	if client != nil {
+		executionSinks = append(executionSinks, store.NewRedisExecutionStreamSink(client, "kalency:v1:stream:executions"))
+	}
+	if feed := startGRPCServer(engine, tradeSource); feed != nil {
+		executionSinks = append(executionSinks, feed)
+	}
+	if orderEntry := startOrderEntryServer(engine); orderEntry != nil {
+		executionSinks = append(executionSinks, orderEntry)
+	}
+	if len(executionSinks) > 0 {
+		engine.SetExecutionSink(executionSinks)
+	}
+	userEventSinks := matching.UserEventSinks{}
+	if client != nil {
+		userEventSinks = append(userEventSinks, store.NewRedisUserEventSink(client, "kalency:v1:stream:user-events"))
+	}
+	if acceptor := startFIXAcceptor(engine, client); acceptor != nil {
+		userEventSinks = append(userEventSinks, acceptor)
+	}
+	if len(userEventSinks) > 0 {
+		engine.SetUserEventSink(userEventSinks)
+	}
+	for _, instrument := range parseInstruments(os.Getenv("ENGINE_INSTRUMENTS")) {
+		if _, err := engine.SetInstrument(instrument); err != nil {
+			log.Printf("ignoring instrument %s: %v", instrument.Symbol, err)
+		}
+	}
+	if faucet, ok := parseFaucetBalances(os.Getenv("FAUCET_BALANCES")); ok {
+		if err := engine.SetFaucetPolicy(matching.FaucetPolicy{Balances: faucet}); err != nil {
+			log.Printf("ignoring FAUCET_BALANCES: %v", err)
+		}
+	}
+	// Deposits stay off for every asset without a limit.
+	for asset, maxDeposit := range parseAssetAmounts(os.Getenv("DEPOSIT_LIMITS")) {
+		if err := engine.SetFundingLimits(asset, matching.FundingLimits{MaxDeposit: maxDeposit}); err != nil {
+			log.Printf("ignoring DEPOSIT_LIMITS for %s: %v", asset, err)
+		}
+	}
 	server := httpapi.NewServer(engine, tradeSource)
+	adminToken := strings.TrimSpace(os.Getenv("ADMIN_TOKEN"))
+	if adminToken == "" {
+		log.Printf("admin API disabled (ADMIN_TOKEN not set)")
+	}
+	server.SetAdminToken(adminToken)
 
 	addr := ":" + port
 	log.Printf("matching-engine listening on %s", addr)
@@ -29,11 +80,11 @@ func main() {
 	}
 }
 
-func newRuntime() (*matching.Engine, httpapi.TradeSource) {
+func newRuntime() (*matching.Engine, httpapi.TradeSource, redis.UniversalClient) {
 	redisAddr := os.Getenv("REDIS_ADDR")
 	if redisAddr == "" {
 		engine := matching.NewEngine()
-		return engine, engine
+		return engine, engine, nil
 	}
 
 	client := redis.NewClient(&redis.Options{Addr: redisAddr})
@@ -44,14 +95,189 @@ func newRuntime() (*matching.Engine, httpapi.TradeSource) {
 		log.Printf("redis integration disabled (ping failed): %v", err)
 		_ = client.Close()
 		engine := matching.NewEngine()
-		return engine, engine
+		return engine, engine, nil
 	}
 
 	log.Printf("redis integration enabled at %s", redisAddr)
 	openOrderStore := store.NewRedisOpenOrdersStore(client, "kalency:v1")
-	streamSink := store.NewRedisExecutionStreamSink(client, "kalency:v1:stream:executions")
 	streamReader := store.NewRedisExecutionStreamReader(client, "kalency:v1:stream:executions")
 
-	engine := matching.NewEngineWithStoreAndSink(openOrderStore, streamSink)
-	return engine, streamReader
+	engine := matching.NewEngineWithStore(openOrderStore)
+	engine.SetTickerSink(store.NewRedisTickerStore(client, "kalency:v1"))
+	engine.SetBookEventSink(store.NewRedisBookStreamSink(client, "kalency:v1"), parseSnapshotInterval(os.Getenv("BOOK_SNAPSHOT_INTERVAL")))
+	if err := engine.SetKillSwitchStore(ctx, store.NewRedisKillSwitchStore(client, "kalency:v1")); err != nil {
+		log.Printf("kill switches will not survive restarts (load failed): %v", err)
+	}
+	return engine, streamReader, client
+}
+
+// startGRPCServer serves the MatchingEngine gRPC API on GRPC_ADDR when set.
+// The returned feed must be added to the engine's execution sinks.
+func startGRPCServer(engine *matching.Engine, tradeSource httpapi.TradeSource) *grpcapi.ExecutionFeed {
+	addr := strings.TrimSpace(os.Getenv("GRPC_ADDR"))
+	if addr == "" {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Printf("grpc api disabled: %v", err)
+		return nil
+	}
+
+	feed := grpcapi.NewExecutionFeed()
+	server := grpc.NewServer()
+	grpcapi.NewServer(engine, tradeSource, feed).Register(server)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Printf("grpc api stopped: %v", err)
+		}
+	}()
+	log.Printf("grpc api listening on %s", addr)
+	return feed
+}
+
+// startOrderEntryServer serves the binary order entry protocol on
+// ORDER_ENTRY_ADDR when set. The returned server must be added to the
+// engine's execution sinks.
+func startOrderEntryServer(engine *matching.Engine) *orderentry.Server {
+	addr := strings.TrimSpace(os.Getenv("ORDER_ENTRY_ADDR"))
+	if addr == "" {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Printf("binary order entry disabled: %v", err)
+		return nil
+	}
+
+	server := orderentry.NewServer(engine)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Printf("binary order entry stopped: %v", err)
+		}
+	}()
+	log.Printf("binary order entry listening on %s", addr)
+	return server
+}
+
+// startFIXAcceptor listens on FIX_ADDR when set. Sequence numbers survive
+// restarts when Redis is available.
+func startFIXAcceptor(engine *matching.Engine, client redis.UniversalClient) *fix.Acceptor {
+	addr := strings.TrimSpace(os.Getenv("FIX_ADDR"))
+	if addr == "" {
+		return nil
+	}
+
+	var sessionStore fix.SessionStore = fix.NewMemorySessionStore()
+	if client != nil {
+		sessionStore = store.NewRedisFIXSessionStore(client, "kalency:v1")
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Printf("fix acceptor disabled: %v", err)
+		return nil
+	}
+
+	acceptor := fix.NewAcceptor(fix.Config{
+		CompID:   os.Getenv("FIX_COMP_ID"),
+		Sessions: parseFIXSessions(os.Getenv("FIX_SESSIONS")),
+		Store:    sessionStore,
+	}, engine)
+	go func() {
+		if err := acceptor.Serve(listener); err != nil {
+			log.Printf("fix acceptor stopped: %v", err)
+		}
+	}()
+	log.Printf("fix acceptor listening on %s", addr)
+	return acceptor
+}
+
+// parseFIXSessions reads "ALGO1:password:user-1,...". The user ID is
+// everything after the second colon, so sub-account IDs work.
+func parseFIXSessions(raw string) map[string]fix.SessionConfig {
+	result := map[string]fix.SessionConfig{}
+	for _, entry := range strings.Split(strings.TrimSpace(raw), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			log.Printf("ignoring FIX_SESSIONS entry for %q: want compID:password:user", parts[0])
+			continue
+		}
+		compID, password, userID := parts[0], parts[1], parts[2]
+		result[compID] = fix.SessionConfig{UserID: userID, Password: password}
+	}
+	return result
+}
+
+func parseSnapshotInterval(raw string) int64 {
+	interval, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || interval <= 0 {
+		return 0
+	}
+	return interval
+}
+
+// parseFaucetBalances reads "USD:100000,BTC:1". It reports false when raw is
+// empty so the default faucet stays; "none" turns the faucet off.
+func parseFaucetBalances(raw string) (map[string]int64, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, false
+	}
+	if strings.EqualFold(raw, "none") {
+		return map[string]int64{}, true
+	}
+	return parseAssetAmounts(raw), true
+}
+
+// parseAssetAmounts reads "USD:100000,BTC:1", skipping malformed pairs.
+func parseAssetAmounts(raw string) map[string]int64 {
+	result := map[string]int64{}
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return result
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.Split(strings.TrimSpace(pair), ":")
+		if len(parts) != 2 {
+			continue
+		}
+		asset := strings.TrimSpace(parts[0])
+		amount, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if asset == "" || err != nil || amount <= 0 {
+			continue
+		}
+		result[asset] = amount
+	}
+	return result
+}
+
+func parseInstruments(raw string) []matching.Instrument {
+	result := []matching.Instrument{}
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return result
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.Split(strings.TrimSpace(pair), ":")
+		if len(parts) != 2 {
+			continue
+		}
+		symbol := strings.TrimSpace(parts[0])
+		policy := strings.TrimSpace(parts[1])
+		if symbol == "" || policy == "" {
+			continue
+		}
+		result = append(result, matching.Instrument{Symbol: symbol, MatchingPolicy: matching.MatchingPolicy(policy)})
+	}
+	return result
 }
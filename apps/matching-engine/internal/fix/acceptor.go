@@ -0,0 +1,246 @@
+package fix
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+const logonTimeout = 10 * time.Second
+
+// Trading is the part of the matching engine the acceptor drives.
+type Trading interface {
+	PlaceOrder(req matching.PlaceOrderRequest) (matching.OrderAck, error)
+	CancelOrder(userID, orderID string) (matching.OrderAck, error)
+	OpenOrders(userID string) []matching.Order
+}
+
+type Config struct {
+	// CompID is the SenderCompID the acceptor sends and expects as
+	// TargetCompID.
+	CompID string
+	// Sessions maps each counterparty SenderCompID to its credential and the
+	// engine user its orders belong to.
+	Sessions map[string]SessionConfig
+	Store    SessionStore
+}
+
+// SessionConfig is one counterparty. Its Logon must carry Password(554).
+type SessionConfig struct {
+	UserID   string
+	Password string
+}
+
+// Acceptor is a FIX 4.4 order entry acceptor. Execution reports are driven
+// by engine order updates, so it must also be registered as the engine's
+// user event sink.
+type Acceptor struct {
+	cfg          Config
+	trading      Trading
+	execIDPrefix string
+	execSeq      atomic.Int64
+
+	mu       sync.Mutex
+	listener net.Listener
+	sessions map[string]*session
+	closed   bool
+}
+
+func NewAcceptor(cfg Config, trading Trading) *Acceptor {
+	cfg.CompID = strings.TrimSpace(cfg.CompID)
+	if cfg.CompID == "" {
+		cfg.CompID = "KALENCY"
+	}
+	if cfg.Store == nil {
+		cfg.Store = NewMemorySessionStore()
+	}
+	if cfg.Sessions == nil {
+		cfg.Sessions = map[string]SessionConfig{}
+	}
+	return &Acceptor{
+		cfg:          cfg,
+		trading:      trading,
+		execIDPrefix: fmt.Sprintf("%x", time.Now().UnixNano()),
+		sessions:     map[string]*session{},
+	}
+}
+
+// Serve accepts connections until Close is called.
+func (a *Acceptor) Serve(listener net.Listener) error {
+	a.mu.Lock()
+	a.listener = listener
+	a.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			a.mu.Lock()
+			closed := a.closed
+			a.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		go a.handleConn(conn)
+	}
+}
+
+func (a *Acceptor) Close() error {
+	a.mu.Lock()
+	a.closed = true
+	listener := a.listener
+	sessions := make([]*session, 0, len(a.sessions))
+	for _, s := range a.sessions {
+		sessions = append(sessions, s)
+	}
+	a.mu.Unlock()
+
+	for _, s := range sessions {
+		s.close()
+	}
+	if listener != nil {
+		return listener.Close()
+	}
+	return nil
+}
+
+func (a *Acceptor) PublishOrderUpdate(_ context.Context, update matching.OrderUpdate) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, s := range a.sessions {
+		if s.userID == update.UserID {
+			s.deliver(update)
+		}
+	}
+	return nil
+}
+
+func (a *Acceptor) PublishWalletUpdate(context.Context, matching.Wallet) error {
+	return nil
+}
+
+func (a *Acceptor) nextExecID() string {
+	return fmt.Sprintf("%s-%d", a.execIDPrefix, a.execSeq.Add(1))
+}
+
+func (a *Acceptor) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	_ = conn.SetReadDeadline(time.Now().Add(logonTimeout))
+	raw, err := ReadMessage(reader)
+	if err != nil {
+		return
+	}
+	logon, err := Parse(raw)
+	if err != nil || logon.MsgType() != MsgTypeLogon {
+		return
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	s, err := a.logon(conn, reader, logon)
+	if err != nil {
+		log.Printf("fix logon rejected from %s: %v", conn.RemoteAddr(), err)
+		return
+	}
+	defer a.unregister(s)
+	s.run()
+}
+
+func (a *Acceptor) logon(conn net.Conn, reader *bufio.Reader, logon *Message) (*session, error) {
+	senderCompID, _ := logon.Get(TagSenderCompID)
+	targetCompID, _ := logon.Get(TagTargetCompID)
+	cfg, ok := a.cfg.Sessions[senderCompID]
+	if !ok {
+		return nil, fmt.Errorf("unknown SenderCompID %q", senderCompID)
+	}
+	if targetCompID != a.cfg.CompID {
+		return nil, fmt.Errorf("unexpected TargetCompID %q", targetCompID)
+	}
+	password, _ := logon.Get(TagPassword)
+	if cfg.Password == "" || subtle.ConstantTimeCompare([]byte(password), []byte(cfg.Password)) != 1 {
+		return nil, fmt.Errorf("invalid Password for %s", senderCompID)
+	}
+	seq, ok := logon.GetInt(TagMsgSeqNum)
+	if !ok {
+		return nil, errors.New("logon without MsgSeqNum")
+	}
+
+	heartBt := defaultHeartBtInt
+	if value, ok := logon.GetInt(TagHeartBtInt); ok && value > 0 {
+		heartBt = value
+	}
+	s := newSession(a, conn, reader, senderCompID, cfg.UserID, time.Duration(heartBt)*time.Second)
+
+	// Claim the session before touching its sequence numbers, so a second
+	// logon cannot reset them under the one already running.
+	a.mu.Lock()
+	if _, active := a.sessions[senderCompID]; active || a.closed {
+		a.mu.Unlock()
+		return nil, fmt.Errorf("session %s already logged on", senderCompID)
+	}
+	a.sessions[senderCompID] = s
+	a.mu.Unlock()
+
+	ctx := context.Background()
+	reset := flag(logon, TagResetSeqNumFlag)
+	if reset {
+		if err := a.cfg.Store.Reset(ctx, senderCompID); err != nil {
+			a.unregister(s)
+			return nil, err
+		}
+	}
+	seqs, err := a.cfg.Store.Load(ctx, senderCompID)
+	if err != nil {
+		a.unregister(s)
+		return nil, err
+	}
+	s.nextIn = max(seqs.NextIn, 1)
+	s.nextOut = max(seqs.NextOut, 1)
+
+	if seq < s.nextIn {
+		s.logout(fmt.Sprintf("MsgSeqNum too low, expecting %d but received %d", s.nextIn, seq))
+		a.unregister(s)
+		return nil, errors.New("logon MsgSeqNum too low")
+	}
+
+	response := NewMessage(MsgTypeLogon).
+		Set(TagEncryptMethod, "0").
+		SetInt(TagHeartBtInt, int64(heartBt))
+	if reset {
+		response.Set(TagResetSeqNumFlag, "Y")
+	}
+	s.send(response)
+
+	if seq > s.nextIn {
+		s.requestResend(seq)
+	} else {
+		s.setNextIn(seq + 1)
+	}
+	return s, nil
+}
+
+func (a *Acceptor) unregister(s *session) {
+	a.mu.Lock()
+	if a.sessions[s.id] == s {
+		delete(a.sessions, s.id)
+	}
+	a.mu.Unlock()
+	s.close()
+}
+
+func flag(msg *Message, tag int) bool {
+	value, _ := msg.Get(tag)
+	return value == "Y"
+}
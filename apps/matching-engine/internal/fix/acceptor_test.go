@@ -0,0 +1,254 @@
+package fix
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+// initiator is a minimal FIX client standing in for a counterparty engine.
+type initiator struct {
+	t       *testing.T
+	conn    net.Conn
+	reader  *bufio.Reader
+	nextOut int
+}
+
+func startAcceptor(t *testing.T, store SessionStore) (*Acceptor, *matching.Engine, string) {
+	t.Helper()
+	engine := matching.NewEngine()
+	acceptor := NewAcceptor(Config{CompID: "KALENCY", Sessions: map[string]SessionConfig{"ALGO": {UserID: "algo-user", Password: "algo-secret"}}, Store: store}, engine)
+	engine.SetUserEventSink(acceptor)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	go func() { _ = acceptor.Serve(listener) }()
+	t.Cleanup(func() { _ = acceptor.Close() })
+	return acceptor, engine, listener.Addr().String()
+}
+
+func dialInitiator(t *testing.T, addr string, nextOut int) *initiator {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return &initiator{t: t, conn: conn, reader: bufio.NewReader(conn), nextOut: nextOut}
+}
+
+func (i *initiator) send(msg *Message) {
+	i.t.Helper()
+	msg.Set(TagSenderCompID, "ALGO").
+		Set(TagTargetCompID, "KALENCY").
+		SetInt(TagMsgSeqNum, int64(i.nextOut)).
+		SetTime(TagSendingTime, time.Now())
+	i.nextOut++
+	if _, err := i.conn.Write(msg.Bytes()); err != nil {
+		i.t.Fatalf("write failed: %v", err)
+	}
+}
+
+// read returns the next message that is not a heartbeat.
+func (i *initiator) read() *Message {
+	i.t.Helper()
+	_ = i.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		raw, err := ReadMessage(i.reader)
+		if err != nil {
+			i.t.Fatalf("read failed: %v", err)
+		}
+		msg, err := Parse(raw)
+		if err != nil {
+			i.t.Fatalf("parse failed: %v", err)
+		}
+		if msg.MsgType() != MsgTypeHeartbeat {
+			return msg
+		}
+	}
+}
+
+func (i *initiator) logon(reset bool) *Message {
+	i.t.Helper()
+	logon := NewMessage(MsgTypeLogon).Set(TagEncryptMethod, "0").Set(TagHeartBtInt, "30").Set(TagPassword, "algo-secret")
+	if reset {
+		logon.Set(TagResetSeqNumFlag, "Y")
+	}
+	i.send(logon)
+	response := i.read()
+	if response.MsgType() != MsgTypeLogon {
+		i.t.Fatalf("expected logon response, got %q", response.Bytes())
+	}
+	return response
+}
+
+func expectFields(t *testing.T, msg *Message, want map[int]string) {
+	t.Helper()
+	for tag, value := range want {
+		if got, _ := msg.Get(tag); got != value {
+			t.Fatalf("expected tag %d=%q, got %q in %q", tag, value, got, msg.Bytes())
+		}
+	}
+}
+
+func newOrderSingle(clOrdID, side, qty, price string) *Message {
+	msg := NewMessage(MsgTypeNewOrderSingle).
+		Set(TagClOrdID, clOrdID).
+		Set(TagSymbol, "BTC-USD").
+		Set(TagSide, side).
+		Set(TagOrderQty, qty).
+		Set(TagOrdType, "2").
+		Set(TagTimeInForce, "1")
+	if price != "" {
+		msg.Set(TagPrice, price)
+	}
+	return msg
+}
+
+func TestAcceptorFillsNewOrderSingle(t *testing.T) {
+	_, engine, addr := startAcceptor(t, nil)
+	engine.FundWallet("maker", "BTC", 10)
+	if _, err := engine.PlaceOrder(matching.PlaceOrderRequest{UserID: "maker", Symbol: "BTC-USD", Side: matching.SideSell, Type: matching.OrderTypeLimit, Price: 100, Qty: 2}); err != nil {
+		t.Fatalf("maker order failed: %v", err)
+	}
+
+	client := dialInitiator(t, addr, 1)
+	client.logon(true)
+	client.send(newOrderSingle("c1", "1", "3", "101"))
+
+	expectFields(t, client.read(), map[int]string{TagMsgType: "8", TagClOrdID: "c1", TagExecType: "0", TagOrdStatus: "0", TagLeavesQty: "3"})
+	expectFields(t, client.read(), map[int]string{TagExecType: "F", TagOrdStatus: "1", TagLastQty: "2", TagLastPx: "100", TagCumQty: "2", TagLeavesQty: "1"})
+
+	client.send(newOrderSingle("c2", "1", "1.5", "101"))
+	expectFields(t, client.read(), map[int]string{TagMsgType: "8", TagClOrdID: "c2", TagExecType: "8", TagOrderID: "NONE"})
+}
+
+func TestAcceptorCancelsAndReplaces(t *testing.T) {
+	_, engine, addr := startAcceptor(t, nil)
+	client := dialInitiator(t, addr, 1)
+	client.logon(true)
+
+	client.send(newOrderSingle("c1", "1", "2", "90"))
+	expectFields(t, client.read(), map[int]string{TagExecType: "0", TagClOrdID: "c1"})
+
+	client.send(NewMessage(MsgTypeOrderCancelReplace).
+		Set(TagClOrdID, "c2").
+		Set(TagOrigClOrdID, "c1").
+		Set(TagSymbol, "BTC-USD").
+		Set(TagSide, "1").
+		Set(TagOrderQty, "5").
+		Set(TagOrdType, "2").
+		Set(TagPrice, "95"))
+	replaced := client.read()
+	expectFields(t, replaced, map[int]string{TagExecType: "5", TagClOrdID: "c2", TagOrigClOrdID: "c1", TagOrderQty: "5", TagPrice: "95"})
+	open := engine.OpenOrders("algo-user")
+	if len(open) != 1 || open[0].ClientOrderID != "c2" || open[0].Price != 95 {
+		t.Fatalf("expected only the replacement to rest, got %+v", open)
+	}
+
+	client.send(NewMessage(MsgTypeOrderCancelRequest).
+		Set(TagClOrdID, "c3").
+		Set(TagOrigClOrdID, "c2").
+		Set(TagSymbol, "BTC-USD").
+		Set(TagSide, "1"))
+	expectFields(t, client.read(), map[int]string{TagExecType: "4", TagOrdStatus: "4", TagClOrdID: "c3", TagOrigClOrdID: "c2", TagLeavesQty: "0"})
+
+	client.send(NewMessage(MsgTypeOrderCancelRequest).
+		Set(TagClOrdID, "c4").
+		Set(TagOrigClOrdID, "c2").
+		Set(TagSymbol, "BTC-USD").
+		Set(TagSide, "1"))
+	expectFields(t, client.read(), map[int]string{TagMsgType: "9", TagClOrdID: "c4", TagCxlRejResponseTo: "1", TagCxlRejReason: "1"})
+}
+
+func TestAcceptorPersistsSequenceNumbersAndResends(t *testing.T) {
+	store := NewMemorySessionStore()
+	_, _, addr := startAcceptor(t, store)
+
+	client := dialInitiator(t, addr, 1)
+	client.logon(true)
+	client.send(newOrderSingle("c1", "1", "1", "90"))
+	report := client.read()
+	expectFields(t, report, map[int]string{TagExecType: "0", TagMsgSeqNum: "2"})
+	client.send(NewMessage(MsgTypeLogout))
+	expectFields(t, client.read(), map[int]string{TagMsgType: MsgTypeLogout})
+	_ = client.conn.Close()
+
+	// Reconnect without a reset; both sides continue their sequences.
+	deadline := time.Now().Add(2 * time.Second)
+	var reconnected *initiator
+	var response *Message
+	for {
+		reconnected = dialInitiator(t, addr, client.nextOut)
+		reconnected.send(NewMessage(MsgTypeLogon).Set(TagEncryptMethod, "0").Set(TagHeartBtInt, "30").Set(TagPassword, "algo-secret"))
+		_ = reconnected.conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+		raw, err := ReadMessage(reconnected.reader)
+		if err == nil {
+			response, _ = Parse(raw)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("reconnect failed: %v", err)
+		}
+	}
+	expectFields(t, response, map[int]string{TagMsgType: MsgTypeLogon, TagMsgSeqNum: "4"})
+
+	reconnected.send(NewMessage(MsgTypeResendRequest).Set(TagBeginSeqNo, "1").Set(TagEndSeqNo, "0"))
+	expectFields(t, reconnected.read(), map[int]string{TagMsgType: MsgTypeSequenceReset, TagMsgSeqNum: "1", TagGapFillFlag: "Y", TagNewSeqNo: "2"})
+	resent := reconnected.read()
+	expectFields(t, resent, map[int]string{TagMsgType: "8", TagMsgSeqNum: "2", TagPossDupFlag: "Y", TagExecID: mustGet(t, report, TagExecID)})
+	if _, ok := resent.Get(TagOrigSendingTime); !ok {
+		t.Fatal("expected OrigSendingTime on the resent report")
+	}
+	expectFields(t, reconnected.read(), map[int]string{TagMsgType: MsgTypeSequenceReset, TagMsgSeqNum: "3", TagNewSeqNo: "5"})
+
+	// A gap in the initiator's sequence triggers a resend request.
+	reconnected.nextOut += 2
+	reconnected.send(NewMessage(MsgTypeTestRequest).Set(TagTestReqID, "t1"))
+	expectFields(t, reconnected.read(), map[int]string{TagMsgType: MsgTypeResendRequest, TagBeginSeqNo: strconv.Itoa(reconnected.nextOut - 3)})
+}
+
+func mustGet(t *testing.T, msg *Message, tag int) string {
+	t.Helper()
+	value, ok := msg.Get(tag)
+	if !ok {
+		t.Fatalf("missing tag %d", tag)
+	}
+	return value
+}
+
+func TestAcceptorRejectsBadPasswordsAndDuplicateResets(t *testing.T) {
+	store := NewMemorySessionStore()
+	_, _, addr := startAcceptor(t, store)
+
+	intruder := dialInitiator(t, addr, 1)
+	intruder.send(NewMessage(MsgTypeLogon).Set(TagEncryptMethod, "0").Set(TagHeartBtInt, "30").Set(TagPassword, "wrong"))
+	_ = intruder.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := ReadMessage(intruder.reader); err == nil {
+		t.Fatal("expected a logon with the wrong password to be dropped")
+	}
+
+	client := dialInitiator(t, addr, 1)
+	client.logon(true)
+	client.send(newOrderSingle("c1", "1", "1", "90"))
+	expectFields(t, client.read(), map[int]string{TagExecType: "0", TagMsgSeqNum: "2"})
+
+	// A second logon with ResetSeqNumFlag=Y must not reset the live session.
+	duplicate := dialInitiator(t, addr, 1)
+	duplicate.send(NewMessage(MsgTypeLogon).Set(TagEncryptMethod, "0").Set(TagHeartBtInt, "30").Set(TagPassword, "algo-secret").Set(TagResetSeqNumFlag, "Y"))
+	_ = duplicate.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := ReadMessage(duplicate.reader); err == nil {
+		t.Fatal("expected the duplicate logon to be dropped")
+	}
+	seqs, err := store.Load(context.Background(), "ALGO")
+	if err != nil || seqs.NextIn != 3 || seqs.NextOut != 3 {
+		t.Fatalf("expected the live session's sequence numbers kept, got %+v %v", seqs, err)
+	}
+}
@@ -0,0 +1,283 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	BeginString = "FIX.4.4"
+	soh         = '\x01'
+
+	timestampLayout = "20060102-15:04:05.000"
+)
+
+const (
+	TagAvgPx                = 6
+	TagBeginSeqNo           = 7
+	TagBeginString          = 8
+	TagBodyLength           = 9
+	TagCheckSum             = 10
+	TagClOrdID              = 11
+	TagCumQty               = 14
+	TagEndSeqNo             = 16
+	TagExecID               = 17
+	TagLastPx               = 31
+	TagLastQty              = 32
+	TagMsgSeqNum            = 34
+	TagMsgType              = 35
+	TagNewSeqNo             = 36
+	TagOrderID              = 37
+	TagOrderQty             = 38
+	TagOrdStatus            = 39
+	TagOrdType              = 40
+	TagOrigClOrdID          = 41
+	TagPossDupFlag          = 43
+	TagPrice                = 44
+	TagRefSeqNum            = 45
+	TagSenderCompID         = 49
+	TagSendingTime          = 52
+	TagSide                 = 54
+	TagSymbol               = 55
+	TagTargetCompID         = 56
+	TagText                 = 58
+	TagTimeInForce          = 59
+	TagTransactTime         = 60
+	TagEncryptMethod        = 98
+	TagStopPx               = 99
+	TagCxlRejReason         = 102
+	TagHeartBtInt           = 108
+	TagTestReqID            = 112
+	TagOrigSendingTime      = 122
+	TagGapFillFlag          = 123
+	TagResetSeqNumFlag      = 141
+	TagExecType             = 150
+	TagLeavesQty            = 151
+	TagRefTagID             = 371
+	TagRefMsgType           = 372
+	TagSessionRejectReason  = 373
+	TagBusinessRejectReason = 380
+	TagCxlRejResponseTo     = 434
+	TagPassword             = 554
+)
+
+const (
+	MsgTypeHeartbeat             = "0"
+	MsgTypeTestRequest           = "1"
+	MsgTypeResendRequest         = "2"
+	MsgTypeReject                = "3"
+	MsgTypeSequenceReset         = "4"
+	MsgTypeLogout                = "5"
+	MsgTypeExecutionReport       = "8"
+	MsgTypeOrderCancelReject     = "9"
+	MsgTypeLogon                 = "A"
+	MsgTypeNewOrderSingle        = "D"
+	MsgTypeOrderCancelRequest    = "F"
+	MsgTypeOrderCancelReplace    = "G"
+	MsgTypeBusinessMessageReject = "j"
+)
+
+// headerOrder is the order session header fields are written in, ahead of
+// the body fields.
+var headerOrder = []int{TagMsgType, TagSenderCompID, TagTargetCompID, TagMsgSeqNum, TagPossDupFlag, TagSendingTime, TagOrigSendingTime}
+
+type Field struct {
+	Tag   int
+	Value string
+}
+
+// Message is a FIX message without its framing fields (8, 9 and 10), which
+// are computed on Bytes and checked on Parse.
+type Message struct {
+	fields []Field
+}
+
+func NewMessage(msgType string) *Message {
+	m := &Message{}
+	m.Set(TagMsgType, msgType)
+	return m
+}
+
+func (m *Message) MsgType() string {
+	value, _ := m.Get(TagMsgType)
+	return value
+}
+
+func (m *Message) Get(tag int) (string, bool) {
+	for _, field := range m.fields {
+		if field.Tag == tag {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+func (m *Message) GetInt(tag int) (int, bool) {
+	raw, ok := m.Get(tag)
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// Set replaces the first field with tag, or appends it.
+func (m *Message) Set(tag int, value string) *Message {
+	for i := range m.fields {
+		if m.fields[i].Tag == tag {
+			m.fields[i].Value = value
+			return m
+		}
+	}
+	m.fields = append(m.fields, Field{Tag: tag, Value: value})
+	return m
+}
+
+func (m *Message) SetInt(tag int, value int64) *Message {
+	return m.Set(tag, strconv.FormatInt(value, 10))
+}
+
+func (m *Message) SetTime(tag int, value time.Time) *Message {
+	return m.Set(tag, value.UTC().Format(timestampLayout))
+}
+
+// Bytes encodes the message with header fields first and a fresh body length
+// and checksum.
+func (m *Message) Bytes() []byte {
+	var body bytes.Buffer
+	written := map[int]bool{}
+	for _, tag := range headerOrder {
+		if value, ok := m.Get(tag); ok {
+			writeField(&body, tag, value)
+			written[tag] = true
+		}
+	}
+	for _, field := range m.fields {
+		if written[field.Tag] || field.Tag == TagBeginString || field.Tag == TagBodyLength || field.Tag == TagCheckSum {
+			continue
+		}
+		writeField(&body, field.Tag, field.Value)
+	}
+
+	var out bytes.Buffer
+	writeField(&out, TagBeginString, BeginString)
+	writeField(&out, TagBodyLength, strconv.Itoa(body.Len()))
+	out.Write(body.Bytes())
+	writeField(&out, TagCheckSum, fmt.Sprintf("%03d", checksum(out.Bytes())))
+	return out.Bytes()
+}
+
+func writeField(buf *bytes.Buffer, tag int, value string) {
+	buf.WriteString(strconv.Itoa(tag))
+	buf.WriteByte('=')
+	buf.WriteString(value)
+	buf.WriteByte(soh)
+}
+
+func checksum(raw []byte) int {
+	sum := 0
+	for _, b := range raw {
+		sum += int(b)
+	}
+	return sum % 256
+}
+
+// Parse decodes one framed message, verifying BeginString, BodyLength and
+// CheckSum.
+func Parse(raw []byte) (*Message, error) {
+	fields, err := splitFields(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < 4 || fields[0].Tag != TagBeginString || fields[1].Tag != TagBodyLength || fields[len(fields)-1].Tag != TagCheckSum {
+		return nil, errors.New("message must start with 8, 9 and end with 10")
+	}
+	if fields[0].Value != BeginString {
+		return nil, fmt.Errorf("unsupported BeginString %q", fields[0].Value)
+	}
+
+	// BodyLength counts from after the 9= field up to and including the SOH
+	// before 10=.
+	first := bytes.IndexByte(raw, soh)
+	bodyStart := first + 1 + bytes.IndexByte(raw[first+1:], soh) + 1
+	trailer := bytes.LastIndex(raw, []byte{soh, '1', '0', '='}) + 1
+	bodyLength, err := strconv.Atoi(fields[1].Value)
+	if err != nil || bodyLength != trailer-bodyStart {
+		return nil, errors.New("invalid BodyLength")
+	}
+	want, err := strconv.Atoi(fields[len(fields)-1].Value)
+	if err != nil || want != checksum(raw[:trailer]) {
+		return nil, errors.New("invalid CheckSum")
+	}
+
+	m := &Message{fields: fields[2 : len(fields)-1]}
+	if m.MsgType() == "" {
+		return nil, errors.New("missing MsgType")
+	}
+	return m, nil
+}
+
+func splitFields(raw []byte) ([]Field, error) {
+	if len(raw) == 0 || raw[len(raw)-1] != soh {
+		return nil, errors.New("message must end with SOH")
+	}
+	fields := make([]Field, 0, 16)
+	for _, part := range bytes.Split(raw[:len(raw)-1], []byte{soh}) {
+		tagPart, value, ok := bytes.Cut(part, []byte{'='})
+		if !ok {
+			return nil, fmt.Errorf("malformed field %q", part)
+		}
+		tag, err := strconv.Atoi(string(tagPart))
+		if err != nil || tag <= 0 {
+			return nil, fmt.Errorf("malformed tag %q", tagPart)
+		}
+		fields = append(fields, Field{Tag: tag, Value: string(value)})
+	}
+	return fields, nil
+}
+
+// ReadMessage reads one framed message using its BodyLength.
+func ReadMessage(r *bufio.Reader) ([]byte, error) {
+	begin, err := r.ReadBytes(soh)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(begin, []byte("8=")) {
+		return nil, fmt.Errorf("expected BeginString, got %q", begin)
+	}
+	length, err := r.ReadBytes(soh)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(length, []byte("9=")) {
+		return nil, fmt.Errorf("expected BodyLength, got %q", length)
+	}
+	bodyLength, err := strconv.Atoi(string(length[2 : len(length)-1]))
+	if err != nil || bodyLength <= 0 || bodyLength > 1<<20 {
+		return nil, errors.New("invalid BodyLength")
+	}
+
+	body := make([]byte, bodyLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	trailer, err := r.ReadBytes(soh)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 0, len(begin)+len(length)+len(body)+len(trailer))
+	raw = append(raw, begin...)
+	raw = append(raw, length...)
+	raw = append(raw, body...)
+	raw = append(raw, trailer...)
+	return raw, nil
+}
@@ -0,0 +1,57 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	msg := NewMessage(MsgTypeNewOrderSingle).
+		Set(TagClOrdID, "c1").
+		Set(TagSymbol, "BTC-USD").
+		SetInt(TagMsgSeqNum, 7).
+		Set(TagSenderCompID, "ALGO")
+
+	raw := msg.Bytes()
+	if !bytes.HasPrefix(raw, []byte("8=FIX.4.4\x019=")) {
+		t.Fatalf("unexpected framing %q", raw)
+	}
+	if !strings.Contains(string(raw), "\x0135=D\x0149=ALGO\x0134=7\x0111=c1\x01") {
+		t.Fatalf("expected header fields ahead of the body, got %q", raw)
+	}
+
+	read, err := ReadMessage(bufio.NewReader(bytes.NewReader(append(append([]byte{}, raw...), raw...))))
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if !bytes.Equal(read, raw) {
+		t.Fatalf("expected one framed message, got %q", read)
+	}
+
+	parsed, err := Parse(read)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if parsed.MsgType() != MsgTypeNewOrderSingle {
+		t.Fatalf("unexpected MsgType %q", parsed.MsgType())
+	}
+	if seq, _ := parsed.GetInt(TagMsgSeqNum); seq != 7 {
+		t.Fatalf("unexpected MsgSeqNum %d", seq)
+	}
+}
+
+func TestParseRejectsBadChecksumAndLength(t *testing.T) {
+	raw := NewMessage(MsgTypeHeartbeat).SetInt(TagMsgSeqNum, 1).Bytes()
+
+	corrupted := bytes.Replace(raw, []byte("34=1"), []byte("34=2"), 1)
+	if _, err := Parse(corrupted); err == nil || !strings.Contains(err.Error(), "CheckSum") {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+
+	longer := bytes.Replace(raw, []byte("34=1"), []byte("34=10"), 1)
+	if _, err := Parse(longer); err == nil || !strings.Contains(err.Error(), "BodyLength") {
+		t.Fatalf("expected body length error, got %v", err)
+	}
+}
@@ -0,0 +1,396 @@
+package fix
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+// orderState is what a session has reported for one order, so each update
+// is turned into the execution reports for what changed since.
+type orderState struct {
+	pendingReported bool
+	acked           bool
+	filled          int64
+	notional        int64
+}
+
+type cancelRequest struct {
+	clOrdID     string
+	origClOrdID string
+}
+
+const (
+	cxlRejResponseToCancel  = "1"
+	cxlRejResponseToReplace = "2"
+	cxlRejReasonUnknown     = "1"
+	cxlRejReasonOther       = "99"
+)
+
+func (s *session) onNewOrderSingle(msg *Message) {
+	for _, tag := range []int{TagClOrdID, TagSymbol, TagSide, TagOrderQty, TagOrdType} {
+		if value, _ := msg.Get(tag); strings.TrimSpace(value) == "" {
+			s.sessionReject(msg, tag, 1, "required tag missing")
+			return
+		}
+	}
+
+	req, err := s.orderRequest(msg)
+	if err == nil {
+		_, err = s.placeOrder(req)
+	}
+	if err != nil {
+		s.sendOrderReject(msg, err.Error())
+	}
+}
+
+// placeOrder reports an order refused by a risk check as an error, since the
+// engine publishes no execution report for it.
+func (s *session) placeOrder(req matching.PlaceOrderRequest) (matching.OrderAck, error) {
+	ack, err := s.acceptor.trading.PlaceOrder(req)
+	if err == nil && ack.Status == matching.OrderStatusRejected {
+		return ack, errors.New(string(ack.RejectCode) + ": " + ack.RejectReason)
+	}
+	return ack, err
+}
+
+func (s *session) orderRequest(msg *Message) (matching.PlaceOrderRequest, error) {
+	clOrdID, _ := msg.Get(TagClOrdID)
+	symbol, _ := msg.Get(TagSymbol)
+	req := matching.PlaceOrderRequest{
+		ClientOrderID: clOrdID,
+		UserID:        s.userID,
+		Symbol:        strings.TrimSpace(symbol),
+	}
+
+	sideValue, _ := msg.Get(TagSide)
+	side, ok := sideFromFIX(sideValue)
+	if !ok {
+		return req, errors.New("unsupported Side")
+	}
+	req.Side = side
+
+	typeValue, _ := msg.Get(TagOrdType)
+	orderType, ok := orderTypeFromFIX(typeValue)
+	if !ok {
+		return req, errors.New("unsupported OrdType")
+	}
+	req.Type = orderType
+
+	switch tif, _ := msg.Get(TagTimeInForce); tif {
+	case "", "0", "1":
+	case "3":
+		if orderType != matching.OrderTypeMarket {
+			return req, errors.New("TimeInForce IOC is only supported for market orders")
+		}
+	default:
+		return req, errors.New("unsupported TimeInForce")
+	}
+
+	var err error
+	if req.Qty, err = quantity(msg, TagOrderQty); err != nil {
+		return req, err
+	}
+	if req.Price, err = quantity(msg, TagPrice); err != nil {
+		return req, err
+	}
+	if req.StopPrice, err = quantity(msg, TagStopPx); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func (s *session) onOrderCancelRequest(msg *Message) {
+	clOrdID, _ := msg.Get(TagClOrdID)
+	origClOrdID, _ := msg.Get(TagOrigClOrdID)
+	if clOrdID == "" || origClOrdID == "" {
+		s.sessionReject(msg, TagOrigClOrdID, 1, "ClOrdID and OrigClOrdID are required")
+		return
+	}
+
+	order, ok := s.findOpenOrder(msg)
+	if !ok {
+		s.sendCancelReject(msg, "", cxlRejResponseToCancel, cxlRejReasonUnknown, "unknown order")
+		return
+	}
+
+	s.cancels[order.OrderID] = cancelRequest{clOrdID: clOrdID, origClOrdID: origClOrdID}
+	if _, err := s.acceptor.trading.CancelOrder(s.userID, order.OrderID); err != nil {
+		delete(s.cancels, order.OrderID)
+		s.sendCancelReject(msg, order.OrderID, cxlRejResponseToCancel, cxlRejReasonOther, err.Error())
+	}
+}
+
+// onOrderCancelReplace cancels the original order and places its
+// replacement, which therefore loses time priority and starts a new CumQty.
+func (s *session) onOrderCancelReplace(msg *Message) {
+	clOrdID, _ := msg.Get(TagClOrdID)
+	origClOrdID, _ := msg.Get(TagOrigClOrdID)
+	if clOrdID == "" || origClOrdID == "" {
+		s.sessionReject(msg, TagOrigClOrdID, 1, "ClOrdID and OrigClOrdID are required")
+		return
+	}
+
+	order, ok := s.findOpenOrder(msg)
+	if !ok {
+		s.sendCancelReject(msg, "", cxlRejResponseToReplace, cxlRejReasonUnknown, "unknown order")
+		return
+	}
+
+	newQty, err := quantity(msg, TagOrderQty)
+	if err == nil && newQty <= order.Qty-order.RemainingQty {
+		err = errors.New("OrderQty must exceed the filled quantity")
+	}
+	price := order.Price
+	if err == nil {
+		if value, _ := quantity(msg, TagPrice); value > 0 {
+			price = value
+		}
+	}
+	if err != nil {
+		s.sendCancelReject(msg, order.OrderID, cxlRejResponseToReplace, cxlRejReasonOther, err.Error())
+		return
+	}
+
+	s.replacing[order.OrderID] = true
+	if _, err := s.acceptor.trading.CancelOrder(s.userID, order.OrderID); err != nil {
+		delete(s.replacing, order.OrderID)
+		s.sendCancelReject(msg, order.OrderID, cxlRejResponseToReplace, cxlRejReasonOther, err.Error())
+		return
+	}
+
+	ack, err := s.placeOrder(matching.PlaceOrderRequest{
+		ClientOrderID: clOrdID,
+		UserID:        s.userID,
+		Symbol:        order.Symbol,
+		Side:          order.Side,
+		Type:          order.Type,
+		Price:         price,
+		StopPrice:     order.StopPrice,
+		Qty:           newQty - (order.Qty - order.RemainingQty),
+	})
+	if err != nil {
+		// The original is gone; let its cancel be reported as such.
+		delete(s.replacing, order.OrderID)
+		s.sendCancelReject(msg, order.OrderID, cxlRejResponseToReplace, cxlRejReasonOther, "original canceled, replacement rejected: "+err.Error())
+		return
+	}
+	s.replacements[ack.OrderID] = origClOrdID
+}
+
+// findOpenOrder resolves OrderID, or else OrigClOrdID, among the session
+// user's open orders.
+func (s *session) findOpenOrder(msg *Message) (matching.Order, bool) {
+	orderID, _ := msg.Get(TagOrderID)
+	origClOrdID, _ := msg.Get(TagOrigClOrdID)
+	for _, order := range s.acceptor.trading.OpenOrders(s.userID) {
+		if orderID != "" && orderID != "NONE" {
+			if order.OrderID == orderID {
+				return order, true
+			}
+			continue
+		}
+		if order.ClientOrderID == origClOrdID {
+			return order, true
+		}
+	}
+	return matching.Order{}, false
+}
+
+// onOrderUpdate emits the execution reports for one engine order update.
+func (s *session) onOrderUpdate(update matching.OrderUpdate) {
+	if update.Status == matching.OrderStatusCanceled && s.replacing[update.OrderID] {
+		delete(s.replacing, update.OrderID)
+		delete(s.orders, update.OrderID)
+		return
+	}
+
+	state, ok := s.orders[update.OrderID]
+	if !ok {
+		state = &orderState{}
+		s.orders[update.OrderID] = state
+	}
+
+	if update.Status == matching.OrderStatusPending {
+		if !state.pendingReported {
+			state.pendingReported = true
+			s.send(s.executionReport(update, "A", "A", 0, update.Qty))
+		}
+		return
+	}
+
+	if !state.acked && update.Status != matching.OrderStatusRejected {
+		state.acked = true
+		if origClOrdID, ok := s.replacements[update.OrderID]; ok {
+			s.send(s.executionReport(update, "5", "0", 0, update.Qty).Set(TagOrigClOrdID, origClOrdID))
+		} else {
+			s.send(s.executionReport(update, "0", "0", 0, update.Qty))
+		}
+	}
+
+	if delta := update.FilledQty - state.filled; delta > 0 {
+		notional := update.AvgPrice * update.FilledQty
+		status := "1"
+		if update.FilledQty >= update.Qty {
+			status = "2"
+		}
+		leaves := update.Qty - update.FilledQty
+		report := s.executionReport(update, "F", status, update.FilledQty, leaves).
+			SetInt(TagLastQty, delta).
+			SetInt(TagLastPx, (notional-state.notional)/delta)
+		s.send(report)
+		state.filled = update.FilledQty
+		state.notional = notional
+	}
+
+	switch update.Status {
+	case matching.OrderStatusCanceled:
+		report := s.executionReport(update, "4", "4", update.FilledQty, 0)
+		if cancel, ok := s.cancels[update.OrderID]; ok {
+			report.Set(TagClOrdID, cancel.clOrdID).Set(TagOrigClOrdID, cancel.origClOrdID)
+		}
+		s.send(report)
+	case matching.OrderStatusRejected:
+		s.send(s.executionReport(update, "8", "8", update.FilledQty, 0))
+	}
+
+	switch update.Status {
+	case matching.OrderStatusFilled, matching.OrderStatusCanceled, matching.OrderStatusRejected:
+		delete(s.orders, update.OrderID)
+		delete(s.cancels, update.OrderID)
+		delete(s.replacements, update.OrderID)
+	}
+}
+
+func (s *session) executionReport(update matching.OrderUpdate, execType, ordStatus string, cumQty, leavesQty int64) *Message {
+	avgPx := int64(0)
+	if cumQty > 0 {
+		avgPx = update.AvgPrice
+	}
+	report := NewMessage(MsgTypeExecutionReport).
+		Set(TagOrderID, update.OrderID).
+		Set(TagClOrdID, update.ClientOrderID).
+		Set(TagExecID, s.acceptor.nextExecID()).
+		Set(TagExecType, execType).
+		Set(TagOrdStatus, ordStatus).
+		Set(TagSymbol, update.Symbol).
+		Set(TagSide, sideToFIX(update.Side)).
+		SetInt(TagOrderQty, update.Qty).
+		Set(TagOrdType, orderTypeToFIX(update.Type))
+	if update.Price > 0 {
+		report.SetInt(TagPrice, update.Price)
+	}
+	if update.StopPrice > 0 {
+		report.SetInt(TagStopPx, update.StopPrice)
+	}
+	return report.
+		SetInt(TagLeavesQty, leavesQty).
+		SetInt(TagCumQty, cumQty).
+		SetInt(TagAvgPx, avgPx).
+		SetTime(TagTransactTime, update.TS)
+}
+
+func (s *session) sendOrderReject(msg *Message, text string) {
+	report := NewMessage(MsgTypeExecutionReport).
+		Set(TagOrderID, "NONE").
+		Set(TagExecID, s.acceptor.nextExecID()).
+		Set(TagExecType, "8").
+		Set(TagOrdStatus, "8")
+	for _, tag := range []int{TagClOrdID, TagSymbol, TagSide, TagOrderQty, TagOrdType, TagPrice} {
+		if value, ok := msg.Get(tag); ok {
+			report.Set(tag, value)
+		}
+	}
+	s.send(report.
+		SetInt(TagLeavesQty, 0).
+		SetInt(TagCumQty, 0).
+		SetInt(TagAvgPx, 0).
+		SetTime(TagTransactTime, time.Now()).
+		Set(TagText, text))
+}
+
+func (s *session) sendCancelReject(msg *Message, orderID, responseTo, reason, text string) {
+	clOrdID, _ := msg.Get(TagClOrdID)
+	origClOrdID, _ := msg.Get(TagOrigClOrdID)
+	status := "0"
+	if orderID == "" {
+		orderID = "NONE"
+		status = "8"
+	}
+	s.send(NewMessage(MsgTypeOrderCancelReject).
+		Set(TagOrderID, orderID).
+		Set(TagClOrdID, clOrdID).
+		Set(TagOrigClOrdID, origClOrdID).
+		Set(TagOrdStatus, status).
+		Set(TagCxlRejResponseTo, responseTo).
+		Set(TagCxlRejReason, reason).
+		Set(TagText, text))
+}
+
+// quantity reads an integral price or quantity; the engine works in whole
+// units. A missing tag is 0.
+func quantity(msg *Message, tag int) (int64, error) {
+	raw, ok := msg.Get(tag)
+	raw = strings.TrimSpace(raw)
+	if !ok || raw == "" {
+		return 0, nil
+	}
+	if value, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return value, nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value != math.Trunc(value) {
+		return 0, errors.New("tag " + strconv.Itoa(tag) + " must be a whole number")
+	}
+	return int64(value), nil
+}
+
+func sideFromFIX(value string) (matching.Side, bool) {
+	switch value {
+	case "1":
+		return matching.SideBuy, true
+	case "2":
+		return matching.SideSell, true
+	default:
+		return "", false
+	}
+}
+
+func sideToFIX(side matching.Side) string {
+	if side == matching.SideSell {
+		return "2"
+	}
+	return "1"
+}
+
+func orderTypeFromFIX(value string) (matching.OrderType, bool) {
+	switch value {
+	case "1":
+		return matching.OrderTypeMarket, true
+	case "2":
+		return matching.OrderTypeLimit, true
+	case "3":
+		return matching.OrderTypeStopMarket, true
+	case "4":
+		return matching.OrderTypeStopLimit, true
+	default:
+		return "", false
+	}
+}
+
+func orderTypeToFIX(orderType matching.OrderType) string {
+	switch orderType {
+	case matching.OrderTypeMarket:
+		return "1"
+	case matching.OrderTypeStopMarket:
+		return "3"
+	case matching.OrderTypeStopLimit:
+		return "4"
+	default:
+		return "2"
+	}
+}
@@ -0,0 +1,358 @@
+package fix
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+const (
+	defaultHeartBtInt   = 30
+	sessionUpdateBuffer = 1024
+	writeTimeout        = 10 * time.Second
+)
+
+// session is one logged-on counterparty. Inbound messages, order updates
+// and timers are all handled on the run goroutine, which is the only writer.
+type session struct {
+	acceptor *Acceptor
+	conn     net.Conn
+	reader   *bufio.Reader
+	id       string
+	userID   string
+	heartBt  time.Duration
+
+	nextIn  int
+	nextOut int
+	// resendTarget is the highest MsgSeqNum seen ahead of a gap; a resend is
+	// outstanding until nextIn passes it.
+	resendTarget   int
+	testReqPending string
+	lastSent       time.Time
+	lastReceived   time.Time
+
+	updates   chan matching.OrderUpdate
+	done      chan struct{}
+	closeOnce sync.Once
+
+	orders       map[string]*orderState
+	cancels      map[string]cancelRequest
+	replacing    map[string]bool
+	replacements map[string]string
+}
+
+func newSession(acceptor *Acceptor, conn net.Conn, reader *bufio.Reader, id, userID string, heartBt time.Duration) *session {
+	now := time.Now()
+	s := &session{
+		acceptor:     acceptor,
+		conn:         conn,
+		reader:       reader,
+		id:           id,
+		userID:       userID,
+		heartBt:      heartBt,
+		nextIn:       1,
+		nextOut:      1,
+		lastSent:     now,
+		lastReceived: now,
+		updates:      make(chan matching.OrderUpdate, sessionUpdateBuffer),
+		done:         make(chan struct{}),
+		orders:       map[string]*orderState{},
+		cancels:      map[string]cancelRequest{},
+		replacing:    map[string]bool{},
+		replacements: map[string]string{},
+	}
+	return s
+}
+
+// deliver queues an order update. A session that cannot keep up is
+// disconnected rather than stalling the engine.
+func (s *session) deliver(update matching.OrderUpdate) {
+	select {
+	case s.updates <- update:
+	case <-s.done:
+	default:
+		log.Printf("fix session %s dropped as a slow consumer", s.id)
+		s.close()
+	}
+}
+
+func (s *session) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		_ = s.conn.Close()
+	})
+}
+
+func (s *session) run() {
+	inbound := make(chan *Message)
+	go func() {
+		defer close(inbound)
+		for {
+			raw, err := ReadMessage(s.reader)
+			if err != nil {
+				return
+			}
+			msg, err := Parse(raw)
+			if err != nil {
+				// Garbled messages are ignored; the sequence gap they leave
+				// is recovered by a resend.
+				continue
+			}
+			select {
+			case inbound <- msg:
+			case <-s.done:
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case msg, ok := <-inbound:
+			if !ok {
+				return
+			}
+			s.lastReceived = time.Now()
+			if !s.handle(msg) {
+				return
+			}
+		case update := <-s.updates:
+			s.onOrderUpdate(update)
+		case now := <-ticker.C:
+			if !s.onTimer(now) {
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// handle applies sequence checks and dispatches msg. It reports false when
+// the session must end.
+func (s *session) handle(msg *Message) bool {
+	seq, ok := msg.GetInt(TagMsgSeqNum)
+	if !ok {
+		s.logout("MsgSeqNum missing")
+		return false
+	}
+	msgType := msg.MsgType()
+
+	if msgType == MsgTypeSequenceReset && !flag(msg, TagGapFillFlag) {
+		if next, ok := msg.GetInt(TagNewSeqNo); ok && next > s.nextIn {
+			s.setNextIn(next)
+		}
+		return true
+	}
+
+	switch {
+	case seq > s.nextIn:
+		switch msgType {
+		case MsgTypeResendRequest:
+			s.onResendRequest(msg)
+		case MsgTypeLogout:
+			s.logout("")
+			return false
+		}
+		s.requestResend(seq)
+		return true
+	case seq < s.nextIn:
+		if flag(msg, TagPossDupFlag) {
+			return true
+		}
+		s.logout(fmt.Sprintf("MsgSeqNum too low, expecting %d but received %d", s.nextIn, seq))
+		return false
+	}
+
+	if msgType == MsgTypeSequenceReset {
+		next, ok := msg.GetInt(TagNewSeqNo)
+		if !ok || next <= seq {
+			s.sessionReject(msg, TagNewSeqNo, 5, "NewSeqNo must be greater than MsgSeqNum")
+			s.setNextIn(seq + 1)
+			return true
+		}
+		s.setNextIn(next)
+		return true
+	}
+	s.setNextIn(seq + 1)
+
+	switch msgType {
+	case MsgTypeHeartbeat:
+		if id, _ := msg.Get(TagTestReqID); id != "" && id == s.testReqPending {
+			s.testReqPending = ""
+		}
+	case MsgTypeTestRequest:
+		id, _ := msg.Get(TagTestReqID)
+		s.send(NewMessage(MsgTypeHeartbeat).Set(TagTestReqID, id))
+	case MsgTypeResendRequest:
+		s.onResendRequest(msg)
+	case MsgTypeReject:
+	case MsgTypeLogout:
+		s.logout("")
+		return false
+	case MsgTypeLogon:
+		s.sessionReject(msg, TagMsgType, 11, "already logged on")
+	case MsgTypeNewOrderSingle:
+		s.onNewOrderSingle(msg)
+	case MsgTypeOrderCancelRequest:
+		s.onOrderCancelRequest(msg)
+	case MsgTypeOrderCancelReplace:
+		s.onOrderCancelReplace(msg)
+	default:
+		s.send(NewMessage(MsgTypeBusinessMessageReject).
+			SetInt(TagRefSeqNum, int64(seq)).
+			Set(TagRefMsgType, msgType).
+			Set(TagBusinessRejectReason, "3").
+			Set(TagText, "unsupported message type"))
+	}
+	return true
+}
+
+func (s *session) onTimer(now time.Time) bool {
+	if now.Sub(s.lastSent) >= s.heartBt {
+		s.send(NewMessage(MsgTypeHeartbeat))
+	}
+	silent := now.Sub(s.lastReceived)
+	if silent >= 2*s.heartBt+s.heartBt/5 {
+		s.logout("heartbeat timeout")
+		return false
+	}
+	if silent >= s.heartBt+s.heartBt/5 && s.testReqPending == "" {
+		s.testReqPending = "TEST-" + strconv.FormatInt(now.UnixNano(), 10)
+		s.send(NewMessage(MsgTypeTestRequest).Set(TagTestReqID, s.testReqPending))
+	}
+	return true
+}
+
+// requestResend asks for everything from nextIn once per gap.
+func (s *session) requestResend(seenSeq int) {
+	if s.resendTarget >= s.nextIn {
+		s.resendTarget = max(s.resendTarget, seenSeq)
+		return
+	}
+	s.resendTarget = seenSeq
+	s.send(NewMessage(MsgTypeResendRequest).
+		SetInt(TagBeginSeqNo, int64(s.nextIn)).
+		SetInt(TagEndSeqNo, 0))
+}
+
+// onResendRequest replays stored application messages with PossDupFlag and
+// replaces session-level messages and missing entries with gap fills.
+func (s *session) onResendRequest(msg *Message) {
+	begin, _ := msg.GetInt(TagBeginSeqNo)
+	end, _ := msg.GetInt(TagEndSeqNo)
+	if begin < 1 {
+		begin = 1
+	}
+	if end == 0 || end >= s.nextOut {
+		end = s.nextOut - 1
+	}
+	if begin > end {
+		return
+	}
+
+	stored, err := s.acceptor.cfg.Store.Outgoing(context.Background(), s.id, begin, end)
+	if err != nil {
+		log.Printf("fix session %s resend lookup failed: %v", s.id, err)
+		stored = nil
+	}
+
+	gapStart := begin
+	for _, entry := range stored {
+		original, err := Parse(entry.Raw)
+		if err != nil || isSessionMessage(original.MsgType()) {
+			continue
+		}
+		if gapStart < entry.Seq {
+			s.sendGapFill(gapStart, entry.Seq)
+		}
+		sendingTime, _ := original.Get(TagSendingTime)
+		original.Set(TagPossDupFlag, "Y").
+			Set(TagOrigSendingTime, sendingTime).
+			SetTime(TagSendingTime, time.Now())
+		s.write(original.Bytes())
+		gapStart = entry.Seq + 1
+	}
+	if gapStart <= end {
+		s.sendGapFill(gapStart, end+1)
+	}
+}
+
+func (s *session) sendGapFill(seq, next int) {
+	msg := NewMessage(MsgTypeSequenceReset).
+		Set(TagSenderCompID, s.acceptor.cfg.CompID).
+		Set(TagTargetCompID, s.id).
+		SetInt(TagMsgSeqNum, int64(seq)).
+		Set(TagPossDupFlag, "Y").
+		SetTime(TagSendingTime, time.Now()).
+		Set(TagGapFillFlag, "Y").
+		SetInt(TagNewSeqNo, int64(next))
+	s.write(msg.Bytes())
+}
+
+func isSessionMessage(msgType string) bool {
+	switch msgType {
+	case MsgTypeHeartbeat, MsgTypeTestRequest, MsgTypeResendRequest, MsgTypeReject, MsgTypeSequenceReset, MsgTypeLogout, MsgTypeLogon:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *session) setNextIn(next int) {
+	s.nextIn = next
+	if err := s.acceptor.cfg.Store.SetNextIn(context.Background(), s.id, next); err != nil {
+		log.Printf("fix session %s failed to persist inbound seq: %v", s.id, err)
+	}
+}
+
+// send stamps the header, stores the message for resends and writes it.
+func (s *session) send(msg *Message) {
+	now := time.Now()
+	msg.Set(TagSenderCompID, s.acceptor.cfg.CompID).
+		Set(TagTargetCompID, s.id).
+		SetInt(TagMsgSeqNum, int64(s.nextOut)).
+		SetTime(TagSendingTime, now)
+	raw := msg.Bytes()
+	if err := s.acceptor.cfg.Store.SaveOutgoing(context.Background(), s.id, s.nextOut, raw); err != nil {
+		log.Printf("fix session %s failed to persist outbound message: %v", s.id, err)
+	}
+	s.nextOut++
+	s.write(raw)
+}
+
+func (s *session) write(raw []byte) {
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := s.conn.Write(raw); err != nil {
+		s.close()
+		return
+	}
+	s.lastSent = time.Now()
+}
+
+func (s *session) logout(text string) {
+	msg := NewMessage(MsgTypeLogout)
+	if text != "" {
+		msg.Set(TagText, text)
+	}
+	s.send(msg)
+}
+
+func (s *session) sessionReject(msg *Message, refTag, reason int, text string) {
+	seq, _ := msg.Get(TagMsgSeqNum)
+	s.send(NewMessage(MsgTypeReject).
+		Set(TagRefSeqNum, seq).
+		SetInt(TagRefTagID, int64(refTag)).
+		Set(TagRefMsgType, msg.MsgType()).
+		SetInt(TagSessionRejectReason, int64(reason)).
+		Set(TagText, text))
+}
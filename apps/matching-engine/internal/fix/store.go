@@ -0,0 +1,100 @@
+package fix
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// SeqNums are the next sequence numbers a session expects to receive and
+// will send. Zero means 1.
+type SeqNums struct {
+	NextIn  int
+	NextOut int
+}
+
+type StoredMessage struct {
+	Seq int
+	Raw []byte
+}
+
+// SessionStore persists sequence numbers and sent messages per session so a
+// reconnecting counterparty continues the same sequence and can ask for a
+// resend.
+type SessionStore interface {
+	Load(ctx context.Context, sessionID string) (SeqNums, error)
+	SetNextIn(ctx context.Context, sessionID string, next int) error
+	// SaveOutgoing stores a sent message and advances NextOut past it.
+	SaveOutgoing(ctx context.Context, sessionID string, seq int, raw []byte) error
+	// Outgoing returns the stored messages with begin <= seq <= end, in
+	// order; end 0 means no upper bound.
+	Outgoing(ctx context.Context, sessionID string, begin, end int) ([]StoredMessage, error)
+	Reset(ctx context.Context, sessionID string) error
+}
+
+type memorySession struct {
+	seqs     SeqNums
+	outgoing map[int][]byte
+}
+
+// MemorySessionStore keeps sessions for the life of the process.
+type MemorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*memorySession
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{sessions: map[string]*memorySession{}}
+}
+
+func (s *MemorySessionStore) session(sessionID string) *memorySession {
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		session = &memorySession{outgoing: map[int][]byte{}}
+		s.sessions[sessionID] = session
+	}
+	return session
+}
+
+func (s *MemorySessionStore) Load(_ context.Context, sessionID string) (SeqNums, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.session(sessionID).seqs, nil
+}
+
+func (s *MemorySessionStore) SetNextIn(_ context.Context, sessionID string, next int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session(sessionID).seqs.NextIn = next
+	return nil
+}
+
+func (s *MemorySessionStore) SaveOutgoing(_ context.Context, sessionID string, seq int, raw []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session := s.session(sessionID)
+	session.outgoing[seq] = append([]byte(nil), raw...)
+	session.seqs.NextOut = seq + 1
+	return nil
+}
+
+func (s *MemorySessionStore) Outgoing(_ context.Context, sessionID string, begin, end int) ([]StoredMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []StoredMessage{}
+	for seq, raw := range s.session(sessionID).outgoing {
+		if seq >= begin && (end == 0 || seq <= end) {
+			out = append(out, StoredMessage{Seq: seq, Raw: raw})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Seq < out[j].Seq })
+	return out, nil
+}
+
+func (s *MemorySessionStore) Reset(_ context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+	return nil
+}
@@ -0,0 +1,250 @@
+package grpcapi
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"kalency/apps/matching-engine/internal/matching"
+	"kalency/apps/matching-engine/matchingpb"
+)
+
+// Proto enum names are the engine's string values behind a type prefix, e.g.
+// SIDE_BUY for BUY, so conversions go through the generated name maps.
+
+func enumName(name, prefix string) string {
+	if !strings.HasPrefix(name, prefix) || strings.HasSuffix(name, "_UNSPECIFIED") {
+		return ""
+	}
+	return strings.TrimPrefix(name, prefix)
+}
+
+func sidePB(side matching.Side) matchingpb.Side {
+	return matchingpb.Side(matchingpb.Side_value["SIDE_"+string(side)])
+}
+
+func orderTypePB(orderType matching.OrderType) matchingpb.OrderType {
+	return matchingpb.OrderType(matchingpb.OrderType_value["ORDER_TYPE_"+string(orderType)])
+}
+
+func orderStatusPB(orderStatus matching.OrderStatus) matchingpb.OrderStatus {
+	return matchingpb.OrderStatus(matchingpb.OrderStatus_value["ORDER_STATUS_"+string(orderStatus)])
+}
+
+func orderListTypePB(listType matching.OrderListType) matchingpb.OrderListType {
+	return matchingpb.OrderListType(matchingpb.OrderListType_value["ORDER_LIST_TYPE_"+string(listType)])
+}
+
+func orderListStatusPB(listStatus matching.OrderListStatus) matchingpb.OrderListStatus {
+	return matchingpb.OrderListStatus(matchingpb.OrderListStatus_value["ORDER_LIST_STATUS_"+string(listStatus)])
+}
+
+func orderListRolePB(role matching.OrderListRole) matchingpb.OrderListRole {
+	return matchingpb.OrderListRole(matchingpb.OrderListRole_value["ORDER_LIST_ROLE_"+string(role)])
+}
+
+func fundingTypePB(kind matching.FundingType) matchingpb.FundingType {
+	return matchingpb.FundingType(matchingpb.FundingType_value["FUNDING_TYPE_"+string(kind)])
+}
+
+func fundingStatusPB(status matching.FundingStatus) matchingpb.FundingStatus {
+	return matchingpb.FundingStatus(matchingpb.FundingStatus_value["FUNDING_STATUS_"+string(status)])
+}
+
+func timestampPB(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func placeOrderRequest(req *matchingpb.PlaceOrderRequest) matching.PlaceOrderRequest {
+	return matching.PlaceOrderRequest{
+		ClientOrderID: req.GetClientOrderId(),
+		UserID:        req.GetUserId(),
+		Symbol:        req.GetSymbol(),
+		Side:          matching.Side(enumName(req.GetSide().String(), "SIDE_")),
+		Type:          matching.OrderType(enumName(req.GetType().String(), "ORDER_TYPE_")),
+		Price:         req.GetPrice(),
+		StopPrice:     req.GetStopPrice(),
+		Qty:           req.GetQty(),
+	}
+}
+
+func orderLeg(leg *matchingpb.OrderLeg) matching.OrderLeg {
+	return matching.OrderLeg{
+		ClientOrderID: leg.GetClientOrderId(),
+		Type:          matching.OrderType(enumName(leg.GetType().String(), "ORDER_TYPE_")),
+		Price:         leg.GetPrice(),
+		StopPrice:     leg.GetStopPrice(),
+	}
+}
+
+func placeOrderListRequest(req *matchingpb.PlaceOrderListRequest) matching.PlaceOrderListRequest {
+	out := matching.PlaceOrderListRequest{
+		ClientListID: req.GetClientListId(),
+		UserID:       req.GetUserId(),
+		Symbol:       req.GetSymbol(),
+		Type:         matching.OrderListType(enumName(req.GetType().String(), "ORDER_LIST_TYPE_")),
+		Side:         matching.Side(enumName(req.GetSide().String(), "SIDE_")),
+		Qty:          req.GetQty(),
+		TakeProfit:   orderLeg(req.GetTakeProfit()),
+		StopLoss:     orderLeg(req.GetStopLoss()),
+	}
+	if req.GetEntry() != nil {
+		entry := orderLeg(req.GetEntry())
+		out.Entry = &entry
+	}
+	return out
+}
+
+func orderAckPB(ack matching.OrderAck) *matchingpb.OrderAck {
+	return &matchingpb.OrderAck{
+		OrderId:       ack.OrderID,
+		Status:        orderStatusPB(ack.Status),
+		FilledQty:     ack.FilledQty,
+		RemainingQty:  ack.RemainingQty,
+		AvgPrice:      ack.AvgPrice,
+		ClientOrderId: ack.ClientOrderID,
+		Symbol:        ack.Symbol,
+		ListId:        ack.ListID,
+		Ts:            timestampPB(ack.TS),
+		RejectCode:    string(ack.RejectCode),
+		RejectReason:  ack.RejectReason,
+	}
+}
+
+func orderBatchResponsePB(results []matching.BatchResult) *matchingpb.OrderBatchResponse {
+	out := &matchingpb.OrderBatchResponse{Results: make([]*matchingpb.BatchResult, 0, len(results))}
+	for _, result := range results {
+		pb := &matchingpb.BatchResult{Error: result.Error, ErrorCode: string(result.ErrorCode)}
+		if result.Ack != nil {
+			pb.Ack = orderAckPB(*result.Ack)
+		}
+		out.Results = append(out.Results, pb)
+	}
+	return out
+}
+
+func orderListAckPB(ack matching.OrderListAck) *matchingpb.OrderListAck {
+	out := &matchingpb.OrderListAck{
+		ListId:       ack.ListID,
+		ClientListId: ack.ClientListID,
+		Type:         orderListTypePB(ack.Type),
+		Status:       orderListStatusPB(ack.Status),
+		Symbol:       ack.Symbol,
+		Orders:       make([]*matchingpb.OrderAck, 0, len(ack.Orders)),
+		Ts:           timestampPB(ack.TS),
+	}
+	for _, order := range ack.Orders {
+		out.Orders = append(out.Orders, orderAckPB(order))
+	}
+	return out
+}
+
+func orderPB(order matching.Order) *matchingpb.Order {
+	return &matchingpb.Order{
+		OrderId:       order.OrderID,
+		ClientOrderId: order.ClientOrderID,
+		UserId:        order.UserID,
+		Symbol:        order.Symbol,
+		Side:          sidePB(order.Side),
+		Type:          orderTypePB(order.Type),
+		Price:         order.Price,
+		StopPrice:     order.StopPrice,
+		Triggered:     order.Triggered,
+		Qty:           order.Qty,
+		RemainingQty:  order.RemainingQty,
+		ListId:        order.ListID,
+		ListRole:      orderListRolePB(order.ListRole),
+		ListStatus:    orderListStatusPB(order.ListStatus),
+		CreatedAt:     timestampPB(order.CreatedAt),
+	}
+}
+
+func walletPB(wallet matching.Wallet) *matchingpb.Wallet {
+	return &matchingpb.Wallet{
+		UserId:    wallet.UserID,
+		Available: wallet.Available,
+		Reserved:  wallet.Reserved,
+		UpdatedAt: timestampPB(wallet.UpdatedAt),
+	}
+}
+
+func fundingRequest(req *matchingpb.FundingRequest) matching.FundingRequest {
+	return matching.FundingRequest{
+		RequestID: req.GetRequestId(),
+		UserID:    req.GetUserId(),
+		Asset:     req.GetAsset(),
+		Amount:    req.GetAmount(),
+	}
+}
+
+func fundingOperationPB(op matching.FundingOperation) *matchingpb.FundingOperation {
+	return &matchingpb.FundingOperation{
+		Id:        op.ID,
+		RequestId: op.RequestID,
+		Type:      fundingTypePB(op.Type),
+		Status:    fundingStatusPB(op.Status),
+		UserId:    op.UserID,
+		ToUserId:  op.ToUserID,
+		Asset:     op.Asset,
+		Amount:    op.Amount,
+		Reason:    op.Reason,
+		CreatedAt: timestampPB(op.CreatedAt),
+		UpdatedAt: timestampPB(op.UpdatedAt),
+	}
+}
+
+func bookLevelsPB(levels []matching.BookLevel) []*matchingpb.BookLevel {
+	out := make([]*matchingpb.BookLevel, 0, len(levels))
+	for _, level := range levels {
+		out = append(out, &matchingpb.BookLevel{Price: level.Price, Qty: level.Qty, Orders: int32(level.Orders)})
+	}
+	return out
+}
+
+func orderBookPB(snapshot matching.OrderBookSnapshot) *matchingpb.OrderBook {
+	return &matchingpb.OrderBook{
+		Symbol: snapshot.Symbol,
+		Seq:    snapshot.Seq,
+		Bids:   bookLevelsPB(snapshot.Bids),
+		Asks:   bookLevelsPB(snapshot.Asks),
+		Ts:     timestampPB(snapshot.TS),
+	}
+}
+
+func executionPB(execution matching.Execution) *matchingpb.Execution {
+	return &matchingpb.Execution{
+		TradeId:      execution.TradeID,
+		Symbol:       execution.Symbol,
+		Price:        execution.Price,
+		Qty:          execution.Qty,
+		MakerOrderId: execution.MakerOrderID,
+		MakerUserId:  execution.MakerUserID,
+		TakerOrderId: execution.TakerOrderID,
+		TakerUserId:  execution.TakerUserID,
+		Ts:           timestampPB(execution.TS),
+	}
+}
+
+func tickerPB(ticker matching.Ticker) *matchingpb.Ticker {
+	return &matchingpb.Ticker{
+		Symbol:            ticker.Symbol,
+		LastPrice:         ticker.LastPrice,
+		LastQty:           ticker.LastQty,
+		BestBid:           ticker.BestBid,
+		BestBidQty:        ticker.BestBidQty,
+		BestAsk:           ticker.BestAsk,
+		BestAskQty:        ticker.BestAskQty,
+		Open_24H:          ticker.Open24h,
+		High_24H:          ticker.High24h,
+		Low_24H:           ticker.Low24h,
+		Volume_24H:        ticker.Volume24h,
+		QuoteVolume_24H:   ticker.QuoteVolume24h,
+		Trades_24H:        ticker.Trades24h,
+		Change_24H:        ticker.Change24h,
+		ChangePercent_24H: ticker.ChangePercent24h,
+		Ts:                timestampPB(ticker.TS),
+	}
+}
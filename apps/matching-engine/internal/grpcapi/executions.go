@@ -0,0 +1,76 @@
+package grpcapi
+
+import (
+	"context"
+	"sync"
+
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+const executionStreamBuffer = 1024
+
+// ExecutionFeed fans engine executions out to StreamExecutions calls. It is
+// an engine execution sink and never blocks the engine: a subscriber whose
+// buffer is full is dropped.
+type ExecutionFeed struct {
+	mu          sync.Mutex
+	subscribers map[*executionSubscriber]struct{}
+}
+
+type executionSubscriber struct {
+	symbol     string
+	userID     string
+	executions chan matching.Execution
+	dropped    chan struct{}
+}
+
+func NewExecutionFeed() *ExecutionFeed {
+	return &ExecutionFeed{subscribers: map[*executionSubscriber]struct{}{}}
+}
+
+func (f *ExecutionFeed) PublishExecution(_ context.Context, execution matching.Execution) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for sub := range f.subscribers {
+		if !sub.matches(execution) {
+			continue
+		}
+		select {
+		case sub.executions <- execution:
+		default:
+			delete(f.subscribers, sub)
+			close(sub.dropped)
+		}
+	}
+	return nil
+}
+
+func (f *ExecutionFeed) subscribe(symbol, userID string) *executionSubscriber {
+	sub := &executionSubscriber{
+		symbol:     symbol,
+		userID:     userID,
+		executions: make(chan matching.Execution, executionStreamBuffer),
+		dropped:    make(chan struct{}),
+	}
+	f.mu.Lock()
+	f.subscribers[sub] = struct{}{}
+	f.mu.Unlock()
+	return sub
+}
+
+func (f *ExecutionFeed) unsubscribe(sub *executionSubscriber) {
+	f.mu.Lock()
+	delete(f.subscribers, sub)
+	f.mu.Unlock()
+}
+
+func (s *executionSubscriber) matches(execution matching.Execution) bool {
+	if s.symbol != "" && s.symbol != execution.Symbol {
+		return false
+	}
+	if s.userID != "" && s.userID != execution.MakerUserID && s.userID != execution.TakerUserID {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,291 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"kalency/apps/matching-engine/internal/matching"
+	"kalency/apps/matching-engine/matchingpb"
+)
+
+const defaultTradesLimit = 100
+
+type TradeSource interface {
+	ListExecutions(symbol string, limit int) ([]matching.Execution, error)
+}
+
+// Server implements the MatchingEngine gRPC service on top of the engine.
+// Trades are read from tradeSource like the HTTP API; live executions come
+// from feed, which must be registered as an engine execution sink.
+type Server struct {
+	matchingpb.UnimplementedMatchingEngineServer
+
+	engine      *matching.Engine
+	tradeSource TradeSource
+	feed        *ExecutionFeed
+}
+
+func NewServer(engine *matching.Engine, tradeSource TradeSource, feed *ExecutionFeed) *Server {
+	if tradeSource == nil {
+		tradeSource = engine
+	}
+	if feed == nil {
+		feed = NewExecutionFeed()
+	}
+	return &Server{engine: engine, tradeSource: tradeSource, feed: feed}
+}
+
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	matchingpb.RegisterMatchingEngineServer(registrar, s)
+}
+
+func (s *Server) PlaceOrder(_ context.Context, req *matchingpb.PlaceOrderRequest) (*matchingpb.OrderAck, error) {
+	ack, err := s.engine.PlaceOrder(placeOrderRequest(req))
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return orderAckPB(ack), nil
+}
+
+func (s *Server) CancelOrder(_ context.Context, req *matchingpb.CancelOrderRequest) (*matchingpb.OrderAck, error) {
+	if req.GetUserId() == "" || req.GetOrderId() == "" {
+		return nil, requestError("user_id and order_id are required")
+	}
+	ack, err := s.engine.CancelOrder(req.GetUserId(), req.GetOrderId())
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return orderAckPB(ack), nil
+}
+
+func (s *Server) AmendOrder(_ context.Context, req *matchingpb.AmendOrderRequest) (*matchingpb.OrderAck, error) {
+	if req.GetUserId() == "" || req.GetOrderId() == "" {
+		return nil, requestError("user_id and order_id are required")
+	}
+	ack, err := s.engine.AmendOrder(matching.AmendOrderRequest{
+		UserID:  req.GetUserId(),
+		OrderID: req.GetOrderId(),
+		Price:   req.GetPrice(),
+		Qty:     req.GetQty(),
+	})
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return orderAckPB(ack), nil
+}
+
+func (s *Server) PlaceOrderBatch(_ context.Context, req *matchingpb.PlaceOrderBatchRequest) (*matchingpb.OrderBatchResponse, error) {
+	reqs := make([]matching.PlaceOrderRequest, 0, len(req.GetOrders()))
+	for _, order := range req.GetOrders() {
+		reqs = append(reqs, placeOrderRequest(order))
+	}
+	results, err := s.engine.PlaceOrders(reqs)
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return orderBatchResponsePB(results), nil
+}
+
+func (s *Server) CancelOrderBatch(_ context.Context, req *matchingpb.CancelOrderBatchRequest) (*matchingpb.OrderBatchResponse, error) {
+	results, err := s.engine.CancelOrders(req.GetUserId(), req.GetOrderIds())
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return orderBatchResponsePB(results), nil
+}
+
+func (s *Server) PlaceOrderList(_ context.Context, req *matchingpb.PlaceOrderListRequest) (*matchingpb.OrderListAck, error) {
+	ack, err := s.engine.PlaceOrderList(placeOrderListRequest(req))
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return orderListAckPB(ack), nil
+}
+
+func (s *Server) CancelOrderList(_ context.Context, req *matchingpb.CancelOrderListRequest) (*matchingpb.OrderListAck, error) {
+	if req.GetUserId() == "" || req.GetListId() == "" {
+		return nil, requestError("user_id and list_id are required")
+	}
+	ack, err := s.engine.CancelOrderList(req.GetUserId(), req.GetListId())
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return orderListAckPB(ack), nil
+}
+
+func (s *Server) OpenOrders(_ context.Context, req *matchingpb.OpenOrdersRequest) (*matchingpb.OpenOrdersResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, requestError("user_id is required")
+	}
+	orders := s.engine.OpenOrders(req.GetUserId())
+	out := &matchingpb.OpenOrdersResponse{Orders: make([]*matchingpb.Order, 0, len(orders))}
+	for _, order := range orders {
+		out.Orders = append(out.Orders, orderPB(order))
+	}
+	return out, nil
+}
+
+func (s *Server) GetWallet(_ context.Context, req *matchingpb.GetWalletRequest) (*matchingpb.Wallet, error) {
+	if req.GetUserId() == "" {
+		return nil, requestError("user_id is required")
+	}
+	return walletPB(s.engine.Wallet(req.GetUserId())), nil
+}
+
+func (s *Server) Transfer(_ context.Context, req *matchingpb.TransferRequest) (*matchingpb.TransferResponse, error) {
+	result, err := s.engine.Transfer(matching.TransferRequest{
+		RequestID:  req.GetRequestId(),
+		FromUserID: req.GetFromUserId(),
+		ToUserID:   req.GetToUserId(),
+		Asset:      req.GetAsset(),
+		Amount:     req.GetAmount(),
+	})
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return &matchingpb.TransferResponse{From: walletPB(result.From), To: walletPB(result.To), Operation: fundingOperationPB(result.Operation)}, nil
+}
+
+func (s *Server) Deposit(_ context.Context, req *matchingpb.FundingRequest) (*matchingpb.FundingOperation, error) {
+	op, err := s.engine.Deposit(fundingRequest(req))
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return fundingOperationPB(op), nil
+}
+
+func (s *Server) Withdraw(_ context.Context, req *matchingpb.FundingRequest) (*matchingpb.FundingOperation, error) {
+	op, err := s.engine.Withdraw(fundingRequest(req))
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return fundingOperationPB(op), nil
+}
+
+func (s *Server) ListFundingHistory(_ context.Context, req *matchingpb.ListFundingHistoryRequest) (*matchingpb.ListFundingHistoryResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, requestError("user_id is required")
+	}
+	if req.GetLimit() < 0 {
+		return nil, requestError("limit must not be negative")
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = 100
+	}
+	history := s.engine.FundingHistory(req.GetUserId(), limit)
+	out := make([]*matchingpb.FundingOperation, 0, len(history))
+	for _, op := range history {
+		out = append(out, fundingOperationPB(op))
+	}
+	return &matchingpb.ListFundingHistoryResponse{Operations: out}, nil
+}
+
+func (s *Server) GetOrderBook(_ context.Context, req *matchingpb.GetOrderBookRequest) (*matchingpb.OrderBook, error) {
+	if req.GetSymbol() == "" {
+		return nil, requestError("symbol is required")
+	}
+	if req.GetDepth() < 0 {
+		return nil, requestError("depth must not be negative")
+	}
+	return orderBookPB(s.engine.OrderBookSnapshot(req.GetSymbol(), int(req.GetDepth()))), nil
+}
+
+func (s *Server) ListTrades(_ context.Context, req *matchingpb.ListTradesRequest) (*matchingpb.ListTradesResponse, error) {
+	if req.GetSymbol() == "" {
+		return nil, requestError("symbol is required")
+	}
+	if req.GetLimit() < 0 {
+		return nil, requestError("limit must not be negative")
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultTradesLimit
+	}
+
+	trades, err := s.tradeSource.ListExecutions(req.GetSymbol(), limit)
+	if err != nil {
+		return nil, engineError(matching.NewError(matching.ErrorInternal, "failed to load trades"))
+	}
+	out := &matchingpb.ListTradesResponse{Trades: make([]*matchingpb.Execution, 0, len(trades))}
+	for _, trade := range trades {
+		out.Trades = append(out.Trades, executionPB(trade))
+	}
+	return out, nil
+}
+
+func (s *Server) GetTicker(_ context.Context, req *matchingpb.GetTickerRequest) (*matchingpb.Ticker, error) {
+	if req.GetSymbol() == "" {
+		return nil, requestError("symbol is required")
+	}
+	return tickerPB(s.engine.Ticker(req.GetSymbol())), nil
+}
+
+func (s *Server) ListTickers(context.Context, *matchingpb.ListTickersRequest) (*matchingpb.ListTickersResponse, error) {
+	tickers := s.engine.Tickers()
+	out := &matchingpb.ListTickersResponse{Tickers: make([]*matchingpb.Ticker, 0, len(tickers))}
+	for _, ticker := range tickers {
+		out.Tickers = append(out.Tickers, tickerPB(ticker))
+	}
+	return out, nil
+}
+
+func (s *Server) StreamExecutions(req *matchingpb.StreamExecutionsRequest, stream grpc.ServerStreamingServer[matchingpb.Execution]) error {
+	sub := s.feed.subscribe(req.GetSymbol(), req.GetUserId())
+	defer s.feed.unsubscribe(sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-sub.dropped:
+			return status.Error(codes.ResourceExhausted, "execution stream fell behind")
+		case execution := <-sub.executions:
+			if err := stream.Send(executionPB(execution)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// engineError answers an engine error with the status code for its error
+// code and an ErrorDetail carrying the code itself.
+func engineError(err error) error {
+	apiErr := matching.AsError(err)
+	st := status.New(grpcCode(apiErr.Code), apiErr.Message)
+	if detailed, detailErr := st.WithDetails(&matchingpb.ErrorDetail{
+		Code:    string(apiErr.Code),
+		Message: apiErr.Message,
+		Details: apiErr.Details,
+	}); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func requestError(message string) error {
+	return engineError(&matching.Error{Code: matching.ErrorInvalidRequest, Message: message})
+}
+
+func grpcCode(code matching.ErrorCode) codes.Code {
+	switch code {
+	case matching.ErrorInvalidRequest, matching.ErrorValidationFailed:
+		return codes.InvalidArgument
+	case matching.ErrorOrderNotFound, matching.ErrorOrderListNotFound, matching.ErrorNotFound:
+		return codes.NotFound
+	case matching.ErrorInsufficientFunds, matching.ErrorNoLiquidity, matching.ErrorRiskLimitExceeded, matching.ErrorOrderNotAmendable, matching.ErrorFundingLimit:
+		return codes.FailedPrecondition
+	case matching.ErrorConflict:
+		return codes.AlreadyExists
+	case matching.ErrorTradingDisabled:
+		return codes.PermissionDenied
+	case matching.ErrorUnauthorized:
+		return codes.Unauthenticated
+	case matching.ErrorSymbolHalted, matching.ErrorUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
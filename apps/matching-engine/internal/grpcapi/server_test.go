@@ -0,0 +1,278 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"kalency/apps/matching-engine/internal/matching"
+	"kalency/apps/matching-engine/matchingpb"
+)
+
+func newTestClient(t *testing.T, engine *matching.Engine, feed *ExecutionFeed) matchingpb.MatchingEngineClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	NewServer(engine, engine, feed).Register(server)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return matchingpb.NewMatchingEngineClient(conn)
+}
+
+func TestPlaceAmendAndCancelOverGRPC(t *testing.T) {
+	engine := matching.NewEngine()
+	client := newTestClient(t, engine, nil)
+	ctx := context.Background()
+
+	ack, err := client.PlaceOrder(ctx, &matchingpb.PlaceOrderRequest{
+		ClientOrderId: "c-1",
+		UserId:        "buyer1",
+		Symbol:        "BTC-USD",
+		Side:          matchingpb.Side_SIDE_BUY,
+		Type:          matchingpb.OrderType_ORDER_TYPE_LIMIT,
+		Price:         100,
+		Qty:           5,
+	})
+	if err != nil {
+		t.Fatalf("place failed: %v", err)
+	}
+	if ack.GetStatus() != matchingpb.OrderStatus_ORDER_STATUS_ACCEPTED || ack.GetClientOrderId() != "c-1" || ack.GetTs() == nil {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+
+	amended, err := client.AmendOrder(ctx, &matchingpb.AmendOrderRequest{UserId: "buyer1", OrderId: ack.GetOrderId(), Qty: 3})
+	if err != nil {
+		t.Fatalf("amend failed: %v", err)
+	}
+	if amended.GetRemainingQty() != 3 {
+		t.Fatalf("expected 3 remaining after amend, got %+v", amended)
+	}
+
+	open, err := client.OpenOrders(ctx, &matchingpb.OpenOrdersRequest{UserId: "buyer1"})
+	if err != nil {
+		t.Fatalf("open orders failed: %v", err)
+	}
+	if len(open.GetOrders()) != 1 || open.GetOrders()[0].GetSide() != matchingpb.Side_SIDE_BUY || open.GetOrders()[0].GetQty() != 3 {
+		t.Fatalf("unexpected open orders: %+v", open.GetOrders())
+	}
+
+	wallet, err := client.GetWallet(ctx, &matchingpb.GetWalletRequest{UserId: "buyer1"})
+	if err != nil {
+		t.Fatalf("wallet failed: %v", err)
+	}
+	if wallet.GetReserved()["USD"] != 300 {
+		t.Fatalf("expected 300 USD reserved, got %+v", wallet.GetReserved())
+	}
+
+	canceled, err := client.CancelOrder(ctx, &matchingpb.CancelOrderRequest{UserId: "buyer1", OrderId: ack.GetOrderId()})
+	if err != nil {
+		t.Fatalf("cancel failed: %v", err)
+	}
+	if canceled.GetStatus() != matchingpb.OrderStatus_ORDER_STATUS_CANCELED {
+		t.Fatalf("expected canceled, got %s", canceled.GetStatus())
+	}
+}
+
+func TestEngineErrorsMapToStatusCodes(t *testing.T) {
+	client := newTestClient(t, matching.NewEngine(), nil)
+	ctx := context.Background()
+
+	_, err := client.PlaceOrder(ctx, &matchingpb.PlaceOrderRequest{UserId: "buyer1", Symbol: "BTC-USD", Type: matchingpb.OrderType_ORDER_TYPE_LIMIT, Price: 100, Qty: 1})
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "side must be BUY or SELL" {
+		t.Fatalf("expected InvalidArgument for missing side, got %v", err)
+	}
+
+	_, err = client.CancelOrder(ctx, &matchingpb.CancelOrderRequest{UserId: "buyer1", OrderId: "ord-missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for missing order, got %v", err)
+	}
+
+	_, err = client.PlaceOrder(ctx, &matchingpb.PlaceOrderRequest{UserId: "seller1", Symbol: "BTC-USD", Side: matchingpb.Side_SIDE_SELL, Type: matchingpb.OrderType_ORDER_TYPE_LIMIT, Price: 100, Qty: 1})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for unfunded sell, got %v", err)
+	}
+	details := status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatalf("expected one error detail, got %v", details)
+	}
+	detail, ok := details[0].(*matchingpb.ErrorDetail)
+	if !ok || detail.GetCode() != string(matching.ErrorInsufficientFunds) || detail.GetDetails()["asset"] != "BTC" {
+		t.Fatalf("expected INSUFFICIENT_FUNDS detail for BTC, got %v", details[0])
+	}
+}
+
+func TestMarketDataOverGRPC(t *testing.T) {
+	engine := matching.NewEngine()
+	engine.FundWallet("seller1", "BTC", 5)
+	client := newTestClient(t, engine, nil)
+	ctx := context.Background()
+
+	if _, err := engine.PlaceOrder(matching.PlaceOrderRequest{UserID: "seller1", Symbol: "BTC-USD", Side: matching.SideSell, Type: matching.OrderTypeLimit, Price: 101, Qty: 5}); err != nil {
+		t.Fatalf("seed ask failed: %v", err)
+	}
+	if _, err := engine.PlaceOrder(matching.PlaceOrderRequest{UserID: "buyer1", Symbol: "BTC-USD", Side: matching.SideBuy, Type: matching.OrderTypeMarket, Qty: 2}); err != nil {
+		t.Fatalf("market buy failed: %v", err)
+	}
+
+	book, err := client.GetOrderBook(ctx, &matchingpb.GetOrderBookRequest{Symbol: "BTC-USD"})
+	if err != nil {
+		t.Fatalf("book failed: %v", err)
+	}
+	if len(book.GetAsks()) != 1 || book.GetAsks()[0].GetQty() != 3 || book.GetAsks()[0].GetOrders() != 1 {
+		t.Fatalf("unexpected book: %+v", book)
+	}
+
+	trades, err := client.ListTrades(ctx, &matchingpb.ListTradesRequest{Symbol: "BTC-USD"})
+	if err != nil {
+		t.Fatalf("trades failed: %v", err)
+	}
+	if len(trades.GetTrades()) != 1 || trades.GetTrades()[0].GetPrice() != 101 || trades.GetTrades()[0].GetTakerUserId() != "buyer1" {
+		t.Fatalf("unexpected trades: %+v", trades.GetTrades())
+	}
+
+	ticker, err := client.GetTicker(ctx, &matchingpb.GetTickerRequest{Symbol: "BTC-USD"})
+	if err != nil {
+		t.Fatalf("ticker failed: %v", err)
+	}
+	if ticker.GetLastPrice() != 101 || ticker.GetVolume_24H() != 2 {
+		t.Fatalf("unexpected ticker: %+v", ticker)
+	}
+}
+
+func TestStreamExecutionsFiltersByUser(t *testing.T) {
+	feed := NewExecutionFeed()
+	engine := matching.NewEngine()
+	engine.SetExecutionSink(feed)
+	engine.FundWallet("seller1", "BTC", 10)
+	client := newTestClient(t, engine, feed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.StreamExecutions(ctx, &matchingpb.StreamExecutionsRequest{UserId: "buyer2"})
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	// The subscription is registered by the handler; wait for it before
+	// trading so nothing is missed.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		feed.mu.Lock()
+		subscribed := len(feed.subscribers) == 1
+		feed.mu.Unlock()
+		if subscribed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stream never subscribed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, err := engine.PlaceOrder(matching.PlaceOrderRequest{UserID: "seller1", Symbol: "BTC-USD", Side: matching.SideSell, Type: matching.OrderTypeLimit, Price: 100, Qty: 10}); err != nil {
+		t.Fatalf("seed ask failed: %v", err)
+	}
+	for _, buyer := range []string{"buyer1", "buyer2"} {
+		if _, err := engine.PlaceOrder(matching.PlaceOrderRequest{UserID: buyer, Symbol: "BTC-USD", Side: matching.SideBuy, Type: matching.OrderTypeMarket, Qty: 1}); err != nil {
+			t.Fatalf("market buy failed: %v", err)
+		}
+	}
+
+	execution, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("recv failed: %v", err)
+	}
+	if execution.GetTakerUserId() != "buyer2" || execution.GetMakerUserId() != "seller1" || execution.GetQty() != 1 {
+		t.Fatalf("expected buyer2's execution only, got %+v", execution)
+	}
+}
+
+func TestExecutionFeedDropsSlowSubscriber(t *testing.T) {
+	feed := NewExecutionFeed()
+	sub := feed.subscribe("", "")
+	for i := 0; i <= executionStreamBuffer; i++ {
+		_ = feed.PublishExecution(context.Background(), matching.Execution{Symbol: "BTC-USD"})
+	}
+
+	select {
+	case <-sub.dropped:
+	default:
+		t.Fatal("expected slow subscriber to be dropped")
+	}
+	if len(feed.subscribers) != 0 {
+		t.Fatalf("expected no subscribers left, got %d", len(feed.subscribers))
+	}
+}
+
+func TestOrderBatchesOverGRPC(t *testing.T) {
+	client := newTestClient(t, matching.NewEngine(), nil)
+	ctx := context.Background()
+
+	placed, err := client.PlaceOrderBatch(ctx, &matchingpb.PlaceOrderBatchRequest{Orders: []*matchingpb.PlaceOrderRequest{
+		{UserId: "mm1", Symbol: "BTC-USD", Side: matchingpb.Side_SIDE_BUY, Type: matchingpb.OrderType_ORDER_TYPE_LIMIT, Price: 99, Qty: 1},
+		{UserId: "mm1", Symbol: "BTC-USD", Side: matchingpb.Side_SIDE_BUY, Type: matchingpb.OrderType_ORDER_TYPE_LIMIT, Qty: 1},
+	}})
+	if err != nil {
+		t.Fatalf("batch place failed: %v", err)
+	}
+	if len(placed.GetResults()) != 2 || placed.GetResults()[0].GetAck() == nil || placed.GetResults()[1].GetError() == "" {
+		t.Fatalf("unexpected batch place results: %+v", placed.GetResults())
+	}
+
+	canceled, err := client.CancelOrderBatch(ctx, &matchingpb.CancelOrderBatchRequest{UserId: "mm1", OrderIds: []string{placed.GetResults()[0].GetAck().GetOrderId()}})
+	if err != nil {
+		t.Fatalf("batch cancel failed: %v", err)
+	}
+	if canceled.GetResults()[0].GetAck().GetStatus() != matchingpb.OrderStatus_ORDER_STATUS_CANCELED {
+		t.Fatalf("unexpected batch cancel results: %+v", canceled.GetResults())
+	}
+
+	if _, err := client.CancelOrderBatch(ctx, &matchingpb.CancelOrderBatchRequest{UserId: "mm1"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for empty batch, got %v", err)
+	}
+}
+
+func TestFundingOverGRPC(t *testing.T) {
+	engine := matching.NewEngine()
+	if err := engine.SetFundingLimits("BTC", matching.FundingLimits{MaxDeposit: 10}); err != nil {
+		t.Fatalf("set limits failed: %v", err)
+	}
+	client := newTestClient(t, engine, nil)
+	ctx := context.Background()
+
+	op, err := client.Deposit(ctx, &matchingpb.FundingRequest{RequestId: "d1", UserId: "u1", Asset: "BTC", Amount: 2})
+	if err != nil || op.GetStatus() != matchingpb.FundingStatus_FUNDING_STATUS_COMPLETED || op.GetType() != matchingpb.FundingType_FUNDING_TYPE_DEPOSIT {
+		t.Fatalf("unexpected deposit %v %v", op, err)
+	}
+	_, err = client.Deposit(ctx, &matchingpb.FundingRequest{RequestId: "d1", UserId: "u1", Asset: "BTC", Amount: 3})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists for a reused request ID, got %v", err)
+	}
+	if _, err := client.Withdraw(ctx, &matchingpb.FundingRequest{RequestId: "w1", UserId: "u1", Asset: "BTC", Amount: 1}); err != nil {
+		t.Fatalf("withdraw failed: %v", err)
+	}
+
+	history, err := client.ListFundingHistory(ctx, &matchingpb.ListFundingHistoryRequest{UserId: "u1", Limit: 2})
+	if err != nil {
+		t.Fatalf("history failed: %v", err)
+	}
+	ops := history.GetOperations()
+	if len(ops) != 2 || ops[0].GetRequestId() != "w1" || ops[1].GetId() != op.GetId() {
+		t.Fatalf("unexpected history %v", ops)
+	}
+}
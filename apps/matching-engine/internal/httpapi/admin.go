@@ -0,0 +1,54 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+// SetAdminToken sets the shared secret /v1/admin/ routes require as
+// "Authorization: Bearer <token>". Until it is set those routes answer 503.
+func (s *Server) SetAdminToken(token string) {
+	s.adminToken = strings.TrimSpace(token)
+}
+
+// requireAdmin checks the admin token and logs every admin write, allowed or
+// not, as an audit line.
+func (s *Server) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		switch {
+		case s.adminToken == "":
+			writeErrorCode(recorder, matching.ErrorUnavailable, "admin API disabled: ADMIN_TOKEN is not set")
+		case !validAdminToken(r, s.adminToken):
+			writeErrorCode(recorder, matching.ErrorUnauthorized, "admin token required")
+		default:
+			next(recorder, r)
+		}
+		if r.Method != http.MethodGet {
+			log.Printf("admin audit: %s %s from %s status=%d", r.Method, r.URL.Path, r.RemoteAddr, recorder.status)
+		}
+	}
+}
+
+func validAdminToken(r *http.Request, token string) bool {
+	authorization := strings.TrimSpace(r.Header.Get("Authorization"))
+	if !strings.HasPrefix(strings.ToLower(authorization), "bearer ") {
+		return false
+	}
+	presented := strings.TrimSpace(authorization[len("Bearer "):])
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
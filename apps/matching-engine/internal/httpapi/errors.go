@@ -0,0 +1,18 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+// writeError answers err as {code, message, details} with the status of its
+// code.
+func writeError(w http.ResponseWriter, err error) {
+	apiErr := matching.AsError(err)
+	writeJSON(w, apiErr.Code.HTTPStatus(), apiErr)
+}
+
+func writeErrorCode(w http.ResponseWriter, code matching.ErrorCode, message string) {
+	writeError(w, &matching.Error{Code: code, Message: message})
+}
@@ -2,6 +2,8 @@ package httpapi
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -17,6 +19,7 @@ type Server struct {
 	engine      *matching.Engine
 	tradeSource TradeSource
 	mux         *http.ServeMux
+	adminToken  string
 }
 
 func NewServer(engine *matching.Engine, tradeSource ...TradeSource) *Server {
@@ -46,16 +49,35 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func (s *Server) routes() {
 	s.mux.HandleFunc("/v1/orders", s.handleOrders)
 	s.mux.HandleFunc("/v1/orders/", s.handleOrderByID)
+	s.mux.HandleFunc("/v1/orders/batch", s.handleOrderBatch)
 	s.mux.HandleFunc("/v1/orders/open/", s.handleOpenOrders)
+	s.mux.HandleFunc("/v1/orders/lists", s.handleOrderLists)
+	s.mux.HandleFunc("/v1/orders/lists/", s.handleOrderListByID)
 	s.mux.HandleFunc("/v1/wallet/", s.handleWallet)
-	s.mux.HandleFunc("/v1/admin/wallets/fund", s.handleFundWallet)
+	s.mux.HandleFunc("/v1/transfers", s.handleTransfers)
+	s.mux.HandleFunc("/v1/funding/deposits", s.handleFunding(s.engine.Deposit))
+	s.mux.HandleFunc("/v1/funding/withdrawals", s.handleFunding(s.engine.Withdraw))
+	s.mux.HandleFunc("/v1/funding/history/", s.handleFundingHistory)
+	s.mux.HandleFunc("/v1/admin/wallets/fund", s.requireAdmin(s.handleFundWallet))
+	s.mux.HandleFunc("/v1/admin/funding/pending", s.requireAdmin(s.handlePendingFunding))
+	s.mux.HandleFunc("/v1/admin/funding/operations/", s.requireAdmin(s.handleSettleFunding))
+	s.mux.HandleFunc("/v1/admin/funding/limits", s.requireAdmin(s.handleFundingLimits))
+	s.mux.HandleFunc("/v1/admin/funding/faucet", s.requireAdmin(s.handleFaucet))
+	s.mux.HandleFunc("/v1/admin/instruments", s.requireAdmin(s.handleInstruments))
+	s.mux.HandleFunc("/v1/admin/risk/limits", s.requireAdmin(s.handleRiskLimits))
+	s.mux.HandleFunc("/v1/admin/risk/users/", s.requireAdmin(s.handleRiskStatus))
+	s.mux.HandleFunc("/v1/admin/kill-switches", s.requireAdmin(s.handleKillSwitches))
 	s.mux.HandleFunc("/v1/markets/", s.handleMarkets)
+	s.mux.HandleFunc("/v1/tickers", s.handleTickers)
 	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeErrorCode(w, matching.ErrorNotFound, "not found")
+	})
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -64,46 +86,141 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req matching.PlaceOrderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
 		return
 	}
 
 	ack, err := s.engine.PlaceOrder(req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, ack)
+	status := http.StatusCreated
+	if ack.Status == matching.OrderStatusRejected {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, ack)
 }
 
 func (s *Server) handleOrderByID(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	orderID := strings.TrimPrefix(r.URL.Path, "/v1/orders/")
 	if orderID == "" {
-		http.Error(w, "order id is required", http.StatusBadRequest)
+		writeErrorCode(w, matching.ErrorInvalidRequest, "order id is required")
 		return
 	}
 
 	userID := r.URL.Query().Get("userId")
 	if userID == "" {
-		http.Error(w, "userId query is required", http.StatusBadRequest)
+		writeErrorCode(w, matching.ErrorInvalidRequest, "userId query is required")
 		return
 	}
 
 	ack, err := s.engine.CancelOrder(userID, orderID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ack)
+}
+
+type placeOrderBatchRequest struct {
+	Orders []matching.PlaceOrderRequest `json:"orders"`
+}
+
+type cancelOrderBatchRequest struct {
+	UserID   string   `json:"userId"`
+	OrderIDs []string `json:"orderIds"`
+}
+
+type orderBatchResponse struct {
+	Results []matching.BatchResult `json:"results"`
+}
+
+func (s *Server) handleOrderBatch(w http.ResponseWriter, r *http.Request) {
+	var (
+		results []matching.BatchResult
+		err     error
+	)
+	switch r.Method {
+	case http.MethodPost:
+		var req placeOrderBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+			return
+		}
+		results, err = s.engine.PlaceOrders(req.Orders)
+	case http.MethodDelete:
+		var req cancelOrderBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+			return
+		}
+		results, err = s.engine.CancelOrders(req.UserID, req.OrderIDs)
+	default:
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, orderBatchResponse{Results: results})
+}
+
+func (s *Server) handleOrderLists(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req matching.PlaceOrderListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+		return
+	}
+
+	ack, err := s.engine.PlaceOrderList(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, ack)
+}
+
+func (s *Server) handleOrderListByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	listID := strings.TrimPrefix(r.URL.Path, "/v1/orders/lists/")
+	if listID == "" {
+		writeErrorCode(w, matching.ErrorInvalidRequest, "list id is required")
+		return
+	}
+
+	userID := r.URL.Query().Get("userId")
+	if userID == "" {
+		writeErrorCode(w, matching.ErrorInvalidRequest, "userId query is required")
+		return
+	}
+
+	ack, err := s.engine.CancelOrderList(userID, listID)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusOK, ack)
@@ -111,13 +228,13 @@ func (s *Server) handleOrderByID(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleOpenOrders(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	userID := strings.TrimPrefix(r.URL.Path, "/v1/orders/open/")
 	if userID == "" {
-		http.Error(w, "user id is required", http.StatusBadRequest)
+		writeErrorCode(w, matching.ErrorInvalidRequest, "user id is required")
 		return
 	}
 
@@ -127,13 +244,13 @@ func (s *Server) handleOpenOrders(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleWallet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	userID := strings.TrimPrefix(r.URL.Path, "/v1/wallet/")
 	if userID == "" {
-		http.Error(w, "user id is required", http.StatusBadRequest)
+		writeErrorCode(w, matching.ErrorInvalidRequest, "user id is required")
 		return
 	}
 
@@ -141,9 +258,28 @@ func (s *Server) handleWallet(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, wallet)
 }
 
+func (s *Server) handleTransfers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req matching.TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+		return
+	}
+	result, err := s.engine.Transfer(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
 func (s *Server) handleFundWallet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
 		return
 	}
 
@@ -153,21 +289,21 @@ func (s *Server) handleFundWallet(w http.ResponseWriter, r *http.Request) {
 		Amount int64  `json:"amount"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
 		return
 	}
 	req.UserID = strings.TrimSpace(req.UserID)
 	req.Asset = strings.TrimSpace(req.Asset)
 	if req.UserID == "" {
-		http.Error(w, "userId is required", http.StatusBadRequest)
+		writeErrorCode(w, matching.ErrorInvalidRequest, "userId is required")
 		return
 	}
 	if req.Asset == "" {
-		http.Error(w, "asset is required", http.StatusBadRequest)
+		writeErrorCode(w, matching.ErrorInvalidRequest, "asset is required")
 		return
 	}
 	if req.Amount <= 0 {
-		http.Error(w, "amount must be positive", http.StatusBadRequest)
+		writeErrorCode(w, matching.ErrorInvalidRequest, "amount must be positive")
 		return
 	}
 
@@ -175,16 +311,265 @@ func (s *Server) handleFundWallet(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, s.engine.Wallet(req.UserID))
 }
 
+func (s *Server) handleFunding(request func(matching.FundingRequest) (matching.FundingOperation, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		var req matching.FundingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+			return
+		}
+		op, err := request(req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, op)
+	}
+}
+
+func (s *Server) handleFundingHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	userID := strings.TrimPrefix(r.URL.Path, "/v1/funding/history/")
+	if userID == "" {
+		writeErrorCode(w, matching.ErrorInvalidRequest, "user id is required")
+		return
+	}
+	limit := 100
+	if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil || parsed <= 0 {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
+	writeJSON(w, http.StatusOK, s.engine.FundingHistory(userID, limit))
+}
+
+func (s *Server) handlePendingFunding(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.engine.PendingFunding())
+}
+
+// handleSettleFunding serves POST /v1/admin/funding/operations/{id}/complete
+// and /reject; a rejection may carry {"reason": "..."}.
+func (s *Server) handleSettleFunding(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/admin/funding/operations/")
+	id, action, ok := strings.Cut(path, "/")
+	if !ok || id == "" {
+		writeErrorCode(w, matching.ErrorNotFound, "not found")
+		return
+	}
+
+	var (
+		op  matching.FundingOperation
+		err error
+	)
+	switch action {
+	case "complete":
+		op, err = s.engine.CompleteFunding(id)
+	case "reject":
+		var req struct {
+			Reason string `json:"reason"`
+		}
+		if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil && !errors.Is(decodeErr, io.EOF) {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+			return
+		}
+		op, err = s.engine.RejectFunding(id, req.Reason)
+	default:
+		writeErrorCode(w, matching.ErrorNotFound, "not found")
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, op)
+}
+
+type fundingLimitsRequest struct {
+	Asset string `json:"asset"`
+	matching.FundingLimits
+}
+
+func (s *Server) handleFundingLimits(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req fundingLimitsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+			return
+		}
+		if err := s.engine.SetFundingLimits(req.Asset, req.FundingLimits); err != nil {
+			writeError(w, err)
+			return
+		}
+	case http.MethodDelete:
+		asset := strings.TrimSpace(r.URL.Query().Get("asset"))
+		if asset == "" {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "asset query is required")
+			return
+		}
+		s.engine.ClearFundingLimits(asset)
+	default:
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.engine.FundingConfig())
+}
+
+func (s *Server) handleFaucet(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req matching.FaucetPolicy
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+			return
+		}
+		if err := s.engine.SetFaucetPolicy(req); err != nil {
+			writeError(w, err)
+			return
+		}
+	default:
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.engine.FundingConfig())
+}
+
+func (s *Server) handleInstruments(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.engine.Instruments())
+	case http.MethodPost:
+		var req matching.Instrument
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+			return
+		}
+		instrument, err := s.engine.SetInstrument(req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, instrument)
+	default:
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+	}
+}
+
+// riskLimitsRequest sets the default limits when UserID is empty.
+type riskLimitsRequest struct {
+	UserID string `json:"userId"`
+	matching.RiskLimits
+}
+
+func (s *Server) handleRiskLimits(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req riskLimitsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+			return
+		}
+		if err := s.engine.SetRiskLimits(req.UserID, req.RiskLimits); err != nil {
+			writeError(w, err)
+			return
+		}
+	case http.MethodDelete:
+		userID := strings.TrimSpace(r.URL.Query().Get("userId"))
+		if userID == "" {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "userId query is required")
+			return
+		}
+		s.engine.ClearRiskLimits(userID)
+	default:
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.engine.RiskConfig())
+}
+
+func (s *Server) handleRiskStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	userID := strings.TrimPrefix(r.URL.Path, "/v1/admin/risk/users/")
+	if userID == "" {
+		writeErrorCode(w, matching.ErrorInvalidRequest, "user id is required")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.engine.RiskStatus(userID))
+}
+
+type killSwitchResponse struct {
+	KillSwitch matching.KillSwitch `json:"killSwitch"`
+	Canceled   []matching.OrderAck `json:"canceled"`
+}
+
+func (s *Server) handleKillSwitches(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req matching.KillSwitch
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErrorCode(w, matching.ErrorInvalidRequest, "invalid JSON body")
+			return
+		}
+		killSwitch, canceled, err := s.engine.ActivateKillSwitch(req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, killSwitchResponse{KillSwitch: killSwitch, Canceled: canceled})
+		return
+	case http.MethodDelete:
+		query := r.URL.Query()
+		err := s.engine.ReleaseKillSwitch(matching.KillSwitchScope(query.Get("scope")), query.Get("target"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+	default:
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.engine.KillSwitches())
+}
+
 func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	path := strings.TrimPrefix(r.URL.Path, "/v1/markets/")
 	parts := strings.Split(path, "/")
 	if len(parts) != 2 || parts[0] == "" {
-		http.NotFound(w, r)
+		writeErrorCode(w, matching.ErrorNotFound, "not found")
 		return
 	}
 
@@ -195,7 +580,7 @@ func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
 		if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
 			parsed, err := strconv.Atoi(rawLimit)
 			if err != nil || parsed <= 0 {
-				http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+				writeErrorCode(w, matching.ErrorInvalidRequest, "limit must be a positive integer")
 				return
 			}
 			limit = parsed
@@ -203,7 +588,7 @@ func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
 
 		trades, err := s.tradeSource.ListExecutions(symbol, limit)
 		if err != nil {
-			http.Error(w, "failed to load trades", http.StatusInternalServerError)
+			writeErrorCode(w, matching.ErrorInternal, "failed to load trades")
 			return
 		}
 		writeJSON(w, http.StatusOK, trades)
@@ -212,7 +597,7 @@ func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
 		if rawDepth := r.URL.Query().Get("depth"); rawDepth != "" {
 			parsed, err := strconv.Atoi(rawDepth)
 			if err != nil || parsed <= 0 {
-				http.Error(w, "depth must be a positive integer", http.StatusBadRequest)
+				writeErrorCode(w, matching.ErrorInvalidRequest, "depth must be a positive integer")
 				return
 			}
 			depth = parsed
@@ -220,9 +605,21 @@ func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
 
 		snapshot := s.engine.OrderBookSnapshot(symbol, depth)
 		writeJSON(w, http.StatusOK, snapshot)
+	case "l3":
+		writeJSON(w, http.StatusOK, s.engine.L3Snapshot(symbol))
+	case "ticker":
+		writeJSON(w, http.StatusOK, s.engine.Ticker(symbol))
 	default:
-		http.NotFound(w, r)
+		writeErrorCode(w, matching.ErrorNotFound, "not found")
+	}
+}
+
+func (s *Server) handleTickers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorCode(w, matching.ErrorMethodNotAllowed, "method not allowed")
+		return
 	}
+	writeJSON(w, http.StatusOK, s.engine.Tickers())
 }
 
 func writeJSON(w http.ResponseWriter, status int, payload any) {
@@ -0,0 +1,57 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+func TestOrderBatchEndpoints(t *testing.T) {
+	engine := matching.NewEngine()
+	server := NewServer(engine)
+
+	placeReq := httptest.NewRequest(http.MethodPost, "/v1/orders/batch", strings.NewReader(`{"orders":[
+		{"clientOrderId":"b-1","userId":"u1","symbol":"BTC-USD","side":"BUY","type":"LIMIT","price":99,"qty":1},
+		{"clientOrderId":"b-2","userId":"u1","symbol":"BTC-USD","side":"BUY","type":"LIMIT","qty":1}
+	]}`))
+	placeRR := httptest.NewRecorder()
+	server.ServeHTTP(placeRR, placeReq)
+	if placeRR.Code != http.StatusOK {
+		t.Fatalf("expected batch place status 200, got %d: %s", placeRR.Code, placeRR.Body.String())
+	}
+
+	var placed orderBatchResponse
+	if err := json.Unmarshal(placeRR.Body.Bytes(), &placed); err != nil {
+		t.Fatalf("failed to decode batch place response: %v", err)
+	}
+	if len(placed.Results) != 2 || placed.Results[0].Ack == nil || placed.Results[1].Error == "" {
+		t.Fatalf("unexpected batch place results: %+v", placed.Results)
+	}
+
+	cancelBody := `{"userId":"u1","orderIds":["` + placed.Results[0].Ack.OrderID + `","ord-missing"]}`
+	cancelReq := httptest.NewRequest(http.MethodDelete, "/v1/orders/batch", strings.NewReader(cancelBody))
+	cancelRR := httptest.NewRecorder()
+	server.ServeHTTP(cancelRR, cancelReq)
+	if cancelRR.Code != http.StatusOK {
+		t.Fatalf("expected batch cancel status 200, got %d: %s", cancelRR.Code, cancelRR.Body.String())
+	}
+
+	var canceled orderBatchResponse
+	if err := json.Unmarshal(cancelRR.Body.Bytes(), &canceled); err != nil {
+		t.Fatalf("failed to decode batch cancel response: %v", err)
+	}
+	if canceled.Results[0].Ack == nil || canceled.Results[0].Ack.Status != matching.OrderStatusCanceled || canceled.Results[1].Error != "order not found" {
+		t.Fatalf("unexpected batch cancel results: %+v", canceled.Results)
+	}
+
+	emptyReq := httptest.NewRequest(http.MethodPost, "/v1/orders/batch", strings.NewReader(`{"orders":[]}`))
+	emptyRR := httptest.NewRecorder()
+	server.ServeHTTP(emptyRR, emptyReq)
+	if emptyRR.Code != http.StatusBadRequest {
+		t.Fatalf("expected empty batch status 400, got %d", emptyRR.Code)
+	}
+}
@@ -0,0 +1,62 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+func TestOrderListEndpoints(t *testing.T) {
+	engine := matching.NewEngine()
+	engine.FundWallet("u1", "BTC", 4)
+	server := NewServer(engine)
+
+	createReq := httptest.NewRequest(http.MethodPost, "/v1/orders/lists", strings.NewReader(`{
+		"clientListId":"oco-1",
+		"userId":"u1",
+		"symbol":"BTC-USD",
+		"type":"OCO",
+		"side":"SELL",
+		"qty":4,
+		"takeProfit":{"clientOrderId":"tp-1","type":"LIMIT","price":120},
+		"stopLoss":{"clientOrderId":"sl-1","type":"STOP_MARKET","stopPrice":90}
+	}`))
+	createReq.Header.Set("Content-Type", "application/json")
+	createRR := httptest.NewRecorder()
+	server.ServeHTTP(createRR, createReq)
+	if createRR.Code != http.StatusCreated {
+		t.Fatalf("expected create status 201, got %d: %s", createRR.Code, createRR.Body.String())
+	}
+
+	var placed matching.OrderListAck
+	if err := json.Unmarshal(createRR.Body.Bytes(), &placed); err != nil {
+		t.Fatalf("failed to decode create response: %v", err)
+	}
+	if placed.ListID == "" || len(placed.Orders) != 2 {
+		t.Fatalf("expected list id and 2 legs, got %+v", placed)
+	}
+
+	cancelReq := httptest.NewRequest(http.MethodDelete, "/v1/orders/lists/"+placed.ListID+"?userId=u1", nil)
+	cancelRR := httptest.NewRecorder()
+	server.ServeHTTP(cancelRR, cancelReq)
+	if cancelRR.Code != http.StatusOK {
+		t.Fatalf("expected cancel status 200, got %d: %s", cancelRR.Code, cancelRR.Body.String())
+	}
+
+	var canceled matching.OrderListAck
+	if err := json.Unmarshal(cancelRR.Body.Bytes(), &canceled); err != nil {
+		t.Fatalf("failed to decode cancel response: %v", err)
+	}
+	if canceled.Status != matching.OrderListStatusDone {
+		t.Fatalf("expected status %s, got %s", matching.OrderListStatusDone, canceled.Status)
+	}
+	for _, leg := range canceled.Orders {
+		if leg.Status != matching.OrderStatusCanceled {
+			t.Fatalf("expected leg %s canceled, got %s", leg.OrderID, leg.Status)
+		}
+	}
+}
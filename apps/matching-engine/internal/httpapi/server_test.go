@@ -234,6 +234,7 @@ func TestOrderBookEndpoint(t *testing.T) {
 func TestFundWalletEndpoint(t *testing.T) {
 	engine := matching.NewEngine()
 	server := NewServer(engine)
+	server.SetAdminToken("admin-secret")
 
 	fundReq := httptest.NewRequest(http.MethodPost, "/v1/admin/wallets/fund", strings.NewReader(`{
 		"userId":"maker-bot",
@@ -241,6 +242,7 @@ func TestFundWalletEndpoint(t *testing.T) {
 		"amount":50
 	}`))
 	fundReq.Header.Set("Content-Type", "application/json")
+	fundReq.Header.Set("Authorization", "Bearer admin-secret")
 	fundRR := httptest.NewRecorder()
 	server.ServeHTTP(fundRR, fundReq)
 
@@ -264,6 +266,94 @@ func TestFundWalletEndpoint(t *testing.T) {
 	}
 }
 
+func TestTransferEndpointMovesFundsToSubAccount(t *testing.T) {
+	server := NewServer(matching.NewEngine())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transfers", strings.NewReader(`{"fromUserId":"u1","toUserId":"u1:sub-1","asset":"USD","amount":250}`))
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected transfer status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var result matching.TransferResult
+	if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to decode transfer response: %v", err)
+	}
+	if result.To.UserID != "u1:sub-1" || result.To.Available["USD"] != 250 {
+		t.Fatalf("unexpected transfer result: %+v", result)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/transfers", strings.NewReader(`{"fromUserId":"u1:sub-1","toUserId":"u1","asset":"USD","amount":1000}`))
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnprocessableEntity || !strings.Contains(rr.Body.String(), `"INSUFFICIENT_FUNDS"`) {
+		t.Fatalf("expected 422 INSUFFICIENT_FUNDS, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestFundingEndpointsHoldWithdrawalsForReview(t *testing.T) {
+	server := NewServer(matching.NewEngine())
+	server.SetAdminToken("admin-secret")
+
+	limitsReq := httptest.NewRequest(http.MethodPost, "/v1/admin/funding/limits", strings.NewReader(`{"asset":"USD","reviewAbove":100}`))
+	limitsReq.Header.Set("Authorization", "Bearer admin-secret")
+	limitsRR := httptest.NewRecorder()
+	server.ServeHTTP(limitsRR, limitsReq)
+	var config matching.FundingConfig
+	if err := json.Unmarshal(limitsRR.Body.Bytes(), &config); err != nil || config.Limits["USD"].ReviewAbove != 100 {
+		t.Fatalf("unexpected funding config %d: %s", limitsRR.Code, limitsRR.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/funding/withdrawals", strings.NewReader(`{"requestId":"w1","userId":"u1","asset":"USD","amount":500}`))
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	var op matching.FundingOperation
+	if err := json.Unmarshal(rr.Body.Bytes(), &op); err != nil || op.Status != matching.FundingPending {
+		t.Fatalf("expected pending withdrawal, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	completeReq := httptest.NewRequest(http.MethodPost, "/v1/admin/funding/operations/"+op.ID+"/complete", nil)
+	completeReq.Header.Set("Authorization", "Bearer admin-secret")
+	completeRR := httptest.NewRecorder()
+	server.ServeHTTP(completeRR, completeReq)
+	if completeRR.Code != http.StatusOK || !strings.Contains(completeRR.Body.String(), `"COMPLETED"`) {
+		t.Fatalf("expected completed withdrawal, got %d: %s", completeRR.Code, completeRR.Body.String())
+	}
+
+	historyReq := httptest.NewRequest(http.MethodGet, "/v1/funding/history/u1?limit=1", nil)
+	historyRR := httptest.NewRecorder()
+	server.ServeHTTP(historyRR, historyReq)
+	var history []matching.FundingOperation
+	if err := json.Unmarshal(historyRR.Body.Bytes(), &history); err != nil || len(history) != 1 || history[0].ID != op.ID || history[0].Status != matching.FundingCompleted {
+		t.Fatalf("unexpected history %s", historyRR.Body.String())
+	}
+}
+
+func TestAdminRoutesRequireToken(t *testing.T) {
+	server := NewServer(matching.NewEngine())
+	body := `{"userId":"maker-bot","asset":"BTC","amount":50}`
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/wallets/fund", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503 without a configured token, got %d", rr.Code)
+	}
+
+	server.SetAdminToken("admin-secret")
+	for _, header := range []string{"", "Bearer wrong"} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/wallets/fund", strings.NewReader(body))
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401 for %q, got %d", header, rr.Code)
+		}
+	}
+}
+
 func TestCORSPreflightForOrders(t *testing.T) {
 	engine := matching.NewEngine()
 	server := NewServer(engine)
@@ -282,3 +372,198 @@ func TestCORSPreflightForOrders(t *testing.T) {
 		t.Fatalf("expected Access-Control-Allow-Origin=*, got %q", rr.Header().Get("Access-Control-Allow-Origin"))
 	}
 }
+
+func TestInstrumentsEndpoint(t *testing.T) {
+	engine := matching.NewEngine()
+	server := NewServer(engine)
+	server.SetAdminToken("admin-secret")
+
+	setReq := httptest.NewRequest(http.MethodPost, "/v1/admin/instruments", strings.NewReader(`{"symbol":"BTC-USD","matchingPolicy":"pro_rata"}`))
+	setReq.Header.Set("Content-Type", "application/json")
+	setReq.Header.Set("Authorization", "Bearer admin-secret")
+	setRR := httptest.NewRecorder()
+	server.ServeHTTP(setRR, setReq)
+	if setRR.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", setRR.Code, setRR.Body.String())
+	}
+	if got := engine.Instrument("BTC-USD").MatchingPolicy; got != matching.MatchingPolicyProRata {
+		t.Fatalf("expected policy %s, got %s", matching.MatchingPolicyProRata, got)
+	}
+
+	badReq := httptest.NewRequest(http.MethodPost, "/v1/admin/instruments", strings.NewReader(`{"symbol":"BTC-USD","matchingPolicy":"random"}`))
+	badReq.Header.Set("Authorization", "Bearer admin-secret")
+	badRR := httptest.NewRecorder()
+	server.ServeHTTP(badRR, badReq)
+	if badRR.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", badRR.Code)
+	}
+
+	listReq := httptest.NewRequest(http.MethodGet, "/v1/admin/instruments", nil)
+	listReq.Header.Set("Authorization", "Bearer admin-secret")
+	listRR := httptest.NewRecorder()
+	server.ServeHTTP(listRR, listReq)
+
+	var instruments []matching.Instrument
+	if err := json.Unmarshal(listRR.Body.Bytes(), &instruments); err != nil {
+		t.Fatalf("failed to decode instruments: %v", err)
+	}
+	if len(instruments) != 1 || instruments[0].Symbol != "BTC-USD" {
+		t.Fatalf("expected BTC-USD instrument, got %+v", instruments)
+	}
+}
+
+func TestRiskLimitsEndpointRejectsOrders(t *testing.T) {
+	engine := matching.NewEngine()
+	server := NewServer(engine)
+	server.SetAdminToken("admin-secret")
+
+	setReq := httptest.NewRequest(http.MethodPost, "/v1/admin/risk/limits", strings.NewReader(`{"userId":"u1","maxOrderQty":5}`))
+	setReq.Header.Set("Authorization", "Bearer admin-secret")
+	setRR := httptest.NewRecorder()
+	server.ServeHTTP(setRR, setReq)
+	if setRR.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", setRR.Code, setRR.Body.String())
+	}
+	var config matching.RiskConfig
+	if err := json.Unmarshal(setRR.Body.Bytes(), &config); err != nil || config.Users["u1"].MaxOrderQty != 5 {
+		t.Fatalf("unexpected risk config %s", setRR.Body.String())
+	}
+
+	orderReq := httptest.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(`{"userId":"u1","symbol":"BTC-USD","side":"BUY","type":"LIMIT","price":10,"qty":6}`))
+	orderRR := httptest.NewRecorder()
+	server.ServeHTTP(orderRR, orderReq)
+	if orderRR.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for a rejected order, got %d", orderRR.Code)
+	}
+	var ack matching.OrderAck
+	if err := json.Unmarshal(orderRR.Body.Bytes(), &ack); err != nil {
+		t.Fatalf("failed to decode ack: %v", err)
+	}
+	if ack.Status != matching.OrderStatusRejected || ack.RejectCode != matching.RejectMaxOrderSize {
+		t.Fatalf("expected MAX_ORDER_SIZE rejection, got %+v", ack)
+	}
+
+	statusReq := httptest.NewRequest(http.MethodGet, "/v1/admin/risk/users/u1", nil)
+	statusReq.Header.Set("Authorization", "Bearer admin-secret")
+	statusRR := httptest.NewRecorder()
+	server.ServeHTTP(statusRR, statusReq)
+	var status matching.RiskStatus
+	if err := json.Unmarshal(statusRR.Body.Bytes(), &status); err != nil || status.Limits.MaxOrderQty != 5 {
+		t.Fatalf("unexpected risk status %s", statusRR.Body.String())
+	}
+}
+
+func TestKillSwitchEndpointCancelsAndReleases(t *testing.T) {
+	engine := matching.NewEngine()
+	server := NewServer(engine)
+	server.SetAdminToken("admin-secret")
+	if _, err := engine.PlaceOrder(matching.PlaceOrderRequest{UserID: "bot", Symbol: "BTC-USD", Side: matching.SideBuy, Type: matching.OrderTypeLimit, Price: 10, Qty: 1}); err != nil {
+		t.Fatalf("place order: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/kill-switches", strings.NewReader(`{"scope":"USER","target":"bot","reason":"runaway"}`))
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var activated killSwitchResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &activated); err != nil || len(activated.Canceled) != 1 || activated.KillSwitch.Reason != "runaway" {
+		t.Fatalf("unexpected kill switch response %s", rr.Body.String())
+	}
+
+	orderRR := httptest.NewRecorder()
+	server.ServeHTTP(orderRR, httptest.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(`{"userId":"bot","symbol":"BTC-USD","side":"BUY","type":"LIMIT","price":10,"qty":1}`)))
+	var ack matching.OrderAck
+	if err := json.Unmarshal(orderRR.Body.Bytes(), &ack); err != nil || ack.RejectCode != matching.RejectKillSwitch {
+		t.Fatalf("expected KILL_SWITCH rejection, got %s", orderRR.Body.String())
+	}
+
+	for _, want := range []int{http.StatusOK, http.StatusNotFound} {
+		deleteReq := httptest.NewRequest(http.MethodDelete, "/v1/admin/kill-switches?scope=USER&target=bot", nil)
+		deleteReq.Header.Set("Authorization", "Bearer admin-secret")
+		deleteRR := httptest.NewRecorder()
+		server.ServeHTTP(deleteRR, deleteReq)
+		if deleteRR.Code != want {
+			t.Fatalf("expected status %d, got %d: %s", want, deleteRR.Code, deleteRR.Body.String())
+		}
+	}
+}
+
+func TestL3BookEndpointOmitsUserIDs(t *testing.T) {
+	engine := matching.NewEngine()
+	engine.FundWallet("seller1", "BTC", 2)
+	if _, err := engine.PlaceOrder(matching.PlaceOrderRequest{
+		ClientOrderID: "s-1",
+		UserID:        "seller1",
+		Symbol:        "BTC-USD",
+		Side:          matching.SideSell,
+		Type:          matching.OrderTypeLimit,
+		Price:         110,
+		Qty:           2,
+	}); err != nil {
+		t.Fatalf("seed order failed: %v", err)
+	}
+	server := NewServer(engine)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/markets/BTC-USD/l3", nil)
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if strings.Contains(rr.Body.String(), "seller1") || strings.Contains(rr.Body.String(), "s-1") {
+		t.Fatalf("expected L3 book to mask owners, got %s", rr.Body.String())
+	}
+
+	var snapshot matching.L3Snapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("decode L3 response failed: %v", err)
+	}
+	if len(snapshot.Asks) != 1 || snapshot.Asks[0].Qty != 2 || snapshot.Asks[0].OrderID == "" {
+		t.Fatalf("unexpected L3 asks %+v", snapshot.Asks)
+	}
+}
+
+func TestTickerEndpoints(t *testing.T) {
+	engine := matching.NewEngine()
+	engine.FundWallet("seller1", "BTC", 2)
+	for _, req := range []matching.PlaceOrderRequest{
+		{UserID: "seller1", Symbol: "BTC-USD", Side: matching.SideSell, Type: matching.OrderTypeLimit, Price: 110, Qty: 2},
+		{UserID: "buyer1", Symbol: "BTC-USD", Side: matching.SideBuy, Type: matching.OrderTypeMarket, Qty: 1},
+	} {
+		if _, err := engine.PlaceOrder(req); err != nil {
+			t.Fatalf("seed order failed: %v", err)
+		}
+	}
+	server := NewServer(engine)
+
+	tickerReq := httptest.NewRequest(http.MethodGet, "/v1/markets/BTC-USD/ticker", nil)
+	tickerRR := httptest.NewRecorder()
+	server.ServeHTTP(tickerRR, tickerReq)
+	if tickerRR.Code != http.StatusOK {
+		t.Fatalf("expected ticker status 200, got %d", tickerRR.Code)
+	}
+	var ticker matching.Ticker
+	if err := json.Unmarshal(tickerRR.Body.Bytes(), &ticker); err != nil {
+		t.Fatalf("decode ticker failed: %v", err)
+	}
+	if ticker.LastPrice != 110 || ticker.BestAsk != 110 || ticker.BestAskQty != 1 || ticker.Volume24h != 1 {
+		t.Fatalf("unexpected ticker %+v", ticker)
+	}
+
+	listReq := httptest.NewRequest(http.MethodGet, "/v1/tickers", nil)
+	listRR := httptest.NewRecorder()
+	server.ServeHTTP(listRR, listReq)
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected tickers status 200, got %d", listRR.Code)
+	}
+	var tickers []matching.Ticker
+	if err := json.Unmarshal(listRR.Body.Bytes(), &tickers); err != nil {
+		t.Fatalf("decode tickers failed: %v", err)
+	}
+	if len(tickers) != 1 || tickers[0].Symbol != "BTC-USD" {
+		t.Fatalf("unexpected tickers %+v", tickers)
+	}
+}
@@ -0,0 +1,154 @@
+package matching
+
+import (
+	"errors"
+	"time"
+)
+
+// AmendOrderRequest changes a resting limit order. Zero Price or Qty keeps the
+// current value; Qty is the new total quantity including what has filled.
+type AmendOrderRequest struct {
+	UserID  string `json:"userId"`
+	OrderID string `json:"orderId"`
+	Price   int64  `json:"price,omitempty"`
+	Qty     int64  `json:"qty,omitempty"`
+}
+
+// AmendOrder reduces a resting order in place when only its quantity goes
+// down, keeping queue priority and publishing an L3 MODIFY. Any other change
+// takes the order off the book and resubmits it behind the orders already at
+// its new price, so it may trade immediately.
+func (e *Engine) AmendOrder(req AmendOrderRequest) (OrderAck, error) {
+	e.mu.Lock()
+
+	order, ok := e.ordersByUser[req.UserID][req.OrderID]
+	if !ok {
+		e.mu.Unlock()
+		return OrderAck{}, ErrOrderNotFound
+	}
+	book := e.books[order.Symbol]
+	if book == nil || !bookContains(book, order) {
+		e.mu.Unlock()
+		return OrderAck{}, NewError(ErrorOrderNotAmendable, "only resting limit orders can be amended")
+	}
+	if order.ListID != "" {
+		e.mu.Unlock()
+		return OrderAck{}, NewError(ErrorOrderNotAmendable, "order list legs cannot be amended")
+	}
+	if req.Price < 0 || req.Qty < 0 {
+		e.mu.Unlock()
+		return OrderAck{}, errors.New("price and qty must not be negative")
+	}
+
+	price := order.Price
+	if req.Price > 0 {
+		price = req.Price
+	}
+	qty := order.Qty
+	if req.Qty > 0 {
+		qty = req.Qty
+	}
+	remaining := qty - order.cumQty
+	if remaining <= 0 {
+		e.mu.Unlock()
+		return OrderAck{}, errors.New("qty must be greater than the filled quantity")
+	}
+	if price == order.Price && qty == order.Qty {
+		ack := orderAck(order)
+		e.mu.Unlock()
+		return ack, nil
+	}
+
+	batch := newEventBatch()
+	batch.touchedUsers[order.UserID] = struct{}{}
+	batch.symbols[order.Symbol] = struct{}{}
+
+	if price == order.Price && remaining < order.RemainingQty {
+		e.releasePartialReservationLocked(order, order.RemainingQty-remaining)
+		order.Qty = qty
+		order.RemainingQty = remaining
+		batch.orderChanged(order)
+		e.recordOrderEventLocked(batch, OrderEventModify, order, 0, "")
+
+		ack := orderAck(order)
+		e.unlockAndPublish(batch)
+		return ack, nil
+	}
+
+	// Reductions always pass; anything else is held to the size and notional
+	// limits like a new order.
+	limits := e.riskLimitsLocked(order.UserID)
+	if rejection := e.checkOrderLimitsLocked(limits, order.Symbol, order.Side, order.Type, qty, price, order.StopPrice); rejection != nil {
+		e.mu.Unlock()
+		return OrderAck{}, rejection
+	}
+	if err := e.checkAmendReservationLocked(order, price, remaining); err != nil {
+		e.mu.Unlock()
+		return OrderAck{}, err
+	}
+
+	e.removeFromBook(book, order)
+	e.recordOrderEventLocked(batch, OrderEventDelete, order, 0, "")
+	e.releaseOrderReservationLocked(order)
+	e.orderSeq++
+	order.seq = e.orderSeq
+	order.Price = price
+	order.Qty = qty
+	order.RemainingQty = remaining
+	// The balance check above holds the engine lock, so the reservation and
+	// resubmission only fail on the same internal errors PlaceOrder can hit.
+	err := e.reserveForOrderLocked(order, book)
+	if err == nil {
+		err = e.submitLocked(book, order, batch)
+	}
+	if err != nil {
+		e.removeOpenOrder(order)
+		order.RemainingQty = 0
+		order.status = OrderStatusRejected
+		batch.orderChanged(order)
+		e.unlockAndPublish(batch)
+		return OrderAck{}, err
+	}
+	if order.RemainingQty == 0 {
+		e.removeOpenOrder(order)
+	}
+	e.processContingenciesLocked(batch)
+
+	ack := orderAck(order)
+	e.unlockAndPublish(batch)
+	return ack, nil
+}
+
+// checkAmendReservationLocked reports whether the order's current reservation
+// plus the owner's available balance covers the amended order.
+func (e *Engine) checkAmendReservationLocked(order *Order, price, remaining int64) error {
+	wallet := e.ensureWalletLocked(order.UserID)
+	if order.Side == SideSell {
+		if wallet.Available[order.BaseAsset]+order.ReservedBaseQty < remaining {
+			return insufficientBalance("base", order.BaseAsset)
+		}
+		return nil
+	}
+	if wallet.Available[order.QuoteAsset]+order.ReservedQuoteQty < price*remaining {
+		return insufficientBalance("quote", order.QuoteAsset)
+	}
+	return nil
+}
+
+// releasePartialReservationLocked returns the reservation held for qty of the
+// order's remaining quantity.
+func (e *Engine) releasePartialReservationLocked(order *Order, qty int64) {
+	wallet := e.ensureWalletLocked(order.UserID)
+	if order.Side == SideSell {
+		release := minInt64(qty, order.ReservedBaseQty)
+		wallet.Reserved[order.BaseAsset] -= release
+		wallet.Available[order.BaseAsset] += release
+		order.ReservedBaseQty -= release
+	} else {
+		release := minInt64(order.Price*qty, order.ReservedQuoteQty)
+		wallet.Reserved[order.QuoteAsset] -= release
+		wallet.Available[order.QuoteAsset] += release
+		order.ReservedQuoteQty -= release
+	}
+	wallet.UpdatedAt = time.Now().UTC()
+}
@@ -0,0 +1,109 @@
+package matching
+
+import "testing"
+
+func TestAmendOrderReducingQtyKeepsPriority(t *testing.T) {
+	engine := NewEngine()
+	sink := &recordingBookSink{}
+	engine.SetBookEventSink(sink, 0)
+	engine.FundWallet("buyer1", "USD", 1000)
+
+	first, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 100, Qty: 5})
+	if err != nil {
+		t.Fatalf("place order failed: %v", err)
+	}
+	second, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 100, Qty: 1})
+	if err != nil {
+		t.Fatalf("place order failed: %v", err)
+	}
+
+	ack, err := engine.AmendOrder(AmendOrderRequest{UserID: "buyer1", OrderID: first.OrderID, Qty: 2})
+	if err != nil {
+		t.Fatalf("amend failed: %v", err)
+	}
+	if ack.RemainingQty != 2 || ack.Status != OrderStatusAccepted {
+		t.Fatalf("expected 2 remaining and accepted, got %+v", ack)
+	}
+
+	snapshot := engine.L3Snapshot("BTC-USD")
+	if len(snapshot.Bids) != 2 || snapshot.Bids[0].OrderID != first.OrderID || snapshot.Bids[0].Qty != 2 || snapshot.Bids[1].OrderID != second.OrderID {
+		t.Fatalf("expected amended order to stay first with qty 2, got %+v", snapshot.Bids)
+	}
+	last := sink.events[len(sink.events)-1]
+	if last.Type != OrderEventModify || last.OrderID != first.OrderID || last.Qty != 2 {
+		t.Fatalf("expected MODIFY event with qty 2, got %+v", last)
+	}
+	if wallet := engine.Wallet("buyer1"); wallet.Reserved["USD"] != 300 {
+		t.Fatalf("expected 300 USD reserved after amend, got %d", wallet.Reserved["USD"])
+	}
+}
+
+func TestAmendOrderPriceChangeRequeuesAndCanTrade(t *testing.T) {
+	engine := NewEngine()
+	sink := &recordingBookSink{}
+	engine.SetBookEventSink(sink, 0)
+	engine.FundWallet("seller1", "BTC", 10)
+
+	if _, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 105, Qty: 2}); err != nil {
+		t.Fatalf("place ask failed: %v", err)
+	}
+	bid, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 100, Qty: 3})
+	if err != nil {
+		t.Fatalf("place bid failed: %v", err)
+	}
+	before := len(sink.events)
+
+	ack, err := engine.AmendOrder(AmendOrderRequest{UserID: "buyer1", OrderID: bid.OrderID, Price: 105})
+	if err != nil {
+		t.Fatalf("amend failed: %v", err)
+	}
+	if ack.Status != OrderStatusPartiallyFill || ack.FilledQty != 2 || ack.RemainingQty != 1 {
+		t.Fatalf("expected partial fill of 2, got %+v", ack)
+	}
+
+	var types []OrderEventType
+	for _, event := range sink.events[before:] {
+		types = append(types, event.Type)
+	}
+	want := []OrderEventType{OrderEventDelete, OrderEventExecute, OrderEventAdd}
+	if len(types) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, types)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("expected events %v, got %v", want, types)
+		}
+	}
+
+	wallet := engine.Wallet("buyer1")
+	if wallet.Reserved["USD"] != 105 || wallet.Available["USD"] != 100000-210-105 {
+		t.Fatalf("unexpected buyer wallet after amend: %+v", wallet)
+	}
+}
+
+func TestAmendOrderRejectsInvalidChanges(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("seller1", "BTC", 5)
+
+	ask, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 100, Qty: 5})
+	if err != nil {
+		t.Fatalf("place order failed: %v", err)
+	}
+
+	cases := []AmendOrderRequest{
+		{UserID: "seller1", OrderID: "ord-missing", Qty: 1},
+		{UserID: "other", OrderID: ask.OrderID, Qty: 1},
+		{UserID: "seller1", OrderID: ask.OrderID, Qty: 6},
+		{UserID: "seller1", OrderID: ask.OrderID, Price: -1},
+	}
+	for _, req := range cases {
+		if _, err := engine.AmendOrder(req); err == nil {
+			t.Fatalf("expected amend %+v to fail", req)
+		}
+	}
+
+	open := engine.OpenOrders("seller1")
+	if len(open) != 1 || open[0].RemainingQty != 5 || open[0].Price != 100 {
+		t.Fatalf("expected order untouched after rejected amends, got %+v", open)
+	}
+}
@@ -0,0 +1,69 @@
+package matching
+
+import (
+	"errors"
+	"fmt"
+)
+
+const MaxBatchOrders = 100
+
+// BatchResult is the outcome of one entry of a batch call, in request order:
+// the ack, or the error the single-order call would have returned.
+type BatchResult struct {
+	Ack       *OrderAck `json:"ack,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	ErrorCode ErrorCode `json:"errorCode,omitempty"`
+}
+
+// PlaceOrders places each order in turn under one engine lock. A rejected
+// order does not stop the rest, and each touched user's open orders are
+// stored once for the whole batch.
+func (e *Engine) PlaceOrders(reqs []PlaceOrderRequest) ([]BatchResult, error) {
+	if err := validateBatchSize(len(reqs)); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	batch := newEventBatch()
+	results := make([]BatchResult, 0, len(reqs))
+	for _, req := range reqs {
+		ack, err := e.placeOrderLocked(req, batch)
+		results = append(results, batchResult(ack, err))
+	}
+	e.unlockAndPublish(batch)
+	return results, nil
+}
+
+// CancelOrders cancels each of the user's orders under one engine lock.
+func (e *Engine) CancelOrders(userID string, orderIDs []string) ([]BatchResult, error) {
+	if userID == "" {
+		return nil, errors.New("userId is required")
+	}
+	if err := validateBatchSize(len(orderIDs)); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	batch := newEventBatch()
+	results := make([]BatchResult, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		ack, err := e.cancelOrderByIDLocked(userID, orderID, batch)
+		results = append(results, batchResult(ack, err))
+	}
+	e.unlockAndPublish(batch)
+	return results, nil
+}
+
+func validateBatchSize(n int) error {
+	if n == 0 || n > MaxBatchOrders {
+		return fmt.Errorf("batch must contain 1 to %d orders", MaxBatchOrders)
+	}
+	return nil
+}
+
+func batchResult(ack OrderAck, err error) BatchResult {
+	if err != nil {
+		return BatchResult{Error: err.Error(), ErrorCode: AsError(err).Code}
+	}
+	return BatchResult{Ack: &ack}
+}
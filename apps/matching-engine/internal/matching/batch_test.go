@@ -0,0 +1,91 @@
+package matching
+
+import (
+	"context"
+	"testing"
+)
+
+type countingOpenOrdersStore struct {
+	*fakeOpenOrdersStore
+	writes map[string]int
+}
+
+func (c *countingOpenOrdersStore) SetUserOrders(ctx context.Context, userID string, orders []Order) error {
+	c.writes[userID]++
+	return c.fakeOpenOrdersStore.SetUserOrders(ctx, userID, orders)
+}
+
+func TestPlaceOrdersReturnsPerOrderResultsWithOneStoreUpdate(t *testing.T) {
+	store := &countingOpenOrdersStore{fakeOpenOrdersStore: newFakeOpenOrdersStore(), writes: map[string]int{}}
+	engine := NewEngineWithStore(store)
+	engine.FundWallet("mm1", "BTC", 10)
+	store.writes["mm1"] = 0
+
+	results, err := engine.PlaceOrders([]PlaceOrderRequest{
+		{ClientOrderID: "a-1", UserID: "mm1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 101, Qty: 2},
+		{ClientOrderID: "a-2", UserID: "mm1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 102, Qty: 20},
+		{ClientOrderID: "b-1", UserID: "mm1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 99, Qty: 3},
+	})
+	if err != nil {
+		t.Fatalf("batch failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].Ack == nil || results[0].Ack.ClientOrderID != "a-1" || results[2].Ack == nil {
+		t.Fatalf("expected first and last orders accepted, got %+v", results)
+	}
+	if results[1].Ack != nil || results[1].Error != "insufficient base balance" {
+		t.Fatalf("expected second order rejected for balance, got %+v", results[1])
+	}
+	if store.writes["mm1"] != 1 {
+		t.Fatalf("expected one open-orders store update, got %d", store.writes["mm1"])
+	}
+	if got := len(store.data["mm1"]); got != 2 {
+		t.Fatalf("expected 2 stored open orders, got %d", got)
+	}
+}
+
+func TestCancelOrdersCancelsUnderOneLock(t *testing.T) {
+	store := &countingOpenOrdersStore{fakeOpenOrdersStore: newFakeOpenOrdersStore(), writes: map[string]int{}}
+	engine := NewEngineWithStore(store)
+
+	orderIDs := []string{}
+	for _, price := range []int64{97, 98, 99} {
+		ack, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "mm1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: price, Qty: 1})
+		if err != nil {
+			t.Fatalf("seed failed: %v", err)
+		}
+		orderIDs = append(orderIDs, ack.OrderID)
+	}
+	store.writes["mm1"] = 0
+
+	results, err := engine.CancelOrders("mm1", append(orderIDs, "ord-missing"))
+	if err != nil {
+		t.Fatalf("batch cancel failed: %v", err)
+	}
+	for i := range orderIDs {
+		if results[i].Ack == nil || results[i].Ack.Status != OrderStatusCanceled {
+			t.Fatalf("expected order %d canceled, got %+v", i, results[i])
+		}
+	}
+	if results[3].Error != "order not found" {
+		t.Fatalf("expected missing order error, got %+v", results[3])
+	}
+	if store.writes["mm1"] != 1 || len(store.data["mm1"]) != 0 {
+		t.Fatalf("expected one store update leaving no open orders, got %d writes and %d orders", store.writes["mm1"], len(store.data["mm1"]))
+	}
+	if wallet := engine.Wallet("mm1"); wallet.Reserved["USD"] != 0 {
+		t.Fatalf("expected reservations released, got %+v", wallet.Reserved)
+	}
+}
+
+func TestBatchSizeIsLimited(t *testing.T) {
+	engine := NewEngine()
+	if _, err := engine.PlaceOrders(nil); err == nil {
+		t.Fatal("expected error for empty batch")
+	}
+	if _, err := engine.CancelOrders("mm1", make([]string, MaxBatchOrders+1)); err == nil {
+		t.Fatal("expected error for oversized batch")
+	}
+}
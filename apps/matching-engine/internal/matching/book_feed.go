@@ -0,0 +1,185 @@
+package matching
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+const defaultBookSnapshotInterval = int64(100)
+
+// BookDelta is the new aggregate state of one price level. Qty 0 means the
+// level was removed. Seq increases by one per delta within a symbol, so a
+// consumer that sees a jump has missed updates and must resync from a
+// snapshot.
+type BookDelta struct {
+	Symbol string    `json:"symbol"`
+	Seq    int64     `json:"seq"`
+	Side   Side      `json:"side"`
+	Price  int64     `json:"price"`
+	Qty    int64     `json:"qty"`
+	Orders int       `json:"orders"`
+	TS     time.Time `json:"ts"`
+}
+
+type BookEventSink interface {
+	PublishBookDelta(ctx context.Context, delta BookDelta) error
+	PublishBookSnapshot(ctx context.Context, snapshot OrderBookSnapshot) error
+	PublishOrderEvent(ctx context.Context, event OrderEvent) error
+}
+
+type bookFeedState struct {
+	seq             int64
+	lastSnapshotSeq int64
+	orderSeq        int64
+	bids            map[int64]BookLevel
+	asks            map[int64]BookLevel
+}
+
+// SetBookEventSink enables the level-2 delta feed. A full-depth snapshot is
+// emitted whenever snapshotInterval deltas have accumulated since the last one.
+func (e *Engine) SetBookEventSink(sink BookEventSink, snapshotInterval int64) {
+	if snapshotInterval <= 0 {
+		snapshotInterval = defaultBookSnapshotInterval
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.bookSink = sink
+	e.bookSnapshotInterval = snapshotInterval
+}
+
+type bookLevelKey struct {
+	side  Side
+	price int64
+}
+
+// levelChanged marks a price level for the next book deltas. Every change to
+// a resting order records an order event, which calls it.
+func (b *eventBatch) levelChanged(symbol string, side Side, price int64) {
+	if b.dirtyLevels == nil {
+		b.dirtyLevels = make(map[string]map[bookLevelKey]struct{})
+	}
+	levels, ok := b.dirtyLevels[symbol]
+	if !ok {
+		levels = make(map[bookLevelKey]struct{})
+		b.dirtyLevels[symbol] = levels
+	}
+	levels[bookLevelKey{side: side, price: price}] = struct{}{}
+}
+
+func (e *Engine) collectBookDeltasLocked(batch *eventBatch) {
+	if e.bookSink == nil {
+		return
+	}
+
+	symbols := make(map[string]struct{}, len(batch.dirtyLevels))
+	for symbol := range batch.dirtyLevels {
+		symbols[symbol] = struct{}{}
+	}
+	for _, symbol := range sortedKeys(symbols) {
+		book := e.books[symbol]
+		if book == nil {
+			continue
+		}
+
+		state := e.bookFeedLocked(symbol)
+		now := time.Now().UTC()
+		for _, key := range sortedLevelKeys(batch.dirtyLevels[symbol]) {
+			published, entries := state.asks, book.asks
+			if key.side == SideBuy {
+				published, entries = state.bids, book.bids
+			}
+			level := levelAt(entries, key.side, key.price)
+			// A removal is sent even for a level this feed never published: it
+			// may have rested before the sink was set.
+			if previous, ok := published[key.price]; ok && previous == level {
+				continue
+			}
+			if level.Qty == 0 {
+				delete(published, key.price)
+			} else {
+				published[key.price] = level
+			}
+
+			state.seq++
+			batch.bookDeltas = append(batch.bookDeltas, BookDelta{
+				Symbol: symbol,
+				Seq:    state.seq,
+				Side:   key.side,
+				Price:  level.Price,
+				Qty:    level.Qty,
+				Orders: level.Orders,
+				TS:     now,
+			})
+		}
+
+		if state.seq-state.lastSnapshotSeq >= e.bookSnapshotInterval {
+			state.lastSnapshotSeq = state.seq
+			batch.bookSnapshots = append(batch.bookSnapshots, e.fullBookSnapshotLocked(symbol))
+		}
+	}
+}
+
+func (e *Engine) bookFeedLocked(symbol string) *bookFeedState {
+	state, ok := e.bookFeeds[symbol]
+	if !ok {
+		state = &bookFeedState{bids: map[int64]BookLevel{}, asks: map[int64]BookLevel{}}
+		e.bookFeeds[symbol] = state
+	}
+	return state
+}
+
+// sortedLevelKeys orders bids before asks, each best price first.
+func sortedLevelKeys(levels map[bookLevelKey]struct{}) []bookLevelKey {
+	out := make([]bookLevelKey, 0, len(levels))
+	for key := range levels {
+		out = append(out, key)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].side != out[j].side {
+			return out[i].side == SideBuy
+		}
+		if out[i].side == SideBuy {
+			return out[i].price > out[j].price
+		}
+		return out[i].price < out[j].price
+	})
+	return out
+}
+
+// levelAt aggregates the resting orders at price on one side of the book.
+// A removed level comes back with zero Qty and Orders.
+func levelAt(entries []*Order, side Side, price int64) BookLevel {
+	i := sort.Search(len(entries), func(i int) bool {
+		if side == SideBuy {
+			return entries[i].Price <= price
+		}
+		return entries[i].Price >= price
+	})
+	level := BookLevel{Price: price}
+	for ; i < len(entries) && entries[i].Price == price; i++ {
+		if entries[i].RemainingQty > 0 {
+			level.Qty += entries[i].RemainingQty
+			level.Orders++
+		}
+	}
+	return level
+}
+
+func (e *Engine) fullBookSnapshotLocked(symbol string) OrderBookSnapshot {
+	snapshot := OrderBookSnapshot{
+		Symbol: symbol,
+		Bids:   []BookLevel{},
+		Asks:   []BookLevel{},
+		TS:     time.Now().UTC(),
+	}
+	if state, ok := e.bookFeeds[symbol]; ok {
+		snapshot.Seq = state.seq
+	}
+	if book := e.books[symbol]; book != nil {
+		snapshot.Bids = aggregateBookLevels(book.bids, len(book.bids))
+		snapshot.Asks = aggregateBookLevels(book.asks, len(book.asks))
+	}
+	return snapshot
+}
@@ -0,0 +1,127 @@
+package matching
+
+import (
+	"context"
+	"testing"
+)
+
+type recordingBookSink struct {
+	deltas    []BookDelta
+	snapshots []OrderBookSnapshot
+	events    []OrderEvent
+}
+
+func (s *recordingBookSink) PublishBookDelta(_ context.Context, delta BookDelta) error {
+	s.deltas = append(s.deltas, delta)
+	return nil
+}
+
+func (s *recordingBookSink) PublishBookSnapshot(_ context.Context, snapshot OrderBookSnapshot) error {
+	s.snapshots = append(s.snapshots, snapshot)
+	return nil
+}
+
+func (s *recordingBookSink) PublishOrderEvent(_ context.Context, event OrderEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestBookDeltasTrackLevelChanges(t *testing.T) {
+	engine := NewEngine()
+	sink := &recordingBookSink{}
+	engine.SetBookEventSink(sink, 3)
+	engine.FundWallet("seller1", "BTC", 10)
+
+	for _, req := range []PlaceOrderRequest{
+		{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 101, Qty: 2},
+		{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 101, Qty: 3},
+		{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 102, Qty: 1},
+		{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeMarket, Qty: 5},
+	} {
+		if _, err := engine.PlaceOrder(req); err != nil {
+			t.Fatalf("place order failed: %v", err)
+		}
+	}
+
+	want := []BookDelta{
+		{Seq: 1, Side: SideSell, Price: 101, Qty: 2, Orders: 1},
+		{Seq: 2, Side: SideSell, Price: 101, Qty: 5, Orders: 2},
+		{Seq: 3, Side: SideSell, Price: 102, Qty: 1, Orders: 1},
+		{Seq: 4, Side: SideSell, Price: 101, Qty: 0, Orders: 0},
+	}
+	if len(sink.deltas) != len(want) {
+		t.Fatalf("expected %d deltas, got %+v", len(want), sink.deltas)
+	}
+	for i, delta := range sink.deltas {
+		got := BookDelta{Seq: delta.Seq, Side: delta.Side, Price: delta.Price, Qty: delta.Qty, Orders: delta.Orders}
+		if got != want[i] || delta.Symbol != "BTC-USD" {
+			t.Fatalf("delta %d: expected %+v, got %+v", i, want[i], delta)
+		}
+	}
+
+	if len(sink.snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot, got %d", len(sink.snapshots))
+	}
+	snapshot := sink.snapshots[0]
+	if snapshot.Seq != 3 || len(snapshot.Asks) != 2 || snapshot.Asks[0].Qty != 5 {
+		t.Fatalf("expected snapshot at seq 3 with both ask levels, got %+v", snapshot)
+	}
+	if got := engine.OrderBookSnapshot("BTC-USD", 10).Seq; got != 4 {
+		t.Fatalf("expected book snapshot seq 4, got %d", got)
+	}
+}
+
+func TestBookDeltasEmittedOnCancel(t *testing.T) {
+	engine := NewEngine()
+	sink := &recordingBookSink{}
+	engine.SetBookEventSink(sink, 0)
+	engine.FundWallet("buyer1", "USD", 1000)
+
+	ack, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 99, Qty: 4})
+	if err != nil {
+		t.Fatalf("place order failed: %v", err)
+	}
+	if _, err := engine.CancelOrder("buyer1", ack.OrderID); err != nil {
+		t.Fatalf("cancel failed: %v", err)
+	}
+
+	if len(sink.deltas) != 2 {
+		t.Fatalf("expected add and remove deltas, got %+v", sink.deltas)
+	}
+	removed := sink.deltas[1]
+	if removed.Seq != 2 || removed.Side != SideBuy || removed.Price != 99 || removed.Qty != 0 {
+		t.Fatalf("expected level removal at seq 2, got %+v", removed)
+	}
+}
+
+func TestBookDeltasOnlyCoverTouchedLevels(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("buyer1", "USD", 10000)
+	var last OrderAck
+	for price := int64(90); price < 100; price++ {
+		last = mustPlace(t, engine, limitOrder("buyer1", SideBuy, price, 1))
+	}
+
+	// Levels resting before the sink was set are not re-sent when another
+	// level changes.
+	sink := &recordingBookSink{}
+	engine.SetBookEventSink(sink, 0)
+	if _, err := engine.CancelOrder("buyer1", last.OrderID); err != nil {
+		t.Fatalf("cancel failed: %v", err)
+	}
+	mustPlace(t, engine, limitOrder("buyer1", SideBuy, 95, 2))
+
+	want := []BookDelta{
+		{Seq: 1, Side: SideBuy, Price: 99},
+		{Seq: 2, Side: SideBuy, Price: 95, Qty: 3, Orders: 2},
+	}
+	if len(sink.deltas) != len(want) {
+		t.Fatalf("expected %d deltas, got %+v", len(want), sink.deltas)
+	}
+	for i, delta := range sink.deltas {
+		got := BookDelta{Seq: delta.Seq, Side: delta.Side, Price: delta.Price, Qty: delta.Qty, Orders: delta.Orders}
+		if got != want[i] {
+			t.Fatalf("delta %d: expected %+v, got %+v", i, want[i], delta)
+		}
+	}
+}
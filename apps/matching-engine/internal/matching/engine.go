@@ -20,13 +20,16 @@ const (
 type OrderType string
 
 const (
-	OrderTypeMarket OrderType = "MARKET"
-	OrderTypeLimit  OrderType = "LIMIT"
+	OrderTypeMarket     OrderType = "MARKET"
+	OrderTypeLimit      OrderType = "LIMIT"
+	OrderTypeStopMarket OrderType = "STOP_MARKET"
+	OrderTypeStopLimit  OrderType = "STOP_LIMIT"
 )
 
 type OrderStatus string
 
 const (
+	OrderStatusPending       OrderStatus = "PENDING"
 	OrderStatusAccepted      OrderStatus = "ACCEPTED"
 	OrderStatusPartiallyFill OrderStatus = "PARTIALLY_FILLED"
 	OrderStatusFilled        OrderStatus = "FILLED"
@@ -46,6 +49,7 @@ type PlaceOrderRequest struct {
 	Side          Side      `json:"side"`
 	Type          OrderType `json:"type"`
 	Price         int64     `json:"price,omitempty"`
+	StopPrice     int64     `json:"stopPrice,omitempty"`
 	Qty           int64     `json:"qty"`
 }
 
@@ -57,21 +61,32 @@ type OrderAck struct {
 	AvgPrice      int64       `json:"avgPrice"`
 	ClientOrderID string      `json:"clientOrderId,omitempty"`
 	Symbol        string      `json:"symbol,omitempty"`
+	ListID        string      `json:"listId,omitempty"`
+	RejectCode    RejectCode  `json:"rejectCode,omitempty"`
+	RejectReason  string      `json:"rejectReason,omitempty"`
 	TS            time.Time   `json:"ts"`
 }
 
 type Order struct {
-	OrderID       string    `json:"orderId"`
-	ClientOrderID string    `json:"clientOrderId"`
-	UserID        string    `json:"userId"`
-	Symbol        string    `json:"symbol"`
-	Side          Side      `json:"side"`
-	Type          OrderType `json:"type"`
-	Price         int64     `json:"price"`
-	Qty           int64     `json:"qty"`
-	RemainingQty  int64     `json:"remainingQty"`
-	CreatedAt     time.Time `json:"createdAt"`
-	seq           int64
+	OrderID        string          `json:"orderId"`
+	ClientOrderID  string          `json:"clientOrderId"`
+	UserID         string          `json:"userId"`
+	Symbol         string          `json:"symbol"`
+	Side           Side            `json:"side"`
+	Type           OrderType       `json:"type"`
+	Price          int64           `json:"price"`
+	StopPrice      int64           `json:"stopPrice,omitempty"`
+	Triggered      bool            `json:"triggered,omitempty"`
+	Qty            int64           `json:"qty"`
+	RemainingQty   int64           `json:"remainingQty"`
+	ListID         string          `json:"listId,omitempty"`
+	ListRole       OrderListRole   `json:"listRole,omitempty"`
+	ListStatus     OrderListStatus `json:"listStatus,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	seq            int64
+	status         OrderStatus
+	cumQty         int64
+	filledNotional int64
 
 	BaseAsset        string `json:"-"`
 	QuoteAsset       string `json:"-"`
@@ -106,6 +121,7 @@ type BookLevel struct {
 
 type OrderBookSnapshot struct {
 	Symbol string      `json:"symbol"`
+	Seq    int64       `json:"seq"`
 	Bids   []BookLevel `json:"bids"`
 	Asks   []BookLevel `json:"asks"`
 	TS     time.Time   `json:"ts"`
@@ -120,21 +136,96 @@ type ExecutionSink interface {
 	PublishExecution(ctx context.Context, execution Execution) error
 }
 
+// ExecutionSinks publishes every execution to each sink in turn and returns
+// the first error.
+type ExecutionSinks []ExecutionSink
+
+func (s ExecutionSinks) PublishExecution(ctx context.Context, execution Execution) error {
+	var first error
+	for _, sink := range s {
+		if err := sink.PublishExecution(ctx, execution); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
 type orderBook struct {
-	bids []*Order
-	asks []*Order
+	bids  []*Order
+	asks  []*Order
+	stops []*Order
 }
 
 type Engine struct {
 	mu              sync.Mutex
+	publishMu       sync.Mutex
 	books           map[string]*orderBook
 	ordersByUser    map[string]map[string]*Order
 	executions      map[string][]Execution
 	wallets         map[string]*Wallet
+	lists           map[string]*orderList
+	lastPrices      map[string]int64
+	instruments     map[string]Instrument
 	openOrdersStore OpenOrdersStore
 	executionSink   ExecutionSink
+	bookSink        BookEventSink
+	tickerSink      TickerSink
+	userEventSink   UserEventSink
+	tickers         map[string]*tickerState
+	bookFeeds       map[string]*bookFeedState
 	orderSeq        int64
 	tradeSeq        int64
+	listSeq         int64
+
+	defaultRiskLimits RiskLimits
+	userRiskLimits    map[string]RiskLimits
+	risk              map[string]*userRisk
+
+	killSwitches    map[string]KillSwitch
+	killSwitchStore KillSwitchStore
+	// killSwitchMu orders kill switch writes so the store and memory agree
+	// without holding mu across store calls.
+	killSwitchMu sync.Mutex
+
+	faucet           map[string]int64
+	fundingLimits    map[string]FundingLimits
+	fundingOps       map[string]*FundingOperation
+	fundingLog       []*FundingOperation
+	fundingHistory   map[string][]*FundingOperation
+	fundingByRequest map[fundingKey]*FundingOperation
+	fundingSeq       int64
+
+	bookSnapshotInterval int64
+}
+
+// eventBatch collects the side effects of one engine call so they can be
+// published after the engine lock is released.
+type eventBatch struct {
+	touchedUsers map[string]struct{}
+	executions   []Execution
+	filledLegs   []*Order
+	symbols      map[string]struct{}
+
+	// dirtyLevels are the price levels, by symbol, whose resting orders
+	// changed; only they are diffed into book deltas.
+	dirtyLevels   map[string]map[bookLevelKey]struct{}
+	bookDeltas    []BookDelta
+	bookSnapshots []OrderBookSnapshot
+	orderEvents   []OrderEvent
+	tickers       []Ticker
+
+	changedOrders map[*Order]struct{}
+	orderChanges  []*Order
+	orderUpdates  []OrderUpdate
+	walletUpdates []Wallet
+}
+
+func newEventBatch() *eventBatch {
+	return &eventBatch{
+		touchedUsers:  make(map[string]struct{}),
+		symbols:       make(map[string]struct{}),
+		changedOrders: make(map[*Order]struct{}),
+	}
 }
 
 func NewEngine() *Engine {
@@ -151,164 +242,118 @@ func NewEngineWithStoreAndSink(openOrdersStore OpenOrdersStore, executionSink Ex
 		ordersByUser:    make(map[string]map[string]*Order),
 		executions:      make(map[string][]Execution),
 		wallets:         make(map[string]*Wallet),
+		lists:           make(map[string]*orderList),
+		lastPrices:      make(map[string]int64),
+		instruments:     make(map[string]Instrument),
+		bookFeeds:       make(map[string]*bookFeedState),
+		tickers:         make(map[string]*tickerState),
+		userRiskLimits:  make(map[string]RiskLimits),
+		risk:            make(map[string]*userRisk),
+		killSwitches:    make(map[string]KillSwitch),
 		openOrdersStore: openOrdersStore,
 		executionSink:   executionSink,
+
+		faucet:           defaultFaucet(),
+		fundingLimits:    make(map[string]FundingLimits),
+		fundingOps:       make(map[string]*FundingOperation),
+		fundingHistory:   make(map[string][]*FundingOperation),
+		fundingByRequest: make(map[fundingKey]*FundingOperation),
 	}
 }
 
+func (e *Engine) SetExecutionSink(sink ExecutionSink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.executionSink = sink
+}
+
 func (e *Engine) PlaceOrder(req PlaceOrderRequest) (OrderAck, error) {
 	e.mu.Lock()
 
-	if err := validate(req); err != nil {
-		e.mu.Unlock()
+	// A settlement error can stop matching after earlier fills changed the
+	// book and wallets, so the batch is published either way.
+	batch := newEventBatch()
+	ack, err := e.placeOrderLocked(req, batch)
+	e.unlockAndPublish(batch)
+	if err != nil {
 		return OrderAck{}, err
 	}
+	return ack, nil
+}
 
-	baseAsset, quoteAsset, _ := parseSymbol(req.Symbol)
+func (e *Engine) placeOrderLocked(req PlaceOrderRequest, batch *eventBatch) (OrderAck, error) {
+	if err := validate(req); err != nil {
+		return OrderAck{}, err
+	}
 
-	e.orderSeq++
-	order := &Order{
-		OrderID:       fmt.Sprintf("ord-%d", e.orderSeq),
-		ClientOrderID: req.ClientOrderID,
-		UserID:        req.UserID,
-		Symbol:        req.Symbol,
-		Side:          req.Side,
-		Type:          req.Type,
-		Price:         req.Price,
-		Qty:           req.Qty,
-		RemainingQty:  req.Qty,
-		CreatedAt:     time.Now().UTC(),
-		seq:           e.orderSeq,
-		BaseAsset:     baseAsset,
-		QuoteAsset:    quoteAsset,
+	// Risk rejections are acks, so clients get the order ID and a code.
+	rejection := e.checkKillSwitchLocked(req.UserID, req.Symbol)
+	if rejection == nil {
+		rejection = e.checkRiskLocked(req, time.Now())
+	}
+	if rejection != nil {
+		order := e.newOrderLocked(req)
+		order.RemainingQty = 0
+		order.status = OrderStatusRejected
+		ack := orderAck(order)
+		ack.RejectCode = rejection.Code
+		ack.RejectReason = rejection.Reason
+		return ack, nil
 	}
 
+	order := e.newOrderLocked(req)
 	book := e.ensureBook(req.Symbol)
 	if err := e.reserveForOrderLocked(order, book); err != nil {
-		e.mu.Unlock()
 		return OrderAck{}, err
 	}
 
-	filled, avgPrice, touchedUsers, matchedExecutions, err := e.match(book, order)
+	err := e.submitLocked(book, order, batch)
+	batch.touchedUsers[order.UserID] = struct{}{}
+	batch.symbols[order.Symbol] = struct{}{}
 	if err != nil {
-		e.releaseOrderReservationLocked(order)
-		e.mu.Unlock()
 		return OrderAck{}, err
 	}
-	touchedUsers[order.UserID] = struct{}{}
+	e.processContingenciesLocked(batch)
+	return orderAck(order), nil
+}
 
-	if order.Type == OrderTypeLimit && order.RemainingQty > 0 {
-		e.addToBook(book, order)
-		e.trackOpenOrder(order)
-	}
+func (e *Engine) CancelOrder(userID, orderID string) (OrderAck, error) {
+	e.mu.Lock()
 
-	if order.Type == OrderTypeMarket && filled == 0 {
-		e.releaseOrderReservationLocked(order)
+	batch := newEventBatch()
+	ack, err := e.cancelOrderByIDLocked(userID, orderID, batch)
+	if err != nil {
 		e.mu.Unlock()
-		return OrderAck{}, errors.New("no liquidity for market order")
-	}
-
-	if order.Type == OrderTypeMarket || order.RemainingQty == 0 {
-		e.releaseOrderReservationLocked(order)
-	}
-
-	status := OrderStatusAccepted
-	switch {
-	case filled > 0 && order.RemainingQty == 0:
-		status = OrderStatusFilled
-	case filled > 0 && order.RemainingQty > 0:
-		status = OrderStatusPartiallyFill
-	}
-
-	ack := OrderAck{
-		OrderID:       order.OrderID,
-		Status:        status,
-		FilledQty:     filled,
-		RemainingQty:  order.RemainingQty,
-		AvgPrice:      avgPrice,
-		ClientOrderID: order.ClientOrderID,
-		Symbol:        order.Symbol,
-		TS:            time.Now().UTC(),
-	}
-
-	snapshots := make(map[string][]Order)
-	if e.openOrdersStore != nil {
-		for userID := range touchedUsers {
-			snapshots[userID] = e.openOrdersSnapshotLocked(userID)
-		}
-	}
-
-	e.mu.Unlock()
-
-	if e.openOrdersStore != nil {
-		ctx := context.Background()
-		for userID, orders := range snapshots {
-			_ = e.openOrdersStore.SetUserOrders(ctx, userID, orders)
-		}
-	}
-
-	if e.executionSink != nil {
-		ctx := context.Background()
-		for _, execution := range matchedExecutions {
-			_ = e.executionSink.PublishExecution(ctx, execution)
-		}
+		return OrderAck{}, err
 	}
-
+	e.unlockAndPublish(batch)
 	return ack, nil
 }
 
-func (e *Engine) CancelOrder(userID, orderID string) (OrderAck, error) {
-	e.mu.Lock()
-
+func (e *Engine) cancelOrderByIDLocked(userID, orderID string, batch *eventBatch) (OrderAck, error) {
 	byUser, ok := e.ordersByUser[userID]
 	if !ok {
-		e.mu.Unlock()
-		return OrderAck{}, errors.New("order not found")
+		return OrderAck{}, ErrOrderNotFound
 	}
 
 	order, ok := byUser[orderID]
 	if !ok {
-		e.mu.Unlock()
-		return OrderAck{}, errors.New("order not found")
+		return OrderAck{}, ErrOrderNotFound
 	}
 
 	book := e.books[order.Symbol]
 	if book == nil {
-		e.mu.Unlock()
-		return OrderAck{}, errors.New("order book not found")
+		return OrderAck{}, ErrOrderNotFound
 	}
 
-	remainingBeforeCancel := order.RemainingQty
-	filledQty := order.Qty - remainingBeforeCancel
-
-	e.removeFromBook(book, order)
-	e.removeOpenOrder(order)
-	e.releaseOrderReservationLocked(order)
-	order.RemainingQty = 0
-
-	ack := OrderAck{
-		OrderID:       order.OrderID,
-		Status:        OrderStatusCanceled,
-		FilledQty:     filledQty,
-		RemainingQty:  0,
-		AvgPrice:      0,
-		ClientOrderID: order.ClientOrderID,
-		Symbol:        order.Symbol,
-		TS:            time.Now().UTC(),
+	e.cancelOrderLocked(book, order, batch)
+	batch.touchedUsers[userID] = struct{}{}
+	batch.symbols[order.Symbol] = struct{}{}
+	if order.ListID != "" {
+		e.onListLegCanceledLocked(order, batch)
 	}
-
-	snapshot := []Order{}
-	if e.openOrdersStore != nil {
-		snapshot = e.openOrdersSnapshotLocked(userID)
-	}
-
-	e.mu.Unlock()
-
-	if e.openOrdersStore != nil {
-		_ = e.openOrdersStore.SetUserOrders(context.Background(), userID, snapshot)
-	}
-
-	return ack, nil
+	e.processContingenciesLocked(batch)
+	return orderAck(order), nil
 }
 
 func (e *Engine) OpenOrders(userID string) []Order {
@@ -370,6 +415,9 @@ func (e *Engine) OrderBookSnapshot(symbol string, depth int) OrderBookSnapshot {
 		Asks:   []BookLevel{},
 		TS:     time.Now().UTC(),
 	}
+	if state, ok := e.bookFeeds[symbol]; ok {
+		snapshot.Seq = state.seq
+	}
 
 	book, ok := e.books[symbol]
 	if !ok || book == nil {
@@ -381,6 +429,9 @@ func (e *Engine) OrderBookSnapshot(symbol string, depth int) OrderBookSnapshot {
 	return snapshot
 }
 
+// FundWallet credits userID directly, skipping funding limits and review. It
+// is how operators and the simulator fund accounts; the credit still shows in
+// the funding history.
 func (e *Engine) FundWallet(userID, asset string, amount int64) {
 	if amount <= 0 {
 		return
@@ -391,11 +442,15 @@ func (e *Engine) FundWallet(userID, asset string, amount int64) {
 	}
 
 	e.mu.Lock()
-	defer e.mu.Unlock()
-
+	now := time.Now().UTC()
 	wallet := e.ensureWalletLocked(userID)
 	wallet.Available[asset] += amount
-	wallet.UpdatedAt = time.Now().UTC()
+	wallet.UpdatedAt = now
+	e.recordFundingLocked(FundingDeposit, FundingCompleted, "", userID, "", asset, amount, now)
+
+	batch := newEventBatch()
+	batch.touchedUsers[userID] = struct{}{}
+	e.unlockAndPublish(batch)
 }
 
 func (e *Engine) Wallet(userID string) Wallet {
@@ -422,15 +477,28 @@ func validate(req PlaceOrderRequest) error {
 	if req.Side != SideBuy && req.Side != SideSell {
 		return errors.New("side must be BUY or SELL")
 	}
-	if req.Type != OrderTypeLimit && req.Type != OrderTypeMarket {
-		return errors.New("type must be LIMIT or MARKET")
+	switch req.Type {
+	case OrderTypeLimit, OrderTypeMarket, OrderTypeStopLimit, OrderTypeStopMarket:
+	default:
+		return errors.New("type must be LIMIT, MARKET, STOP_LIMIT or STOP_MARKET")
 	}
-	if req.Type == OrderTypeLimit && req.Price <= 0 {
-		return errors.New("price must be positive for LIMIT order")
+	if req.Type.hasLimitPrice() && req.Price <= 0 {
+		return fmt.Errorf("price must be positive for %s order", req.Type)
+	}
+	if req.Type.isStop() && req.StopPrice <= 0 {
+		return fmt.Errorf("stopPrice must be positive for %s order", req.Type)
 	}
 	return nil
 }
 
+func (t OrderType) hasLimitPrice() bool {
+	return t == OrderTypeLimit || t == OrderTypeStopLimit
+}
+
+func (t OrderType) isStop() bool {
+	return t == OrderTypeStopLimit || t == OrderTypeStopMarket
+}
+
 func parseSymbol(symbol string) (string, string, error) {
 	parts := strings.Split(symbol, "-")
 	if len(parts) != 2 {
@@ -444,6 +512,29 @@ func parseSymbol(symbol string) (string, string, error) {
 	return base, quote, nil
 }
 
+func (e *Engine) newOrderLocked(req PlaceOrderRequest) *Order {
+	baseAsset, quoteAsset, _ := parseSymbol(req.Symbol)
+
+	e.orderSeq++
+	return &Order{
+		OrderID:       fmt.Sprintf("ord-%d", e.orderSeq),
+		ClientOrderID: req.ClientOrderID,
+		UserID:        req.UserID,
+		Symbol:        req.Symbol,
+		Side:          req.Side,
+		Type:          req.Type,
+		Price:         req.Price,
+		StopPrice:     req.StopPrice,
+		Qty:           req.Qty,
+		RemainingQty:  req.Qty,
+		CreatedAt:     time.Now().UTC(),
+		seq:           e.orderSeq,
+		status:        OrderStatusAccepted,
+		BaseAsset:     baseAsset,
+		QuoteAsset:    quoteAsset,
+	}
+}
+
 func (e *Engine) ensureBook(symbol string) *orderBook {
 	book, ok := e.books[symbol]
 	if !ok {
@@ -469,9 +560,153 @@ func (e *Engine) removeOpenOrder(order *Order) {
 	}
 }
 
-func (e *Engine) match(book *orderBook, taker *Order) (filledQty int64, avgPrice int64, touchedUsers map[string]struct{}, matchedExecutions []Execution, err error) {
-	touchedUsers = make(map[string]struct{})
-	var weightedNotional int64
+func (e *Engine) isOpenLocked(order *Order) bool {
+	byUser, ok := e.ordersByUser[order.UserID]
+	if !ok {
+		return false
+	}
+	_, ok = byUser[order.OrderID]
+	return ok
+}
+
+// submitLocked matches an already reserved order against the book and rests
+// any remainder. Untriggered stop orders are parked until their stop price
+// trades.
+func (e *Engine) submitLocked(book *orderBook, order *Order, batch *eventBatch) error {
+	batch.orderChanged(order)
+	if order.Type.isStop() && !order.Triggered {
+		book.stops = append(book.stops, order)
+		e.trackOpenOrder(order)
+		batch.symbols[order.Symbol] = struct{}{}
+		return nil
+	}
+
+	filled, err := e.match(book, order, batch)
+	if err != nil {
+		e.releaseOrderReservationLocked(order)
+		order.status = OrderStatusRejected
+		return err
+	}
+
+	limitPriced := order.Type.hasLimitPrice()
+	if limitPriced && order.RemainingQty > 0 {
+		e.addToBook(book, order)
+		e.trackOpenOrder(order)
+		e.recordOrderEventLocked(batch, OrderEventAdd, order, 0, "")
+	}
+
+	if !limitPriced && filled == 0 {
+		e.releaseOrderReservationLocked(order)
+		order.status = OrderStatusRejected
+		return ErrNoLiquidity
+	}
+
+	if !limitPriced || order.RemainingQty == 0 {
+		e.releaseOrderReservationLocked(order)
+	}
+
+	if filled > 0 && order.ListID != "" {
+		batch.filledLegs = append(batch.filledLegs, order)
+	}
+	return nil
+}
+
+func (e *Engine) cancelOrderLocked(book *orderBook, order *Order, batch *eventBatch) {
+	resting := bookContains(book, order)
+	e.removeFromBook(book, order)
+	e.removeOpenOrder(order)
+	e.releaseOrderReservationLocked(order)
+	order.RemainingQty = 0
+	order.status = OrderStatusCanceled
+	batch.orderChanged(order)
+	if resting {
+		e.recordOrderEventLocked(batch, OrderEventDelete, order, 0, "")
+	}
+}
+
+// processContingenciesLocked resolves order-list legs that received fills and
+// triggers stop orders whose stop price has traded, repeating until the books
+// settle because each step can produce new fills.
+func (e *Engine) processContingenciesLocked(batch *eventBatch) {
+	for {
+		if len(batch.filledLegs) > 0 {
+			leg := batch.filledLegs[0]
+			batch.filledLegs = batch.filledLegs[1:]
+			e.onListLegFilledLocked(leg, batch)
+			continue
+		}
+
+		stop := e.nextTriggeredStopLocked(batch)
+		if stop == nil {
+			return
+		}
+		e.triggerStopLocked(stop, batch)
+	}
+}
+
+func (e *Engine) nextTriggeredStopLocked(batch *eventBatch) *Order {
+	for _, symbol := range sortedKeys(batch.symbols) {
+		lastPrice, ok := e.lastPrices[symbol]
+		if !ok {
+			continue
+		}
+		book := e.books[symbol]
+		if book == nil {
+			continue
+		}
+
+		var triggered *Order
+		for _, stop := range book.stops {
+			if !stopTriggered(stop, lastPrice) {
+				continue
+			}
+			if triggered == nil || stop.seq < triggered.seq {
+				triggered = stop
+			}
+		}
+		if triggered != nil {
+			return triggered
+		}
+	}
+	return nil
+}
+
+func stopTriggered(order *Order, lastPrice int64) bool {
+	if order.Side == SideBuy {
+		return lastPrice >= order.StopPrice
+	}
+	return lastPrice <= order.StopPrice
+}
+
+func (e *Engine) triggerStopLocked(stop *Order, batch *eventBatch) {
+	book := e.books[stop.Symbol]
+	e.removeFromBook(book, stop)
+	e.removeOpenOrder(stop)
+	stop.Triggered = true
+	batch.touchedUsers[stop.UserID] = struct{}{}
+
+	if stop.ListID != "" {
+		e.cancelListSiblingsLocked(stop, batch)
+	}
+
+	if stop.ReservedBaseQty == 0 && stop.ReservedQuoteQty == 0 {
+		if err := e.reserveForOrderLocked(stop, book); err != nil {
+			stop.RemainingQty = 0
+			stop.status = OrderStatusRejected
+			batch.orderChanged(stop)
+			e.finishListIfDoneLocked(stop.ListID)
+			return
+		}
+	}
+
+	if err := e.submitLocked(book, stop, batch); err != nil {
+		stop.RemainingQty = 0
+	}
+	e.finishListIfDoneLocked(stop.ListID)
+}
+
+func (e *Engine) match(book *orderBook, taker *Order, batch *eventBatch) (filledQty int64, err error) {
+	policy := e.instrumentLocked(taker.Symbol).MatchingPolicy
 
 	for taker.RemainingQty > 0 {
 		maker := e.bestMatch(book, taker)
@@ -479,44 +714,128 @@ func (e *Engine) match(book *orderBook, taker *Order) (filledQty int64, avgPrice
 			break
 		}
 
-		tradeQty := minInt64(taker.RemainingQty, maker.RemainingQty)
-		tradePrice := maker.Price
+		if policy == MatchingPolicyFIFO {
+			tradeQty := minInt64(taker.RemainingQty, maker.RemainingQty)
+			if err := e.fillLocked(book, taker, maker, tradeQty, batch); err != nil {
+				return 0, err
+			}
+			filledQty += tradeQty
+			continue
+		}
 
-		if err := e.settleTradeLocked(taker, maker, tradeQty, tradePrice); err != nil {
-			return 0, 0, nil, nil, err
+		level := priceLevel(book, maker)
+		resting := make([]int64, len(level))
+		for i, order := range level {
+			resting[i] = order.RemainingQty
+		}
+		allocations := allocateProRata(resting, taker.RemainingQty, policy == MatchingPolicyProRataTopOrder)
+		for i, order := range level {
+			if allocations[i] == 0 {
+				continue
+			}
+			if err := e.fillLocked(book, taker, order, allocations[i], batch); err != nil {
+				return 0, err
+			}
+			filledQty += allocations[i]
 		}
+	}
 
-		taker.RemainingQty -= tradeQty
-		maker.RemainingQty -= tradeQty
-		filledQty += tradeQty
-		weightedNotional += tradeQty * tradePrice
-		touchedUsers[maker.UserID] = struct{}{}
+	if filledQty > 0 {
+		taker.status = fillStatus(taker)
+	}
+	return filledQty, nil
+}
 
-		e.tradeSeq++
-		execution := Execution{
-			TradeID:      fmt.Sprintf("trd-%d", e.tradeSeq),
-			Symbol:       taker.Symbol,
-			Price:        tradePrice,
-			Qty:          tradeQty,
-			MakerOrderID: maker.OrderID,
-			MakerUserID:  maker.UserID,
-			TakerOrderID: taker.OrderID,
-			TakerUserID:  taker.UserID,
-			TS:           time.Now().UTC(),
-		}
-		e.executions[taker.Symbol] = append(e.executions[taker.Symbol], execution)
-		matchedExecutions = append(matchedExecutions, execution)
+// priceLevel returns the resting orders sharing the best order's price, in
+// time priority.
+func priceLevel(book *orderBook, best *Order) []*Order {
+	side := book.asks
+	if best.Side == SideBuy {
+		side = book.bids
+	}
 
-		if maker.RemainingQty == 0 {
-			e.removeFromBook(book, maker)
-			e.removeOpenOrder(maker)
+	level := make([]*Order, 0, 4)
+	for _, order := range side {
+		if order.Price != best.Price {
+			break
 		}
+		level = append(level, order)
 	}
+	return level
+}
 
-	if filledQty > 0 {
-		avgPrice = weightedNotional / filledQty
+func (e *Engine) fillLocked(book *orderBook, taker *Order, maker *Order, tradeQty int64, batch *eventBatch) error {
+	tradePrice := maker.Price
+	if err := e.settleTradeLocked(taker, maker, tradeQty, tradePrice); err != nil {
+		return err
+	}
+
+	taker.RemainingQty -= tradeQty
+	maker.RemainingQty -= tradeQty
+	taker.cumQty += tradeQty
+	maker.cumQty += tradeQty
+	taker.filledNotional += tradeQty * tradePrice
+	maker.filledNotional += tradeQty * tradePrice
+	maker.status = fillStatus(maker)
+	batch.orderChanged(taker)
+	batch.orderChanged(maker)
+	batch.touchedUsers[maker.UserID] = struct{}{}
+	batch.symbols[taker.Symbol] = struct{}{}
+	e.lastPrices[taker.Symbol] = tradePrice
+
+	e.tradeSeq++
+	execution := Execution{
+		TradeID:      fmt.Sprintf("trd-%d", e.tradeSeq),
+		Symbol:       taker.Symbol,
+		Price:        tradePrice,
+		Qty:          tradeQty,
+		MakerOrderID: maker.OrderID,
+		MakerUserID:  maker.UserID,
+		TakerOrderID: taker.OrderID,
+		TakerUserID:  taker.UserID,
+		TS:           time.Now().UTC(),
+	}
+	e.executions[taker.Symbol] = append(e.executions[taker.Symbol], execution)
+	e.recordRiskFillLocked(taker, tradeQty, tradePrice, execution.TS)
+	e.recordRiskFillLocked(maker, tradeQty, tradePrice, execution.TS)
+	e.recordTickerTradeLocked(taker.Symbol, tradePrice, tradeQty, execution.TS)
+	batch.executions = append(batch.executions, execution)
+	e.recordOrderEventLocked(batch, OrderEventExecute, maker, tradeQty, execution.TradeID)
+
+	if maker.RemainingQty == 0 {
+		e.removeFromBook(book, maker)
+		e.removeOpenOrder(maker)
+	}
+	if maker.ListID != "" {
+		batch.filledLegs = append(batch.filledLegs, maker)
+	}
+	return nil
+}
+
+func fillStatus(order *Order) OrderStatus {
+	if order.RemainingQty == 0 {
+		return OrderStatusFilled
+	}
+	return OrderStatusPartiallyFill
+}
+
+func orderAck(order *Order) OrderAck {
+	var avgPrice int64
+	if order.cumQty > 0 {
+		avgPrice = order.filledNotional / order.cumQty
+	}
+
+	return OrderAck{
+		OrderID:       order.OrderID,
+		Status:        order.status,
+		FilledQty:     order.cumQty,
+		RemainingQty:  order.RemainingQty,
+		AvgPrice:      avgPrice,
+		ClientOrderID: order.ClientOrderID,
+		Symbol:        order.Symbol,
+		ListID:        order.ListID,
+		TS:            time.Now().UTC(),
 	}
-	return filledQty, avgPrice, touchedUsers, matchedExecutions, nil
 }
 
 func (e *Engine) settleTradeLocked(taker *Order, maker *Order, tradeQty int64, tradePrice int64) error {
@@ -539,14 +858,14 @@ func (e *Engine) settleTradeLocked(taker *Order, maker *Order, tradeQty int64, t
 
 	if buyer.ReservedQuoteQty > 0 {
 		reserveRelease := notional
-		if buyer.Type == OrderTypeLimit {
+		if buyer.Type.hasLimitPrice() {
 			reserveRelease = buyer.Price * tradeQty
 		}
 		if reserveRelease > buyer.ReservedQuoteQty {
 			reserveRelease = buyer.ReservedQuoteQty
 		}
 		if buyerWallet.Reserved[quoteAsset] < reserveRelease {
-			return errors.New("buyer reserved quote balance underflow")
+			return NewError(ErrorInternal, "buyer reserved quote balance underflow")
 		}
 
 		buyerWallet.Reserved[quoteAsset] -= reserveRelease
@@ -558,13 +877,13 @@ func (e *Engine) settleTradeLocked(taker *Order, maker *Order, tradeQty int64, t
 		case reserveRelease < notional:
 			extra := notional - reserveRelease
 			if buyerWallet.Available[quoteAsset] < extra {
-				return errors.New("insufficient quote balance")
+				return insufficientBalance("quote", quoteAsset)
 			}
 			buyerWallet.Available[quoteAsset] -= extra
 		}
 	} else {
 		if buyerWallet.Available[quoteAsset] < notional {
-			return errors.New("insufficient quote balance")
+			return insufficientBalance("quote", quoteAsset)
 		}
 		buyerWallet.Available[quoteAsset] -= notional
 	}
@@ -574,7 +893,7 @@ func (e *Engine) settleTradeLocked(taker *Order, maker *Order, tradeQty int64, t
 	if seller.ReservedBaseQty > 0 {
 		release := minInt64(tradeQty, seller.ReservedBaseQty)
 		if sellerWallet.Reserved[baseAsset] < release {
-			return errors.New("seller reserved base balance underflow")
+			return NewError(ErrorInternal, "seller reserved base balance underflow")
 		}
 		sellerWallet.Reserved[baseAsset] -= release
 		seller.ReservedBaseQty -= release
@@ -582,13 +901,13 @@ func (e *Engine) settleTradeLocked(taker *Order, maker *Order, tradeQty int64, t
 		if release < tradeQty {
 			shortfall := tradeQty - release
 			if sellerWallet.Available[baseAsset] < shortfall {
-				return errors.New("insufficient base balance")
+				return insufficientBalance("base", baseAsset)
 			}
 			sellerWallet.Available[baseAsset] -= shortfall
 		}
 	} else {
 		if sellerWallet.Available[baseAsset] < tradeQty {
-			return errors.New("insufficient base balance")
+			return insufficientBalance("base", baseAsset)
 		}
 		sellerWallet.Available[baseAsset] -= tradeQty
 	}
@@ -600,44 +919,43 @@ func (e *Engine) settleTradeLocked(taker *Order, maker *Order, tradeQty int64, t
 }
 
 func (e *Engine) reserveForOrderLocked(order *Order, book *orderBook) error {
-	wallet := e.ensureWalletLocked(order.UserID)
-	baseAsset := order.BaseAsset
-	quoteAsset := order.QuoteAsset
-
 	if order.Side == SideSell {
-		if wallet.Available[baseAsset] < order.Qty {
-			return errors.New("insufficient base balance")
-		}
-		wallet.Available[baseAsset] -= order.Qty
-		wallet.Reserved[baseAsset] += order.Qty
-		wallet.UpdatedAt = time.Now().UTC()
-		order.ReservedBaseQty = order.Qty
-		return nil
+		return e.reserveLocked(order, order.BaseAsset, order.RemainingQty)
 	}
 
-	if order.Type == OrderTypeLimit {
-		required := order.Price * order.Qty
-		if wallet.Available[quoteAsset] < required {
-			return errors.New("insufficient quote balance")
-		}
-		wallet.Available[quoteAsset] -= required
-		wallet.Reserved[quoteAsset] += required
-		wallet.UpdatedAt = time.Now().UTC()
-		order.ReservedQuoteQty = required
+	if order.Type.hasLimitPrice() {
+		return e.reserveLocked(order, order.QuoteAsset, order.Price*order.RemainingQty)
+	}
+
+	// Stop-market buys cannot be priced until they trigger; they reserve
+	// against the book at that point.
+	if order.Type.isStop() && !order.Triggered {
 		return nil
 	}
 
-	required := estimateMarketBuyNotional(book, order.Qty)
+	required := estimateMarketBuyNotional(book, order.RemainingQty)
 	if required == 0 {
 		return nil
 	}
-	if wallet.Available[quoteAsset] < required {
-		return errors.New("insufficient quote balance")
+	return e.reserveLocked(order, order.QuoteAsset, required)
+}
+
+func (e *Engine) reserveLocked(order *Order, asset string, amount int64) error {
+	wallet := e.ensureWalletLocked(order.UserID)
+	if wallet.Available[asset] < amount {
+		if asset == order.BaseAsset {
+			return insufficientBalance("base", asset)
+		}
+		return insufficientBalance("quote", asset)
 	}
-	wallet.Available[quoteAsset] -= required
-	wallet.Reserved[quoteAsset] += required
+	wallet.Available[asset] -= amount
+	wallet.Reserved[asset] += amount
 	wallet.UpdatedAt = time.Now().UTC()
-	order.ReservedQuoteQty = required
+	if asset == order.BaseAsset {
+		order.ReservedBaseQty += amount
+	} else {
+		order.ReservedQuoteQty += amount
+	}
 	return nil
 }
 
@@ -679,22 +997,27 @@ func (e *Engine) ensureWalletLocked(userID string) *Wallet {
 	if !ok {
 		wallet = &Wallet{
 			UserID:    userID,
-			Available: map[string]int64{defaultQuoteAsset: defaultQuoteBalance},
+			Available: map[string]int64{},
 			Reserved:  map[string]int64{},
 			UpdatedAt: time.Now().UTC(),
 		}
+		// Sub-accounts are funded by transfers from their parent.
+		if !isSubAccount(userID) {
+			e.grantFaucetLocked(wallet)
+		}
 		e.wallets[userID] = wallet
 	}
 	return wallet
 }
 
 func (e *Engine) bestMatch(book *orderBook, taker *Order) *Order {
+	limitPriced := taker.Type.hasLimitPrice()
 	if taker.Side == SideBuy {
 		if len(book.asks) == 0 {
 			return nil
 		}
 		bestAsk := book.asks[0]
-		if taker.Type == OrderTypeLimit && taker.Price < bestAsk.Price {
+		if limitPriced && taker.Price < bestAsk.Price {
 			return nil
 		}
 		return bestAsk
@@ -704,7 +1027,7 @@ func (e *Engine) bestMatch(book *orderBook, taker *Order) *Order {
 		return nil
 	}
 	bestBid := book.bids[0]
-	if taker.Type == OrderTypeLimit && taker.Price > bestBid.Price {
+	if limitPriced && taker.Price > bestBid.Price {
 		return nil
 	}
 	return bestBid
@@ -732,21 +1055,24 @@ func (e *Engine) addToBook(book *orderBook, order *Order) {
 }
 
 func (e *Engine) removeFromBook(book *orderBook, order *Order) {
+	if order.Type.isStop() && !order.Triggered {
+		book.stops = removeOrder(book.stops, order)
+		return
+	}
 	if order.Side == SideBuy {
-		for i := range book.bids {
-			if book.bids[i].OrderID == order.OrderID {
-				book.bids = append(book.bids[:i], book.bids[i+1:]...)
-				return
-			}
-		}
+		book.bids = removeOrder(book.bids, order)
 		return
 	}
-	for i := range book.asks {
-		if book.asks[i].OrderID == order.OrderID {
-			book.asks = append(book.asks[:i], book.asks[i+1:]...)
-			return
+	book.asks = removeOrder(book.asks, order)
+}
+
+func removeOrder(entries []*Order, order *Order) []*Order {
+	for i := range entries {
+		if entries[i].OrderID == order.OrderID {
+			return append(entries[:i], entries[i+1:]...)
 		}
 	}
+	return entries
 }
 
 func aggregateBookLevels(entries []*Order, depth int) []BookLevel {
@@ -779,6 +1105,15 @@ func aggregateBookLevels(entries []*Order, depth int) []BookLevel {
 	return levels
 }
 
+func sortedKeys(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for key := range set {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
+
 func minInt64(a, b int64) int64 {
 	if a < b {
 		return a
@@ -817,8 +1152,84 @@ func (e *Engine) openOrdersSnapshotLocked(userID string) []Order {
 
 	list := make([]Order, 0, len(orders))
 	for _, o := range orders {
-		list = append(list, *o)
+		snapshot := *o
+		if orderList, ok := e.lists[o.ListID]; ok {
+			snapshot.ListStatus = orderList.Status
+		}
+		list = append(list, snapshot)
 	}
 	sortByCreatedAt(list)
 	return list
 }
+
+func (e *Engine) userSnapshotsLocked(batch *eventBatch) map[string][]Order {
+	snapshots := make(map[string][]Order)
+	if e.openOrdersStore == nil {
+		return snapshots
+	}
+	for userID := range batch.touchedUsers {
+		snapshots[userID] = e.openOrdersSnapshotLocked(userID)
+	}
+	return snapshots
+}
+
+// unlockAndPublish releases the engine lock and publishes the batch. The
+// publish lock is taken before the engine lock is released so book deltas
+// reach the sink in sequence order.
+func (e *Engine) unlockAndPublish(batch *eventBatch) {
+	e.collectBookDeltasLocked(batch)
+	e.collectTickersLocked(batch)
+	e.collectUserEventsLocked(batch)
+	snapshots := e.userSnapshotsLocked(batch)
+	e.publishMu.Lock()
+	e.mu.Unlock()
+
+	e.publish(snapshots, batch)
+	e.publishMu.Unlock()
+}
+
+func (e *Engine) publish(snapshots map[string][]Order, batch *eventBatch) {
+	if e.openOrdersStore != nil {
+		ctx := context.Background()
+		for userID, orders := range snapshots {
+			_ = e.openOrdersStore.SetUserOrders(ctx, userID, orders)
+		}
+	}
+
+	if e.executionSink != nil {
+		ctx := context.Background()
+		for _, execution := range batch.executions {
+			_ = e.executionSink.PublishExecution(ctx, execution)
+		}
+	}
+
+	if e.bookSink != nil {
+		ctx := context.Background()
+		for _, delta := range batch.bookDeltas {
+			_ = e.bookSink.PublishBookDelta(ctx, delta)
+		}
+		for _, snapshot := range batch.bookSnapshots {
+			_ = e.bookSink.PublishBookSnapshot(ctx, snapshot)
+		}
+		for _, event := range batch.orderEvents {
+			_ = e.bookSink.PublishOrderEvent(ctx, event)
+		}
+	}
+
+	if e.tickerSink != nil {
+		ctx := context.Background()
+		for _, ticker := range batch.tickers {
+			_ = e.tickerSink.PublishTicker(ctx, ticker)
+		}
+	}
+
+	if e.userEventSink != nil {
+		ctx := context.Background()
+		for _, update := range batch.orderUpdates {
+			_ = e.userEventSink.PublishOrderUpdate(ctx, update)
+		}
+		for _, wallet := range batch.walletUpdates {
+			_ = e.userEventSink.PublishWalletUpdate(ctx, wallet)
+		}
+	}
+}
@@ -68,3 +68,43 @@ func TestEnginePublishesExecutionsToSinkInMatchOrder(t *testing.T) {
 		t.Fatalf("expected second published event from seller2 qty=2, got maker=%s qty=%d", sink.events[1].MakerUserID, sink.events[1].Qty)
 	}
 }
+
+func TestEnginePublishesFillsBeforeAMidMatchSettlementError(t *testing.T) {
+	sink := &fakeExecutionSink{}
+	engine := NewEngineWithStoreAndSink(nil, sink)
+	engine.FundWallet("seller1", "BTC", 5)
+	engine.FundWallet("seller2", "BTC", 5)
+
+	for _, seller := range []string{"seller1", "seller2"} {
+		_, err := engine.PlaceOrder(PlaceOrderRequest{
+			ClientOrderID: "s-" + seller,
+			UserID:        seller,
+			Symbol:        "BTC-USD",
+			Side:          SideSell,
+			Type:          OrderTypeLimit,
+			Price:         100,
+			Qty:           5,
+		})
+		if err != nil {
+			t.Fatalf("seed order for %s failed: %v", seller, err)
+		}
+	}
+	// Break seller2's reservation so the second fill fails to settle.
+	engine.wallets["seller2"].Reserved["BTC"] = 0
+
+	_, err := engine.PlaceOrder(PlaceOrderRequest{
+		ClientOrderID: "b-1",
+		UserID:        "buyer1",
+		Symbol:        "BTC-USD",
+		Side:          SideBuy,
+		Type:          OrderTypeMarket,
+		Qty:           7,
+	})
+	if err == nil {
+		t.Fatal("expected the second fill to fail")
+	}
+
+	if len(sink.events) != 1 || sink.events[0].MakerUserID != "seller1" || sink.events[0].Qty != 5 {
+		t.Fatalf("expected the settled seller1 fill to be published, got %+v", sink.events)
+	}
+}
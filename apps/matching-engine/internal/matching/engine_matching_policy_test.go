@@ -0,0 +1,113 @@
+package matching
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllocateProRata(t *testing.T) {
+	cases := []struct {
+		name     string
+		resting  []int64
+		qty      int64
+		topOrder bool
+		want     []int64
+	}{
+		{name: "exact split", resting: []int64{10, 30}, qty: 20, want: []int64{5, 15}},
+		{name: "rounding leftover goes by time priority", resting: []int64{1, 1, 1}, qty: 2, want: []int64{1, 1, 0}},
+		{name: "floor then leftover", resting: []int64{5, 3, 2}, qty: 7, want: []int64{4, 2, 1}},
+		{name: "qty exceeds level", resting: []int64{2, 3}, qty: 9, want: []int64{2, 3}},
+		{name: "top order filled first", resting: []int64{4, 10, 30}, qty: 24, topOrder: true, want: []int64{4, 5, 15}},
+		{name: "top order absorbs everything", resting: []int64{10, 10}, qty: 6, topOrder: true, want: []int64{6, 0}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := allocateProRata(tc.resting, tc.qty, tc.topOrder)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func seedLevel(t *testing.T, engine *Engine) {
+	t.Helper()
+	for _, seller := range []struct {
+		userID string
+		qty    int64
+	}{{"seller1", 2}, {"seller2", 6}, {"seller3", 12}} {
+		engine.FundWallet(seller.userID, "BTC", seller.qty)
+		_, err := engine.PlaceOrder(PlaceOrderRequest{
+			UserID: seller.userID,
+			Symbol: "BTC-USD",
+			Side:   SideSell,
+			Type:   OrderTypeLimit,
+			Price:  100,
+			Qty:    seller.qty,
+		})
+		if err != nil {
+			t.Fatalf("seed %s failed: %v", seller.userID, err)
+		}
+	}
+
+	_, err := engine.PlaceOrder(PlaceOrderRequest{
+		UserID: "buyer1",
+		Symbol: "BTC-USD",
+		Side:   SideBuy,
+		Type:   OrderTypeMarket,
+		Qty:    10,
+	})
+	if err != nil {
+		t.Fatalf("market buy failed: %v", err)
+	}
+}
+
+func filledByMaker(engine *Engine) map[string]int64 {
+	out := map[string]int64{}
+	for _, execution := range engine.Executions("BTC-USD") {
+		out[execution.MakerUserID] += execution.Qty
+	}
+	return out
+}
+
+func TestMatchingPoliciesAllocateLevelDifferently(t *testing.T) {
+	cases := []struct {
+		policy MatchingPolicy
+		want   map[string]int64
+	}{
+		{policy: MatchingPolicyFIFO, want: map[string]int64{"seller1": 2, "seller2": 6, "seller3": 2}},
+		{policy: MatchingPolicyProRata, want: map[string]int64{"seller1": 1, "seller2": 3, "seller3": 6}},
+		{policy: MatchingPolicyProRataTopOrder, want: map[string]int64{"seller1": 2, "seller2": 3, "seller3": 5}},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			engine := NewEngine()
+			if _, err := engine.SetInstrument(Instrument{Symbol: "BTC-USD", MatchingPolicy: tc.policy}); err != nil {
+				t.Fatalf("set instrument failed: %v", err)
+			}
+			seedLevel(t, engine)
+
+			got := filledByMaker(engine)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected allocations %v, got %v", tc.want, got)
+			}
+
+			wallet := engine.Wallet("buyer1")
+			if wallet.Available["BTC"] != 10 || wallet.Reserved["USD"] != 0 {
+				t.Fatalf("expected buyer to hold 10 BTC with no reservation, got %+v", wallet)
+			}
+		})
+	}
+}
+
+func TestSetInstrumentRejectsUnknownPolicy(t *testing.T) {
+	engine := NewEngine()
+	if _, err := engine.SetInstrument(Instrument{Symbol: "BTC-USD", MatchingPolicy: "RANDOM"}); err == nil {
+		t.Fatal("expected error for unknown matching policy")
+	}
+	if got := engine.Instrument("BTC-USD").MatchingPolicy; got != MatchingPolicyFIFO {
+		t.Fatalf("expected default policy FIFO, got %s", got)
+	}
+}
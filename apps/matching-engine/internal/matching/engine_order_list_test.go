@@ -0,0 +1,287 @@
+package matching
+
+import "testing"
+
+func placeSellOCO(t *testing.T, engine *Engine, userID string, qty int64) OrderListAck {
+	t.Helper()
+	ack, err := engine.PlaceOrderList(PlaceOrderListRequest{
+		ClientListID: "oco-1",
+		UserID:       userID,
+		Symbol:       "BTC-USD",
+		Type:         OrderListTypeOCO,
+		Side:         SideSell,
+		Qty:          qty,
+		TakeProfit:   OrderLeg{ClientOrderID: "tp-1", Type: OrderTypeLimit, Price: 120},
+		StopLoss:     OrderLeg{ClientOrderID: "sl-1", Type: OrderTypeStopMarket, StopPrice: 90},
+	})
+	if err != nil {
+		t.Fatalf("place OCO failed: %v", err)
+	}
+	return ack
+}
+
+func TestOCOSharesReservationAcrossLegs(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("trader1", "BTC", 5)
+
+	ack := placeSellOCO(t, engine, "trader1", 5)
+	if ack.Status != OrderListStatusActive {
+		t.Fatalf("expected list status %s, got %s", OrderListStatusActive, ack.Status)
+	}
+	if len(ack.Orders) != 2 {
+		t.Fatalf("expected 2 leg acks, got %d", len(ack.Orders))
+	}
+
+	wallet := engine.Wallet("trader1")
+	if wallet.Reserved["BTC"] != 5 {
+		t.Fatalf("expected 5 BTC reserved once for both legs, got %d", wallet.Reserved["BTC"])
+	}
+	if wallet.Available["BTC"] != 0 {
+		t.Fatalf("expected 0 BTC available, got %d", wallet.Available["BTC"])
+	}
+
+	open := engine.OpenOrders("trader1")
+	if len(open) != 2 {
+		t.Fatalf("expected 2 open legs, got %d", len(open))
+	}
+	for _, order := range open {
+		if order.ListID != ack.ListID {
+			t.Fatalf("expected leg list id %s, got %s", ack.ListID, order.ListID)
+		}
+		if order.ListStatus != OrderListStatusActive {
+			t.Fatalf("expected leg list status %s, got %s", OrderListStatusActive, order.ListStatus)
+		}
+	}
+
+	book := engine.OrderBookSnapshot("BTC-USD", 10)
+	if len(book.Asks) != 1 || book.Asks[0].Price != 120 {
+		t.Fatalf("expected only the take-profit leg in the book, got %+v", book.Asks)
+	}
+}
+
+func TestOCOTakeProfitFillCancelsStopLoss(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("trader1", "BTC", 5)
+	placeSellOCO(t, engine, "trader1", 5)
+
+	_, err := engine.PlaceOrder(PlaceOrderRequest{
+		ClientOrderID: "b-1",
+		UserID:        "buyer1",
+		Symbol:        "BTC-USD",
+		Side:          SideBuy,
+		Type:          OrderTypeLimit,
+		Price:         120,
+		Qty:           2,
+	})
+	if err != nil {
+		t.Fatalf("buy order failed: %v", err)
+	}
+
+	open := engine.OpenOrders("trader1")
+	if len(open) != 1 {
+		t.Fatalf("expected only the take-profit remainder open, got %d orders", len(open))
+	}
+	if open[0].ListRole != OrderListRoleTakeProfit || open[0].RemainingQty != 3 {
+		t.Fatalf("expected take-profit with 3 remaining, got role=%s remaining=%d", open[0].ListRole, open[0].RemainingQty)
+	}
+
+	wallet := engine.Wallet("trader1")
+	if wallet.Reserved["BTC"] != 3 {
+		t.Fatalf("expected 3 BTC reserved, got %d", wallet.Reserved["BTC"])
+	}
+	if wallet.Available["USD"] != 100240 {
+		t.Fatalf("expected 100240 USD available, got %d", wallet.Available["USD"])
+	}
+}
+
+func TestOCOStopLossTriggerCancelsTakeProfit(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("trader1", "BTC", 5)
+	engine.FundWallet("seller2", "BTC", 1)
+	placeSellOCO(t, engine, "trader1", 5)
+
+	_, err := engine.PlaceOrder(PlaceOrderRequest{
+		ClientOrderID: "bid-1",
+		UserID:        "buyer1",
+		Symbol:        "BTC-USD",
+		Side:          SideBuy,
+		Type:          OrderTypeLimit,
+		Price:         90,
+		Qty:           6,
+	})
+	if err != nil {
+		t.Fatalf("bid failed: %v", err)
+	}
+
+	_, err = engine.PlaceOrder(PlaceOrderRequest{
+		ClientOrderID: "s-2",
+		UserID:        "seller2",
+		Symbol:        "BTC-USD",
+		Side:          SideSell,
+		Type:          OrderTypeMarket,
+		Qty:           1,
+	})
+	if err != nil {
+		t.Fatalf("trigger trade failed: %v", err)
+	}
+
+	if open := engine.OpenOrders("trader1"); len(open) != 0 {
+		t.Fatalf("expected no open legs after stop-loss fill, got %d", len(open))
+	}
+
+	execs := engine.Executions("BTC-USD")
+	if len(execs) != 2 {
+		t.Fatalf("expected trigger trade and stop-loss fill, got %d executions", len(execs))
+	}
+	if execs[1].TakerUserID != "trader1" || execs[1].Qty != 5 || execs[1].Price != 90 {
+		t.Fatalf("expected trader1 stop-loss to sell 5 @ 90, got %+v", execs[1])
+	}
+
+	wallet := engine.Wallet("trader1")
+	if wallet.Reserved["BTC"] != 0 || wallet.Available["BTC"] != 0 {
+		t.Fatalf("expected BTC fully sold, got available=%d reserved=%d", wallet.Available["BTC"], wallet.Reserved["BTC"])
+	}
+	if wallet.Available["USD"] != 100450 {
+		t.Fatalf("expected 100450 USD available, got %d", wallet.Available["USD"])
+	}
+}
+
+func TestCancelingOCOLegCancelsSibling(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("trader1", "BTC", 5)
+	ack := placeSellOCO(t, engine, "trader1", 5)
+
+	stopLossID := ack.Orders[1].OrderID
+	cancelAck, err := engine.CancelOrder("trader1", stopLossID)
+	if err != nil {
+		t.Fatalf("cancel leg failed: %v", err)
+	}
+	if cancelAck.ListID != ack.ListID {
+		t.Fatalf("expected cancel ack list id %s, got %s", ack.ListID, cancelAck.ListID)
+	}
+
+	if open := engine.OpenOrders("trader1"); len(open) != 0 {
+		t.Fatalf("expected both legs canceled, got %d open", len(open))
+	}
+	wallet := engine.Wallet("trader1")
+	if wallet.Reserved["BTC"] != 0 || wallet.Available["BTC"] != 5 {
+		t.Fatalf("expected BTC released, got available=%d reserved=%d", wallet.Available["BTC"], wallet.Reserved["BTC"])
+	}
+
+	if _, err := engine.CancelOrderList("trader1", ack.ListID); err == nil {
+		t.Fatal("expected finished list to be gone")
+	}
+}
+
+func TestBracketArmsExitsWhenEntryFills(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("seller1", "BTC", 2)
+
+	ack, err := engine.PlaceOrderList(PlaceOrderListRequest{
+		ClientListID: "br-1",
+		UserID:       "trader1",
+		Symbol:       "BTC-USD",
+		Type:         OrderListTypeBracket,
+		Side:         SideBuy,
+		Qty:          2,
+		Entry:        &OrderLeg{ClientOrderID: "entry-1", Type: OrderTypeLimit, Price: 100},
+		TakeProfit:   OrderLeg{ClientOrderID: "tp-1", Type: OrderTypeLimit, Price: 110},
+		StopLoss:     OrderLeg{ClientOrderID: "sl-1", Type: OrderTypeStopLimit, StopPrice: 95, Price: 94},
+	})
+	if err != nil {
+		t.Fatalf("place bracket failed: %v", err)
+	}
+	if ack.Status != OrderListStatusPending {
+		t.Fatalf("expected list status %s, got %s", OrderListStatusPending, ack.Status)
+	}
+	if ack.Orders[1].Status != OrderStatusPending || ack.Orders[2].Status != OrderStatusPending {
+		t.Fatalf("expected exit legs pending, got %s and %s", ack.Orders[1].Status, ack.Orders[2].Status)
+	}
+	if got := len(engine.OpenOrders("trader1")); got != 3 {
+		t.Fatalf("expected entry and two pending exits open, got %d", got)
+	}
+
+	_, err = engine.PlaceOrder(PlaceOrderRequest{
+		ClientOrderID: "s-1",
+		UserID:        "seller1",
+		Symbol:        "BTC-USD",
+		Side:          SideSell,
+		Type:          OrderTypeLimit,
+		Price:         100,
+		Qty:           2,
+	})
+	if err != nil {
+		t.Fatalf("sell order failed: %v", err)
+	}
+
+	open := engine.OpenOrders("trader1")
+	if len(open) != 2 {
+		t.Fatalf("expected two armed exits, got %d", len(open))
+	}
+	for _, order := range open {
+		if order.Side != SideSell || order.Qty != 2 {
+			t.Fatalf("expected SELL exit for 2, got side=%s qty=%d", order.Side, order.Qty)
+		}
+		if order.ListStatus != OrderListStatusActive {
+			t.Fatalf("expected list status %s, got %s", OrderListStatusActive, order.ListStatus)
+		}
+	}
+
+	wallet := engine.Wallet("trader1")
+	if wallet.Reserved["BTC"] != 2 {
+		t.Fatalf("expected 2 BTC reserved for exits, got %d", wallet.Reserved["BTC"])
+	}
+	if wallet.Reserved["USD"] != 0 {
+		t.Fatalf("expected entry USD reservation released, got %d", wallet.Reserved["USD"])
+	}
+}
+
+func TestCancelingUnfilledBracketEntryCancelsList(t *testing.T) {
+	engine := NewEngine()
+
+	ack, err := engine.PlaceOrderList(PlaceOrderListRequest{
+		UserID:     "trader1",
+		Symbol:     "BTC-USD",
+		Type:       OrderListTypeBracket,
+		Side:       SideBuy,
+		Qty:        2,
+		Entry:      &OrderLeg{Type: OrderTypeLimit, Price: 100},
+		TakeProfit: OrderLeg{Type: OrderTypeLimit, Price: 110},
+		StopLoss:   OrderLeg{Type: OrderTypeStopMarket, StopPrice: 95},
+	})
+	if err != nil {
+		t.Fatalf("place bracket failed: %v", err)
+	}
+
+	cancelAck, err := engine.CancelOrderList("trader1", ack.ListID)
+	if err != nil {
+		t.Fatalf("cancel list failed: %v", err)
+	}
+	if cancelAck.Status != OrderListStatusDone {
+		t.Fatalf("expected list status %s, got %s", OrderListStatusDone, cancelAck.Status)
+	}
+	if got := len(engine.OpenOrders("trader1")); got != 0 {
+		t.Fatalf("expected no open orders, got %d", got)
+	}
+	if wallet := engine.Wallet("trader1"); wallet.Available["USD"] != 100000 || wallet.Reserved["USD"] != 0 {
+		t.Fatalf("expected USD released, got available=%d reserved=%d", wallet.Available["USD"], wallet.Reserved["USD"])
+	}
+}
+
+func TestPlaceOrderListRejectsInvertedExitPrices(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("trader1", "BTC", 5)
+
+	_, err := engine.PlaceOrderList(PlaceOrderListRequest{
+		UserID:     "trader1",
+		Symbol:     "BTC-USD",
+		Type:       OrderListTypeOCO,
+		Side:       SideSell,
+		Qty:        5,
+		TakeProfit: OrderLeg{Type: OrderTypeLimit, Price: 80},
+		StopLoss:   OrderLeg{Type: OrderTypeStopMarket, StopPrice: 90},
+	})
+	if err == nil {
+		t.Fatal("expected error for take-profit below stop-loss on SELL exits")
+	}
+}
@@ -0,0 +1,67 @@
+package matching
+
+import "testing"
+
+func TestStopLimitOrderRestsUntilTriggered(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("seller1", "BTC", 10)
+
+	ack, err := engine.PlaceOrder(PlaceOrderRequest{
+		ClientOrderID: "stop-1",
+		UserID:        "buyer1",
+		Symbol:        "BTC-USD",
+		Side:          SideBuy,
+		Type:          OrderTypeStopLimit,
+		StopPrice:     105,
+		Price:         106,
+		Qty:           2,
+	})
+	if err != nil {
+		t.Fatalf("place stop failed: %v", err)
+	}
+	if ack.Status != OrderStatusAccepted {
+		t.Fatalf("expected %s, got %s", OrderStatusAccepted, ack.Status)
+	}
+	if wallet := engine.Wallet("buyer1"); wallet.Reserved["USD"] != 212 {
+		t.Fatalf("expected 212 USD reserved for stop-limit, got %d", wallet.Reserved["USD"])
+	}
+	if book := engine.OrderBookSnapshot("BTC-USD", 10); len(book.Bids) != 0 {
+		t.Fatalf("expected untriggered stop to stay out of the book, got %+v", book.Bids)
+	}
+
+	for _, req := range []PlaceOrderRequest{
+		{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 105, Qty: 1},
+		{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 106, Qty: 5},
+		{UserID: "buyer2", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeMarket, Qty: 1},
+	} {
+		if _, err := engine.PlaceOrder(req); err != nil {
+			t.Fatalf("place order failed: %v", err)
+		}
+	}
+
+	execs := engine.Executions("BTC-USD")
+	if len(execs) != 2 {
+		t.Fatalf("expected trigger trade and stop fill, got %d executions", len(execs))
+	}
+	if execs[1].TakerOrderID != ack.OrderID || execs[1].Price != 106 || execs[1].Qty != 2 {
+		t.Fatalf("expected triggered stop to buy 2 @ 106, got %+v", execs[1])
+	}
+	if open := engine.OpenOrders("buyer1"); len(open) != 0 {
+		t.Fatalf("expected triggered stop to be filled, got %d open", len(open))
+	}
+}
+
+func TestPlaceOrderRejectsStopWithoutStopPrice(t *testing.T) {
+	engine := NewEngine()
+
+	_, err := engine.PlaceOrder(PlaceOrderRequest{
+		UserID: "u1",
+		Symbol: "BTC-USD",
+		Side:   SideSell,
+		Type:   OrderTypeStopMarket,
+		Qty:    1,
+	})
+	if err == nil {
+		t.Fatal("expected error for missing stopPrice")
+	}
+}
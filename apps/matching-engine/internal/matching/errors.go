@@ -0,0 +1,110 @@
+package matching
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrorCode is the machine-readable code of an API error. Every service
+// answers errors as {code, message, details}, and clients branch on the code
+// rather than the message.
+type ErrorCode string
+
+const (
+	ErrorInvalidRequest    ErrorCode = "INVALID_REQUEST"
+	ErrorValidationFailed  ErrorCode = "VALIDATION_FAILED"
+	ErrorInsufficientFunds ErrorCode = "INSUFFICIENT_FUNDS"
+	ErrorNoLiquidity       ErrorCode = "NO_LIQUIDITY"
+	ErrorRiskLimitExceeded ErrorCode = "RISK_LIMIT_EXCEEDED"
+	ErrorFundingLimit      ErrorCode = "FUNDING_LIMIT_EXCEEDED"
+	ErrorOrderNotFound     ErrorCode = "ORDER_NOT_FOUND"
+	ErrorOrderListNotFound ErrorCode = "ORDER_LIST_NOT_FOUND"
+	ErrorOrderNotAmendable ErrorCode = "ORDER_NOT_AMENDABLE"
+	ErrorConflict          ErrorCode = "CONFLICT"
+	ErrorTradingDisabled   ErrorCode = "TRADING_DISABLED"
+	ErrorSymbolHalted      ErrorCode = "SYMBOL_HALTED"
+	ErrorNotFound          ErrorCode = "NOT_FOUND"
+	ErrorUnauthorized      ErrorCode = "UNAUTHORIZED"
+	ErrorMethodNotAllowed  ErrorCode = "METHOD_NOT_ALLOWED"
+	ErrorUnavailable       ErrorCode = "UNAVAILABLE"
+	ErrorInternal          ErrorCode = "INTERNAL"
+)
+
+// HTTPStatus is the status an error with the code is answered with.
+func (c ErrorCode) HTTPStatus() int {
+	switch c {
+	case ErrorInvalidRequest, ErrorValidationFailed:
+		return http.StatusBadRequest
+	case ErrorUnauthorized:
+		return http.StatusUnauthorized
+	case ErrorTradingDisabled:
+		return http.StatusForbidden
+	case ErrorOrderNotFound, ErrorOrderListNotFound, ErrorNotFound:
+		return http.StatusNotFound
+	case ErrorMethodNotAllowed:
+		return http.StatusMethodNotAllowed
+	case ErrorOrderNotAmendable, ErrorConflict:
+		return http.StatusConflict
+	case ErrorInsufficientFunds, ErrorNoLiquidity, ErrorRiskLimitExceeded, ErrorFundingLimit:
+		return http.StatusUnprocessableEntity
+	case ErrorSymbolHalted, ErrorUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Error is an engine error with its code. Details carries values a client may
+// act on, such as the asset that is short.
+type Error struct {
+	Code    ErrorCode         `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func NewError(code ErrorCode, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+var (
+	ErrOrderNotFound      = &Error{Code: ErrorOrderNotFound, Message: "order not found"}
+	ErrOrderListNotFound  = &Error{Code: ErrorOrderListNotFound, Message: "order list not found"}
+	ErrNoLiquidity        = &Error{Code: ErrorNoLiquidity, Message: "no liquidity for market order"}
+	ErrKillSwitchNotFound = &Error{Code: ErrorNotFound, Message: "kill switch not found"}
+	ErrFundingNotFound    = &Error{Code: ErrorNotFound, Message: "funding operation not found"}
+)
+
+func insufficientBalance(side, asset string) *Error {
+	return &Error{
+		Code:    ErrorInsufficientFunds,
+		Message: "insufficient " + side + " balance",
+		Details: map[string]string{"asset": asset},
+	}
+}
+
+// AsError gives any engine error its code. Risk rejections keep their reject
+// code in the details, and errors without a code are validation failures.
+func AsError(err error) *Error {
+	var engineErr *Error
+	if errors.As(err, &engineErr) {
+		return engineErr
+	}
+
+	var rejection *RiskRejection
+	if errors.As(err, &rejection) {
+		code := ErrorRiskLimitExceeded
+		switch {
+		case rejection.Code == RejectKillSwitch && rejection.halted:
+			code = ErrorSymbolHalted
+		case rejection.Code == RejectKillSwitch:
+			code = ErrorTradingDisabled
+		}
+		return &Error{Code: code, Message: rejection.Reason, Details: map[string]string{"rejectCode": string(rejection.Code)}}
+	}
+	return &Error{Code: ErrorValidationFailed, Message: err.Error()}
+}
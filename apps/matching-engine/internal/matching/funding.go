@@ -0,0 +1,385 @@
+package matching
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type FundingType string
+
+const (
+	FundingDeposit    FundingType = "DEPOSIT"
+	FundingWithdrawal FundingType = "WITHDRAWAL"
+	FundingTransfer   FundingType = "TRANSFER"
+)
+
+type FundingStatus string
+
+const (
+	FundingPending   FundingStatus = "PENDING"
+	FundingCompleted FundingStatus = "COMPLETED"
+	FundingRejected  FundingStatus = "REJECTED"
+)
+
+// faucetRequestPrefix starts the request IDs of the faucet grants a new
+// wallet gets, one per asset. Clients cannot use it, so their requests never
+// replay against a faucet grant.
+const faucetRequestPrefix = "faucet-"
+
+// FundingOperation is one deposit, withdrawal or transfer. ToUserID is only
+// set for transfers, which show in the history of both accounts.
+type FundingOperation struct {
+	ID        string        `json:"id"`
+	RequestID string        `json:"requestId,omitempty"`
+	Type      FundingType   `json:"type"`
+	Status    FundingStatus `json:"status"`
+	UserID    string        `json:"userId"`
+	ToUserID  string        `json:"toUserId,omitempty"`
+	Asset     string        `json:"asset"`
+	Amount    int64         `json:"amount"`
+	Reason    string        `json:"reason,omitempty"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+// FundingRequest deposits to or withdraws from UserID. The client picks
+// RequestID; retrying with the same one returns the first operation instead of
+// moving funds twice.
+type FundingRequest struct {
+	RequestID string `json:"requestId"`
+	UserID    string `json:"userId"`
+	Asset     string `json:"asset"`
+	Amount    int64  `json:"amount"`
+}
+
+// FundingLimits apply to each operation in one asset. An asset without a
+// MaxDeposit takes no deposits at all; a zero MaxWithdrawal or ReviewAbove
+// disables that limit. Deposits and withdrawals above ReviewAbove stay PENDING
+// until an operator completes or rejects them; a pending withdrawal holds its
+// funds as reserved.
+type FundingLimits struct {
+	MaxDeposit    int64 `json:"maxDeposit,omitempty"`
+	MaxWithdrawal int64 `json:"maxWithdrawal,omitempty"`
+	ReviewAbove   int64 `json:"reviewAbove,omitempty"`
+}
+
+// FaucetPolicy is what a new main account is credited with by asset.
+// Sub-accounts always start empty.
+type FaucetPolicy struct {
+	Balances map[string]int64 `json:"balances"`
+}
+
+// FundingConfig is the faucet policy and the limits by asset.
+type FundingConfig struct {
+	Faucet FaucetPolicy             `json:"faucet"`
+	Limits map[string]FundingLimits `json:"limits"`
+}
+
+var errReservedRequestID = errors.New("requestId must not start with " + faucetRequestPrefix)
+
+type fundingKey struct {
+	userID    string
+	requestID string
+}
+
+func defaultFaucet() map[string]int64 {
+	return map[string]int64{defaultQuoteAsset: defaultQuoteBalance}
+}
+
+func (l FundingLimits) validate() error {
+	if l.MaxDeposit < 0 || l.MaxWithdrawal < 0 || l.ReviewAbove < 0 {
+		return errors.New("funding limits must not be negative")
+	}
+	return nil
+}
+
+// SetFundingLimits replaces the limits of asset.
+func (e *Engine) SetFundingLimits(asset string, limits FundingLimits) error {
+	asset = normalizeAsset(asset)
+	if asset == "" {
+		return errors.New("asset is required")
+	}
+	if err := limits.validate(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fundingLimits[asset] = limits
+	return nil
+}
+
+func (e *Engine) ClearFundingLimits(asset string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.fundingLimits, normalizeAsset(asset))
+}
+
+// SetFaucetPolicy changes what wallets created from now on are credited with.
+// Empty balances turn the faucet off.
+func (e *Engine) SetFaucetPolicy(policy FaucetPolicy) error {
+	balances := make(map[string]int64, len(policy.Balances))
+	for asset, amount := range policy.Balances {
+		asset = normalizeAsset(asset)
+		if asset == "" {
+			return errors.New("faucet asset is required")
+		}
+		if amount < 0 {
+			return errors.New("faucet balances must not be negative")
+		}
+		if amount > 0 {
+			balances[asset] = amount
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.faucet = balances
+	return nil
+}
+
+func (e *Engine) FundingConfig() FundingConfig {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	faucet := make(map[string]int64, len(e.faucet))
+	for asset, amount := range e.faucet {
+		faucet[asset] = amount
+	}
+	limits := make(map[string]FundingLimits, len(e.fundingLimits))
+	for asset, assetLimits := range e.fundingLimits {
+		limits[asset] = assetLimits
+	}
+	return FundingConfig{Faucet: FaucetPolicy{Balances: faucet}, Limits: limits}
+}
+
+func (e *Engine) Deposit(req FundingRequest) (FundingOperation, error) {
+	return e.requestFunding(FundingDeposit, req)
+}
+
+// Withdraw takes the amount out of the available balance, or holds it as
+// reserved while the withdrawal is pending review.
+func (e *Engine) Withdraw(req FundingRequest) (FundingOperation, error) {
+	return e.requestFunding(FundingWithdrawal, req)
+}
+
+func (e *Engine) requestFunding(kind FundingType, req FundingRequest) (FundingOperation, error) {
+	req.RequestID = strings.TrimSpace(req.RequestID)
+	req.UserID = strings.TrimSpace(req.UserID)
+	req.Asset = normalizeAsset(req.Asset)
+	switch {
+	case req.RequestID == "":
+		return FundingOperation{}, errors.New("requestId is required")
+	case req.UserID == "":
+		return FundingOperation{}, errors.New("userId is required")
+	case req.Asset == "":
+		return FundingOperation{}, errors.New("asset is required")
+	case req.Amount <= 0:
+		return FundingOperation{}, errors.New("amount must be positive")
+	case strings.HasPrefix(req.RequestID, faucetRequestPrefix):
+		return FundingOperation{}, errReservedRequestID
+	}
+
+	e.mu.Lock()
+	if existing, ok := e.fundingByRequest[fundingKey{req.UserID, req.RequestID}]; ok {
+		replayed := *existing
+		e.mu.Unlock()
+		return replayFunding(replayed, kind, "", req.Asset, req.Amount)
+	}
+
+	limits := e.fundingLimits[req.Asset]
+	limit := limits.MaxDeposit
+	if kind == FundingWithdrawal {
+		limit = limits.MaxWithdrawal
+	} else if limit == 0 {
+		e.mu.Unlock()
+		return FundingOperation{}, &Error{
+			Code:    ErrorFundingLimit,
+			Message: "deposits of " + req.Asset + " are disabled until a deposit limit is set",
+			Details: map[string]string{"asset": req.Asset, "limit": "0"},
+		}
+	}
+	if limit > 0 && req.Amount > limit {
+		e.mu.Unlock()
+		return FundingOperation{}, &Error{
+			Code:    ErrorFundingLimit,
+			Message: fmt.Sprintf("%s of %d %s is above the limit of %d", strings.ToLower(string(kind)), req.Amount, req.Asset, limit),
+			Details: map[string]string{"asset": req.Asset, "limit": strconv.FormatInt(limit, 10)},
+		}
+	}
+
+	wallet := e.ensureWalletLocked(req.UserID)
+	if kind == FundingWithdrawal && wallet.Available[req.Asset] < req.Amount {
+		e.mu.Unlock()
+		return FundingOperation{}, &Error{
+			Code:    ErrorInsufficientFunds,
+			Message: "insufficient " + req.Asset + " balance to withdraw",
+			Details: map[string]string{"asset": req.Asset},
+		}
+	}
+
+	now := time.Now().UTC()
+	status := FundingCompleted
+	if limits.ReviewAbove > 0 && req.Amount > limits.ReviewAbove {
+		status = FundingPending
+	}
+	op := e.recordFundingLocked(kind, status, req.RequestID, req.UserID, "", req.Asset, req.Amount, now)
+
+	switch {
+	case kind == FundingDeposit && status == FundingCompleted:
+		wallet.Available[req.Asset] += req.Amount
+	case kind == FundingWithdrawal:
+		wallet.Available[req.Asset] -= req.Amount
+		if status == FundingPending {
+			wallet.Reserved[req.Asset] += req.Amount
+		}
+	}
+	wallet.UpdatedAt = now
+	result := *op
+
+	batch := newEventBatch()
+	batch.touchedUsers[req.UserID] = struct{}{}
+	e.unlockAndPublish(batch)
+	return result, nil
+}
+
+// replayFunding answers a retried request with the operation it created, as
+// long as the retry asks for the same thing.
+func replayFunding(existing FundingOperation, kind FundingType, toUserID, asset string, amount int64) (FundingOperation, error) {
+	if existing.Type != kind || existing.ToUserID != toUserID || existing.Asset != asset || existing.Amount != amount {
+		return FundingOperation{}, &Error{
+			Code:    ErrorConflict,
+			Message: "requestId " + existing.RequestID + " was already used for another operation",
+			Details: map[string]string{"operationId": existing.ID},
+		}
+	}
+	return existing, nil
+}
+
+func (e *Engine) CompleteFunding(id string) (FundingOperation, error) {
+	return e.settleFunding(id, FundingCompleted, "")
+}
+
+// RejectFunding gives a pending withdrawal's funds back; a rejected deposit
+// never credited anything.
+func (e *Engine) RejectFunding(id, reason string) (FundingOperation, error) {
+	return e.settleFunding(id, FundingRejected, strings.TrimSpace(reason))
+}
+
+func (e *Engine) settleFunding(id string, status FundingStatus, reason string) (FundingOperation, error) {
+	e.mu.Lock()
+	op, ok := e.fundingOps[strings.TrimSpace(id)]
+	if !ok {
+		e.mu.Unlock()
+		return FundingOperation{}, ErrFundingNotFound
+	}
+	if op.Status != FundingPending {
+		e.mu.Unlock()
+		return FundingOperation{}, NewError(ErrorConflict, "funding operation %s is already %s", op.ID, op.Status)
+	}
+
+	now := time.Now().UTC()
+	wallet := e.ensureWalletLocked(op.UserID)
+	switch op.Type {
+	case FundingDeposit:
+		if status == FundingCompleted {
+			wallet.Available[op.Asset] += op.Amount
+		}
+	case FundingWithdrawal:
+		wallet.Reserved[op.Asset] -= op.Amount
+		if status == FundingRejected {
+			wallet.Available[op.Asset] += op.Amount
+		}
+	}
+	wallet.UpdatedAt = now
+	op.Status = status
+	op.Reason = reason
+	op.UpdatedAt = now
+	result := *op
+
+	batch := newEventBatch()
+	batch.touchedUsers[op.UserID] = struct{}{}
+	e.unlockAndPublish(batch)
+	return result, nil
+}
+
+// PendingFunding lists the operations waiting for review, oldest first.
+func (e *Engine) PendingFunding() []FundingOperation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := []FundingOperation{}
+	for _, op := range e.fundingLog {
+		if op.Status == FundingPending {
+			out = append(out, *op)
+		}
+	}
+	return out
+}
+
+// FundingHistory lists the operations of userID, newest first. A limit of
+// zero or less lists them all.
+func (e *Engine) FundingHistory(userID string, limit int) []FundingOperation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entries := e.fundingHistory[strings.TrimSpace(userID)]
+	if limit <= 0 || limit > len(entries) {
+		limit = len(entries)
+	}
+	out := make([]FundingOperation, 0, limit)
+	for i := len(entries) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, *entries[i])
+	}
+	return out
+}
+
+func (e *Engine) recordFundingLocked(kind FundingType, status FundingStatus, requestID, userID, toUserID, asset string, amount int64, now time.Time) *FundingOperation {
+	e.fundingSeq++
+	op := &FundingOperation{
+		ID:        fmt.Sprintf("fnd-%d", e.fundingSeq),
+		RequestID: requestID,
+		Type:      kind,
+		Status:    status,
+		UserID:    userID,
+		ToUserID:  toUserID,
+		Asset:     asset,
+		Amount:    amount,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	e.fundingOps[op.ID] = op
+	e.fundingLog = append(e.fundingLog, op)
+	e.fundingHistory[userID] = append(e.fundingHistory[userID], op)
+	if toUserID != "" {
+		e.fundingHistory[toUserID] = append(e.fundingHistory[toUserID], op)
+	}
+	if requestID != "" {
+		e.fundingByRequest[fundingKey{userID, requestID}] = op
+	}
+	return op
+}
+
+// grantFaucetLocked credits a new main account with the faucet balances,
+// recorded as completed deposits.
+func (e *Engine) grantFaucetLocked(wallet *Wallet) {
+	assets := make([]string, 0, len(e.faucet))
+	for asset := range e.faucet {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	for _, asset := range assets {
+		amount := e.faucet[asset]
+		wallet.Available[asset] += amount
+		e.recordFundingLocked(FundingDeposit, FundingCompleted, faucetRequestPrefix+asset, wallet.UserID, "", asset, amount, wallet.UpdatedAt)
+	}
+}
+
+func normalizeAsset(asset string) string {
+	return strings.ToUpper(strings.TrimSpace(asset))
+}
@@ -0,0 +1,146 @@
+package matching
+
+import "testing"
+
+func TestFaucetPolicyFundsNewMainAccounts(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetFaucetPolicy(FaucetPolicy{Balances: map[string]int64{"usd": 500, "BTC": 2}}); err != nil {
+		t.Fatalf("set faucet failed: %v", err)
+	}
+
+	wallet := engine.Wallet("u1")
+	if wallet.Available["USD"] != 500 || wallet.Available["BTC"] != 2 {
+		t.Fatalf("unexpected faucet balances: %+v", wallet.Available)
+	}
+	history := engine.FundingHistory("u1", 0)
+	if len(history) != 2 || history[0].RequestID != "faucet-USD" || history[0].Type != FundingDeposit || history[0].Status != FundingCompleted {
+		t.Fatalf("expected faucet deposits in history, got %+v", history)
+	}
+	if sub := engine.Wallet(SubAccountUserID("u1", "sub-1")); len(sub.Available) != 0 {
+		t.Fatalf("expected empty sub-account wallet, got %+v", sub.Available)
+	}
+
+	if err := engine.SetFaucetPolicy(FaucetPolicy{}); err != nil {
+		t.Fatalf("disable faucet failed: %v", err)
+	}
+	if wallet := engine.Wallet("u2"); len(wallet.Available) != 0 {
+		t.Fatalf("expected no faucet grant, got %+v", wallet.Available)
+	}
+}
+
+func TestDepositsAreIdempotentAndLimited(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetFundingLimits("BTC", FundingLimits{MaxDeposit: 10, ReviewAbove: 5}); err != nil {
+		t.Fatalf("set limits failed: %v", err)
+	}
+
+	op, err := engine.Deposit(FundingRequest{RequestID: "d1", UserID: "u1", Asset: "btc", Amount: 3})
+	if err != nil || op.Status != FundingCompleted {
+		t.Fatalf("expected completed deposit, got %+v %v", op, err)
+	}
+	replayed, err := engine.Deposit(FundingRequest{RequestID: "d1", UserID: "u1", Asset: "BTC", Amount: 3})
+	if err != nil || replayed.ID != op.ID {
+		t.Fatalf("expected the retry to return %s, got %+v %v", op.ID, replayed, err)
+	}
+	if engine.Wallet("u1").Available["BTC"] != 3 {
+		t.Fatalf("expected the retry not to credit twice, got %+v", engine.Wallet("u1").Available)
+	}
+	if _, err := engine.Deposit(FundingRequest{RequestID: "d1", UserID: "u1", Asset: "BTC", Amount: 4}); AsError(err).Code != ErrorConflict {
+		t.Fatalf("expected CONFLICT for a reused request ID, got %v", err)
+	}
+	if _, err := engine.Deposit(FundingRequest{RequestID: "d2", UserID: "u1", Asset: "BTC", Amount: 11}); AsError(err).Code != ErrorFundingLimit {
+		t.Fatalf("expected FUNDING_LIMIT_EXCEEDED, got %v", err)
+	}
+
+	pending, err := engine.Deposit(FundingRequest{RequestID: "d3", UserID: "u1", Asset: "BTC", Amount: 8})
+	if err != nil || pending.Status != FundingPending {
+		t.Fatalf("expected pending deposit, got %+v %v", pending, err)
+	}
+	if engine.Wallet("u1").Available["BTC"] != 3 {
+		t.Fatal("expected a pending deposit not to credit")
+	}
+	if queue := engine.PendingFunding(); len(queue) != 1 || queue[0].ID != pending.ID {
+		t.Fatalf("unexpected pending queue: %+v", queue)
+	}
+	completed, err := engine.CompleteFunding(pending.ID)
+	if err != nil || completed.Status != FundingCompleted || engine.Wallet("u1").Available["BTC"] != 11 {
+		t.Fatalf("expected completed deposit to credit, got %+v %v", completed, err)
+	}
+	if _, err := engine.CompleteFunding(pending.ID); AsError(err).Code != ErrorConflict {
+		t.Fatalf("expected completing twice to conflict, got %v", err)
+	}
+}
+
+func TestRejectedWithdrawalReleasesHeldFunds(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetFundingLimits("USD", FundingLimits{ReviewAbove: 100}); err != nil {
+		t.Fatalf("set limits failed: %v", err)
+	}
+
+	op, err := engine.Withdraw(FundingRequest{RequestID: "w1", UserID: "u1", Asset: "USD", Amount: 1000})
+	if err != nil || op.Status != FundingPending {
+		t.Fatalf("expected pending withdrawal, got %+v %v", op, err)
+	}
+	wallet := engine.Wallet("u1")
+	if wallet.Available["USD"] != defaultQuoteBalance-1000 || wallet.Reserved["USD"] != 1000 {
+		t.Fatalf("expected the withdrawal to be held, got %+v", wallet)
+	}
+
+	rejected, err := engine.RejectFunding(op.ID, "manual review")
+	if err != nil || rejected.Status != FundingRejected || rejected.Reason != "manual review" {
+		t.Fatalf("unexpected rejection: %+v %v", rejected, err)
+	}
+	wallet = engine.Wallet("u1")
+	if wallet.Available["USD"] != defaultQuoteBalance || wallet.Reserved["USD"] != 0 {
+		t.Fatalf("expected held funds back, got %+v", wallet)
+	}
+
+	if _, err := engine.Withdraw(FundingRequest{RequestID: "w2", UserID: "u1", Asset: "BTC", Amount: 1}); AsError(err).Code != ErrorInsufficientFunds {
+		t.Fatalf("expected INSUFFICIENT_FUNDS, got %v", err)
+	}
+}
+
+func TestTransfersShowInBothHistories(t *testing.T) {
+	engine := NewEngine()
+	sub := SubAccountUserID("u1", "sub-1")
+
+	result, err := engine.Transfer(TransferRequest{RequestID: "t1", FromUserID: "u1", ToUserID: sub, Asset: "USD", Amount: 250})
+	if err != nil || result.Operation.Type != FundingTransfer {
+		t.Fatalf("transfer failed: %+v %v", result, err)
+	}
+	retried, err := engine.Transfer(TransferRequest{RequestID: "t1", FromUserID: "u1", ToUserID: sub, Asset: "USD", Amount: 250})
+	if err != nil || retried.Operation.ID != result.Operation.ID || retried.To.Available["USD"] != 250 {
+		t.Fatalf("expected the retry to return the first transfer, got %+v %v", retried, err)
+	}
+
+	if history := engine.FundingHistory("u1", 1); len(history) != 1 || history[0].ID != result.Operation.ID {
+		t.Fatalf("expected the transfer first in the sender's history, got %+v", history)
+	}
+	if history := engine.FundingHistory(sub, 0); len(history) != 1 || history[0].ToUserID != sub {
+		t.Fatalf("expected the transfer in the receiver's history, got %+v", history)
+	}
+}
+
+func TestDepositsNeedALimitAndFaucetRequestIDsAreReserved(t *testing.T) {
+	engine := NewEngine()
+	if _, err := engine.Deposit(FundingRequest{RequestID: "d1", UserID: "u1", Asset: "USD", Amount: 1}); AsError(err).Code != ErrorFundingLimit {
+		t.Fatalf("expected FUNDING_LIMIT_EXCEEDED without a deposit limit, got %v", err)
+	}
+	if err := engine.SetFundingLimits("USD", FundingLimits{MaxDeposit: 100}); err != nil {
+		t.Fatalf("set limits failed: %v", err)
+	}
+
+	engine.Wallet("u1")
+	if _, err := engine.Deposit(FundingRequest{RequestID: "faucet-USD", UserID: "u1", Asset: "USD", Amount: 1}); err == nil {
+		t.Fatal("expected a faucet- request ID to be rejected")
+	}
+	if _, err := engine.Withdraw(FundingRequest{RequestID: "faucet-BTC", UserID: "u1", Asset: "USD", Amount: 1}); err == nil {
+		t.Fatal("expected a faucet- request ID to be rejected")
+	}
+	if _, err := engine.Transfer(TransferRequest{RequestID: "faucet-USD", FromUserID: "u1", ToUserID: "u1:sub-1", Asset: "USD", Amount: 1}); err == nil {
+		t.Fatal("expected a faucet- transfer request ID to be rejected")
+	}
+	if history := engine.FundingHistory("u1", 0); len(history) != 1 || history[0].RequestID != "faucet-USD" {
+		t.Fatalf("expected only the faucet grant in history, got %+v", history)
+	}
+}
@@ -0,0 +1,126 @@
+package matching
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+type MatchingPolicy string
+
+const (
+	MatchingPolicyFIFO            MatchingPolicy = "FIFO"
+	MatchingPolicyProRata         MatchingPolicy = "PRO_RATA"
+	MatchingPolicyProRataTopOrder MatchingPolicy = "PRO_RATA_TOP_ORDER"
+)
+
+type Instrument struct {
+	Symbol         string         `json:"symbol"`
+	MatchingPolicy MatchingPolicy `json:"matchingPolicy"`
+}
+
+func ParseMatchingPolicy(raw string) (MatchingPolicy, error) {
+	policy := MatchingPolicy(strings.ToUpper(strings.TrimSpace(raw)))
+	switch policy {
+	case MatchingPolicyFIFO, MatchingPolicyProRata, MatchingPolicyProRataTopOrder:
+		return policy, nil
+	case "":
+		return MatchingPolicyFIFO, nil
+	default:
+		return "", errors.New("matchingPolicy must be FIFO, PRO_RATA or PRO_RATA_TOP_ORDER")
+	}
+}
+
+func (e *Engine) SetInstrument(instrument Instrument) (Instrument, error) {
+	instrument.Symbol = strings.TrimSpace(instrument.Symbol)
+	if _, _, err := parseSymbol(instrument.Symbol); err != nil {
+		return Instrument{}, err
+	}
+	policy, err := ParseMatchingPolicy(string(instrument.MatchingPolicy))
+	if err != nil {
+		return Instrument{}, err
+	}
+	instrument.MatchingPolicy = policy
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.instruments[instrument.Symbol] = instrument
+	return instrument, nil
+}
+
+func (e *Engine) Instrument(symbol string) Instrument {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.instrumentLocked(symbol)
+}
+
+func (e *Engine) Instruments() []Instrument {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := make([]Instrument, 0, len(e.instruments))
+	for _, instrument := range e.instruments {
+		out = append(out, instrument)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Symbol < out[j].Symbol
+	})
+	return out
+}
+
+func (e *Engine) instrumentLocked(symbol string) Instrument {
+	if instrument, ok := e.instruments[symbol]; ok {
+		return instrument
+	}
+	return Instrument{Symbol: symbol, MatchingPolicy: MatchingPolicyFIFO}
+}
+
+// allocateProRata splits qty across resting quantities listed in time
+// priority. Each order receives floor(qty*resting/total); the lots lost to
+// rounding are then handed out one at a time in time priority, so results are
+// deterministic and never exceed an order's resting quantity. With
+// topOrderPriority the first order is filled before the pro-rata split.
+func allocateProRata(resting []int64, qty int64, topOrderPriority bool) []int64 {
+	allocations := make([]int64, len(resting))
+	if len(resting) == 0 || qty <= 0 {
+		return allocations
+	}
+
+	start := 0
+	if topOrderPriority {
+		allocations[0] = minInt64(resting[0], qty)
+		qty -= allocations[0]
+		start = 1
+	}
+
+	var total int64
+	for _, restingQty := range resting[start:] {
+		total += restingQty
+	}
+	if qty <= 0 || total == 0 {
+		return allocations
+	}
+	if qty >= total {
+		for i := start; i < len(resting); i++ {
+			allocations[i] = resting[i]
+		}
+		return allocations
+	}
+
+	var allocated int64
+	for i := start; i < len(resting); i++ {
+		share := qty * resting[i] / total
+		allocations[i] = share
+		allocated += share
+	}
+
+	for leftover := qty - allocated; leftover > 0; {
+		for i := start; i < len(resting) && leftover > 0; i++ {
+			if allocations[i] < resting[i] {
+				allocations[i]++
+				leftover--
+			}
+		}
+	}
+	return allocations
+}
@@ -0,0 +1,225 @@
+package matching
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+type KillSwitchScope string
+
+const (
+	KillSwitchUser   KillSwitchScope = "USER"
+	KillSwitchSymbol KillSwitchScope = "SYMBOL"
+	KillSwitchGlobal KillSwitchScope = "GLOBAL"
+)
+
+// KillSwitch stops trading for a user, a symbol or, with the GLOBAL scope and
+// no target, everyone.
+type KillSwitch struct {
+	Scope     KillSwitchScope `json:"scope"`
+	Target    string          `json:"target,omitempty"`
+	Reason    string          `json:"reason,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// KillSwitchStore persists active kill switches so they survive restarts.
+type KillSwitchStore interface {
+	SaveKillSwitch(ctx context.Context, killSwitch KillSwitch) error
+	DeleteKillSwitch(ctx context.Context, scope KillSwitchScope, target string) error
+	LoadKillSwitches(ctx context.Context) ([]KillSwitch, error)
+}
+
+// killSwitchStoreTimeout bounds a store write; activating a kill switch
+// must not hang on a slow store.
+const killSwitchStoreTimeout = 2 * time.Second
+
+func killSwitchKey(scope KillSwitchScope, target string) string {
+	return string(scope) + ":" + target
+}
+
+func normalizeKillSwitch(scope KillSwitchScope, target string) (KillSwitchScope, string, error) {
+	scope = KillSwitchScope(strings.ToUpper(strings.TrimSpace(string(scope))))
+	target = strings.TrimSpace(target)
+	switch scope {
+	case KillSwitchGlobal:
+		return scope, "", nil
+	case KillSwitchUser, KillSwitchSymbol:
+		if target == "" {
+			return "", "", errors.New("kill switch target is required")
+		}
+		return scope, target, nil
+	default:
+		return "", "", errors.New("kill switch scope must be USER, SYMBOL or GLOBAL")
+	}
+}
+
+// SetKillSwitchStore persists kill switches in store from now on and
+// activates the ones it already holds.
+func (e *Engine) SetKillSwitchStore(ctx context.Context, store KillSwitchStore) error {
+	e.killSwitchMu.Lock()
+	defer e.killSwitchMu.Unlock()
+	stored, err := store.LoadKillSwitches(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.killSwitchStore = store
+	for _, killSwitch := range stored {
+		e.killSwitches[killSwitchKey(killSwitch.Scope, killSwitch.Target)] = killSwitch
+	}
+	return nil
+}
+
+// ActivateKillSwitch rejects new orders in its scope and cancels the resting
+// orders it covers, returning their acks. Activating an active switch again
+// updates its reason and cancels anything placed since.
+func (e *Engine) ActivateKillSwitch(killSwitch KillSwitch) (KillSwitch, []OrderAck, error) {
+	scope, target, err := normalizeKillSwitch(killSwitch.Scope, killSwitch.Target)
+	if err != nil {
+		return KillSwitch{}, nil, err
+	}
+	killSwitch.Scope = scope
+	killSwitch.Target = target
+	killSwitch.Reason = strings.TrimSpace(killSwitch.Reason)
+	if killSwitch.CreatedAt.IsZero() {
+		killSwitch.CreatedAt = time.Now().UTC()
+	}
+
+	e.killSwitchMu.Lock()
+	defer e.killSwitchMu.Unlock()
+	if store := e.currentKillSwitchStore(); store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), killSwitchStoreTimeout)
+		err := store.SaveKillSwitch(ctx, killSwitch)
+		cancel()
+		if err != nil {
+			return KillSwitch{}, nil, err
+		}
+	}
+
+	e.mu.Lock()
+	e.killSwitches[killSwitchKey(scope, target)] = killSwitch
+
+	type coveredOrder struct{ userID, orderID string }
+	covered := []coveredOrder{}
+	for userID, orders := range e.ordersByUser {
+		for orderID, order := range orders {
+			if killSwitchCovers(killSwitch, userID, order.Symbol) {
+				covered = append(covered, coveredOrder{userID: userID, orderID: orderID})
+			}
+		}
+	}
+	sort.Slice(covered, func(i, j int) bool { return covered[i].orderID < covered[j].orderID })
+
+	batch := newEventBatch()
+	canceled := make([]OrderAck, 0, len(covered))
+	for _, order := range covered {
+		// Canceling one leg of a list can already have canceled its siblings.
+		ack, err := e.cancelOrderByIDLocked(order.userID, order.orderID, batch)
+		if err == nil {
+			canceled = append(canceled, ack)
+		}
+	}
+	e.unlockAndPublish(batch)
+	return killSwitch, canceled, nil
+}
+
+func (e *Engine) ReleaseKillSwitch(scope KillSwitchScope, target string) error {
+	scope, target, err := normalizeKillSwitch(scope, target)
+	if err != nil {
+		return err
+	}
+
+	e.killSwitchMu.Lock()
+	defer e.killSwitchMu.Unlock()
+	key := killSwitchKey(scope, target)
+	e.mu.Lock()
+	_, ok := e.killSwitches[key]
+	store := e.killSwitchStore
+	e.mu.Unlock()
+	if !ok {
+		return ErrKillSwitchNotFound
+	}
+	if store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), killSwitchStoreTimeout)
+		err := store.DeleteKillSwitch(ctx, scope, target)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	e.mu.Lock()
+	delete(e.killSwitches, key)
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *Engine) currentKillSwitchStore() KillSwitchStore {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.killSwitchStore
+}
+
+func (e *Engine) KillSwitches() []KillSwitch {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := make([]KillSwitch, 0, len(e.killSwitches))
+	for _, killSwitch := range e.killSwitches {
+		out = append(out, killSwitch)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return killSwitchKey(out[i].Scope, out[i].Target) < killSwitchKey(out[j].Scope, out[j].Target)
+	})
+	return out
+}
+
+func killSwitchCovers(killSwitch KillSwitch, userID, symbol string) bool {
+	switch killSwitch.Scope {
+	case KillSwitchGlobal:
+		return true
+	case KillSwitchUser:
+		return killSwitch.Target == userID || killSwitch.Target == ParentUserID(userID)
+	case KillSwitchSymbol:
+		return killSwitch.Target == symbol
+	}
+	return false
+}
+
+// checkKillSwitchLocked rejects orders covered by an active kill switch, the
+// broadest scope first. A user's kill switch covers its sub-accounts.
+func (e *Engine) checkKillSwitchLocked(userID, symbol string) *RiskRejection {
+	if len(e.killSwitches) == 0 {
+		return nil
+	}
+	for _, key := range []string{
+		killSwitchKey(KillSwitchGlobal, ""),
+		killSwitchKey(KillSwitchSymbol, symbol),
+		killSwitchKey(KillSwitchUser, ParentUserID(userID)),
+		killSwitchKey(KillSwitchUser, userID),
+	} {
+		killSwitch, ok := e.killSwitches[key]
+		if !ok {
+			continue
+		}
+		reason := "trading is disabled"
+		switch killSwitch.Scope {
+		case KillSwitchSymbol:
+			reason = "trading is disabled on " + symbol
+		case KillSwitchUser:
+			reason = "trading is disabled for " + killSwitch.Target
+		}
+		if killSwitch.Reason != "" {
+			reason += ": " + killSwitch.Reason
+		}
+		rejection := rejectf(RejectKillSwitch, "%s", reason)
+		rejection.halted = killSwitch.Scope != KillSwitchUser
+		return rejection
+	}
+	return nil
+}
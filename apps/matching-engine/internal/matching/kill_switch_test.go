@@ -0,0 +1,155 @@
+package matching
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type memoryKillSwitchStore struct {
+	saved map[string]KillSwitch
+}
+
+func (s *memoryKillSwitchStore) SaveKillSwitch(_ context.Context, killSwitch KillSwitch) error {
+	s.saved[killSwitchKey(killSwitch.Scope, killSwitch.Target)] = killSwitch
+	return nil
+}
+
+func (s *memoryKillSwitchStore) DeleteKillSwitch(_ context.Context, scope KillSwitchScope, target string) error {
+	delete(s.saved, killSwitchKey(scope, target))
+	return nil
+}
+
+func (s *memoryKillSwitchStore) LoadKillSwitches(context.Context) ([]KillSwitch, error) {
+	out := []KillSwitch{}
+	for _, killSwitch := range s.saved {
+		out = append(out, killSwitch)
+	}
+	return out, nil
+}
+
+func TestKillSwitchCancelsRestingOrdersAndRejectsNewOnes(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("bot", "BTC", 10)
+	mustPlace(t, engine, limitOrder("bot", SideBuy, 10, 1))
+	mustPlace(t, engine, limitOrder("bot", SideSell, 20, 1))
+	mustPlace(t, engine, limitOrder("u2", SideBuy, 9, 1))
+
+	killSwitch, canceled, err := engine.ActivateKillSwitch(KillSwitch{Scope: "user", Target: "bot", Reason: "runaway"})
+	if err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	if killSwitch.Scope != KillSwitchUser || len(canceled) != 2 || canceled[0].Status != OrderStatusCanceled {
+		t.Fatalf("expected both bot orders canceled, got %+v %+v", killSwitch, canceled)
+	}
+	if len(engine.OpenOrders("bot")) != 0 || len(engine.OpenOrders("u2")) != 1 {
+		t.Fatal("expected only the bot's orders canceled")
+	}
+	if wallet := engine.Wallet("bot"); wallet.Reserved["USD"] != 0 || wallet.Reserved["BTC"] != 0 {
+		t.Fatalf("expected reservations released, got %+v", wallet)
+	}
+
+	ack, err := engine.PlaceOrder(limitOrder("bot", SideBuy, 10, 1))
+	if err != nil || ack.RejectCode != RejectKillSwitch || ack.RejectReason != "trading is disabled for bot: runaway" {
+		t.Fatalf("expected KILL_SWITCH rejection, got %+v err=%v", ack, err)
+	}
+	_, err = engine.PlaceOrderList(PlaceOrderListRequest{
+		UserID: "bot", Symbol: "BTC-USD", Type: OrderListTypeOCO, Side: SideSell, Qty: 1,
+		TakeProfit: OrderLeg{Type: OrderTypeLimit, Price: 30},
+		StopLoss:   OrderLeg{Type: OrderTypeStopMarket, StopPrice: 5},
+	})
+	var rejection *RiskRejection
+	if !errors.As(err, &rejection) || rejection.Code != RejectKillSwitch {
+		t.Fatalf("expected order list KILL_SWITCH rejection, got %v", err)
+	}
+
+	if err := engine.ReleaseKillSwitch(KillSwitchUser, "bot"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	mustPlace(t, engine, limitOrder("bot", SideBuy, 10, 1))
+	if err := engine.ReleaseKillSwitch(KillSwitchUser, "bot"); err != ErrKillSwitchNotFound {
+		t.Fatalf("expected ErrKillSwitchNotFound, got %v", err)
+	}
+}
+
+func TestKillSwitchSymbolAndGlobalScopes(t *testing.T) {
+	engine := NewEngine()
+	mustPlace(t, engine, limitOrder("u1", SideBuy, 10, 1))
+
+	if _, canceled, err := engine.ActivateKillSwitch(KillSwitch{Scope: KillSwitchSymbol, Target: "ETH-USD"}); err != nil || len(canceled) != 0 {
+		t.Fatalf("expected nothing canceled on another symbol, got %+v err=%v", canceled, err)
+	}
+	mustPlace(t, engine, limitOrder("u1", SideBuy, 10, 1))
+
+	if _, canceled, _ := engine.ActivateKillSwitch(KillSwitch{Scope: KillSwitchGlobal}); len(canceled) != 2 {
+		t.Fatalf("expected every order canceled, got %+v", canceled)
+	}
+	if ack, _ := engine.PlaceOrder(limitOrder("u2", SideBuy, 10, 1)); ack.RejectCode != RejectKillSwitch {
+		t.Fatalf("expected global rejection, got %+v", ack)
+	}
+	if _, _, err := engine.ActivateKillSwitch(KillSwitch{Scope: KillSwitchUser}); err == nil {
+		t.Fatal("expected a user kill switch without a target to be refused")
+	}
+}
+
+func TestKillSwitchesSurviveRestartThroughStore(t *testing.T) {
+	store := &memoryKillSwitchStore{saved: map[string]KillSwitch{}}
+	engine := NewEngine()
+	if err := engine.SetKillSwitchStore(context.Background(), store); err != nil {
+		t.Fatalf("set store: %v", err)
+	}
+	_, _, _ = engine.ActivateKillSwitch(KillSwitch{Scope: KillSwitchUser, Target: "bot"})
+
+	restarted := NewEngine()
+	if err := restarted.SetKillSwitchStore(context.Background(), store); err != nil {
+		t.Fatalf("set store: %v", err)
+	}
+	if ack, _ := restarted.PlaceOrder(limitOrder("bot", SideBuy, 10, 1)); ack.RejectCode != RejectKillSwitch {
+		t.Fatalf("expected the kill switch to survive a restart, got %+v", ack)
+	}
+	if err := restarted.ReleaseKillSwitch(KillSwitchUser, "bot"); err != nil || len(store.saved) != 0 {
+		t.Fatalf("expected release to delete the stored switch, err=%v saved=%+v", err, store.saved)
+	}
+}
+
+// blockingKillSwitchStore holds every save until release is closed.
+type blockingKillSwitchStore struct {
+	memoryKillSwitchStore
+	saving  chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingKillSwitchStore) SaveKillSwitch(ctx context.Context, killSwitch KillSwitch) error {
+	close(s.saving)
+	<-s.release
+	return s.memoryKillSwitchStore.SaveKillSwitch(ctx, killSwitch)
+}
+
+func TestKillSwitchStoreWritesDoNotBlockTrading(t *testing.T) {
+	engine := NewEngine()
+	store := &blockingKillSwitchStore{
+		memoryKillSwitchStore: memoryKillSwitchStore{saved: map[string]KillSwitch{}},
+		saving:                make(chan struct{}),
+		release:               make(chan struct{}),
+	}
+	if err := engine.SetKillSwitchStore(context.Background(), store); err != nil {
+		t.Fatalf("set store: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := engine.ActivateKillSwitch(KillSwitch{Scope: KillSwitchUser, Target: "bot"})
+		done <- err
+	}()
+	<-store.saving
+
+	// The engine lock is free while the store write is in flight.
+	mustPlace(t, engine, limitOrder("u2", SideBuy, 9, 1))
+	close(store.release)
+	if err := <-done; err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	if ack, _ := engine.PlaceOrder(limitOrder("bot", SideBuy, 10, 1)); ack.RejectCode != RejectKillSwitch {
+		t.Fatalf("expected the kill switch active once saved, got %+v", ack)
+	}
+}
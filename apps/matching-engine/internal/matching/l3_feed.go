@@ -0,0 +1,118 @@
+package matching
+
+import (
+	"time"
+)
+
+type OrderEventType string
+
+const (
+	OrderEventAdd     OrderEventType = "ADD"
+	OrderEventModify  OrderEventType = "MODIFY"
+	OrderEventDelete  OrderEventType = "DELETE"
+	OrderEventExecute OrderEventType = "EXECUTE"
+)
+
+// OrderEvent is one change to a resting order in the level-3 feed. Qty is the
+// order's resting quantity after the event; EXECUTE also carries the traded
+// quantity. Owners are never exposed, so the feed carries no user or client IDs.
+type OrderEvent struct {
+	Symbol  string         `json:"symbol"`
+	Seq     int64          `json:"seq"`
+	Type    OrderEventType `json:"type"`
+	OrderID string         `json:"orderId"`
+	Side    Side           `json:"side"`
+	Price   int64          `json:"price"`
+	Qty     int64          `json:"qty"`
+	ExecQty int64          `json:"execQty,omitempty"`
+	TradeID string         `json:"tradeId,omitempty"`
+	TS      time.Time      `json:"ts"`
+}
+
+type L3Order struct {
+	OrderID string `json:"orderId"`
+	Side    Side   `json:"side"`
+	Price   int64  `json:"price"`
+	Qty     int64  `json:"qty"`
+}
+
+// L3Snapshot lists every resting order in queue priority. Seq is the last
+// order event applied, so events with a greater seq continue from it.
+type L3Snapshot struct {
+	Symbol string    `json:"symbol"`
+	Seq    int64     `json:"seq"`
+	Bids   []L3Order `json:"bids"`
+	Asks   []L3Order `json:"asks"`
+	TS     time.Time `json:"ts"`
+}
+
+func (e *Engine) L3Snapshot(symbol string) L3Snapshot {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	snapshot := L3Snapshot{
+		Symbol: symbol,
+		Bids:   []L3Order{},
+		Asks:   []L3Order{},
+		TS:     time.Now().UTC(),
+	}
+	if state, ok := e.bookFeeds[symbol]; ok {
+		snapshot.Seq = state.orderSeq
+	}
+	if book := e.books[symbol]; book != nil {
+		snapshot.Bids = l3Orders(book.bids)
+		snapshot.Asks = l3Orders(book.asks)
+	}
+	return snapshot
+}
+
+func l3Orders(entries []*Order) []L3Order {
+	out := make([]L3Order, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil || entry.RemainingQty <= 0 {
+			continue
+		}
+		out = append(out, L3Order{
+			OrderID: entry.OrderID,
+			Side:    entry.Side,
+			Price:   entry.Price,
+			Qty:     entry.RemainingQty,
+		})
+	}
+	return out
+}
+
+func (e *Engine) recordOrderEventLocked(batch *eventBatch, eventType OrderEventType, order *Order, execQty int64, tradeID string) {
+	if e.bookSink == nil {
+		return
+	}
+
+	batch.levelChanged(order.Symbol, order.Side, order.Price)
+	state := e.bookFeedLocked(order.Symbol)
+	state.orderSeq++
+	batch.orderEvents = append(batch.orderEvents, OrderEvent{
+		Symbol:  order.Symbol,
+		Seq:     state.orderSeq,
+		Type:    eventType,
+		OrderID: order.OrderID,
+		Side:    order.Side,
+		Price:   order.Price,
+		Qty:     order.RemainingQty,
+		ExecQty: execQty,
+		TradeID: tradeID,
+		TS:      time.Now().UTC(),
+	})
+}
+
+func bookContains(book *orderBook, order *Order) bool {
+	entries := book.asks
+	if order.Side == SideBuy {
+		entries = book.bids
+	}
+	for _, entry := range entries {
+		if entry == order {
+			return true
+		}
+	}
+	return false
+}
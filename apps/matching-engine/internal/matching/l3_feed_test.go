@@ -0,0 +1,75 @@
+package matching
+
+import "testing"
+
+func TestOrderEventsReconstructQueue(t *testing.T) {
+	engine := NewEngine()
+	sink := &recordingBookSink{}
+	engine.SetBookEventSink(sink, 0)
+	engine.FundWallet("seller1", "BTC", 10)
+	engine.FundWallet("seller2", "BTC", 10)
+
+	first, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 101, Qty: 3})
+	if err != nil {
+		t.Fatalf("place order failed: %v", err)
+	}
+	second, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "seller2", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 101, Qty: 4})
+	if err != nil {
+		t.Fatalf("place order failed: %v", err)
+	}
+	if _, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeMarket, Qty: 4}); err != nil {
+		t.Fatalf("market buy failed: %v", err)
+	}
+	if _, err := engine.CancelOrder("seller2", second.OrderID); err != nil {
+		t.Fatalf("cancel failed: %v", err)
+	}
+
+	want := []OrderEvent{
+		{Seq: 1, Type: OrderEventAdd, OrderID: first.OrderID, Qty: 3},
+		{Seq: 2, Type: OrderEventAdd, OrderID: second.OrderID, Qty: 4},
+		{Seq: 3, Type: OrderEventExecute, OrderID: first.OrderID, Qty: 0, ExecQty: 3},
+		{Seq: 4, Type: OrderEventExecute, OrderID: second.OrderID, Qty: 3, ExecQty: 1},
+		{Seq: 5, Type: OrderEventDelete, OrderID: second.OrderID, Qty: 0},
+	}
+	if len(sink.events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), sink.events)
+	}
+	for i, event := range sink.events {
+		got := OrderEvent{Seq: event.Seq, Type: event.Type, OrderID: event.OrderID, Qty: event.Qty, ExecQty: event.ExecQty}
+		if got != want[i] {
+			t.Fatalf("event %d: expected %+v, got %+v", i, want[i], event)
+		}
+		if event.Type == OrderEventExecute && event.TradeID == "" {
+			t.Fatalf("expected execute event %d to carry a trade id", i)
+		}
+	}
+
+	snapshot := engine.L3Snapshot("BTC-USD")
+	if snapshot.Seq != 5 || len(snapshot.Asks) != 0 {
+		t.Fatalf("expected empty book at seq 5, got %+v", snapshot)
+	}
+}
+
+func TestL3SnapshotListsOrdersInQueuePriority(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("buyer1", "USD", 1000)
+
+	var ids []string
+	for _, price := range []int64{99, 100, 100} {
+		ack, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: price, Qty: 1})
+		if err != nil {
+			t.Fatalf("place order failed: %v", err)
+		}
+		ids = append(ids, ack.OrderID)
+	}
+
+	snapshot := engine.L3Snapshot("BTC-USD")
+	if len(snapshot.Bids) != 3 {
+		t.Fatalf("expected 3 bids, got %+v", snapshot.Bids)
+	}
+	for i, wantID := range []string{ids[1], ids[2], ids[0]} {
+		if snapshot.Bids[i].OrderID != wantID {
+			t.Fatalf("bid %d: expected %s, got %s", i, wantID, snapshot.Bids[i].OrderID)
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package matching
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type OrderListType string
+
+const (
+	OrderListTypeOCO     OrderListType = "OCO"
+	OrderListTypeBracket OrderListType = "BRACKET"
+)
+
+type OrderListStatus string
+
+const (
+	OrderListStatusPending OrderListStatus = "PENDING"
+	OrderListStatusActive  OrderListStatus = "ACTIVE"
+	OrderListStatusDone    OrderListStatus = "ALL_DONE"
+)
+
+type OrderListRole string
+
+const (
+	OrderListRoleEntry      OrderListRole = "ENTRY"
+	OrderListRoleTakeProfit OrderListRole = "TAKE_PROFIT"
+	OrderListRoleStopLoss   OrderListRole = "STOP_LOSS"
+)
+
+type OrderLeg struct {
+	ClientOrderID string    `json:"clientOrderId"`
+	Type          OrderType `json:"type"`
+	Price         int64     `json:"price,omitempty"`
+	StopPrice     int64     `json:"stopPrice,omitempty"`
+}
+
+// PlaceOrderListRequest describes an OCO pair or a bracket. For OCO lists Side
+// is the side of both exit legs; for brackets it is the side of the entry and
+// the take-profit and stop-loss legs trade the opposite side once it fills.
+type PlaceOrderListRequest struct {
+	ClientListID string        `json:"clientListId"`
+	UserID       string        `json:"userId"`
+	Symbol       string        `json:"symbol"`
+	Type         OrderListType `json:"type"`
+	Side         Side          `json:"side"`
+	Qty          int64         `json:"qty"`
+	Entry        *OrderLeg     `json:"entry,omitempty"`
+	TakeProfit   OrderLeg      `json:"takeProfit"`
+	StopLoss     OrderLeg      `json:"stopLoss"`
+}
+
+type OrderListAck struct {
+	ListID       string          `json:"listId"`
+	ClientListID string          `json:"clientListId,omitempty"`
+	Type         OrderListType   `json:"type"`
+	Status       OrderListStatus `json:"status"`
+	Symbol       string          `json:"symbol"`
+	Orders       []OrderAck      `json:"orders"`
+	TS           time.Time       `json:"ts"`
+}
+
+type orderList struct {
+	ListID       string
+	ClientListID string
+	UserID       string
+	Symbol       string
+	Type         OrderListType
+	Status       OrderListStatus
+	entry        *Order
+	takeProfit   *Order
+	stopLoss     *Order
+}
+
+func (l *orderList) legs() []*Order {
+	if l.entry != nil {
+		return []*Order{l.entry, l.takeProfit, l.stopLoss}
+	}
+	return []*Order{l.takeProfit, l.stopLoss}
+}
+
+func (e *Engine) PlaceOrderList(req PlaceOrderListRequest) (OrderListAck, error) {
+	e.mu.Lock()
+
+	if err := validateOrderList(req); err != nil {
+		e.mu.Unlock()
+		return OrderListAck{}, err
+	}
+
+	e.listSeq++
+	list := &orderList{
+		ListID:       fmt.Sprintf("lst-%d", e.listSeq),
+		ClientListID: req.ClientListID,
+		UserID:       req.UserID,
+		Symbol:       req.Symbol,
+		Type:         req.Type,
+		Status:       OrderListStatusPending,
+	}
+
+	exitSide := req.Side
+	if req.Type == OrderListTypeBracket {
+		exitSide = oppositeSide(req.Side)
+		list.entry = e.newListLegLocked(list, OrderListRoleEntry, req.Side, req.Qty, *req.Entry)
+	}
+	list.takeProfit = e.newListLegLocked(list, OrderListRoleTakeProfit, exitSide, req.Qty, req.TakeProfit)
+	list.stopLoss = e.newListLegLocked(list, OrderListRoleStopLoss, exitSide, req.Qty, req.StopLoss)
+
+	if rejection := e.checkKillSwitchLocked(req.UserID, req.Symbol); rejection != nil {
+		e.mu.Unlock()
+		return OrderListAck{}, rejection
+	}
+	if limits := e.riskLimitsLocked(req.UserID); limits != (RiskLimits{}) {
+		resting := 0
+		for _, leg := range list.legs() {
+			if rejection := e.checkOrderLimitsLocked(limits, leg.Symbol, leg.Side, leg.Type, leg.Qty, leg.Price, leg.StopPrice); rejection != nil {
+				e.mu.Unlock()
+				return OrderListAck{}, rejection
+			}
+			if leg.Type != OrderTypeMarket {
+				resting++
+			}
+		}
+		if rejection := e.checkAccountRiskLocked(limits, req.UserID, req.Symbol, len(list.legs()), resting, time.Now()); rejection != nil {
+			e.mu.Unlock()
+			return OrderListAck{}, rejection
+		}
+	}
+
+	book := e.ensureBook(req.Symbol)
+	batch := newEventBatch()
+	batch.touchedUsers[req.UserID] = struct{}{}
+	batch.symbols[req.Symbol] = struct{}{}
+	e.lists[list.ListID] = list
+
+	if list.entry != nil {
+		if err := e.reserveForOrderLocked(list.entry, book); err != nil {
+			delete(e.lists, list.ListID)
+			e.mu.Unlock()
+			return OrderListAck{}, err
+		}
+		list.takeProfit.status = OrderStatusPending
+		list.stopLoss.status = OrderStatusPending
+		e.trackOpenOrder(list.takeProfit)
+		e.trackOpenOrder(list.stopLoss)
+		batch.orderChanged(list.takeProfit)
+		batch.orderChanged(list.stopLoss)
+		if err := e.submitLocked(book, list.entry, batch); err != nil {
+			e.removeOpenOrder(list.takeProfit)
+			e.removeOpenOrder(list.stopLoss)
+			delete(e.lists, list.ListID)
+			e.mu.Unlock()
+			return OrderListAck{}, err
+		}
+	} else if err := e.activateListLocked(list, req.Qty, batch); err != nil {
+		delete(e.lists, list.ListID)
+		e.mu.Unlock()
+		return OrderListAck{}, err
+	}
+	e.processContingenciesLocked(batch)
+
+	ack := orderListAck(list)
+	e.unlockAndPublish(batch)
+	return ack, nil
+}
+
+func (e *Engine) CancelOrderList(userID, listID string) (OrderListAck, error) {
+	e.mu.Lock()
+
+	list, ok := e.lists[listID]
+	if !ok || list.UserID != userID {
+		e.mu.Unlock()
+		return OrderListAck{}, ErrOrderListNotFound
+	}
+
+	batch := newEventBatch()
+	batch.touchedUsers[userID] = struct{}{}
+	batch.symbols[list.Symbol] = struct{}{}
+	book := e.ensureBook(list.Symbol)
+	for _, leg := range list.legs() {
+		if e.isOpenLocked(leg) {
+			e.cancelOrderLocked(book, leg, batch)
+		}
+	}
+	e.finishListIfDoneLocked(listID)
+
+	ack := orderListAck(list)
+	e.unlockAndPublish(batch)
+	return ack, nil
+}
+
+func validateOrderList(req PlaceOrderListRequest) error {
+	if req.UserID == "" {
+		return errors.New("userId is required")
+	}
+	if req.Symbol == "" {
+		return errors.New("symbol is required")
+	}
+	if _, _, err := parseSymbol(req.Symbol); err != nil {
+		return err
+	}
+	if req.Qty <= 0 {
+		return errors.New("qty must be positive")
+	}
+	if req.Side != SideBuy && req.Side != SideSell {
+		return errors.New("side must be BUY or SELL")
+	}
+
+	exitSide := req.Side
+	switch req.Type {
+	case OrderListTypeOCO:
+		if req.Entry != nil {
+			return errors.New("entry is only supported for BRACKET lists")
+		}
+	case OrderListTypeBracket:
+		if req.Entry == nil {
+			return errors.New("entry is required for BRACKET lists")
+		}
+		if req.Entry.Type != OrderTypeLimit && req.Entry.Type != OrderTypeMarket {
+			return errors.New("entry type must be LIMIT or MARKET")
+		}
+		if req.Entry.Type == OrderTypeLimit && req.Entry.Price <= 0 {
+			return errors.New("entry price must be positive for LIMIT entry")
+		}
+		exitSide = oppositeSide(req.Side)
+	default:
+		return errors.New("type must be OCO or BRACKET")
+	}
+
+	if req.TakeProfit.Type != OrderTypeLimit {
+		return errors.New("takeProfit type must be LIMIT")
+	}
+	if req.TakeProfit.Price <= 0 {
+		return errors.New("takeProfit price must be positive")
+	}
+	if !req.StopLoss.Type.isStop() {
+		return errors.New("stopLoss type must be STOP_LIMIT or STOP_MARKET")
+	}
+	if req.StopLoss.StopPrice <= 0 {
+		return errors.New("stopLoss stopPrice must be positive")
+	}
+	if req.StopLoss.Type == OrderTypeStopLimit && req.StopLoss.Price <= 0 {
+		return errors.New("stopLoss price must be positive for STOP_LIMIT")
+	}
+
+	if exitSide == SideSell && req.TakeProfit.Price <= req.StopLoss.StopPrice {
+		return errors.New("takeProfit price must be above stopLoss stopPrice for SELL exits")
+	}
+	if exitSide == SideBuy && req.TakeProfit.Price >= req.StopLoss.StopPrice {
+		return errors.New("takeProfit price must be below stopLoss stopPrice for BUY exits")
+	}
+	return nil
+}
+
+func oppositeSide(side Side) Side {
+	if side == SideBuy {
+		return SideSell
+	}
+	return SideBuy
+}
+
+func (e *Engine) newListLegLocked(list *orderList, role OrderListRole, side Side, qty int64, leg OrderLeg) *Order {
+	order := e.newOrderLocked(PlaceOrderRequest{
+		ClientOrderID: leg.ClientOrderID,
+		UserID:        list.UserID,
+		Symbol:        list.Symbol,
+		Side:          side,
+		Type:          leg.Type,
+		Price:         leg.Price,
+		StopPrice:     leg.StopPrice,
+		Qty:           qty,
+	})
+	order.ListID = list.ListID
+	order.ListRole = role
+	return order
+}
+
+// activateListLocked arms the take-profit and stop-loss legs for qty. Both
+// legs share one reservation held by the take-profit order; the stop-loss
+// takes it over when it triggers.
+func (e *Engine) activateListLocked(list *orderList, qty int64, batch *eventBatch) error {
+	takeProfit := list.takeProfit
+	stopLoss := list.stopLoss
+	for _, leg := range []*Order{takeProfit, stopLoss} {
+		leg.Qty = qty
+		leg.RemainingQty = qty
+		leg.status = OrderStatusAccepted
+	}
+
+	if err := e.reserveListLocked(list, qty); err != nil {
+		return err
+	}
+
+	list.Status = OrderListStatusActive
+	batch.touchedUsers[list.UserID] = struct{}{}
+	book := e.ensureBook(list.Symbol)
+	e.removeOpenOrder(takeProfit)
+	e.removeOpenOrder(stopLoss)
+
+	if err := e.submitLocked(book, stopLoss, batch); err != nil {
+		return err
+	}
+	return e.submitLocked(book, takeProfit, batch)
+}
+
+func (e *Engine) reserveListLocked(list *orderList, qty int64) error {
+	takeProfit := list.takeProfit
+	if takeProfit.Side == SideSell {
+		return e.reserveLocked(takeProfit, takeProfit.BaseAsset, qty)
+	}
+
+	required := takeProfit.Price * qty
+	if stopLoss := list.stopLoss; stopLoss.Type == OrderTypeStopLimit && stopLoss.Price*qty > required {
+		required = stopLoss.Price * qty
+	}
+	return e.reserveLocked(takeProfit, takeProfit.QuoteAsset, required)
+}
+
+func (e *Engine) onListLegFilledLocked(leg *Order, batch *eventBatch) {
+	list, ok := e.lists[leg.ListID]
+	if !ok {
+		return
+	}
+	batch.touchedUsers[list.UserID] = struct{}{}
+
+	if leg.ListRole == OrderListRoleEntry {
+		if e.isOpenLocked(leg) || list.Status != OrderListStatusPending {
+			return
+		}
+		e.armExitsLocked(list, leg.cumQty, batch)
+		return
+	}
+
+	e.cancelListSiblingsLocked(leg, batch)
+	e.finishListIfDoneLocked(list.ListID)
+}
+
+// onListLegCanceledLocked applies the one-cancels-other rule. Canceling a
+// partially filled bracket entry still arms the exits for the filled qty so
+// the resulting position stays protected.
+func (e *Engine) onListLegCanceledLocked(leg *Order, batch *eventBatch) {
+	list, ok := e.lists[leg.ListID]
+	if !ok {
+		return
+	}
+
+	if leg.ListRole == OrderListRoleEntry && list.Status == OrderListStatusPending && leg.cumQty > 0 {
+		e.armExitsLocked(list, leg.cumQty, batch)
+		return
+	}
+
+	e.cancelListSiblingsLocked(leg, batch)
+	e.finishListIfDoneLocked(list.ListID)
+}
+
+func (e *Engine) armExitsLocked(list *orderList, qty int64, batch *eventBatch) {
+	if qty > 0 && e.activateListLocked(list, qty, batch) == nil {
+		return
+	}
+
+	book := e.ensureBook(list.Symbol)
+	for _, leg := range []*Order{list.takeProfit, list.stopLoss} {
+		e.cancelOrderLocked(book, leg, batch)
+	}
+	e.finishListIfDoneLocked(list.ListID)
+}
+
+func (e *Engine) cancelListSiblingsLocked(leg *Order, batch *eventBatch) {
+	list, ok := e.lists[leg.ListID]
+	if !ok {
+		return
+	}
+
+	book := e.ensureBook(list.Symbol)
+	for _, sibling := range list.legs() {
+		if sibling == leg || !e.isOpenLocked(sibling) {
+			continue
+		}
+		e.cancelOrderLocked(book, sibling, batch)
+		batch.touchedUsers[sibling.UserID] = struct{}{}
+	}
+}
+
+func (e *Engine) finishListIfDoneLocked(listID string) {
+	list, ok := e.lists[listID]
+	if !ok {
+		return
+	}
+	for _, leg := range list.legs() {
+		if e.isOpenLocked(leg) {
+			return
+		}
+	}
+	list.Status = OrderListStatusDone
+	delete(e.lists, listID)
+}
+
+func orderListAck(list *orderList) OrderListAck {
+	legs := list.legs()
+	orders := make([]OrderAck, 0, len(legs))
+	for _, leg := range legs {
+		orders = append(orders, orderAck(leg))
+	}
+
+	return OrderListAck{
+		ListID:       list.ListID,
+		ClientListID: list.ClientListID,
+		Type:         list.Type,
+		Status:       list.Status,
+		Symbol:       list.Symbol,
+		Orders:       orders,
+		TS:           time.Now().UTC(),
+	}
+}
@@ -0,0 +1,316 @@
+package matching
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type RejectCode string
+
+const (
+	RejectMaxOrderSize   RejectCode = "MAX_ORDER_SIZE"
+	RejectMaxNotional    RejectCode = "MAX_NOTIONAL"
+	RejectMaxOpenOrders  RejectCode = "MAX_OPEN_ORDERS"
+	RejectOrderRateLimit RejectCode = "ORDER_RATE_LIMIT"
+	RejectDailyLossLimit RejectCode = "DAILY_LOSS_LIMIT"
+	RejectKillSwitch     RejectCode = "KILL_SWITCH"
+)
+
+// RiskLimits are pre-trade limits for one user. Zero disables a limit.
+// MaxNotional and MaxDailyLoss are in the symbol's quote asset; daily loss is
+// realized PnL since 00:00 UTC.
+type RiskLimits struct {
+	MaxOrderQty            int64 `json:"maxOrderQty,omitempty"`
+	MaxNotional            int64 `json:"maxNotional,omitempty"`
+	MaxOpenOrdersPerSymbol int   `json:"maxOpenOrdersPerSymbol,omitempty"`
+	MaxOrdersPerSecond     int   `json:"maxOrdersPerSecond,omitempty"`
+	MaxDailyLoss           int64 `json:"maxDailyLoss,omitempty"`
+}
+
+// RiskConfig is the default limits and the users that override them.
+type RiskConfig struct {
+	Default RiskLimits            `json:"default"`
+	Users   map[string]RiskLimits `json:"users"`
+}
+
+// RiskRejection is the error for an order that failed a risk check.
+type RiskRejection struct {
+	Code   RejectCode
+	Reason string
+	// halted is set for kill switches that stop a symbol or all trading.
+	halted bool
+}
+
+func (r *RiskRejection) Error() string {
+	return r.Reason
+}
+
+func rejectf(code RejectCode, format string, args ...any) *RiskRejection {
+	return &RiskRejection{Code: code, Reason: fmt.Sprintf(format, args...)}
+}
+
+// userRisk is the trading state the limits are checked against.
+type userRisk struct {
+	recentOrders []time.Time
+	positions    map[string]*RiskPosition
+	day          string
+	// realizedPnL is today's realized PnL by quote asset.
+	realizedPnL map[string]int64
+}
+
+// RiskPosition is a net position with its average entry price; Qty is
+// negative when short.
+type RiskPosition struct {
+	Qty      int64 `json:"qty"`
+	AvgPrice int64 `json:"avgPrice"`
+}
+
+func (l RiskLimits) validate() error {
+	if l.MaxOrderQty < 0 || l.MaxNotional < 0 || l.MaxOpenOrdersPerSymbol < 0 || l.MaxOrdersPerSecond < 0 || l.MaxDailyLoss < 0 {
+		return errors.New("risk limits must not be negative")
+	}
+	return nil
+}
+
+// SetRiskLimits replaces the limits of userID, or the default limits for
+// users without their own when userID is empty.
+func (e *Engine) SetRiskLimits(userID string, limits RiskLimits) error {
+	if err := limits.validate(); err != nil {
+		return err
+	}
+	userID = strings.TrimSpace(userID)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if userID == "" {
+		e.defaultRiskLimits = limits
+		return nil
+	}
+	e.userRiskLimits[userID] = limits
+	return nil
+}
+
+// ClearRiskLimits puts userID back on the default limits.
+func (e *Engine) ClearRiskLimits(userID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.userRiskLimits, strings.TrimSpace(userID))
+}
+
+func (e *Engine) RiskConfig() RiskConfig {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	users := make(map[string]RiskLimits, len(e.userRiskLimits))
+	for userID, limits := range e.userRiskLimits {
+		users[userID] = limits
+	}
+	return RiskConfig{Default: e.defaultRiskLimits, Users: users}
+}
+
+func (e *Engine) riskLimitsLocked(userID string) RiskLimits {
+	if limits, ok := e.userRiskLimits[userID]; ok {
+		return limits
+	}
+	return e.defaultRiskLimits
+}
+
+func (e *Engine) userRiskLocked(userID string, now time.Time) *userRisk {
+	state, ok := e.risk[userID]
+	if !ok {
+		state = &userRisk{positions: make(map[string]*RiskPosition)}
+		e.risk[userID] = state
+	}
+	if day := now.UTC().Format(time.DateOnly); state.day != day {
+		state.day = day
+		state.realizedPnL = make(map[string]int64)
+	}
+	return state
+}
+
+// checkRiskLocked runs the pre-trade checks for a new order and counts it
+// towards the order rate when it passes.
+func (e *Engine) checkRiskLocked(req PlaceOrderRequest, now time.Time) *RiskRejection {
+	limits := e.riskLimitsLocked(req.UserID)
+	if limits == (RiskLimits{}) {
+		return nil
+	}
+	if rejection := e.checkOrderLimitsLocked(limits, req.Symbol, req.Side, req.Type, req.Qty, req.Price, req.StopPrice); rejection != nil {
+		return rejection
+	}
+	resting := 1
+	if req.Type == OrderTypeMarket {
+		resting = 0
+	}
+	return e.checkAccountRiskLocked(limits, req.UserID, req.Symbol, 1, resting, now)
+}
+
+// checkAccountRiskLocked checks open orders, daily loss and order rate for
+// count new orders on symbol, resting of which would stay on the book, and
+// counts them towards the order rate when they pass. Order lists pass all
+// their legs at once so a list is accepted or rejected as a unit.
+func (e *Engine) checkAccountRiskLocked(limits RiskLimits, userID, symbol string, count, resting int, now time.Time) *RiskRejection {
+	if limits.MaxOpenOrdersPerSymbol > 0 && resting > 0 {
+		open := 0
+		for _, order := range e.ordersByUser[userID] {
+			if order.Symbol == symbol {
+				open++
+			}
+		}
+		if open+resting > limits.MaxOpenOrdersPerSymbol {
+			if resting == 1 {
+				return rejectf(RejectMaxOpenOrders, "%d open orders on %s reaches the limit of %d", open, symbol, limits.MaxOpenOrdersPerSymbol)
+			}
+			return rejectf(RejectMaxOpenOrders, "%d open orders on %s and %d new exceed the limit of %d", open, symbol, resting, limits.MaxOpenOrdersPerSymbol)
+		}
+	}
+
+	state := e.userRiskLocked(userID, now)
+	if limits.MaxDailyLoss > 0 {
+		_, quoteAsset, _ := parseSymbol(symbol)
+		if loss := -state.realizedPnL[quoteAsset]; loss >= limits.MaxDailyLoss {
+			return rejectf(RejectDailyLossLimit, "realized loss of %d %s today reaches the daily limit of %d", loss, quoteAsset, limits.MaxDailyLoss)
+		}
+	}
+
+	if limits.MaxOrdersPerSecond > 0 {
+		cutoff := now.Add(-time.Second)
+		kept := state.recentOrders[:0]
+		for _, ts := range state.recentOrders {
+			if ts.After(cutoff) {
+				kept = append(kept, ts)
+			}
+		}
+		state.recentOrders = kept
+		if len(kept)+count > limits.MaxOrdersPerSecond {
+			return rejectf(RejectOrderRateLimit, "more than %d orders per second", limits.MaxOrdersPerSecond)
+		}
+		for i := 0; i < count; i++ {
+			state.recentOrders = append(state.recentOrders, now)
+		}
+	}
+	return nil
+}
+
+// checkOrderLimitsLocked checks size and notional, which also bind amends.
+// Market orders are valued at the last trade or, before the first trade, the
+// best opposite price; an order that cannot be valued passes the notional
+// check.
+func (e *Engine) checkOrderLimitsLocked(limits RiskLimits, symbol string, side Side, orderType OrderType, qty, price, stopPrice int64) *RiskRejection {
+	if limits.MaxOrderQty > 0 && qty > limits.MaxOrderQty {
+		return rejectf(RejectMaxOrderSize, "qty %d exceeds the limit of %d", qty, limits.MaxOrderQty)
+	}
+	if limits.MaxNotional <= 0 {
+		return nil
+	}
+
+	switch {
+	case orderType.hasLimitPrice():
+	case orderType.isStop():
+		price = stopPrice
+	default:
+		price = e.referencePriceLocked(symbol, side)
+	}
+	if price > 0 && qty*price > limits.MaxNotional {
+		return rejectf(RejectMaxNotional, "notional %d exceeds the limit of %d", qty*price, limits.MaxNotional)
+	}
+	return nil
+}
+
+func (e *Engine) referencePriceLocked(symbol string, side Side) int64 {
+	if price, ok := e.lastPrices[symbol]; ok {
+		return price
+	}
+	book := e.books[symbol]
+	if book == nil {
+		return 0
+	}
+	if side == SideBuy && len(book.asks) > 0 {
+		return book.asks[0].Price
+	}
+	if side == SideSell && len(book.bids) > 0 {
+		return book.bids[0].Price
+	}
+	return 0
+}
+
+// recordRiskFillLocked updates the order owner's position and books the PnL
+// of any part of the fill that reduces it.
+func (e *Engine) recordRiskFillLocked(order *Order, qty, price int64, now time.Time) {
+	state := e.userRiskLocked(order.UserID, now)
+	position, ok := state.positions[order.Symbol]
+	if !ok {
+		position = &RiskPosition{}
+		state.positions[order.Symbol] = position
+	}
+
+	delta := qty
+	if order.Side == SideSell {
+		delta = -qty
+	}
+
+	if position.Qty == 0 || (position.Qty > 0) == (delta > 0) {
+		size := absInt64(position.Qty)
+		position.AvgPrice = (size*position.AvgPrice + qty*price) / (size + qty)
+		position.Qty += delta
+		return
+	}
+
+	closed := minInt64(absInt64(position.Qty), qty)
+	pnl := closed * (price - position.AvgPrice)
+	if position.Qty < 0 {
+		pnl = -pnl
+	}
+	state.realizedPnL[order.QuoteAsset] += pnl
+
+	position.Qty += delta
+	switch {
+	case position.Qty == 0:
+		position.AvgPrice = 0
+	case qty > closed:
+		// The fill flipped the position; the rest opens at the fill price.
+		position.AvgPrice = price
+	}
+}
+
+// RiskStatus is what the risk checks see for one user.
+type RiskStatus struct {
+	UserID    string                  `json:"userId"`
+	Limits    RiskLimits              `json:"limits"`
+	Positions map[string]RiskPosition `json:"positions"`
+	// RealizedPnL is today's realized PnL by quote asset.
+	RealizedPnL map[string]int64 `json:"realizedPnl"`
+	Day         string           `json:"day"`
+}
+
+func (e *Engine) RiskStatus(userID string) RiskStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state := e.userRiskLocked(userID, time.Now())
+	status := RiskStatus{
+		UserID:      userID,
+		Limits:      e.riskLimitsLocked(userID),
+		Positions:   make(map[string]RiskPosition, len(state.positions)),
+		RealizedPnL: make(map[string]int64, len(state.realizedPnL)),
+		Day:         state.day,
+	}
+	for symbol, position := range state.positions {
+		if position.Qty != 0 {
+			status.Positions[symbol] = *position
+		}
+	}
+	for asset, pnl := range state.realizedPnL {
+		status.RealizedPnL[asset] = pnl
+	}
+	return status
+}
+
+func absInt64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
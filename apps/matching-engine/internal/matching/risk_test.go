@@ -0,0 +1,210 @@
+package matching
+
+import (
+	"errors"
+	"testing"
+)
+
+func limitOrder(userID string, side Side, price, qty int64) PlaceOrderRequest {
+	return PlaceOrderRequest{UserID: userID, Symbol: "BTC-USD", Side: side, Type: OrderTypeLimit, Price: price, Qty: qty}
+}
+
+func TestRiskRejectsWithCodes(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetRiskLimits("", RiskLimits{MaxOrderQty: 10, MaxNotional: 500, MaxOpenOrdersPerSymbol: 2}); err != nil {
+		t.Fatalf("set default limits: %v", err)
+	}
+
+	cases := []struct {
+		req  PlaceOrderRequest
+		code RejectCode
+	}{
+		{limitOrder("u1", SideBuy, 10, 11), RejectMaxOrderSize},
+		{limitOrder("u1", SideBuy, 100, 6), RejectMaxNotional},
+	}
+	for _, tc := range cases {
+		ack, err := engine.PlaceOrder(tc.req)
+		if err != nil {
+			t.Fatalf("expected a rejected ack, got error %v", err)
+		}
+		if ack.Status != OrderStatusRejected || ack.RejectCode != tc.code || ack.RejectReason == "" || ack.OrderID == "" {
+			t.Fatalf("expected %s rejection, got %+v", tc.code, ack)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if ack, _ := engine.PlaceOrder(limitOrder("u1", SideBuy, 10, 1)); ack.Status != OrderStatusAccepted {
+			t.Fatalf("expected order %d accepted, got %+v", i, ack)
+		}
+	}
+	ack, _ := engine.PlaceOrder(limitOrder("u1", SideBuy, 10, 1))
+	if ack.RejectCode != RejectMaxOpenOrders {
+		t.Fatalf("expected MAX_OPEN_ORDERS, got %+v", ack)
+	}
+	if len(engine.OpenOrders("u1")) != 2 {
+		t.Fatalf("expected rejected orders not to rest")
+	}
+	if wallet := engine.Wallet("u1"); wallet.Reserved["USD"] != 20 {
+		t.Fatalf("expected only accepted orders reserved, got %+v", wallet)
+	}
+
+	// A user override replaces the default limits.
+	if err := engine.SetRiskLimits("u1", RiskLimits{MaxOrderQty: 100}); err != nil {
+		t.Fatalf("set user limits: %v", err)
+	}
+	if ack, _ := engine.PlaceOrder(limitOrder("u1", SideBuy, 10, 11)); ack.Status != OrderStatusAccepted {
+		t.Fatalf("expected override to allow the order, got %+v", ack)
+	}
+	engine.ClearRiskLimits("u1")
+	if ack, _ := engine.PlaceOrder(limitOrder("u1", SideBuy, 10, 11)); ack.RejectCode != RejectMaxOrderSize {
+		t.Fatalf("expected default limits after clearing, got %+v", ack)
+	}
+
+	if err := engine.SetRiskLimits("u2", RiskLimits{MaxNotional: -1}); err == nil {
+		t.Fatal("expected negative limits to be refused")
+	}
+}
+
+func TestRiskLimitsOrderRate(t *testing.T) {
+	engine := NewEngine()
+	_ = engine.SetRiskLimits("u1", RiskLimits{MaxOrdersPerSecond: 3})
+
+	for i := 0; i < 3; i++ {
+		if ack, _ := engine.PlaceOrder(limitOrder("u1", SideBuy, 1, 1)); ack.Status != OrderStatusAccepted {
+			t.Fatalf("expected order %d accepted, got %+v", i, ack)
+		}
+	}
+	if ack, _ := engine.PlaceOrder(limitOrder("u1", SideBuy, 1, 1)); ack.RejectCode != RejectOrderRateLimit {
+		t.Fatalf("expected ORDER_RATE_LIMIT, got %+v", ack)
+	}
+	if ack, _ := engine.PlaceOrder(limitOrder("u2", SideBuy, 1, 1)); ack.Status != OrderStatusAccepted {
+		t.Fatalf("expected other users unaffected, got %+v", ack)
+	}
+}
+
+func TestRiskDailyLossLimitUsesRealizedPnL(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("mm", "BTC", 100)
+	_ = engine.SetRiskLimits("trader", RiskLimits{MaxDailyLoss: 50})
+
+	// trader buys 5 @ 100, then sells them to mm at 90: a realized loss of 50.
+	mustPlace(t, engine, limitOrder("mm", SideSell, 100, 5))
+	mustPlace(t, engine, limitOrder("trader", SideBuy, 100, 5))
+	mustPlace(t, engine, limitOrder("mm", SideBuy, 90, 5))
+	mustPlace(t, engine, limitOrder("trader", SideSell, 90, 5))
+
+	status := engine.RiskStatus("trader")
+	if status.RealizedPnL["USD"] != -50 || len(status.Positions) != 0 {
+		t.Fatalf("unexpected risk status %+v", status)
+	}
+	if mm := engine.RiskStatus("mm"); mm.RealizedPnL["USD"] != 50 {
+		t.Fatalf("expected the counterparty to book the gain, got %+v", mm)
+	}
+
+	ack, _ := engine.PlaceOrder(limitOrder("trader", SideBuy, 1, 1))
+	if ack.RejectCode != RejectDailyLossLimit {
+		t.Fatalf("expected DAILY_LOSS_LIMIT, got %+v", ack)
+	}
+}
+
+func TestRiskPositionFlipsAtFillPrice(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("u1", "BTC", 10)
+	engine.FundWallet("mm", "BTC", 10)
+
+	mustPlace(t, engine, limitOrder("mm", SideSell, 100, 2))
+	mustPlace(t, engine, limitOrder("u1", SideBuy, 100, 2))
+	mustPlace(t, engine, limitOrder("mm", SideBuy, 110, 5))
+	mustPlace(t, engine, limitOrder("u1", SideSell, 110, 5))
+
+	status := engine.RiskStatus("u1")
+	if status.RealizedPnL["USD"] != 20 {
+		t.Fatalf("expected realized PnL 20, got %+v", status.RealizedPnL)
+	}
+	if position := status.Positions["BTC-USD"]; position.Qty != -3 || position.AvgPrice != 110 {
+		t.Fatalf("expected short 3 @ 110, got %+v", position)
+	}
+}
+
+func TestRiskLimitsApplyToAmends(t *testing.T) {
+	engine := NewEngine()
+	_ = engine.SetRiskLimits("u1", RiskLimits{MaxOrderQty: 5})
+	ack := mustPlace(t, engine, limitOrder("u1", SideBuy, 10, 5))
+
+	_, err := engine.AmendOrder(AmendOrderRequest{UserID: "u1", OrderID: ack.OrderID, Qty: 6})
+	var rejection *RiskRejection
+	if !errors.As(err, &rejection) || rejection.Code != RejectMaxOrderSize {
+		t.Fatalf("expected MAX_ORDER_SIZE amend rejection, got %v", err)
+	}
+	if _, err := engine.AmendOrder(AmendOrderRequest{UserID: "u1", OrderID: ack.OrderID, Qty: 4}); err != nil {
+		t.Fatalf("expected reduction to pass, got %v", err)
+	}
+}
+
+func mustPlace(t *testing.T, engine *Engine, req PlaceOrderRequest) OrderAck {
+	t.Helper()
+	ack, err := engine.PlaceOrder(req)
+	if err != nil || ack.Status == OrderStatusRejected {
+		t.Fatalf("place %+v: ack=%+v err=%v", req, ack, err)
+	}
+	return ack
+}
+
+func ocoList(userID string) PlaceOrderListRequest {
+	return PlaceOrderListRequest{
+		UserID:     userID,
+		Symbol:     "BTC-USD",
+		Type:       OrderListTypeOCO,
+		Side:       SideSell,
+		Qty:        1,
+		TakeProfit: OrderLeg{Type: OrderTypeLimit, Price: 120},
+		StopLoss:   OrderLeg{Type: OrderTypeStopMarket, StopPrice: 90},
+	}
+}
+
+func TestRiskLimitsApplyToOrderLists(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("u1", "BTC", 10)
+	_ = engine.SetRiskLimits("u1", RiskLimits{MaxOpenOrdersPerSymbol: 3})
+
+	mustPlace(t, engine, limitOrder("u1", SideBuy, 10, 1))
+	mustPlace(t, engine, limitOrder("u1", SideBuy, 10, 1))
+	var rejection *RiskRejection
+	if _, err := engine.PlaceOrderList(ocoList("u1")); !errors.As(err, &rejection) || rejection.Code != RejectMaxOpenOrders {
+		t.Fatalf("expected MAX_OPEN_ORDERS for both legs, got %v", err)
+	}
+	if len(engine.OpenOrders("u1")) != 2 {
+		t.Fatalf("expected the rejected list not to rest")
+	}
+
+	_ = engine.SetRiskLimits("u1", RiskLimits{MaxOrdersPerSecond: 3})
+	if _, err := engine.PlaceOrderList(ocoList("u1")); err != nil {
+		t.Fatalf("expected the list within the rate, got %v", err)
+	}
+	if _, err := engine.PlaceOrderList(ocoList("u1")); !errors.As(err, &rejection) || rejection.Code != RejectOrderRateLimit {
+		t.Fatalf("expected ORDER_RATE_LIMIT for the second list, got %v", err)
+	}
+	if ack, _ := engine.PlaceOrder(limitOrder("u1", SideBuy, 1, 1)); ack.Status != OrderStatusAccepted {
+		t.Fatalf("expected one more order within the rate, got %+v", ack)
+	}
+	if ack, _ := engine.PlaceOrder(limitOrder("u1", SideBuy, 1, 1)); ack.RejectCode != RejectOrderRateLimit {
+		t.Fatalf("expected the list legs to count towards the rate, got %+v", ack)
+	}
+}
+
+func TestRiskDailyLossLimitAppliesToOrderLists(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("mm", "BTC", 100)
+	_ = engine.SetRiskLimits("trader", RiskLimits{MaxDailyLoss: 50})
+
+	mustPlace(t, engine, limitOrder("mm", SideSell, 100, 5))
+	mustPlace(t, engine, limitOrder("trader", SideBuy, 100, 5))
+	mustPlace(t, engine, limitOrder("mm", SideBuy, 90, 5))
+	mustPlace(t, engine, limitOrder("trader", SideSell, 90, 5))
+
+	engine.FundWallet("trader", "BTC", 1)
+	var rejection *RiskRejection
+	if _, err := engine.PlaceOrderList(ocoList("trader")); !errors.As(err, &rejection) || rejection.Code != RejectDailyLossLimit {
+		t.Fatalf("expected DAILY_LOSS_LIMIT, got %v", err)
+	}
+}
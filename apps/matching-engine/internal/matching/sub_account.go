@@ -0,0 +1,106 @@
+package matching
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// subAccountSeparator joins a user ID and a sub-account ID into the ID the
+// engine keys the sub-account's wallet, open orders and risk state by.
+const subAccountSeparator = ":"
+
+// SubAccountUserID is the engine user ID of a user's sub-account. An empty
+// accountID is the user's main account.
+func SubAccountUserID(userID, accountID string) string {
+	if accountID == "" {
+		return userID
+	}
+	return userID + subAccountSeparator + accountID
+}
+
+// ParentUserID is the user a sub-account belongs to, or userID itself for a
+// main account.
+func ParentUserID(userID string) string {
+	parent, _, _ := strings.Cut(userID, subAccountSeparator)
+	return parent
+}
+
+func isSubAccount(userID string) bool {
+	return strings.Contains(userID, subAccountSeparator)
+}
+
+// TransferRequest moves available balance between a user's main account and
+// its sub-accounts, or between two of its sub-accounts. A RequestID makes
+// retries safe the way it does for deposits.
+type TransferRequest struct {
+	RequestID  string `json:"requestId,omitempty"`
+	FromUserID string `json:"fromUserId"`
+	ToUserID   string `json:"toUserId"`
+	Asset      string `json:"asset"`
+	Amount     int64  `json:"amount"`
+}
+
+type TransferResult struct {
+	Operation FundingOperation `json:"operation"`
+	From      Wallet           `json:"from"`
+	To        Wallet           `json:"to"`
+}
+
+func (e *Engine) Transfer(req TransferRequest) (TransferResult, error) {
+	req.RequestID = strings.TrimSpace(req.RequestID)
+	req.FromUserID = strings.TrimSpace(req.FromUserID)
+	req.ToUserID = strings.TrimSpace(req.ToUserID)
+	req.Asset = strings.ToUpper(strings.TrimSpace(req.Asset))
+	switch {
+	case req.FromUserID == "" || req.ToUserID == "":
+		return TransferResult{}, errors.New("fromUserId and toUserId are required")
+	case req.FromUserID == req.ToUserID:
+		return TransferResult{}, errors.New("cannot transfer to the same account")
+	case ParentUserID(req.FromUserID) != ParentUserID(req.ToUserID):
+		return TransferResult{}, errors.New("transfers must stay within one user's accounts")
+	case req.Asset == "":
+		return TransferResult{}, errors.New("asset is required")
+	case req.Amount <= 0:
+		return TransferResult{}, errors.New("amount must be positive")
+	case strings.HasPrefix(req.RequestID, faucetRequestPrefix):
+		return TransferResult{}, errReservedRequestID
+	}
+
+	e.mu.Lock()
+	if existing, ok := e.fundingByRequest[fundingKey{req.FromUserID, req.RequestID}]; ok && req.RequestID != "" {
+		replayed := *existing
+		result := TransferResult{From: copyWallet(e.ensureWalletLocked(req.FromUserID)), To: copyWallet(e.ensureWalletLocked(req.ToUserID))}
+		e.mu.Unlock()
+		op, err := replayFunding(replayed, FundingTransfer, req.ToUserID, req.Asset, req.Amount)
+		if err != nil {
+			return TransferResult{}, err
+		}
+		result.Operation = op
+		return result, nil
+	}
+	from := e.ensureWalletLocked(req.FromUserID)
+	if from.Available[req.Asset] < req.Amount {
+		e.mu.Unlock()
+		return TransferResult{}, &Error{
+			Code:    ErrorInsufficientFunds,
+			Message: "insufficient " + req.Asset + " balance to transfer",
+			Details: map[string]string{"asset": req.Asset},
+		}
+	}
+	to := e.ensureWalletLocked(req.ToUserID)
+
+	now := time.Now().UTC()
+	from.Available[req.Asset] -= req.Amount
+	to.Available[req.Asset] += req.Amount
+	from.UpdatedAt = now
+	to.UpdatedAt = now
+	op := e.recordFundingLocked(FundingTransfer, FundingCompleted, req.RequestID, req.FromUserID, req.ToUserID, req.Asset, req.Amount, now)
+	result := TransferResult{Operation: *op, From: copyWallet(from), To: copyWallet(to)}
+
+	batch := newEventBatch()
+	batch.touchedUsers[req.FromUserID] = struct{}{}
+	batch.touchedUsers[req.ToUserID] = struct{}{}
+	e.unlockAndPublish(batch)
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package matching
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSubAccountsStartEmptyAndTradeOnTransferredFunds(t *testing.T) {
+	engine := NewEngine()
+	sub := SubAccountUserID("u1", "sub-1")
+
+	if wallet := engine.Wallet(sub); len(wallet.Available) != 0 {
+		t.Fatalf("expected empty sub-account wallet, got %+v", wallet.Available)
+	}
+	_, err := engine.PlaceOrder(PlaceOrderRequest{UserID: sub, Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 100, Qty: 1})
+	if AsError(err).Code != ErrorInsufficientFunds {
+		t.Fatalf("expected unfunded sub-account order to fail, got %v", err)
+	}
+
+	result, err := engine.Transfer(TransferRequest{FromUserID: "u1", ToUserID: sub, Asset: "usd", Amount: 500})
+	if err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	if result.From.Available["USD"] != defaultQuoteBalance-500 || result.To.Available["USD"] != 500 {
+		t.Fatalf("unexpected balances after transfer: %+v", result)
+	}
+
+	ack, err := engine.PlaceOrder(PlaceOrderRequest{UserID: sub, Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 100, Qty: 1})
+	if err != nil || ack.Status != OrderStatusAccepted {
+		t.Fatalf("expected funded sub-account order to rest, got %+v %v", ack, err)
+	}
+	if open := engine.OpenOrders(sub); len(open) != 1 {
+		t.Fatalf("expected one open order on the sub-account, got %d", len(open))
+	}
+	if open := engine.OpenOrders("u1"); len(open) != 0 {
+		t.Fatalf("expected no open orders on the main account, got %d", len(open))
+	}
+}
+
+func TestTransferRejectsOtherUsersAndOverdrafts(t *testing.T) {
+	engine := NewEngine()
+	sub := SubAccountUserID("u1", "sub-1")
+
+	if _, err := engine.Transfer(TransferRequest{FromUserID: "u2", ToUserID: sub, Asset: "USD", Amount: 1}); err == nil {
+		t.Fatal("expected transfer from another user to fail")
+	}
+	_, err := engine.Transfer(TransferRequest{FromUserID: sub, ToUserID: "u1", Asset: "USD", Amount: 1})
+	var engineErr *Error
+	if !errors.As(err, &engineErr) || engineErr.Code != ErrorInsufficientFunds || engineErr.Details["asset"] != "USD" {
+		t.Fatalf("expected INSUFFICIENT_FUNDS for USD, got %v", err)
+	}
+}
+
+func TestUserKillSwitchCoversSubAccounts(t *testing.T) {
+	engine := NewEngine()
+	sub := SubAccountUserID("u1", "sub-1")
+	if _, err := engine.Transfer(TransferRequest{FromUserID: "u1", ToUserID: sub, Asset: "USD", Amount: 500}); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	if _, err := engine.PlaceOrder(PlaceOrderRequest{UserID: sub, Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 100, Qty: 1}); err != nil {
+		t.Fatalf("place failed: %v", err)
+	}
+
+	_, canceled, err := engine.ActivateKillSwitch(KillSwitch{Scope: KillSwitchUser, Target: "u1"})
+	if err != nil || len(canceled) != 1 {
+		t.Fatalf("expected the sub-account order to be canceled, got %d %v", len(canceled), err)
+	}
+	ack, err := engine.PlaceOrder(PlaceOrderRequest{UserID: sub, Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 100, Qty: 1})
+	if err != nil || ack.RejectCode != RejectKillSwitch || ack.RejectReason != "trading is disabled for u1" {
+		t.Fatalf("expected kill switch rejection, got %+v %v", ack, err)
+	}
+}
@@ -0,0 +1,172 @@
+package matching
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+const tickerWindow = 24 * time.Hour
+
+// Ticker summarizes a market. The 24h fields cover trades in the minute
+// buckets that fall inside the last 24 hours.
+type Ticker struct {
+	Symbol           string    `json:"symbol"`
+	LastPrice        int64     `json:"lastPrice"`
+	LastQty          int64     `json:"lastQty"`
+	BestBid          int64     `json:"bestBid"`
+	BestBidQty       int64     `json:"bestBidQty"`
+	BestAsk          int64     `json:"bestAsk"`
+	BestAskQty       int64     `json:"bestAskQty"`
+	Open24h          int64     `json:"open24h"`
+	High24h          int64     `json:"high24h"`
+	Low24h           int64     `json:"low24h"`
+	Volume24h        int64     `json:"volume24h"`
+	QuoteVolume24h   int64     `json:"quoteVolume24h"`
+	Trades24h        int64     `json:"trades24h"`
+	Change24h        int64     `json:"change24h"`
+	ChangePercent24h float64   `json:"changePercent24h"`
+	TS               time.Time `json:"ts"`
+}
+
+type TickerSink interface {
+	PublishTicker(ctx context.Context, ticker Ticker) error
+}
+
+type tickerBucket struct {
+	start       time.Time
+	open        int64
+	high        int64
+	low         int64
+	volume      int64
+	quoteVolume int64
+	trades      int64
+}
+
+type tickerState struct {
+	lastQty int64
+	buckets []tickerBucket
+}
+
+func (e *Engine) SetTickerSink(sink TickerSink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.tickerSink = sink
+}
+
+func (e *Engine) Ticker(symbol string) Ticker {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.tickerLocked(symbol, time.Now().UTC())
+}
+
+func (e *Engine) Tickers() []Ticker {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	symbols := make([]string, 0, len(e.books))
+	for symbol := range e.books {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	now := time.Now().UTC()
+	out := make([]Ticker, 0, len(symbols))
+	for _, symbol := range symbols {
+		out = append(out, e.tickerLocked(symbol, now))
+	}
+	return out
+}
+
+func (e *Engine) recordTickerTradeLocked(symbol string, price, qty int64, ts time.Time) {
+	state, ok := e.tickers[symbol]
+	if !ok {
+		state = &tickerState{}
+		e.tickers[symbol] = state
+	}
+	state.lastQty = qty
+
+	start := ts.Truncate(time.Minute)
+	last := len(state.buckets) - 1
+	if last < 0 || state.buckets[last].start.Before(start) {
+		state.buckets = append(state.buckets, tickerBucket{start: start, open: price, high: price, low: price})
+		last++
+	}
+
+	bucket := &state.buckets[last]
+	if price > bucket.high {
+		bucket.high = price
+	}
+	if price < bucket.low {
+		bucket.low = price
+	}
+	bucket.volume += qty
+	bucket.quoteVolume += price * qty
+	bucket.trades++
+}
+
+func (e *Engine) tickerLocked(symbol string, now time.Time) Ticker {
+	ticker := Ticker{
+		Symbol:    symbol,
+		LastPrice: e.lastPrices[symbol],
+		TS:        now,
+	}
+
+	if book := e.books[symbol]; book != nil {
+		if bids := aggregateBookLevels(book.bids, 1); len(bids) > 0 {
+			ticker.BestBid = bids[0].Price
+			ticker.BestBidQty = bids[0].Qty
+		}
+		if asks := aggregateBookLevels(book.asks, 1); len(asks) > 0 {
+			ticker.BestAsk = asks[0].Price
+			ticker.BestAskQty = asks[0].Qty
+		}
+	}
+
+	state, ok := e.tickers[symbol]
+	if !ok {
+		return ticker
+	}
+	ticker.LastQty = state.lastQty
+
+	cutoff := now.Add(-tickerWindow).Truncate(time.Minute)
+	expired := 0
+	for expired < len(state.buckets) && state.buckets[expired].start.Before(cutoff) {
+		expired++
+	}
+	state.buckets = state.buckets[expired:]
+
+	for i, bucket := range state.buckets {
+		if i == 0 {
+			ticker.Open24h = bucket.open
+			ticker.High24h = bucket.high
+			ticker.Low24h = bucket.low
+		}
+		if bucket.high > ticker.High24h {
+			ticker.High24h = bucket.high
+		}
+		if bucket.low < ticker.Low24h {
+			ticker.Low24h = bucket.low
+		}
+		ticker.Volume24h += bucket.volume
+		ticker.QuoteVolume24h += bucket.quoteVolume
+		ticker.Trades24h += bucket.trades
+	}
+
+	if ticker.Open24h > 0 {
+		ticker.Change24h = ticker.LastPrice - ticker.Open24h
+		ticker.ChangePercent24h = float64(ticker.Change24h) * 100 / float64(ticker.Open24h)
+	}
+	return ticker
+}
+
+func (e *Engine) collectTickersLocked(batch *eventBatch) {
+	if e.tickerSink == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, symbol := range sortedKeys(batch.symbols) {
+		batch.tickers = append(batch.tickers, e.tickerLocked(symbol, now))
+	}
+}
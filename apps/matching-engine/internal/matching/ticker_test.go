@@ -0,0 +1,62 @@
+package matching
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTickerReportsLastTradeAndBestQuotes(t *testing.T) {
+	engine := NewEngine()
+	engine.FundWallet("seller1", "BTC", 10)
+	engine.FundWallet("buyer1", "USD", 1000)
+
+	for _, req := range []PlaceOrderRequest{
+		{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 100, Qty: 2},
+		{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 110, Qty: 3},
+		{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeMarket, Qty: 2},
+		{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeLimit, Price: 95, Qty: 4},
+	} {
+		if _, err := engine.PlaceOrder(req); err != nil {
+			t.Fatalf("place order failed: %v", err)
+		}
+	}
+
+	ticker := engine.Ticker("BTC-USD")
+	if ticker.LastPrice != 100 || ticker.LastQty != 2 {
+		t.Fatalf("expected last trade 2 @ 100, got %+v", ticker)
+	}
+	if ticker.BestBid != 95 || ticker.BestBidQty != 4 || ticker.BestAsk != 110 || ticker.BestAskQty != 3 {
+		t.Fatalf("unexpected best quotes %+v", ticker)
+	}
+	if ticker.Volume24h != 2 || ticker.QuoteVolume24h != 200 || ticker.Trades24h != 1 {
+		t.Fatalf("unexpected 24h volume %+v", ticker)
+	}
+
+	tickers := engine.Tickers()
+	if len(tickers) != 1 || tickers[0].Symbol != "BTC-USD" {
+		t.Fatalf("expected one ticker for BTC-USD, got %+v", tickers)
+	}
+}
+
+func TestTickerRollsTwentyFourHourWindow(t *testing.T) {
+	engine := NewEngine()
+	now := time.Date(2026, 1, 2, 12, 0, 30, 0, time.UTC)
+
+	engine.recordTickerTradeLocked("BTC-USD", 90, 5, now.Add(-25*time.Hour))
+	engine.recordTickerTradeLocked("BTC-USD", 100, 1, now.Add(-23*time.Hour))
+	engine.recordTickerTradeLocked("BTC-USD", 120, 2, now.Add(-2*time.Hour))
+	engine.recordTickerTradeLocked("BTC-USD", 80, 1, now.Add(-2*time.Hour).Add(10*time.Second))
+	engine.recordTickerTradeLocked("BTC-USD", 110, 1, now.Add(-time.Minute))
+	engine.lastPrices["BTC-USD"] = 110
+
+	ticker := engine.tickerLocked("BTC-USD", now)
+	if ticker.Open24h != 100 || ticker.High24h != 120 || ticker.Low24h != 80 {
+		t.Fatalf("unexpected 24h range %+v", ticker)
+	}
+	if ticker.Volume24h != 5 || ticker.Trades24h != 4 {
+		t.Fatalf("expected trades older than 24h to roll off, got %+v", ticker)
+	}
+	if ticker.Change24h != 10 || ticker.ChangePercent24h != 10 {
+		t.Fatalf("expected +10 (10%%) change, got %+v", ticker)
+	}
+}
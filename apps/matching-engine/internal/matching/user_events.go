@@ -0,0 +1,100 @@
+package matching
+
+import (
+	"context"
+	"time"
+)
+
+// OrderUpdate is the state of one order after an engine call changed it.
+type OrderUpdate struct {
+	OrderID       string      `json:"orderId"`
+	ClientOrderID string      `json:"clientOrderId,omitempty"`
+	UserID        string      `json:"userId"`
+	Symbol        string      `json:"symbol"`
+	Side          Side        `json:"side"`
+	Type          OrderType   `json:"type"`
+	Price         int64       `json:"price"`
+	StopPrice     int64       `json:"stopPrice,omitempty"`
+	Qty           int64       `json:"qty"`
+	FilledQty     int64       `json:"filledQty"`
+	RemainingQty  int64       `json:"remainingQty"`
+	AvgPrice      int64       `json:"avgPrice"`
+	Status        OrderStatus `json:"status"`
+	ListID        string      `json:"listId,omitempty"`
+	TS            time.Time   `json:"ts"`
+}
+
+type UserEventSink interface {
+	PublishOrderUpdate(ctx context.Context, update OrderUpdate) error
+	PublishWalletUpdate(ctx context.Context, wallet Wallet) error
+}
+
+func (e *Engine) SetUserEventSink(sink UserEventSink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.userEventSink = sink
+}
+
+func (b *eventBatch) orderChanged(order *Order) {
+	if _, ok := b.changedOrders[order]; ok {
+		return
+	}
+	b.changedOrders[order] = struct{}{}
+	b.orderChanges = append(b.orderChanges, order)
+}
+
+func (e *Engine) collectUserEventsLocked(batch *eventBatch) {
+	if e.userEventSink == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, order := range batch.orderChanges {
+		ack := orderAck(order)
+		batch.orderUpdates = append(batch.orderUpdates, OrderUpdate{
+			OrderID:       order.OrderID,
+			ClientOrderID: order.ClientOrderID,
+			UserID:        order.UserID,
+			Symbol:        order.Symbol,
+			Side:          order.Side,
+			Type:          order.Type,
+			Price:         order.Price,
+			StopPrice:     order.StopPrice,
+			Qty:           order.Qty,
+			FilledQty:     ack.FilledQty,
+			RemainingQty:  order.RemainingQty,
+			AvgPrice:      ack.AvgPrice,
+			Status:        order.status,
+			ListID:        order.ListID,
+			TS:            now,
+		})
+	}
+
+	for _, userID := range sortedKeys(batch.touchedUsers) {
+		batch.walletUpdates = append(batch.walletUpdates, copyWallet(e.ensureWalletLocked(userID)))
+	}
+}
+
+// UserEventSinks publishes every event to each sink in turn and returns the
+// first error.
+type UserEventSinks []UserEventSink
+
+func (s UserEventSinks) PublishOrderUpdate(ctx context.Context, update OrderUpdate) error {
+	var first error
+	for _, sink := range s {
+		if err := sink.PublishOrderUpdate(ctx, update); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func (s UserEventSinks) PublishWalletUpdate(ctx context.Context, wallet Wallet) error {
+	var first error
+	for _, sink := range s {
+		if err := sink.PublishWalletUpdate(ctx, wallet); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
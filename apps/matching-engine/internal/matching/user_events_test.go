@@ -0,0 +1,81 @@
+package matching
+
+import (
+	"context"
+	"testing"
+)
+
+type recordingUserEventSink struct {
+	orders  []OrderUpdate
+	wallets []Wallet
+}
+
+func (s *recordingUserEventSink) PublishOrderUpdate(_ context.Context, update OrderUpdate) error {
+	s.orders = append(s.orders, update)
+	return nil
+}
+
+func (s *recordingUserEventSink) PublishWalletUpdate(_ context.Context, wallet Wallet) error {
+	s.wallets = append(s.wallets, wallet)
+	return nil
+}
+
+func (s *recordingUserEventSink) reset() {
+	s.orders = nil
+	s.wallets = nil
+}
+
+func TestUserEventsPublishedOnFillAndCancel(t *testing.T) {
+	engine := NewEngine()
+	sink := &recordingUserEventSink{}
+	engine.SetUserEventSink(sink)
+
+	engine.FundWallet("seller1", "BTC", 5)
+	if len(sink.wallets) != 1 || sink.wallets[0].UserID != "seller1" || sink.wallets[0].Available["BTC"] != 5 {
+		t.Fatalf("expected funding wallet update, got %+v", sink.wallets)
+	}
+
+	maker, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "seller1", Symbol: "BTC-USD", Side: SideSell, Type: OrderTypeLimit, Price: 100, Qty: 5})
+	if err != nil {
+		t.Fatalf("place maker failed: %v", err)
+	}
+	sink.reset()
+
+	taker, err := engine.PlaceOrder(PlaceOrderRequest{UserID: "buyer1", Symbol: "BTC-USD", Side: SideBuy, Type: OrderTypeMarket, Qty: 2})
+	if err != nil {
+		t.Fatalf("place taker failed: %v", err)
+	}
+
+	statuses := map[string]OrderUpdate{}
+	for _, update := range sink.orders {
+		statuses[update.OrderID] = update
+	}
+	if len(sink.orders) != 2 {
+		t.Fatalf("expected one update per order, got %+v", sink.orders)
+	}
+	if got := statuses[taker.OrderID]; got.Status != OrderStatusFilled || got.UserID != "buyer1" || got.AvgPrice != 100 {
+		t.Fatalf("unexpected taker update %+v", got)
+	}
+	if got := statuses[maker.OrderID]; got.Status != OrderStatusPartiallyFill || got.RemainingQty != 3 || got.FilledQty != 2 {
+		t.Fatalf("unexpected maker update %+v", got)
+	}
+
+	wallets := map[string]Wallet{}
+	for _, wallet := range sink.wallets {
+		wallets[wallet.UserID] = wallet
+	}
+	if wallets["buyer1"].Available["BTC"] != 2 || wallets["seller1"].Available["USD"] != 100200 {
+		t.Fatalf("unexpected wallet updates %+v", sink.wallets)
+	}
+
+	sink.reset()
+	if _, err := engine.CancelOrder("seller1", maker.OrderID); err != nil {
+		t.Fatalf("cancel failed: %v", err)
+	}
+	if len(sink.orders) != 1 || sink.orders[0].Status != OrderStatusCanceled {
+		t.Fatalf("expected canceled update, got %+v", sink.orders)
+	}
+	if len(sink.wallets) != 1 || sink.wallets[0].Reserved["BTC"] != 0 || sink.wallets[0].Available["BTC"] != 3 {
+		t.Fatalf("expected released reservation in wallet update, got %+v", sink.wallets)
+	}
+}
@@ -0,0 +1,250 @@
+package orderentry
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"kalency/apps/matching-engine/internal/matching"
+
+	"kalency/apps/matching-engine/sbe"
+)
+
+const (
+	connOutboundBuffer = 1024
+	writeTimeout       = 10 * time.Second
+)
+
+// Trading is the part of the matching engine the server drives.
+type Trading interface {
+	PlaceOrder(req matching.PlaceOrderRequest) (matching.OrderAck, error)
+	CancelOrder(userID, orderID string) (matching.OrderAck, error)
+}
+
+// Server accepts binary order entry connections. Fills are driven by engine
+// executions, so it must also be registered as an execution sink.
+type Server struct {
+	trading Trading
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[*conn]struct{}
+	closed   bool
+}
+
+func NewServer(trading Trading) *Server {
+	return &Server{trading: trading, conns: map[*conn]struct{}{}}
+}
+
+// Serve accepts connections until Close is called.
+func (s *Server) Serve(listener net.Listener) error {
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	for {
+		netConn, err := listener.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		go s.handleConn(netConn)
+	}
+}
+
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	listener := s.listener
+	conns := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range conns {
+		c.close()
+	}
+	if listener != nil {
+		return listener.Close()
+	}
+	return nil
+}
+
+func (s *Server) PublishExecution(_ context.Context, execution matching.Execution) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		if c.trades(execution.MakerUserID) {
+			c.send(fill(execution, execution.MakerOrderID, execution.MakerUserID, sbe.LiquidityMaker))
+		}
+		if c.trades(execution.TakerUserID) {
+			c.send(fill(execution, execution.TakerOrderID, execution.TakerUserID, sbe.LiquidityTaker))
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleConn(netConn net.Conn) {
+	c := &conn{
+		netConn:  netConn,
+		outbound: make(chan []byte, connOutboundBuffer),
+		done:     make(chan struct{}),
+		users:    map[string]struct{}{},
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		_ = netConn.Close()
+		return
+	}
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+
+	go c.writeLoop()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		c.close()
+	}()
+
+	reader := bufio.NewReader(netConn)
+	for {
+		msg, err := sbe.ReadMessage(reader)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				log.Printf("order entry connection %s closed: %v", netConn.RemoteAddr(), err)
+			}
+			return
+		}
+		switch m := msg.(type) {
+		case sbe.NewOrder:
+			c.addUser(m.UserID)
+			ack, err := s.trading.PlaceOrder(matching.PlaceOrderRequest{
+				ClientOrderID: m.ClientOrderID,
+				UserID:        m.UserID,
+				Symbol:        m.Symbol,
+				Side:          matching.Side(m.Side),
+				Type:          matching.OrderType(m.Type),
+				Price:         m.Price,
+				StopPrice:     m.StopPrice,
+				Qty:           m.Qty,
+			})
+			c.reply(m.RequestID, ack, err)
+		case sbe.CancelOrder:
+			ack, err := s.trading.CancelOrder(m.UserID, m.OrderID)
+			c.reply(m.RequestID, ack, err)
+		default:
+			log.Printf("order entry connection %s sent unexpected %T", netConn.RemoteAddr(), msg)
+			return
+		}
+	}
+}
+
+func fill(execution matching.Execution, orderID, userID, liquidity string) sbe.Fill {
+	return sbe.Fill{
+		OrderID:      orderID,
+		TradeID:      execution.TradeID,
+		UserID:       userID,
+		Symbol:       execution.Symbol,
+		Liquidity:    liquidity,
+		Price:        execution.Price,
+		Qty:          execution.Qty,
+		TransactTime: execution.TS.UnixNano(),
+	}
+}
+
+// conn is one client connection. Replies and fills share the outbound queue
+// so they reach the client in the order the engine produced them.
+type conn struct {
+	netConn   net.Conn
+	outbound  chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu    sync.Mutex
+	users map[string]struct{}
+}
+
+func (c *conn) addUser(userID string) {
+	c.mu.Lock()
+	c.users[userID] = struct{}{}
+	c.mu.Unlock()
+}
+
+func (c *conn) trades(userID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.users[userID]
+	return ok
+}
+
+func (c *conn) reply(requestID uint64, ack matching.OrderAck, err error) {
+	if err == nil && ack.Status == matching.OrderStatusRejected {
+		err = errors.New(string(ack.RejectCode) + ": " + ack.RejectReason)
+	}
+	if err != nil {
+		c.send(sbe.OrderReject{RequestID: requestID, Reason: err.Error()})
+		return
+	}
+	c.send(sbe.OrderAck{
+		RequestID:     requestID,
+		OrderID:       ack.OrderID,
+		ClientOrderID: ack.ClientOrderID,
+		Status:        string(ack.Status),
+		FilledQty:     ack.FilledQty,
+		RemainingQty:  ack.RemainingQty,
+		AvgPrice:      ack.AvgPrice,
+		TransactTime:  ack.TS.UnixNano(),
+	})
+}
+
+// send queues a message. A connection that cannot keep up is closed rather
+// than stalling the engine.
+func (c *conn) send(msg any) {
+	frame, err := sbe.Encode(msg)
+	if err != nil {
+		log.Printf("order entry encode failed: %v", err)
+		return
+	}
+	select {
+	case c.outbound <- frame:
+	case <-c.done:
+	default:
+		log.Printf("order entry connection %s too slow, disconnecting", c.netConn.RemoteAddr())
+		c.close()
+	}
+}
+
+func (c *conn) writeLoop() {
+	for {
+		select {
+		case frame := <-c.outbound:
+			_ = c.netConn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if _, err := c.netConn.Write(frame); err != nil {
+				c.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *conn) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		_ = c.netConn.Close()
+	})
+}
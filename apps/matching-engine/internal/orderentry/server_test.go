@@ -0,0 +1,128 @@
+package orderentry
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"kalency/apps/matching-engine/internal/matching"
+
+	"kalency/apps/matching-engine/sbe"
+)
+
+func startServer(t *testing.T, engine *matching.Engine) string {
+	t.Helper()
+
+	server := NewServer(engine)
+	engine.SetExecutionSink(server)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+	return listener.Addr().String()
+}
+
+type testClient struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dial(t *testing.T, addr string) *testClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return &testClient{t: t, conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (c *testClient) send(msg any) {
+	c.t.Helper()
+	frame, err := sbe.Encode(msg)
+	if err != nil {
+		c.t.Fatalf("encode failed: %v", err)
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		c.t.Fatalf("write failed: %v", err)
+	}
+}
+
+func (c *testClient) read() any {
+	c.t.Helper()
+	_ = c.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	msg, err := sbe.ReadMessage(c.reader)
+	if err != nil {
+		c.t.Fatalf("read failed: %v", err)
+	}
+	return msg
+}
+
+func TestServerPlacesAndCancelsOrders(t *testing.T) {
+	engine := matching.NewEngine()
+	client := dial(t, startServer(t, engine))
+
+	client.send(sbe.NewOrder{RequestID: 1, ClientOrderID: "c-1", UserID: "buyer1", Symbol: "BTC-USD", Side: "BUY", Type: "LIMIT", Price: 100, Qty: 2})
+	ack, ok := client.read().(sbe.OrderAck)
+	if !ok || ack.RequestID != 1 || ack.Status != "ACCEPTED" || ack.ClientOrderID != "c-1" || ack.RemainingQty != 2 {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+
+	client.send(sbe.CancelOrder{RequestID: 2, UserID: "buyer1", OrderID: ack.OrderID})
+	canceled, ok := client.read().(sbe.OrderAck)
+	if !ok || canceled.RequestID != 2 || canceled.Status != "CANCELED" {
+		t.Fatalf("unexpected cancel ack: %+v", canceled)
+	}
+
+	client.send(sbe.CancelOrder{RequestID: 3, UserID: "buyer1", OrderID: ack.OrderID})
+	reject, ok := client.read().(sbe.OrderReject)
+	if !ok || reject.RequestID != 3 || reject.Reason == "" {
+		t.Fatalf("expected reject, got %+v", reject)
+	}
+}
+
+func TestServerSendsFillsBeforeTakerAck(t *testing.T) {
+	engine := matching.NewEngine()
+	engine.FundWallet("seller1", "BTC", 5)
+	client := dial(t, startServer(t, engine))
+
+	client.send(sbe.NewOrder{RequestID: 1, UserID: "seller1", Symbol: "BTC-USD", Side: "SELL", Type: "LIMIT", Price: 101, Qty: 5})
+	makerAck := client.read().(sbe.OrderAck)
+
+	client.send(sbe.NewOrder{RequestID: 2, UserID: "buyer1", Symbol: "BTC-USD", Side: "BUY", Type: "MARKET", Qty: 2})
+	makerFill, ok := client.read().(sbe.Fill)
+	if !ok || makerFill.OrderID != makerAck.OrderID || makerFill.Liquidity != sbe.LiquidityMaker || makerFill.Qty != 2 || makerFill.Price != 101 {
+		t.Fatalf("unexpected maker fill: %+v", makerFill)
+	}
+	takerFill, ok := client.read().(sbe.Fill)
+	if !ok || takerFill.UserID != "buyer1" || takerFill.Liquidity != sbe.LiquidityTaker || takerFill.TradeID != makerFill.TradeID {
+		t.Fatalf("unexpected taker fill: %+v", takerFill)
+	}
+	takerAck, ok := client.read().(sbe.OrderAck)
+	if !ok || takerAck.RequestID != 2 || takerAck.OrderID != takerFill.OrderID || takerAck.Status != "FILLED" {
+		t.Fatalf("unexpected taker ack: %+v", takerAck)
+	}
+}
+
+func TestServerOnlySendsFillsForConnectionUsers(t *testing.T) {
+	engine := matching.NewEngine()
+	engine.FundWallet("seller1", "BTC", 5)
+	addr := startServer(t, engine)
+	seller := dial(t, addr)
+	buyer := dial(t, addr)
+
+	seller.send(sbe.NewOrder{RequestID: 1, UserID: "seller1", Symbol: "BTC-USD", Side: "SELL", Type: "LIMIT", Price: 101, Qty: 5})
+	seller.read()
+
+	buyer.send(sbe.NewOrder{RequestID: 1, UserID: "buyer1", Symbol: "BTC-USD", Side: "BUY", Type: "MARKET", Qty: 1})
+	if fill, ok := buyer.read().(sbe.Fill); !ok || fill.Liquidity != sbe.LiquidityTaker {
+		t.Fatalf("expected buyer's taker fill, got %+v", fill)
+	}
+	if fill, ok := seller.read().(sbe.Fill); !ok || fill.Liquidity != sbe.LiquidityMaker {
+		t.Fatalf("expected seller's maker fill, got %+v", fill)
+	}
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+const (
+	defaultBookStreamMaxLen = int64(10000)
+	bookSnapshotsRetained   = int64(10)
+)
+
+// RedisBookStreamSink appends level deltas to {prefix}:stream:book:{symbol},
+// order-by-order events to {prefix}:stream:l3:{symbol}, and keeps the most
+// recent full snapshots in the sorted set {prefix}:book:snapshots:{symbol},
+// scored by the sequence they cover.
+type RedisBookStreamSink struct {
+	client redis.UniversalClient
+	prefix string
+	maxLen int64
+}
+
+func NewRedisBookStreamSink(client redis.UniversalClient, prefix string) *RedisBookStreamSink {
+	if prefix == "" {
+		prefix = "kalency:v1"
+	}
+	return &RedisBookStreamSink{client: client, prefix: prefix, maxLen: defaultBookStreamMaxLen}
+}
+
+func (s *RedisBookStreamSink) PublishBookDelta(ctx context.Context, delta matching.BookDelta) error {
+	values := map[string]any{
+		"seq":    delta.Seq,
+		"side":   string(delta.Side),
+		"price":  delta.Price,
+		"qty":    delta.Qty,
+		"orders": delta.Orders,
+		"ts":     delta.TS.Format(time.RFC3339Nano),
+	}
+
+	return s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.streamKey(delta.Symbol),
+		MaxLen: s.maxLen,
+		Approx: true,
+		ID:     "*",
+		Values: values,
+	}).Err()
+}
+
+func (s *RedisBookStreamSink) PublishOrderEvent(ctx context.Context, event matching.OrderEvent) error {
+	values := map[string]any{
+		"seq":      event.Seq,
+		"type":     string(event.Type),
+		"order_id": event.OrderID,
+		"side":     string(event.Side),
+		"price":    event.Price,
+		"qty":      event.Qty,
+		"exec_qty": event.ExecQty,
+		"trade_id": event.TradeID,
+		"ts":       event.TS.Format(time.RFC3339Nano),
+	}
+
+	return s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.orderStreamKey(event.Symbol),
+		MaxLen: s.maxLen,
+		Approx: true,
+		ID:     "*",
+		Values: values,
+	}).Err()
+}
+
+func (s *RedisBookStreamSink) PublishBookSnapshot(ctx context.Context, snapshot matching.OrderBookSnapshot) error {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	key := s.snapshotsKey(snapshot.Symbol)
+	pipe := s.client.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(snapshot.Seq), Member: payload})
+	pipe.ZRemRangeByRank(ctx, key, 0, -bookSnapshotsRetained-1)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// LatestBookSnapshot returns the newest stored snapshot for symbol. Deltas
+// with a seq greater than the snapshot's apply on top of it.
+func (s *RedisBookStreamSink) LatestBookSnapshot(ctx context.Context, symbol string) (matching.OrderBookSnapshot, bool, error) {
+	payloads, err := s.client.ZRevRange(ctx, s.snapshotsKey(symbol), 0, 0).Result()
+	if err != nil {
+		return matching.OrderBookSnapshot{}, false, err
+	}
+	if len(payloads) == 0 {
+		return matching.OrderBookSnapshot{}, false, nil
+	}
+
+	var snapshot matching.OrderBookSnapshot
+	if err := json.Unmarshal([]byte(payloads[0]), &snapshot); err != nil {
+		return matching.OrderBookSnapshot{}, false, err
+	}
+	return snapshot, true, nil
+}
+
+func (s *RedisBookStreamSink) streamKey(symbol string) string {
+	return fmt.Sprintf("%s:stream:book:%s", s.prefix, symbol)
+}
+
+func (s *RedisBookStreamSink) orderStreamKey(symbol string) string {
+	return fmt.Sprintf("%s:stream:l3:%s", s.prefix, symbol)
+}
+
+func (s *RedisBookStreamSink) snapshotsKey(symbol string) string {
+	return fmt.Sprintf("%s:book:snapshots:%s", s.prefix, symbol)
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+func TestRedisBookStreamSinkPublishesDeltasAndSnapshots(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	sink := NewRedisBookStreamSink(client, "kalency:v1")
+
+	delta := matching.BookDelta{
+		Symbol: "BTC-USD",
+		Seq:    7,
+		Side:   matching.SideSell,
+		Price:  101,
+		Qty:    0,
+		TS:     time.Unix(10, 0).UTC(),
+	}
+	if err := sink.PublishBookDelta(ctx, delta); err != nil {
+		t.Fatalf("publish delta failed: %v", err)
+	}
+
+	messages, err := client.XRange(ctx, "kalency:v1:stream:book:BTC-USD", "-", "+").Result()
+	if err != nil {
+		t.Fatalf("xrange failed: %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 stream message, got %d", len(messages))
+	}
+	if got := fmt.Sprint(messages[0].Values["seq"]); got != "7" {
+		t.Fatalf("expected seq 7, got %s", got)
+	}
+	if got := fmt.Sprint(messages[0].Values["qty"]); got != "0" {
+		t.Fatalf("expected qty 0, got %s", got)
+	}
+
+	for seq := int64(1); seq <= 12; seq++ {
+		snapshot := matching.OrderBookSnapshot{
+			Symbol: "BTC-USD",
+			Seq:    seq * 100,
+			Bids:   []matching.BookLevel{{Price: 99, Qty: seq, Orders: 1}},
+			Asks:   []matching.BookLevel{},
+		}
+		if err := sink.PublishBookSnapshot(ctx, snapshot); err != nil {
+			t.Fatalf("publish snapshot failed: %v", err)
+		}
+	}
+
+	if count := client.ZCard(ctx, "kalency:v1:book:snapshots:BTC-USD").Val(); count != 10 {
+		t.Fatalf("expected 10 retained snapshots, got %d", count)
+	}
+
+	latest, ok, err := sink.LatestBookSnapshot(ctx, "BTC-USD")
+	if err != nil || !ok {
+		t.Fatalf("expected latest snapshot, ok=%v err=%v", ok, err)
+	}
+	if latest.Seq != 1200 || latest.Bids[0].Qty != 12 {
+		t.Fatalf("expected snapshot seq 1200, got %+v", latest)
+	}
+}
+
+func TestRedisBookStreamSinkPublishesOrderEvents(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	sink := NewRedisBookStreamSink(client, "")
+
+	event := matching.OrderEvent{
+		Symbol:  "BTC-USD",
+		Seq:     3,
+		Type:    matching.OrderEventExecute,
+		OrderID: "ord-1",
+		Side:    matching.SideSell,
+		Price:   101,
+		Qty:     1,
+		ExecQty: 2,
+		TradeID: "trd-1",
+		TS:      time.Unix(10, 0).UTC(),
+	}
+	if err := sink.PublishOrderEvent(ctx, event); err != nil {
+		t.Fatalf("publish order event failed: %v", err)
+	}
+
+	messages, err := client.XRange(ctx, "kalency:v1:stream:l3:BTC-USD", "-", "+").Result()
+	if err != nil {
+		t.Fatalf("xrange failed: %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 stream message, got %d", len(messages))
+	}
+	values := messages[0].Values
+	if got := fmt.Sprint(values["type"]); got != "EXECUTE" {
+		t.Fatalf("expected type EXECUTE, got %s", got)
+	}
+	if got := fmt.Sprint(values["exec_qty"]); got != "2" {
+		t.Fatalf("expected exec_qty 2, got %s", got)
+	}
+	if _, ok := values["user_id"]; ok {
+		t.Fatal("expected order events to omit user ids")
+	}
+}
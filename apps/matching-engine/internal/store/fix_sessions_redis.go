@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/matching-engine/internal/fix"
+)
+
+const defaultFIXOutgoingKeep = int64(10000)
+
+// RedisFIXSessionStore keeps FIX sequence numbers in a hash per session and
+// the last sent messages in a sorted set scored by MsgSeqNum.
+type RedisFIXSessionStore struct {
+	client redis.UniversalClient
+	prefix string
+	keep   int64
+}
+
+func NewRedisFIXSessionStore(client redis.UniversalClient, prefix string) *RedisFIXSessionStore {
+	if prefix == "" {
+		prefix = "kalency:v1"
+	}
+	return &RedisFIXSessionStore{client: client, prefix: prefix, keep: defaultFIXOutgoingKeep}
+}
+
+func (s *RedisFIXSessionStore) seqKey(sessionID string) string {
+	return s.prefix + ":fix:seq:" + sessionID
+}
+
+func (s *RedisFIXSessionStore) outgoingKey(sessionID string) string {
+	return s.prefix + ":fix:outgoing:" + sessionID
+}
+
+func (s *RedisFIXSessionStore) Load(ctx context.Context, sessionID string) (fix.SeqNums, error) {
+	values, err := s.client.HMGet(ctx, s.seqKey(sessionID), "next_in", "next_out").Result()
+	if err != nil {
+		return fix.SeqNums{}, err
+	}
+	seqs := fix.SeqNums{}
+	if raw, ok := values[0].(string); ok {
+		seqs.NextIn, _ = strconv.Atoi(raw)
+	}
+	if raw, ok := values[1].(string); ok {
+		seqs.NextOut, _ = strconv.Atoi(raw)
+	}
+	return seqs, nil
+}
+
+func (s *RedisFIXSessionStore) SetNextIn(ctx context.Context, sessionID string, next int) error {
+	return s.client.HSet(ctx, s.seqKey(sessionID), "next_in", next).Err()
+}
+
+func (s *RedisFIXSessionStore) SaveOutgoing(ctx context.Context, sessionID string, seq int, raw []byte) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, s.outgoingKey(sessionID), redis.Z{Score: float64(seq), Member: raw})
+		pipe.ZRemRangeByScore(ctx, s.outgoingKey(sessionID), "-inf", "("+strconv.FormatInt(int64(seq)-s.keep+1, 10))
+		pipe.HSet(ctx, s.seqKey(sessionID), "next_out", seq+1)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisFIXSessionStore) Outgoing(ctx context.Context, sessionID string, begin, end int) ([]fix.StoredMessage, error) {
+	upper := "+inf"
+	if end > 0 {
+		upper = strconv.Itoa(end)
+	}
+	entries, err := s.client.ZRangeByScoreWithScores(ctx, s.outgoingKey(sessionID), &redis.ZRangeBy{
+		Min: strconv.Itoa(begin),
+		Max: upper,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]fix.StoredMessage, 0, len(entries))
+	for _, entry := range entries {
+		raw, _ := entry.Member.(string)
+		out = append(out, fix.StoredMessage{Seq: int(entry.Score), Raw: []byte(raw)})
+	}
+	return out, nil
+}
+
+func (s *RedisFIXSessionStore) Reset(ctx context.Context, sessionID string) error {
+	return s.client.Del(ctx, s.seqKey(sessionID), s.outgoingKey(sessionID)).Err()
+}
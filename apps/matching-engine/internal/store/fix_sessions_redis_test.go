@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisFIXSessionStorePersistsSequencesAndMessages(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	store := NewRedisFIXSessionStore(client, "")
+	store.keep = 2
+
+	seqs, err := store.Load(ctx, "ALGO")
+	if err != nil || seqs.NextIn != 0 || seqs.NextOut != 0 {
+		t.Fatalf("expected empty session, got %+v (%v)", seqs, err)
+	}
+
+	if err := store.SetNextIn(ctx, "ALGO", 4); err != nil {
+		t.Fatalf("set next in failed: %v", err)
+	}
+	for seq, raw := range map[int]string{1: "m1", 2: "m2", 3: "m3"} {
+		if err := store.SaveOutgoing(ctx, "ALGO", seq, []byte(raw)); err != nil {
+			t.Fatalf("save outgoing failed: %v", err)
+		}
+	}
+	if err := store.SaveOutgoing(ctx, "ALGO", 4, []byte("m4")); err != nil {
+		t.Fatalf("save outgoing failed: %v", err)
+	}
+
+	seqs, err = store.Load(ctx, "ALGO")
+	if err != nil || seqs.NextIn != 4 || seqs.NextOut != 5 {
+		t.Fatalf("unexpected seqs %+v (%v)", seqs, err)
+	}
+
+	stored, err := store.Outgoing(ctx, "ALGO", 1, 0)
+	if err != nil {
+		t.Fatalf("outgoing failed: %v", err)
+	}
+	if len(stored) != 2 || stored[0].Seq != 3 || string(stored[1].Raw) != "m4" {
+		t.Fatalf("expected the last 2 messages, got %+v", stored)
+	}
+
+	if err := store.Reset(ctx, "ALGO"); err != nil {
+		t.Fatalf("reset failed: %v", err)
+	}
+	if seqs, _ := store.Load(ctx, "ALGO"); seqs.NextOut != 0 {
+		t.Fatalf("expected reset session, got %+v", seqs)
+	}
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+// RedisKillSwitchStore keeps active kill switches as JSON in one hash keyed
+// by scope and target.
+type RedisKillSwitchStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func NewRedisKillSwitchStore(client redis.UniversalClient, prefix string) *RedisKillSwitchStore {
+	if prefix == "" {
+		prefix = "kalency:v1"
+	}
+	return &RedisKillSwitchStore{client: client, prefix: prefix}
+}
+
+func (s *RedisKillSwitchStore) key() string {
+	return s.prefix + ":killswitches"
+}
+
+func killSwitchField(scope matching.KillSwitchScope, target string) string {
+	return string(scope) + ":" + target
+}
+
+func (s *RedisKillSwitchStore) SaveKillSwitch(ctx context.Context, killSwitch matching.KillSwitch) error {
+	payload, err := json.Marshal(killSwitch)
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(ctx, s.key(), killSwitchField(killSwitch.Scope, killSwitch.Target), payload).Err()
+}
+
+func (s *RedisKillSwitchStore) DeleteKillSwitch(ctx context.Context, scope matching.KillSwitchScope, target string) error {
+	return s.client.HDel(ctx, s.key(), killSwitchField(scope, target)).Err()
+}
+
+func (s *RedisKillSwitchStore) LoadKillSwitches(ctx context.Context) ([]matching.KillSwitch, error) {
+	values, err := s.client.HGetAll(ctx, s.key()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]matching.KillSwitch, 0, len(values))
+	for _, raw := range values {
+		var killSwitch matching.KillSwitch
+		if err := json.Unmarshal([]byte(raw), &killSwitch); err != nil {
+			return nil, err
+		}
+		out = append(out, killSwitch)
+	}
+	return out, nil
+}
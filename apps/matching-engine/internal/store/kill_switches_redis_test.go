@@ -0,0 +1,44 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+func TestRedisKillSwitchStoreRoundTrip(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	store := NewRedisKillSwitchStore(client, "")
+	if err := store.SaveKillSwitch(ctx, matching.KillSwitch{Scope: matching.KillSwitchUser, Target: "bot", Reason: "runaway"}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if err := store.SaveKillSwitch(ctx, matching.KillSwitch{Scope: matching.KillSwitchGlobal}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if got := mini.HGet("kalency:v1:killswitches", "USER:bot"); got == "" {
+		t.Fatal("expected the user kill switch in the hash")
+	}
+
+	if err := store.DeleteKillSwitch(ctx, matching.KillSwitchGlobal, ""); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	loaded, err := store.LoadKillSwitches(ctx)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].Target != "bot" || loaded[0].Reason != "runaway" {
+		t.Fatalf("unexpected kill switches %+v", loaded)
+	}
+}
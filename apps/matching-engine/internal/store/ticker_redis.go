@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+// RedisTickerStore keeps {prefix}:last_price:{symbol} and the JSON ticker in
+// {prefix}:ticker:{symbol} current.
+type RedisTickerStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func NewRedisTickerStore(client redis.UniversalClient, prefix string) *RedisTickerStore {
+	if prefix == "" {
+		prefix = "kalency:v1"
+	}
+	return &RedisTickerStore{client: client, prefix: prefix}
+}
+
+func (s *RedisTickerStore) PublishTicker(ctx context.Context, ticker matching.Ticker) error {
+	payload, err := json.Marshal(ticker)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	if ticker.LastPrice > 0 {
+		pipe.Set(ctx, fmt.Sprintf("%s:last_price:%s", s.prefix, ticker.Symbol), ticker.LastPrice, 0)
+	}
+	pipe.Set(ctx, fmt.Sprintf("%s:ticker:%s", s.prefix, ticker.Symbol), payload, 0)
+	_, err = pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+func TestRedisTickerStorePublishTicker(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	store := NewRedisTickerStore(client, "kalency:v1")
+	ticker := matching.Ticker{Symbol: "BTC-USD", LastPrice: 101, BestBid: 100, BestAsk: 102, Volume24h: 7}
+	if err := store.PublishTicker(context.Background(), ticker); err != nil {
+		t.Fatalf("publish ticker failed: %v", err)
+	}
+
+	lastPrice, err := mini.Get("kalency:v1:last_price:BTC-USD")
+	if err != nil {
+		t.Fatalf("missing last price: %v", err)
+	}
+	if lastPrice != "101" {
+		t.Fatalf("expected last price 101, got %s", lastPrice)
+	}
+
+	payload, err := mini.Get("kalency:v1:ticker:BTC-USD")
+	if err != nil {
+		t.Fatalf("missing ticker: %v", err)
+	}
+	var decoded matching.Ticker
+	if err := json.Unmarshal([]byte(payload), &decoded); err != nil {
+		t.Fatalf("decode ticker failed: %v", err)
+	}
+	if decoded.BestAsk != 102 || decoded.Volume24h != 7 {
+		t.Fatalf("unexpected ticker %+v", decoded)
+	}
+}
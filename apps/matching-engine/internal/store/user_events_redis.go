@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+const defaultUserEventStreamMaxLen = int64(100000)
+
+// RedisUserEventSink appends private order and wallet updates to one stream.
+// Each entry names the channel it belongs to (orders.{userId} or
+// wallet.{userId}) so the gateway can route it without decoding the payload.
+type RedisUserEventSink struct {
+	client redis.UniversalClient
+	stream string
+	maxLen int64
+}
+
+func NewRedisUserEventSink(client redis.UniversalClient, stream string) *RedisUserEventSink {
+	if stream == "" {
+		stream = "kalency:v1:stream:user-events"
+	}
+	return &RedisUserEventSink{client: client, stream: stream, maxLen: defaultUserEventStreamMaxLen}
+}
+
+func (s *RedisUserEventSink) PublishOrderUpdate(ctx context.Context, update matching.OrderUpdate) error {
+	return s.publish(ctx, "orders."+update.UserID, update.UserID, update)
+}
+
+func (s *RedisUserEventSink) PublishWalletUpdate(ctx context.Context, wallet matching.Wallet) error {
+	return s.publish(ctx, "wallet."+wallet.UserID, wallet.UserID, wallet)
+}
+
+func (s *RedisUserEventSink) publish(ctx context.Context, channel, userID string, payload any) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: true,
+		ID:     "*",
+		Values: map[string]any{
+			"channel": channel,
+			"user_id": userID,
+			"payload": string(encoded),
+		},
+	}).Err()
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"kalency/apps/matching-engine/internal/matching"
+)
+
+func TestRedisUserEventSinkPublishesChannelScopedEntries(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	sink := NewRedisUserEventSink(client, "")
+	if err := sink.PublishOrderUpdate(ctx, matching.OrderUpdate{OrderID: "ord-1", UserID: "u1", Status: matching.OrderStatusFilled}); err != nil {
+		t.Fatalf("publish order update failed: %v", err)
+	}
+	if err := sink.PublishWalletUpdate(ctx, matching.Wallet{UserID: "u1", Available: map[string]int64{"USD": 10}}); err != nil {
+		t.Fatalf("publish wallet update failed: %v", err)
+	}
+
+	messages, err := client.XRange(ctx, "kalency:v1:stream:user-events", "-", "+").Result()
+	if err != nil {
+		t.Fatalf("xrange failed: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(messages))
+	}
+	if got := fmt.Sprint(messages[0].Values["channel"]); got != "orders.u1" {
+		t.Fatalf("expected channel orders.u1, got %s", got)
+	}
+	if got := fmt.Sprint(messages[1].Values["channel"]); got != "wallet.u1" {
+		t.Fatalf("expected channel wallet.u1, got %s", got)
+	}
+
+	var update matching.OrderUpdate
+	if err := json.Unmarshal([]byte(fmt.Sprint(messages[0].Values["payload"])), &update); err != nil {
+		t.Fatalf("decode payload failed: %v", err)
+	}
+	if update.OrderID != "ord-1" || update.Status != matching.OrderStatusFilled {
+		t.Fatalf("unexpected order update %+v", update)
+	}
+}
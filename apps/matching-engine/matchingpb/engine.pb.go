@@ -0,0 +1,3364 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: kalency/matching/v1/engine.proto
+
+package matchingpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Side int32
+
+const (
+	Side_SIDE_UNSPECIFIED Side = 0
+	Side_SIDE_BUY         Side = 1
+	Side_SIDE_SELL        Side = 2
+)
+
+// Enum value maps for Side.
+var (
+	Side_name = map[int32]string{
+		0: "SIDE_UNSPECIFIED",
+		1: "SIDE_BUY",
+		2: "SIDE_SELL",
+	}
+	Side_value = map[string]int32{
+		"SIDE_UNSPECIFIED": 0,
+		"SIDE_BUY":         1,
+		"SIDE_SELL":        2,
+	}
+)
+
+func (x Side) Enum() *Side {
+	p := new(Side)
+	*p = x
+	return p
+}
+
+func (x Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_kalency_matching_v1_engine_proto_enumTypes[0].Descriptor()
+}
+
+func (Side) Type() protoreflect.EnumType {
+	return &file_kalency_matching_v1_engine_proto_enumTypes[0]
+}
+
+func (x Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Side.Descriptor instead.
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderType int32
+
+const (
+	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
+	OrderType_ORDER_TYPE_MARKET      OrderType = 1
+	OrderType_ORDER_TYPE_LIMIT       OrderType = 2
+	OrderType_ORDER_TYPE_STOP_MARKET OrderType = 3
+	OrderType_ORDER_TYPE_STOP_LIMIT  OrderType = 4
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "ORDER_TYPE_UNSPECIFIED",
+		1: "ORDER_TYPE_MARKET",
+		2: "ORDER_TYPE_LIMIT",
+		3: "ORDER_TYPE_STOP_MARKET",
+		4: "ORDER_TYPE_STOP_LIMIT",
+	}
+	OrderType_value = map[string]int32{
+		"ORDER_TYPE_UNSPECIFIED": 0,
+		"ORDER_TYPE_MARKET":      1,
+		"ORDER_TYPE_LIMIT":       2,
+		"ORDER_TYPE_STOP_MARKET": 3,
+		"ORDER_TYPE_STOP_LIMIT":  4,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_kalency_matching_v1_engine_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_kalency_matching_v1_engine_proto_enumTypes[1]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED      OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING          OrderStatus = 1
+	OrderStatus_ORDER_STATUS_ACCEPTED         OrderStatus = 2
+	OrderStatus_ORDER_STATUS_PARTIALLY_FILLED OrderStatus = 3
+	OrderStatus_ORDER_STATUS_FILLED           OrderStatus = 4
+	OrderStatus_ORDER_STATUS_CANCELED         OrderStatus = 5
+	OrderStatus_ORDER_STATUS_REJECTED         OrderStatus = 6
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_PENDING",
+		2: "ORDER_STATUS_ACCEPTED",
+		3: "ORDER_STATUS_PARTIALLY_FILLED",
+		4: "ORDER_STATUS_FILLED",
+		5: "ORDER_STATUS_CANCELED",
+		6: "ORDER_STATUS_REJECTED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED":      0,
+		"ORDER_STATUS_PENDING":          1,
+		"ORDER_STATUS_ACCEPTED":         2,
+		"ORDER_STATUS_PARTIALLY_FILLED": 3,
+		"ORDER_STATUS_FILLED":           4,
+		"ORDER_STATUS_CANCELED":         5,
+		"ORDER_STATUS_REJECTED":         6,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_kalency_matching_v1_engine_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_kalency_matching_v1_engine_proto_enumTypes[2]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{2}
+}
+
+type OrderListType int32
+
+const (
+	OrderListType_ORDER_LIST_TYPE_UNSPECIFIED OrderListType = 0
+	OrderListType_ORDER_LIST_TYPE_OCO         OrderListType = 1
+	OrderListType_ORDER_LIST_TYPE_BRACKET     OrderListType = 2
+)
+
+// Enum value maps for OrderListType.
+var (
+	OrderListType_name = map[int32]string{
+		0: "ORDER_LIST_TYPE_UNSPECIFIED",
+		1: "ORDER_LIST_TYPE_OCO",
+		2: "ORDER_LIST_TYPE_BRACKET",
+	}
+	OrderListType_value = map[string]int32{
+		"ORDER_LIST_TYPE_UNSPECIFIED": 0,
+		"ORDER_LIST_TYPE_OCO":         1,
+		"ORDER_LIST_TYPE_BRACKET":     2,
+	}
+)
+
+func (x OrderListType) Enum() *OrderListType {
+	p := new(OrderListType)
+	*p = x
+	return p
+}
+
+func (x OrderListType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderListType) Descriptor() protoreflect.EnumDescriptor {
+	return file_kalency_matching_v1_engine_proto_enumTypes[3].Descriptor()
+}
+
+func (OrderListType) Type() protoreflect.EnumType {
+	return &file_kalency_matching_v1_engine_proto_enumTypes[3]
+}
+
+func (x OrderListType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderListType.Descriptor instead.
+func (OrderListType) EnumDescriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{3}
+}
+
+type OrderListStatus int32
+
+const (
+	OrderListStatus_ORDER_LIST_STATUS_UNSPECIFIED OrderListStatus = 0
+	OrderListStatus_ORDER_LIST_STATUS_PENDING     OrderListStatus = 1
+	OrderListStatus_ORDER_LIST_STATUS_ACTIVE      OrderListStatus = 2
+	OrderListStatus_ORDER_LIST_STATUS_ALL_DONE    OrderListStatus = 3
+)
+
+// Enum value maps for OrderListStatus.
+var (
+	OrderListStatus_name = map[int32]string{
+		0: "ORDER_LIST_STATUS_UNSPECIFIED",
+		1: "ORDER_LIST_STATUS_PENDING",
+		2: "ORDER_LIST_STATUS_ACTIVE",
+		3: "ORDER_LIST_STATUS_ALL_DONE",
+	}
+	OrderListStatus_value = map[string]int32{
+		"ORDER_LIST_STATUS_UNSPECIFIED": 0,
+		"ORDER_LIST_STATUS_PENDING":     1,
+		"ORDER_LIST_STATUS_ACTIVE":      2,
+		"ORDER_LIST_STATUS_ALL_DONE":    3,
+	}
+)
+
+func (x OrderListStatus) Enum() *OrderListStatus {
+	p := new(OrderListStatus)
+	*p = x
+	return p
+}
+
+func (x OrderListStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderListStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_kalency_matching_v1_engine_proto_enumTypes[4].Descriptor()
+}
+
+func (OrderListStatus) Type() protoreflect.EnumType {
+	return &file_kalency_matching_v1_engine_proto_enumTypes[4]
+}
+
+func (x OrderListStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderListStatus.Descriptor instead.
+func (OrderListStatus) EnumDescriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{4}
+}
+
+type OrderListRole int32
+
+const (
+	OrderListRole_ORDER_LIST_ROLE_UNSPECIFIED OrderListRole = 0
+	OrderListRole_ORDER_LIST_ROLE_ENTRY       OrderListRole = 1
+	OrderListRole_ORDER_LIST_ROLE_TAKE_PROFIT OrderListRole = 2
+	OrderListRole_ORDER_LIST_ROLE_STOP_LOSS   OrderListRole = 3
+)
+
+// Enum value maps for OrderListRole.
+var (
+	OrderListRole_name = map[int32]string{
+		0: "ORDER_LIST_ROLE_UNSPECIFIED",
+		1: "ORDER_LIST_ROLE_ENTRY",
+		2: "ORDER_LIST_ROLE_TAKE_PROFIT",
+		3: "ORDER_LIST_ROLE_STOP_LOSS",
+	}
+	OrderListRole_value = map[string]int32{
+		"ORDER_LIST_ROLE_UNSPECIFIED": 0,
+		"ORDER_LIST_ROLE_ENTRY":       1,
+		"ORDER_LIST_ROLE_TAKE_PROFIT": 2,
+		"ORDER_LIST_ROLE_STOP_LOSS":   3,
+	}
+)
+
+func (x OrderListRole) Enum() *OrderListRole {
+	p := new(OrderListRole)
+	*p = x
+	return p
+}
+
+func (x OrderListRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderListRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_kalency_matching_v1_engine_proto_enumTypes[5].Descriptor()
+}
+
+func (OrderListRole) Type() protoreflect.EnumType {
+	return &file_kalency_matching_v1_engine_proto_enumTypes[5]
+}
+
+func (x OrderListRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderListRole.Descriptor instead.
+func (OrderListRole) EnumDescriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{5}
+}
+
+type FundingType int32
+
+const (
+	FundingType_FUNDING_TYPE_UNSPECIFIED FundingType = 0
+	FundingType_FUNDING_TYPE_DEPOSIT     FundingType = 1
+	FundingType_FUNDING_TYPE_WITHDRAWAL  FundingType = 2
+	FundingType_FUNDING_TYPE_TRANSFER    FundingType = 3
+)
+
+// Enum value maps for FundingType.
+var (
+	FundingType_name = map[int32]string{
+		0: "FUNDING_TYPE_UNSPECIFIED",
+		1: "FUNDING_TYPE_DEPOSIT",
+		2: "FUNDING_TYPE_WITHDRAWAL",
+		3: "FUNDING_TYPE_TRANSFER",
+	}
+	FundingType_value = map[string]int32{
+		"FUNDING_TYPE_UNSPECIFIED": 0,
+		"FUNDING_TYPE_DEPOSIT":     1,
+		"FUNDING_TYPE_WITHDRAWAL":  2,
+		"FUNDING_TYPE_TRANSFER":    3,
+	}
+)
+
+func (x FundingType) Enum() *FundingType {
+	p := new(FundingType)
+	*p = x
+	return p
+}
+
+func (x FundingType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FundingType) Descriptor() protoreflect.EnumDescriptor {
+	return file_kalency_matching_v1_engine_proto_enumTypes[6].Descriptor()
+}
+
+func (FundingType) Type() protoreflect.EnumType {
+	return &file_kalency_matching_v1_engine_proto_enumTypes[6]
+}
+
+func (x FundingType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FundingType.Descriptor instead.
+func (FundingType) EnumDescriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{6}
+}
+
+type FundingStatus int32
+
+const (
+	FundingStatus_FUNDING_STATUS_UNSPECIFIED FundingStatus = 0
+	FundingStatus_FUNDING_STATUS_PENDING     FundingStatus = 1
+	FundingStatus_FUNDING_STATUS_COMPLETED   FundingStatus = 2
+	FundingStatus_FUNDING_STATUS_REJECTED    FundingStatus = 3
+)
+
+// Enum value maps for FundingStatus.
+var (
+	FundingStatus_name = map[int32]string{
+		0: "FUNDING_STATUS_UNSPECIFIED",
+		1: "FUNDING_STATUS_PENDING",
+		2: "FUNDING_STATUS_COMPLETED",
+		3: "FUNDING_STATUS_REJECTED",
+	}
+	FundingStatus_value = map[string]int32{
+		"FUNDING_STATUS_UNSPECIFIED": 0,
+		"FUNDING_STATUS_PENDING":     1,
+		"FUNDING_STATUS_COMPLETED":   2,
+		"FUNDING_STATUS_REJECTED":    3,
+	}
+)
+
+func (x FundingStatus) Enum() *FundingStatus {
+	p := new(FundingStatus)
+	*p = x
+	return p
+}
+
+func (x FundingStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FundingStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_kalency_matching_v1_engine_proto_enumTypes[7].Descriptor()
+}
+
+func (FundingStatus) Type() protoreflect.EnumType {
+	return &file_kalency_matching_v1_engine_proto_enumTypes[7]
+}
+
+func (x FundingStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FundingStatus.Descriptor instead.
+func (FundingStatus) EnumDescriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{7}
+}
+
+type PlaceOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientOrderId string                 `protobuf:"bytes,1,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          Side                   `protobuf:"varint,4,opt,name=side,proto3,enum=kalency.matching.v1.Side" json:"side,omitempty"`
+	Type          OrderType              `protobuf:"varint,5,opt,name=type,proto3,enum=kalency.matching.v1.OrderType" json:"type,omitempty"`
+	Price         int64                  `protobuf:"varint,6,opt,name=price,proto3" json:"price,omitempty"`
+	StopPrice     int64                  `protobuf:"varint,7,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	Qty           int64                  `protobuf:"varint,8,opt,name=qty,proto3" json:"qty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlaceOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *PlaceOrderRequest) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *PlaceOrderRequest) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetStopPrice() int64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetQty() int64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CancelOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type AmendOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderId string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// price 0 keeps the current price.
+	Price int64 `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	// qty is the new total order quantity including what has already filled;
+	// 0 keeps the current quantity.
+	Qty           int64 `protobuf:"varint,4,opt,name=qty,proto3" json:"qty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmendOrderRequest) Reset() {
+	*x = AmendOrderRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderRequest) ProtoMessage() {}
+
+func (x *AmendOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderRequest.ProtoReflect.Descriptor instead.
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AmendOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *AmendOrderRequest) GetQty() int64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+type OrderAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=kalency.matching.v1.OrderStatus" json:"status,omitempty"`
+	FilledQty     int64                  `protobuf:"varint,3,opt,name=filled_qty,json=filledQty,proto3" json:"filled_qty,omitempty"`
+	RemainingQty  int64                  `protobuf:"varint,4,opt,name=remaining_qty,json=remainingQty,proto3" json:"remaining_qty,omitempty"`
+	AvgPrice      int64                  `protobuf:"varint,5,opt,name=avg_price,json=avgPrice,proto3" json:"avg_price,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,6,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,7,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	ListId        string                 `protobuf:"bytes,8,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	Ts            *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=ts,proto3" json:"ts,omitempty"`
+	// Set with ORDER_STATUS_REJECTED when a pre-trade risk check refused the
+	// order, e.g. MAX_NOTIONAL.
+	RejectCode    string `protobuf:"bytes,10,opt,name=reject_code,json=rejectCode,proto3" json:"reject_code,omitempty"`
+	RejectReason  string `protobuf:"bytes,11,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderAck) Reset() {
+	*x = OrderAck{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderAck) ProtoMessage() {}
+
+func (x *OrderAck) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderAck.ProtoReflect.Descriptor instead.
+func (*OrderAck) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrderAck) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderAck) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderAck) GetFilledQty() int64 {
+	if x != nil {
+		return x.FilledQty
+	}
+	return 0
+}
+
+func (x *OrderAck) GetRemainingQty() int64 {
+	if x != nil {
+		return x.RemainingQty
+	}
+	return 0
+}
+
+func (x *OrderAck) GetAvgPrice() int64 {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return 0
+}
+
+func (x *OrderAck) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *OrderAck) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OrderAck) GetListId() string {
+	if x != nil {
+		return x.ListId
+	}
+	return ""
+}
+
+func (x *OrderAck) GetTs() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Ts
+	}
+	return nil
+}
+
+func (x *OrderAck) GetRejectCode() string {
+	if x != nil {
+		return x.RejectCode
+	}
+	return ""
+}
+
+func (x *OrderAck) GetRejectReason() string {
+	if x != nil {
+		return x.RejectReason
+	}
+	return ""
+}
+
+type PlaceOrderBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*PlaceOrderRequest   `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderBatchRequest) Reset() {
+	*x = PlaceOrderBatchRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderBatchRequest) ProtoMessage() {}
+
+func (x *PlaceOrderBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderBatchRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderBatchRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PlaceOrderBatchRequest) GetOrders() []*PlaceOrderRequest {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type CancelOrderBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderIds      []string               `protobuf:"bytes,2,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderBatchRequest) Reset() {
+	*x = CancelOrderBatchRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderBatchRequest) ProtoMessage() {}
+
+func (x *CancelOrderBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderBatchRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderBatchRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CancelOrderBatchRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelOrderBatchRequest) GetOrderIds() []string {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+// BatchResult carries the ack, or the error message the single-order call
+// would have returned.
+type BatchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ack           *OrderAck              `protobuf:"bytes,1,opt,name=ack,proto3" json:"ack,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResult) ProtoMessage() {}
+
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResult.ProtoReflect.Descriptor instead.
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchResult) GetAck() *OrderAck {
+	if x != nil {
+		return x.Ack
+	}
+	return nil
+}
+
+func (x *BatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// ErrorDetail is attached to every error status the service returns so
+// clients can branch on code instead of parsing the message.
+type ErrorDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail) ProtoMessage() {}
+
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ErrorDetail) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type OrderBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBatchResponse) Reset() {
+	*x = OrderBatchResponse{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBatchResponse) ProtoMessage() {}
+
+func (x *OrderBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBatchResponse.ProtoReflect.Descriptor instead.
+func (*OrderBatchResponse) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OrderBatchResponse) GetResults() []*BatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type OrderLeg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientOrderId string                 `protobuf:"bytes,1,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Type          OrderType              `protobuf:"varint,2,opt,name=type,proto3,enum=kalency.matching.v1.OrderType" json:"type,omitempty"`
+	Price         int64                  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	StopPrice     int64                  `protobuf:"varint,4,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderLeg) Reset() {
+	*x = OrderLeg{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderLeg) ProtoMessage() {}
+
+func (x *OrderLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderLeg.ProtoReflect.Descriptor instead.
+func (*OrderLeg) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OrderLeg) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *OrderLeg) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *OrderLeg) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OrderLeg) GetStopPrice() int64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+type PlaceOrderListRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ClientListId string                 `protobuf:"bytes,1,opt,name=client_list_id,json=clientListId,proto3" json:"client_list_id,omitempty"`
+	UserId       string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol       string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Type         OrderListType          `protobuf:"varint,4,opt,name=type,proto3,enum=kalency.matching.v1.OrderListType" json:"type,omitempty"`
+	Side         Side                   `protobuf:"varint,5,opt,name=side,proto3,enum=kalency.matching.v1.Side" json:"side,omitempty"`
+	Qty          int64                  `protobuf:"varint,6,opt,name=qty,proto3" json:"qty,omitempty"`
+	// entry is set for brackets only.
+	Entry         *OrderLeg `protobuf:"bytes,7,opt,name=entry,proto3" json:"entry,omitempty"`
+	TakeProfit    *OrderLeg `protobuf:"bytes,8,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
+	StopLoss      *OrderLeg `protobuf:"bytes,9,opt,name=stop_loss,json=stopLoss,proto3" json:"stop_loss,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderListRequest) Reset() {
+	*x = PlaceOrderListRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderListRequest) ProtoMessage() {}
+
+func (x *PlaceOrderListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderListRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderListRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PlaceOrderListRequest) GetClientListId() string {
+	if x != nil {
+		return x.ClientListId
+	}
+	return ""
+}
+
+func (x *PlaceOrderListRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlaceOrderListRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *PlaceOrderListRequest) GetType() OrderListType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderListType_ORDER_LIST_TYPE_UNSPECIFIED
+}
+
+func (x *PlaceOrderListRequest) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *PlaceOrderListRequest) GetQty() int64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *PlaceOrderListRequest) GetEntry() *OrderLeg {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *PlaceOrderListRequest) GetTakeProfit() *OrderLeg {
+	if x != nil {
+		return x.TakeProfit
+	}
+	return nil
+}
+
+func (x *PlaceOrderListRequest) GetStopLoss() *OrderLeg {
+	if x != nil {
+		return x.StopLoss
+	}
+	return nil
+}
+
+type CancelOrderListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ListId        string                 `protobuf:"bytes,2,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderListRequest) Reset() {
+	*x = CancelOrderListRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderListRequest) ProtoMessage() {}
+
+func (x *CancelOrderListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderListRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderListRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelOrderListRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelOrderListRequest) GetListId() string {
+	if x != nil {
+		return x.ListId
+	}
+	return ""
+}
+
+type OrderListAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListId        string                 `protobuf:"bytes,1,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	ClientListId  string                 `protobuf:"bytes,2,opt,name=client_list_id,json=clientListId,proto3" json:"client_list_id,omitempty"`
+	Type          OrderListType          `protobuf:"varint,3,opt,name=type,proto3,enum=kalency.matching.v1.OrderListType" json:"type,omitempty"`
+	Status        OrderListStatus        `protobuf:"varint,4,opt,name=status,proto3,enum=kalency.matching.v1.OrderListStatus" json:"status,omitempty"`
+	Symbol        string                 `protobuf:"bytes,5,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Orders        []*OrderAck            `protobuf:"bytes,6,rep,name=orders,proto3" json:"orders,omitempty"`
+	Ts            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=ts,proto3" json:"ts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderListAck) Reset() {
+	*x = OrderListAck{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderListAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderListAck) ProtoMessage() {}
+
+func (x *OrderListAck) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderListAck.ProtoReflect.Descriptor instead.
+func (*OrderListAck) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OrderListAck) GetListId() string {
+	if x != nil {
+		return x.ListId
+	}
+	return ""
+}
+
+func (x *OrderListAck) GetClientListId() string {
+	if x != nil {
+		return x.ClientListId
+	}
+	return ""
+}
+
+func (x *OrderListAck) GetType() OrderListType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderListType_ORDER_LIST_TYPE_UNSPECIFIED
+}
+
+func (x *OrderListAck) GetStatus() OrderListStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderListStatus_ORDER_LIST_STATUS_UNSPECIFIED
+}
+
+func (x *OrderListAck) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OrderListAck) GetOrders() []*OrderAck {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *OrderListAck) GetTs() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Ts
+	}
+	return nil
+}
+
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          Side                   `protobuf:"varint,5,opt,name=side,proto3,enum=kalency.matching.v1.Side" json:"side,omitempty"`
+	Type          OrderType              `protobuf:"varint,6,opt,name=type,proto3,enum=kalency.matching.v1.OrderType" json:"type,omitempty"`
+	Price         int64                  `protobuf:"varint,7,opt,name=price,proto3" json:"price,omitempty"`
+	StopPrice     int64                  `protobuf:"varint,8,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	Triggered     bool                   `protobuf:"varint,9,opt,name=triggered,proto3" json:"triggered,omitempty"`
+	Qty           int64                  `protobuf:"varint,10,opt,name=qty,proto3" json:"qty,omitempty"`
+	RemainingQty  int64                  `protobuf:"varint,11,opt,name=remaining_qty,json=remainingQty,proto3" json:"remaining_qty,omitempty"`
+	ListId        string                 `protobuf:"bytes,12,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	ListRole      OrderListRole          `protobuf:"varint,13,opt,name=list_role,json=listRole,proto3,enum=kalency.matching.v1.OrderListRole" json:"list_role,omitempty"`
+	ListStatus    OrderListStatus        `protobuf:"varint,14,opt,name=list_status,json=listStatus,proto3,enum=kalency.matching.v1.OrderListStatus" json:"list_status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Order) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Order) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *Order) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *Order) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Order) GetStopPrice() int64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *Order) GetTriggered() bool {
+	if x != nil {
+		return x.Triggered
+	}
+	return false
+}
+
+func (x *Order) GetQty() int64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *Order) GetRemainingQty() int64 {
+	if x != nil {
+		return x.RemainingQty
+	}
+	return 0
+}
+
+func (x *Order) GetListId() string {
+	if x != nil {
+		return x.ListId
+	}
+	return ""
+}
+
+func (x *Order) GetListRole() OrderListRole {
+	if x != nil {
+		return x.ListRole
+	}
+	return OrderListRole_ORDER_LIST_ROLE_UNSPECIFIED
+}
+
+func (x *Order) GetListStatus() OrderListStatus {
+	if x != nil {
+		return x.ListStatus
+	}
+	return OrderListStatus_ORDER_LIST_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type OpenOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenOrdersRequest) Reset() {
+	*x = OpenOrdersRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenOrdersRequest) ProtoMessage() {}
+
+func (x *OpenOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenOrdersRequest.ProtoReflect.Descriptor instead.
+func (*OpenOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OpenOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type OpenOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenOrdersResponse) Reset() {
+	*x = OpenOrdersResponse{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenOrdersResponse) ProtoMessage() {}
+
+func (x *OpenOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenOrdersResponse.ProtoReflect.Descriptor instead.
+func (*OpenOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OpenOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type GetWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletRequest) Reset() {
+	*x = GetWalletRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletRequest) ProtoMessage() {}
+
+func (x *GetWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetWalletRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type Wallet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Available     map[string]int64       `protobuf:"bytes,2,rep,name=available,proto3" json:"available,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Reserved      map[string]int64       `protobuf:"bytes,3,rep,name=reserved,proto3" json:"reserved,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Wallet) Reset() {
+	*x = Wallet{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wallet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wallet) ProtoMessage() {}
+
+func (x *Wallet) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wallet.ProtoReflect.Descriptor instead.
+func (*Wallet) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Wallet) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Wallet) GetAvailable() map[string]int64 {
+	if x != nil {
+		return x.Available
+	}
+	return nil
+}
+
+func (x *Wallet) GetReserved() map[string]int64 {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
+func (x *Wallet) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type TransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromUserId    string                 `protobuf:"bytes,1,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	ToUserId      string                 `protobuf:"bytes,2,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	Asset         string                 `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	RequestId     string                 `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferRequest) Reset() {
+	*x = TransferRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferRequest) ProtoMessage() {}
+
+func (x *TransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TransferRequest) GetFromUserId() string {
+	if x != nil {
+		return x.FromUserId
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetToUserId() string {
+	if x != nil {
+		return x.ToUserId
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type TransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *Wallet                `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *Wallet                `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Operation     *FundingOperation      `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferResponse) Reset() {
+	*x = TransferResponse{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferResponse) ProtoMessage() {}
+
+func (x *TransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TransferResponse) GetFrom() *Wallet {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetTo() *Wallet {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetOperation() *FundingOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+type FundingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Asset         string                 `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FundingRequest) Reset() {
+	*x = FundingRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FundingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FundingRequest) ProtoMessage() {}
+
+func (x *FundingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FundingRequest.ProtoReflect.Descriptor instead.
+func (*FundingRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FundingRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *FundingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FundingRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *FundingRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type FundingOperation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestId string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Type      FundingType            `protobuf:"varint,3,opt,name=type,proto3,enum=kalency.matching.v1.FundingType" json:"type,omitempty"`
+	Status    FundingStatus          `protobuf:"varint,4,opt,name=status,proto3,enum=kalency.matching.v1.FundingStatus" json:"status,omitempty"`
+	UserId    string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// to_user_id is set for transfers only.
+	ToUserId      string                 `protobuf:"bytes,6,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	Asset         string                 `protobuf:"bytes,7,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount        int64                  `protobuf:"varint,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        string                 `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FundingOperation) Reset() {
+	*x = FundingOperation{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FundingOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FundingOperation) ProtoMessage() {}
+
+func (x *FundingOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FundingOperation.ProtoReflect.Descriptor instead.
+func (*FundingOperation) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FundingOperation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FundingOperation) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *FundingOperation) GetType() FundingType {
+	if x != nil {
+		return x.Type
+	}
+	return FundingType_FUNDING_TYPE_UNSPECIFIED
+}
+
+func (x *FundingOperation) GetStatus() FundingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return FundingStatus_FUNDING_STATUS_UNSPECIFIED
+}
+
+func (x *FundingOperation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FundingOperation) GetToUserId() string {
+	if x != nil {
+		return x.ToUserId
+	}
+	return ""
+}
+
+func (x *FundingOperation) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *FundingOperation) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *FundingOperation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *FundingOperation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FundingOperation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListFundingHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// limit 0 returns the last 100 operations.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFundingHistoryRequest) Reset() {
+	*x = ListFundingHistoryRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFundingHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFundingHistoryRequest) ProtoMessage() {}
+
+func (x *ListFundingHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFundingHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListFundingHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListFundingHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFundingHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListFundingHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// operations are newest first.
+	Operations    []*FundingOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFundingHistoryResponse) Reset() {
+	*x = ListFundingHistoryResponse{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFundingHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFundingHistoryResponse) ProtoMessage() {}
+
+func (x *ListFundingHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFundingHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListFundingHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListFundingHistoryResponse) GetOperations() []*FundingOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type GetOrderBookRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// depth 0 uses the engine default of 20 levels.
+	Depth         int32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderBookRequest) Reset() {
+	*x = GetOrderBookRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderBookRequest) ProtoMessage() {}
+
+func (x *GetOrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderBookRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetOrderBookRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetOrderBookRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type BookLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         int64                  `protobuf:"varint,1,opt,name=price,proto3" json:"price,omitempty"`
+	Qty           int64                  `protobuf:"varint,2,opt,name=qty,proto3" json:"qty,omitempty"`
+	Orders        int32                  `protobuf:"varint,3,opt,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookLevel) Reset() {
+	*x = BookLevel{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookLevel) ProtoMessage() {}
+
+func (x *BookLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookLevel.ProtoReflect.Descriptor instead.
+func (*BookLevel) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BookLevel) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *BookLevel) GetQty() int64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *BookLevel) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+type OrderBook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Seq           int64                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Bids          []*BookLevel           `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks          []*BookLevel           `protobuf:"bytes,4,rep,name=asks,proto3" json:"asks,omitempty"`
+	Ts            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=ts,proto3" json:"ts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBook) Reset() {
+	*x = OrderBook{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBook) ProtoMessage() {}
+
+func (x *OrderBook) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBook.ProtoReflect.Descriptor instead.
+func (*OrderBook) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OrderBook) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OrderBook) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *OrderBook) GetBids() []*BookLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderBook) GetAsks() []*BookLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderBook) GetTs() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Ts
+	}
+	return nil
+}
+
+type ListTradesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// limit 0 returns the last 100 trades.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTradesRequest) Reset() {
+	*x = ListTradesRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTradesRequest) ProtoMessage() {}
+
+func (x *ListTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTradesRequest.ProtoReflect.Descriptor instead.
+func (*ListTradesRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListTradesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ListTradesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTradesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trades        []*Execution           `protobuf:"bytes,1,rep,name=trades,proto3" json:"trades,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTradesResponse) Reset() {
+	*x = ListTradesResponse{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTradesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTradesResponse) ProtoMessage() {}
+
+func (x *ListTradesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTradesResponse.ProtoReflect.Descriptor instead.
+func (*ListTradesResponse) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListTradesResponse) GetTrades() []*Execution {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+type Execution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TradeId       string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price         int64                  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	Qty           int64                  `protobuf:"varint,4,opt,name=qty,proto3" json:"qty,omitempty"`
+	MakerOrderId  string                 `protobuf:"bytes,5,opt,name=maker_order_id,json=makerOrderId,proto3" json:"maker_order_id,omitempty"`
+	MakerUserId   string                 `protobuf:"bytes,6,opt,name=maker_user_id,json=makerUserId,proto3" json:"maker_user_id,omitempty"`
+	TakerOrderId  string                 `protobuf:"bytes,7,opt,name=taker_order_id,json=takerOrderId,proto3" json:"taker_order_id,omitempty"`
+	TakerUserId   string                 `protobuf:"bytes,8,opt,name=taker_user_id,json=takerUserId,proto3" json:"taker_user_id,omitempty"`
+	Ts            *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=ts,proto3" json:"ts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Execution) Reset() {
+	*x = Execution{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Execution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Execution) ProtoMessage() {}
+
+func (x *Execution) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Execution.ProtoReflect.Descriptor instead.
+func (*Execution) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Execution) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *Execution) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Execution) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Execution) GetQty() int64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *Execution) GetMakerOrderId() string {
+	if x != nil {
+		return x.MakerOrderId
+	}
+	return ""
+}
+
+func (x *Execution) GetMakerUserId() string {
+	if x != nil {
+		return x.MakerUserId
+	}
+	return ""
+}
+
+func (x *Execution) GetTakerOrderId() string {
+	if x != nil {
+		return x.TakerOrderId
+	}
+	return ""
+}
+
+func (x *Execution) GetTakerUserId() string {
+	if x != nil {
+		return x.TakerUserId
+	}
+	return ""
+}
+
+func (x *Execution) GetTs() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Ts
+	}
+	return nil
+}
+
+type GetTickerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTickerRequest) Reset() {
+	*x = GetTickerRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTickerRequest) ProtoMessage() {}
+
+func (x *GetTickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTickerRequest.ProtoReflect.Descriptor instead.
+func (*GetTickerRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetTickerRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type ListTickersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTickersRequest) Reset() {
+	*x = ListTickersRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTickersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTickersRequest) ProtoMessage() {}
+
+func (x *ListTickersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTickersRequest.ProtoReflect.Descriptor instead.
+func (*ListTickersRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{31}
+}
+
+type ListTickersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tickers       []*Ticker              `protobuf:"bytes,1,rep,name=tickers,proto3" json:"tickers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTickersResponse) Reset() {
+	*x = ListTickersResponse{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTickersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTickersResponse) ProtoMessage() {}
+
+func (x *ListTickersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTickersResponse.ProtoReflect.Descriptor instead.
+func (*ListTickersResponse) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListTickersResponse) GetTickers() []*Ticker {
+	if x != nil {
+		return x.Tickers
+	}
+	return nil
+}
+
+type Ticker struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Symbol            string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	LastPrice         int64                  `protobuf:"varint,2,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	LastQty           int64                  `protobuf:"varint,3,opt,name=last_qty,json=lastQty,proto3" json:"last_qty,omitempty"`
+	BestBid           int64                  `protobuf:"varint,4,opt,name=best_bid,json=bestBid,proto3" json:"best_bid,omitempty"`
+	BestBidQty        int64                  `protobuf:"varint,5,opt,name=best_bid_qty,json=bestBidQty,proto3" json:"best_bid_qty,omitempty"`
+	BestAsk           int64                  `protobuf:"varint,6,opt,name=best_ask,json=bestAsk,proto3" json:"best_ask,omitempty"`
+	BestAskQty        int64                  `protobuf:"varint,7,opt,name=best_ask_qty,json=bestAskQty,proto3" json:"best_ask_qty,omitempty"`
+	Open_24H          int64                  `protobuf:"varint,8,opt,name=open_24h,json=open24h,proto3" json:"open_24h,omitempty"`
+	High_24H          int64                  `protobuf:"varint,9,opt,name=high_24h,json=high24h,proto3" json:"high_24h,omitempty"`
+	Low_24H           int64                  `protobuf:"varint,10,opt,name=low_24h,json=low24h,proto3" json:"low_24h,omitempty"`
+	Volume_24H        int64                  `protobuf:"varint,11,opt,name=volume_24h,json=volume24h,proto3" json:"volume_24h,omitempty"`
+	QuoteVolume_24H   int64                  `protobuf:"varint,12,opt,name=quote_volume_24h,json=quoteVolume24h,proto3" json:"quote_volume_24h,omitempty"`
+	Trades_24H        int64                  `protobuf:"varint,13,opt,name=trades_24h,json=trades24h,proto3" json:"trades_24h,omitempty"`
+	Change_24H        int64                  `protobuf:"varint,14,opt,name=change_24h,json=change24h,proto3" json:"change_24h,omitempty"`
+	ChangePercent_24H float64                `protobuf:"fixed64,15,opt,name=change_percent_24h,json=changePercent24h,proto3" json:"change_percent_24h,omitempty"`
+	Ts                *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=ts,proto3" json:"ts,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Ticker) Reset() {
+	*x = Ticker{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ticker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ticker) ProtoMessage() {}
+
+func (x *Ticker) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ticker.ProtoReflect.Descriptor instead.
+func (*Ticker) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Ticker) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Ticker) GetLastPrice() int64 {
+	if x != nil {
+		return x.LastPrice
+	}
+	return 0
+}
+
+func (x *Ticker) GetLastQty() int64 {
+	if x != nil {
+		return x.LastQty
+	}
+	return 0
+}
+
+func (x *Ticker) GetBestBid() int64 {
+	if x != nil {
+		return x.BestBid
+	}
+	return 0
+}
+
+func (x *Ticker) GetBestBidQty() int64 {
+	if x != nil {
+		return x.BestBidQty
+	}
+	return 0
+}
+
+func (x *Ticker) GetBestAsk() int64 {
+	if x != nil {
+		return x.BestAsk
+	}
+	return 0
+}
+
+func (x *Ticker) GetBestAskQty() int64 {
+	if x != nil {
+		return x.BestAskQty
+	}
+	return 0
+}
+
+func (x *Ticker) GetOpen_24H() int64 {
+	if x != nil {
+		return x.Open_24H
+	}
+	return 0
+}
+
+func (x *Ticker) GetHigh_24H() int64 {
+	if x != nil {
+		return x.High_24H
+	}
+	return 0
+}
+
+func (x *Ticker) GetLow_24H() int64 {
+	if x != nil {
+		return x.Low_24H
+	}
+	return 0
+}
+
+func (x *Ticker) GetVolume_24H() int64 {
+	if x != nil {
+		return x.Volume_24H
+	}
+	return 0
+}
+
+func (x *Ticker) GetQuoteVolume_24H() int64 {
+	if x != nil {
+		return x.QuoteVolume_24H
+	}
+	return 0
+}
+
+func (x *Ticker) GetTrades_24H() int64 {
+	if x != nil {
+		return x.Trades_24H
+	}
+	return 0
+}
+
+func (x *Ticker) GetChange_24H() int64 {
+	if x != nil {
+		return x.Change_24H
+	}
+	return 0
+}
+
+func (x *Ticker) GetChangePercent_24H() float64 {
+	if x != nil {
+		return x.ChangePercent_24H
+	}
+	return 0
+}
+
+func (x *Ticker) GetTs() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Ts
+	}
+	return nil
+}
+
+type StreamExecutionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// symbol and user_id are optional filters; user_id matches either side of
+	// the trade.
+	Symbol        string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamExecutionsRequest) Reset() {
+	*x = StreamExecutionsRequest{}
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamExecutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamExecutionsRequest) ProtoMessage() {}
+
+func (x *StreamExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kalency_matching_v1_engine_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*StreamExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_kalency_matching_v1_engine_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StreamExecutionsRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StreamExecutionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+var File_kalency_matching_v1_engine_proto protoreflect.FileDescriptor
+
+const file_kalency_matching_v1_engine_proto_rawDesc = "" +
+	"\n" +
+	" kalency/matching/v1/engine.proto\x12\x13kalency.matching.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x96\x02\n" +
+	"\x11PlaceOrderRequest\x12&\n" +
+	"\x0fclient_order_id\x18\x01 \x01(\tR\rclientOrderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12-\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x19.kalency.matching.v1.SideR\x04side\x122\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x1e.kalency.matching.v1.OrderTypeR\x04type\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x03R\x05price\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\a \x01(\x03R\tstopPrice\x12\x10\n" +
+	"\x03qty\x18\b \x01(\x03R\x03qty\"H\n" +
+	"\x12CancelOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"o\n" +
+	"\x11AmendOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x03R\x05price\x12\x10\n" +
+	"\x03qty\x18\x04 \x01(\x03R\x03qty\"\x8b\x03\n" +
+	"\bOrderAck\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x128\n" +
+	"\x06status\x18\x02 \x01(\x0e2 .kalency.matching.v1.OrderStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"filled_qty\x18\x03 \x01(\x03R\tfilledQty\x12#\n" +
+	"\rremaining_qty\x18\x04 \x01(\x03R\fremainingQty\x12\x1b\n" +
+	"\tavg_price\x18\x05 \x01(\x03R\bavgPrice\x12&\n" +
+	"\x0fclient_order_id\x18\x06 \x01(\tR\rclientOrderId\x12\x16\n" +
+	"\x06symbol\x18\a \x01(\tR\x06symbol\x12\x17\n" +
+	"\alist_id\x18\b \x01(\tR\x06listId\x12*\n" +
+	"\x02ts\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x02ts\x12\x1f\n" +
+	"\vreject_code\x18\n" +
+	" \x01(\tR\n" +
+	"rejectCode\x12#\n" +
+	"\rreject_reason\x18\v \x01(\tR\frejectReason\"X\n" +
+	"\x16PlaceOrderBatchRequest\x12>\n" +
+	"\x06orders\x18\x01 \x03(\v2&.kalency.matching.v1.PlaceOrderRequestR\x06orders\"O\n" +
+	"\x17CancelOrderBatchRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\torder_ids\x18\x02 \x03(\tR\borderIds\"s\n" +
+	"\vBatchResult\x12/\n" +
+	"\x03ack\x18\x01 \x01(\v2\x1d.kalency.matching.v1.OrderAckR\x03ack\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\xc0\x01\n" +
+	"\vErrorDetail\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12G\n" +
+	"\adetails\x18\x03 \x03(\v2-.kalency.matching.v1.ErrorDetail.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"P\n" +
+	"\x12OrderBatchResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .kalency.matching.v1.BatchResultR\aresults\"\x9b\x01\n" +
+	"\bOrderLeg\x12&\n" +
+	"\x0fclient_order_id\x18\x01 \x01(\tR\rclientOrderId\x122\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1e.kalency.matching.v1.OrderTypeR\x04type\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x03R\x05price\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\x04 \x01(\x03R\tstopPrice\"\x98\x03\n" +
+	"\x15PlaceOrderListRequest\x12$\n" +
+	"\x0eclient_list_id\x18\x01 \x01(\tR\fclientListId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x126\n" +
+	"\x04type\x18\x04 \x01(\x0e2\".kalency.matching.v1.OrderListTypeR\x04type\x12-\n" +
+	"\x04side\x18\x05 \x01(\x0e2\x19.kalency.matching.v1.SideR\x04side\x12\x10\n" +
+	"\x03qty\x18\x06 \x01(\x03R\x03qty\x123\n" +
+	"\x05entry\x18\a \x01(\v2\x1d.kalency.matching.v1.OrderLegR\x05entry\x12>\n" +
+	"\vtake_profit\x18\b \x01(\v2\x1d.kalency.matching.v1.OrderLegR\n" +
+	"takeProfit\x12:\n" +
+	"\tstop_loss\x18\t \x01(\v2\x1d.kalency.matching.v1.OrderLegR\bstopLoss\"J\n" +
+	"\x16CancelOrderListRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\alist_id\x18\x02 \x01(\tR\x06listId\"\xbe\x02\n" +
+	"\fOrderListAck\x12\x17\n" +
+	"\alist_id\x18\x01 \x01(\tR\x06listId\x12$\n" +
+	"\x0eclient_list_id\x18\x02 \x01(\tR\fclientListId\x126\n" +
+	"\x04type\x18\x03 \x01(\x0e2\".kalency.matching.v1.OrderListTypeR\x04type\x12<\n" +
+	"\x06status\x18\x04 \x01(\x0e2$.kalency.matching.v1.OrderListStatusR\x06status\x12\x16\n" +
+	"\x06symbol\x18\x05 \x01(\tR\x06symbol\x125\n" +
+	"\x06orders\x18\x06 \x03(\v2\x1d.kalency.matching.v1.OrderAckR\x06orders\x12*\n" +
+	"\x02ts\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x02ts\"\xc4\x04\n" +
+	"\x05Order\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tR\rclientOrderId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12-\n" +
+	"\x04side\x18\x05 \x01(\x0e2\x19.kalency.matching.v1.SideR\x04side\x122\n" +
+	"\x04type\x18\x06 \x01(\x0e2\x1e.kalency.matching.v1.OrderTypeR\x04type\x12\x14\n" +
+	"\x05price\x18\a \x01(\x03R\x05price\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\b \x01(\x03R\tstopPrice\x12\x1c\n" +
+	"\ttriggered\x18\t \x01(\bR\ttriggered\x12\x10\n" +
+	"\x03qty\x18\n" +
+	" \x01(\x03R\x03qty\x12#\n" +
+	"\rremaining_qty\x18\v \x01(\x03R\fremainingQty\x12\x17\n" +
+	"\alist_id\x18\f \x01(\tR\x06listId\x12?\n" +
+	"\tlist_role\x18\r \x01(\x0e2\".kalency.matching.v1.OrderListRoleR\blistRole\x12E\n" +
+	"\vlist_status\x18\x0e \x01(\x0e2$.kalency.matching.v1.OrderListStatusR\n" +
+	"listStatus\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\",\n" +
+	"\x11OpenOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"H\n" +
+	"\x12OpenOrdersResponse\x122\n" +
+	"\x06orders\x18\x01 \x03(\v2\x1a.kalency.matching.v1.OrderR\x06orders\"+\n" +
+	"\x10GetWalletRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xe8\x02\n" +
+	"\x06Wallet\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12H\n" +
+	"\tavailable\x18\x02 \x03(\v2*.kalency.matching.v1.Wallet.AvailableEntryR\tavailable\x12E\n" +
+	"\breserved\x18\x03 \x03(\v2).kalency.matching.v1.Wallet.ReservedEntryR\breserved\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a<\n" +
+	"\x0eAvailableEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a;\n" +
+	"\rReservedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x9e\x01\n" +
+	"\x0fTransferRequest\x12 \n" +
+	"\ffrom_user_id\x18\x01 \x01(\tR\n" +
+	"fromUserId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x02 \x01(\tR\btoUserId\x12\x14\n" +
+	"\x05asset\x18\x03 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x05 \x01(\tR\trequestId\"\xb5\x01\n" +
+	"\x10TransferResponse\x12/\n" +
+	"\x04from\x18\x01 \x01(\v2\x1b.kalency.matching.v1.WalletR\x04from\x12+\n" +
+	"\x02to\x18\x02 \x01(\v2\x1b.kalency.matching.v1.WalletR\x02to\x12C\n" +
+	"\toperation\x18\x03 \x01(\v2%.kalency.matching.v1.FundingOperationR\toperation\"v\n" +
+	"\x0eFundingRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05asset\x18\x03 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\"\xa6\x03\n" +
+	"\x10FundingOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x124\n" +
+	"\x04type\x18\x03 \x01(\x0e2 .kalency.matching.v1.FundingTypeR\x04type\x12:\n" +
+	"\x06status\x18\x04 \x01(\x0e2\".kalency.matching.v1.FundingStatusR\x06status\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x06 \x01(\tR\btoUserId\x12\x14\n" +
+	"\x05asset\x18\a \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\b \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\t \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"J\n" +
+	"\x19ListFundingHistoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"c\n" +
+	"\x1aListFundingHistoryResponse\x12E\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2%.kalency.matching.v1.FundingOperationR\n" +
+	"operations\"C\n" +
+	"\x13GetOrderBookRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\"K\n" +
+	"\tBookLevel\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x03R\x05price\x12\x10\n" +
+	"\x03qty\x18\x02 \x01(\x03R\x03qty\x12\x16\n" +
+	"\x06orders\x18\x03 \x01(\x05R\x06orders\"\xc9\x01\n" +
+	"\tOrderBook\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x03R\x03seq\x122\n" +
+	"\x04bids\x18\x03 \x03(\v2\x1e.kalency.matching.v1.BookLevelR\x04bids\x122\n" +
+	"\x04asks\x18\x04 \x03(\v2\x1e.kalency.matching.v1.BookLevelR\x04asks\x12*\n" +
+	"\x02ts\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02ts\"A\n" +
+	"\x11ListTradesRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"L\n" +
+	"\x12ListTradesResponse\x126\n" +
+	"\x06trades\x18\x01 \x03(\v2\x1e.kalency.matching.v1.ExecutionR\x06trades\"\xa6\x02\n" +
+	"\tExecution\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\tR\atradeId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x03R\x05price\x12\x10\n" +
+	"\x03qty\x18\x04 \x01(\x03R\x03qty\x12$\n" +
+	"\x0emaker_order_id\x18\x05 \x01(\tR\fmakerOrderId\x12\"\n" +
+	"\rmaker_user_id\x18\x06 \x01(\tR\vmakerUserId\x12$\n" +
+	"\x0etaker_order_id\x18\a \x01(\tR\ftakerOrderId\x12\"\n" +
+	"\rtaker_user_id\x18\b \x01(\tR\vtakerUserId\x12*\n" +
+	"\x02ts\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x02ts\"*\n" +
+	"\x10GetTickerRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\x14\n" +
+	"\x12ListTickersRequest\"L\n" +
+	"\x13ListTickersResponse\x125\n" +
+	"\atickers\x18\x01 \x03(\v2\x1b.kalency.matching.v1.TickerR\atickers\"\x84\x04\n" +
+	"\x06Ticker\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\x02 \x01(\x03R\tlastPrice\x12\x19\n" +
+	"\blast_qty\x18\x03 \x01(\x03R\alastQty\x12\x19\n" +
+	"\bbest_bid\x18\x04 \x01(\x03R\abestBid\x12 \n" +
+	"\fbest_bid_qty\x18\x05 \x01(\x03R\n" +
+	"bestBidQty\x12\x19\n" +
+	"\bbest_ask\x18\x06 \x01(\x03R\abestAsk\x12 \n" +
+	"\fbest_ask_qty\x18\a \x01(\x03R\n" +
+	"bestAskQty\x12\x19\n" +
+	"\bopen_24h\x18\b \x01(\x03R\aopen24h\x12\x19\n" +
+	"\bhigh_24h\x18\t \x01(\x03R\ahigh24h\x12\x17\n" +
+	"\alow_24h\x18\n" +
+	" \x01(\x03R\x06low24h\x12\x1d\n" +
+	"\n" +
+	"volume_24h\x18\v \x01(\x03R\tvolume24h\x12(\n" +
+	"\x10quote_volume_24h\x18\f \x01(\x03R\x0equoteVolume24h\x12\x1d\n" +
+	"\n" +
+	"trades_24h\x18\r \x01(\x03R\ttrades24h\x12\x1d\n" +
+	"\n" +
+	"change_24h\x18\x0e \x01(\x03R\tchange24h\x12,\n" +
+	"\x12change_percent_24h\x18\x0f \x01(\x01R\x10changePercent24h\x12*\n" +
+	"\x02ts\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x02ts\"J\n" +
+	"\x17StreamExecutionsRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId*9\n" +
+	"\x04Side\x12\x14\n" +
+	"\x10SIDE_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bSIDE_BUY\x10\x01\x12\r\n" +
+	"\tSIDE_SELL\x10\x02*\x8b\x01\n" +
+	"\tOrderType\x12\x1a\n" +
+	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11ORDER_TYPE_MARKET\x10\x01\x12\x14\n" +
+	"\x10ORDER_TYPE_LIMIT\x10\x02\x12\x1a\n" +
+	"\x16ORDER_TYPE_STOP_MARKET\x10\x03\x12\x19\n" +
+	"\x15ORDER_TYPE_STOP_LIMIT\x10\x04*\xd2\x01\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ORDER_STATUS_PENDING\x10\x01\x12\x19\n" +
+	"\x15ORDER_STATUS_ACCEPTED\x10\x02\x12!\n" +
+	"\x1dORDER_STATUS_PARTIALLY_FILLED\x10\x03\x12\x17\n" +
+	"\x13ORDER_STATUS_FILLED\x10\x04\x12\x19\n" +
+	"\x15ORDER_STATUS_CANCELED\x10\x05\x12\x19\n" +
+	"\x15ORDER_STATUS_REJECTED\x10\x06*f\n" +
+	"\rOrderListType\x12\x1f\n" +
+	"\x1bORDER_LIST_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13ORDER_LIST_TYPE_OCO\x10\x01\x12\x1b\n" +
+	"\x17ORDER_LIST_TYPE_BRACKET\x10\x02*\x91\x01\n" +
+	"\x0fOrderListStatus\x12!\n" +
+	"\x1dORDER_LIST_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ORDER_LIST_STATUS_PENDING\x10\x01\x12\x1c\n" +
+	"\x18ORDER_LIST_STATUS_ACTIVE\x10\x02\x12\x1e\n" +
+	"\x1aORDER_LIST_STATUS_ALL_DONE\x10\x03*\x8b\x01\n" +
+	"\rOrderListRole\x12\x1f\n" +
+	"\x1bORDER_LIST_ROLE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ORDER_LIST_ROLE_ENTRY\x10\x01\x12\x1f\n" +
+	"\x1bORDER_LIST_ROLE_TAKE_PROFIT\x10\x02\x12\x1d\n" +
+	"\x19ORDER_LIST_ROLE_STOP_LOSS\x10\x03*}\n" +
+	"\vFundingType\x12\x1c\n" +
+	"\x18FUNDING_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14FUNDING_TYPE_DEPOSIT\x10\x01\x12\x1b\n" +
+	"\x17FUNDING_TYPE_WITHDRAWAL\x10\x02\x12\x19\n" +
+	"\x15FUNDING_TYPE_TRANSFER\x10\x03*\x86\x01\n" +
+	"\rFundingStatus\x12\x1e\n" +
+	"\x1aFUNDING_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16FUNDING_STATUS_PENDING\x10\x01\x12\x1c\n" +
+	"\x18FUNDING_STATUS_COMPLETED\x10\x02\x12\x1b\n" +
+	"\x17FUNDING_STATUS_REJECTED\x10\x032\xa8\r\n" +
+	"\x0eMatchingEngine\x12S\n" +
+	"\n" +
+	"PlaceOrder\x12&.kalency.matching.v1.PlaceOrderRequest\x1a\x1d.kalency.matching.v1.OrderAck\x12U\n" +
+	"\vCancelOrder\x12'.kalency.matching.v1.CancelOrderRequest\x1a\x1d.kalency.matching.v1.OrderAck\x12S\n" +
+	"\n" +
+	"AmendOrder\x12&.kalency.matching.v1.AmendOrderRequest\x1a\x1d.kalency.matching.v1.OrderAck\x12g\n" +
+	"\x0fPlaceOrderBatch\x12+.kalency.matching.v1.PlaceOrderBatchRequest\x1a'.kalency.matching.v1.OrderBatchResponse\x12i\n" +
+	"\x10CancelOrderBatch\x12,.kalency.matching.v1.CancelOrderBatchRequest\x1a'.kalency.matching.v1.OrderBatchResponse\x12_\n" +
+	"\x0ePlaceOrderList\x12*.kalency.matching.v1.PlaceOrderListRequest\x1a!.kalency.matching.v1.OrderListAck\x12a\n" +
+	"\x0fCancelOrderList\x12+.kalency.matching.v1.CancelOrderListRequest\x1a!.kalency.matching.v1.OrderListAck\x12]\n" +
+	"\n" +
+	"OpenOrders\x12&.kalency.matching.v1.OpenOrdersRequest\x1a'.kalency.matching.v1.OpenOrdersResponse\x12O\n" +
+	"\tGetWallet\x12%.kalency.matching.v1.GetWalletRequest\x1a\x1b.kalency.matching.v1.Wallet\x12W\n" +
+	"\bTransfer\x12$.kalency.matching.v1.TransferRequest\x1a%.kalency.matching.v1.TransferResponse\x12U\n" +
+	"\aDeposit\x12#.kalency.matching.v1.FundingRequest\x1a%.kalency.matching.v1.FundingOperation\x12V\n" +
+	"\bWithdraw\x12#.kalency.matching.v1.FundingRequest\x1a%.kalency.matching.v1.FundingOperation\x12u\n" +
+	"\x12ListFundingHistory\x12..kalency.matching.v1.ListFundingHistoryRequest\x1a/.kalency.matching.v1.ListFundingHistoryResponse\x12X\n" +
+	"\fGetOrderBook\x12(.kalency.matching.v1.GetOrderBookRequest\x1a\x1e.kalency.matching.v1.OrderBook\x12]\n" +
+	"\n" +
+	"ListTrades\x12&.kalency.matching.v1.ListTradesRequest\x1a'.kalency.matching.v1.ListTradesResponse\x12O\n" +
+	"\tGetTicker\x12%.kalency.matching.v1.GetTickerRequest\x1a\x1b.kalency.matching.v1.Ticker\x12`\n" +
+	"\vListTickers\x12'.kalency.matching.v1.ListTickersRequest\x1a(.kalency.matching.v1.ListTickersResponse\x12b\n" +
+	"\x10StreamExecutions\x12,.kalency.matching.v1.StreamExecutionsRequest\x1a\x1e.kalency.matching.v1.Execution0\x01B)Z'kalency/apps/matching-engine/matchingpbb\x06proto3"
+
+var (
+	file_kalency_matching_v1_engine_proto_rawDescOnce sync.Once
+	file_kalency_matching_v1_engine_proto_rawDescData []byte
+)
+
+func file_kalency_matching_v1_engine_proto_rawDescGZIP() []byte {
+	file_kalency_matching_v1_engine_proto_rawDescOnce.Do(func() {
+		file_kalency_matching_v1_engine_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kalency_matching_v1_engine_proto_rawDesc), len(file_kalency_matching_v1_engine_proto_rawDesc)))
+	})
+	return file_kalency_matching_v1_engine_proto_rawDescData
+}
+
+var file_kalency_matching_v1_engine_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_kalency_matching_v1_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_kalency_matching_v1_engine_proto_goTypes = []any{
+	(Side)(0),                          // 0: kalency.matching.v1.Side
+	(OrderType)(0),                     // 1: kalency.matching.v1.OrderType
+	(OrderStatus)(0),                   // 2: kalency.matching.v1.OrderStatus
+	(OrderListType)(0),                 // 3: kalency.matching.v1.OrderListType
+	(OrderListStatus)(0),               // 4: kalency.matching.v1.OrderListStatus
+	(OrderListRole)(0),                 // 5: kalency.matching.v1.OrderListRole
+	(FundingType)(0),                   // 6: kalency.matching.v1.FundingType
+	(FundingStatus)(0),                 // 7: kalency.matching.v1.FundingStatus
+	(*PlaceOrderRequest)(nil),          // 8: kalency.matching.v1.PlaceOrderRequest
+	(*CancelOrderRequest)(nil),         // 9: kalency.matching.v1.CancelOrderRequest
+	(*AmendOrderRequest)(nil),          // 10: kalency.matching.v1.AmendOrderRequest
+	(*OrderAck)(nil),                   // 11: kalency.matching.v1.OrderAck
+	(*PlaceOrderBatchRequest)(nil),     // 12: kalency.matching.v1.PlaceOrderBatchRequest
+	(*CancelOrderBatchRequest)(nil),    // 13: kalency.matching.v1.CancelOrderBatchRequest
+	(*BatchResult)(nil),                // 14: kalency.matching.v1.BatchResult
+	(*ErrorDetail)(nil),                // 15: kalency.matching.v1.ErrorDetail
+	(*OrderBatchResponse)(nil),         // 16: kalency.matching.v1.OrderBatchResponse
+	(*OrderLeg)(nil),                   // 17: kalency.matching.v1.OrderLeg
+	(*PlaceOrderListRequest)(nil),      // 18: kalency.matching.v1.PlaceOrderListRequest
+	(*CancelOrderListRequest)(nil),     // 19: kalency.matching.v1.CancelOrderListRequest
+	(*OrderListAck)(nil),               // 20: kalency.matching.v1.OrderListAck
+	(*Order)(nil),                      // 21: kalency.matching.v1.Order
+	(*OpenOrdersRequest)(nil),          // 22: kalency.matching.v1.OpenOrdersRequest
+	(*OpenOrdersResponse)(nil),         // 23: kalency.matching.v1.OpenOrdersResponse
+	(*GetWalletRequest)(nil),           // 24: kalency.matching.v1.GetWalletRequest
+	(*Wallet)(nil),                     // 25: kalency.matching.v1.Wallet
+	(*TransferRequest)(nil),            // 26: kalency.matching.v1.TransferRequest
+	(*TransferResponse)(nil),           // 27: kalency.matching.v1.TransferResponse
+	(*FundingRequest)(nil),             // 28: kalency.matching.v1.FundingRequest
+	(*FundingOperation)(nil),           // 29: kalency.matching.v1.FundingOperation
+	(*ListFundingHistoryRequest)(nil),  // 30: kalency.matching.v1.ListFundingHistoryRequest
+	(*ListFundingHistoryResponse)(nil), // 31: kalency.matching.v1.ListFundingHistoryResponse
+	(*GetOrderBookRequest)(nil),        // 32: kalency.matching.v1.GetOrderBookRequest
+	(*BookLevel)(nil),                  // 33: kalency.matching.v1.BookLevel
+	(*OrderBook)(nil),                  // 34: kalency.matching.v1.OrderBook
+	(*ListTradesRequest)(nil),          // 35: kalency.matching.v1.ListTradesRequest
+	(*ListTradesResponse)(nil),         // 36: kalency.matching.v1.ListTradesResponse
+	(*Execution)(nil),                  // 37: kalency.matching.v1.Execution
+	(*GetTickerRequest)(nil),           // 38: kalency.matching.v1.GetTickerRequest
+	(*ListTickersRequest)(nil),         // 39: kalency.matching.v1.ListTickersRequest
+	(*ListTickersResponse)(nil),        // 40: kalency.matching.v1.ListTickersResponse
+	(*Ticker)(nil),                     // 41: kalency.matching.v1.Ticker
+	(*StreamExecutionsRequest)(nil),    // 42: kalency.matching.v1.StreamExecutionsRequest
+	nil,                                // 43: kalency.matching.v1.ErrorDetail.DetailsEntry
+	nil,                                // 44: kalency.matching.v1.Wallet.AvailableEntry
+	nil,                                // 45: kalency.matching.v1.Wallet.ReservedEntry
+	(*timestamppb.Timestamp)(nil),      // 46: google.protobuf.Timestamp
+}
+var file_kalency_matching_v1_engine_proto_depIdxs = []int32{
+	0,  // 0: kalency.matching.v1.PlaceOrderRequest.side:type_name -> kalency.matching.v1.Side
+	1,  // 1: kalency.matching.v1.PlaceOrderRequest.type:type_name -> kalency.matching.v1.OrderType
+	2,  // 2: kalency.matching.v1.OrderAck.status:type_name -> kalency.matching.v1.OrderStatus
+	46, // 3: kalency.matching.v1.OrderAck.ts:type_name -> google.protobuf.Timestamp
+	8,  // 4: kalency.matching.v1.PlaceOrderBatchRequest.orders:type_name -> kalency.matching.v1.PlaceOrderRequest
+	11, // 5: kalency.matching.v1.BatchResult.ack:type_name -> kalency.matching.v1.OrderAck
+	43, // 6: kalency.matching.v1.ErrorDetail.details:type_name -> kalency.matching.v1.ErrorDetail.DetailsEntry
+	14, // 7: kalency.matching.v1.OrderBatchResponse.results:type_name -> kalency.matching.v1.BatchResult
+	1,  // 8: kalency.matching.v1.OrderLeg.type:type_name -> kalency.matching.v1.OrderType
+	3,  // 9: kalency.matching.v1.PlaceOrderListRequest.type:type_name -> kalency.matching.v1.OrderListType
+	0,  // 10: kalency.matching.v1.PlaceOrderListRequest.side:type_name -> kalency.matching.v1.Side
+	17, // 11: kalency.matching.v1.PlaceOrderListRequest.entry:type_name -> kalency.matching.v1.OrderLeg
+	17, // 12: kalency.matching.v1.PlaceOrderListRequest.take_profit:type_name -> kalency.matching.v1.OrderLeg
+	17, // 13: kalency.matching.v1.PlaceOrderListRequest.stop_loss:type_name -> kalency.matching.v1.OrderLeg
+	3,  // 14: kalency.matching.v1.OrderListAck.type:type_name -> kalency.matching.v1.OrderListType
+	4,  // 15: kalency.matching.v1.OrderListAck.status:type_name -> kalency.matching.v1.OrderListStatus
+	11, // 16: kalency.matching.v1.OrderListAck.orders:type_name -> kalency.matching.v1.OrderAck
+	46, // 17: kalency.matching.v1.OrderListAck.ts:type_name -> google.protobuf.Timestamp
+	0,  // 18: kalency.matching.v1.Order.side:type_name -> kalency.matching.v1.Side
+	1,  // 19: kalency.matching.v1.Order.type:type_name -> kalency.matching.v1.OrderType
+	5,  // 20: kalency.matching.v1.Order.list_role:type_name -> kalency.matching.v1.OrderListRole
+	4,  // 21: kalency.matching.v1.Order.list_status:type_name -> kalency.matching.v1.OrderListStatus
+	46, // 22: kalency.matching.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	21, // 23: kalency.matching.v1.OpenOrdersResponse.orders:type_name -> kalency.matching.v1.Order
+	44, // 24: kalency.matching.v1.Wallet.available:type_name -> kalency.matching.v1.Wallet.AvailableEntry
+	45, // 25: kalency.matching.v1.Wallet.reserved:type_name -> kalency.matching.v1.Wallet.ReservedEntry
+	46, // 26: kalency.matching.v1.Wallet.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 27: kalency.matching.v1.TransferResponse.from:type_name -> kalency.matching.v1.Wallet
+	25, // 28: kalency.matching.v1.TransferResponse.to:type_name -> kalency.matching.v1.Wallet
+	29, // 29: kalency.matching.v1.TransferResponse.operation:type_name -> kalency.matching.v1.FundingOperation
+	6,  // 30: kalency.matching.v1.FundingOperation.type:type_name -> kalency.matching.v1.FundingType
+	7,  // 31: kalency.matching.v1.FundingOperation.status:type_name -> kalency.matching.v1.FundingStatus
+	46, // 32: kalency.matching.v1.FundingOperation.created_at:type_name -> google.protobuf.Timestamp
+	46, // 33: kalency.matching.v1.FundingOperation.updated_at:type_name -> google.protobuf.Timestamp
+	29, // 34: kalency.matching.v1.ListFundingHistoryResponse.operations:type_name -> kalency.matching.v1.FundingOperation
+	33, // 35: kalency.matching.v1.OrderBook.bids:type_name -> kalency.matching.v1.BookLevel
+	33, // 36: kalency.matching.v1.OrderBook.asks:type_name -> kalency.matching.v1.BookLevel
+	46, // 37: kalency.matching.v1.OrderBook.ts:type_name -> google.protobuf.Timestamp
+	37, // 38: kalency.matching.v1.ListTradesResponse.trades:type_name -> kalency.matching.v1.Execution
+	46, // 39: kalency.matching.v1.Execution.ts:type_name -> google.protobuf.Timestamp
+	41, // 40: kalency.matching.v1.ListTickersResponse.tickers:type_name -> kalency.matching.v1.Ticker
+	46, // 41: kalency.matching.v1.Ticker.ts:type_name -> google.protobuf.Timestamp
+	8,  // 42: kalency.matching.v1.MatchingEngine.PlaceOrder:input_type -> kalency.matching.v1.PlaceOrderRequest
+	9,  // 43: kalency.matching.v1.MatchingEngine.CancelOrder:input_type -> kalency.matching.v1.CancelOrderRequest
+	10, // 44: kalency.matching.v1.MatchingEngine.AmendOrder:input_type -> kalency.matching.v1.AmendOrderRequest
+	12, // 45: kalency.matching.v1.MatchingEngine.PlaceOrderBatch:input_type -> kalency.matching.v1.PlaceOrderBatchRequest
+	13, // 46: kalency.matching.v1.MatchingEngine.CancelOrderBatch:input_type -> kalency.matching.v1.CancelOrderBatchRequest
+	18, // 47: kalency.matching.v1.MatchingEngine.PlaceOrderList:input_type -> kalency.matching.v1.PlaceOrderListRequest
+	19, // 48: kalency.matching.v1.MatchingEngine.CancelOrderList:input_type -> kalency.matching.v1.CancelOrderListRequest
+	22, // 49: kalency.matching.v1.MatchingEngine.OpenOrders:input_type -> kalency.matching.v1.OpenOrdersRequest
+	24, // 50: kalency.matching.v1.MatchingEngine.GetWallet:input_type -> kalency.matching.v1.GetWalletRequest
+	26, // 51: kalency.matching.v1.MatchingEngine.Transfer:input_type -> kalency.matching.v1.TransferRequest
+	28, // 52: kalency.matching.v1.MatchingEngine.Deposit:input_type -> kalency.matching.v1.FundingRequest
+	28, // 53: kalency.matching.v1.MatchingEngine.Withdraw:input_type -> kalency.matching.v1.FundingRequest
+	30, // 54: kalency.matching.v1.MatchingEngine.ListFundingHistory:input_type -> kalency.matching.v1.ListFundingHistoryRequest
+	32, // 55: kalency.matching.v1.MatchingEngine.GetOrderBook:input_type -> kalency.matching.v1.GetOrderBookRequest
+	35, // 56: kalency.matching.v1.MatchingEngine.ListTrades:input_type -> kalency.matching.v1.ListTradesRequest
+	38, // 57: kalency.matching.v1.MatchingEngine.GetTicker:input_type -> kalency.matching.v1.GetTickerRequest
+	39, // 58: kalency.matching.v1.MatchingEngine.ListTickers:input_type -> kalency.matching.v1.ListTickersRequest
+	42, // 59: kalency.matching.v1.MatchingEngine.StreamExecutions:input_type -> kalency.matching.v1.StreamExecutionsRequest
+	11, // 60: kalency.matching.v1.MatchingEngine.PlaceOrder:output_type -> kalency.matching.v1.OrderAck
+	11, // 61: kalency.matching.v1.MatchingEngine.CancelOrder:output_type -> kalency.matching.v1.OrderAck
+	11, // 62: kalency.matching.v1.MatchingEngine.AmendOrder:output_type -> kalency.matching.v1.OrderAck
+	16, // 63: kalency.matching.v1.MatchingEngine.PlaceOrderBatch:output_type -> kalency.matching.v1.OrderBatchResponse
+	16, // 64: kalency.matching.v1.MatchingEngine.CancelOrderBatch:output_type -> kalency.matching.v1.OrderBatchResponse
+	20, // 65: kalency.matching.v1.MatchingEngine.PlaceOrderList:output_type -> kalency.matching.v1.OrderListAck
+	20, // 66: kalency.matching.v1.MatchingEngine.CancelOrderList:output_type -> kalency.matching.v1.OrderListAck
+	23, // 67: kalency.matching.v1.MatchingEngine.OpenOrders:output_type -> kalency.matching.v1.OpenOrdersResponse
+	25, // 68: kalency.matching.v1.MatchingEngine.GetWallet:output_type -> kalency.matching.v1.Wallet
+	27, // 69: kalency.matching.v1.MatchingEngine.Transfer:output_type -> kalency.matching.v1.TransferResponse
+	29, // 70: kalency.matching.v1.MatchingEngine.Deposit:output_type -> kalency.matching.v1.FundingOperation
+	29, // 71: kalency.matching.v1.MatchingEngine.Withdraw:output_type -> kalency.matching.v1.FundingOperation
+	31, // 72: kalency.matching.v1.MatchingEngine.ListFundingHistory:output_type -> kalency.matching.v1.ListFundingHistoryResponse
+	34, // 73: kalency.matching.v1.MatchingEngine.GetOrderBook:output_type -> kalency.matching.v1.OrderBook
+	36, // 74: kalency.matching.v1.MatchingEngine.ListTrades:output_type -> kalency.matching.v1.ListTradesResponse
+	41, // 75: kalency.matching.v1.MatchingEngine.GetTicker:output_type -> kalency.matching.v1.Ticker
+	40, // 76: kalency.matching.v1.MatchingEngine.ListTickers:output_type -> kalency.matching.v1.ListTickersResponse
+	37, // 77: kalency.matching.v1.MatchingEngine.StreamExecutions:output_type -> kalency.matching.v1.Execution
+	60, // [60:78] is the sub-list for method output_type
+	42, // [42:60] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_kalency_matching_v1_engine_proto_init() }
+func file_kalency_matching_v1_engine_proto_init() {
+	if File_kalency_matching_v1_engine_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kalency_matching_v1_engine_proto_rawDesc), len(file_kalency_matching_v1_engine_proto_rawDesc)),
+			NumEnums:      8,
+			NumMessages:   38,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kalency_matching_v1_engine_proto_goTypes,
+		DependencyIndexes: file_kalency_matching_v1_engine_proto_depIdxs,
+		EnumInfos:         file_kalency_matching_v1_engine_proto_enumTypes,
+		MessageInfos:      file_kalency_matching_v1_engine_proto_msgTypes,
+	}.Build()
+	File_kalency_matching_v1_engine_proto = out.File
+	file_kalency_matching_v1_engine_proto_goTypes = nil
+	file_kalency_matching_v1_engine_proto_depIdxs = nil
+}
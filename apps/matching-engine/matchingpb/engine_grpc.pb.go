@@ -0,0 +1,803 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: kalency/matching/v1/engine.proto
+
+package matchingpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MatchingEngine_PlaceOrder_FullMethodName         = "/kalency.matching.v1.MatchingEngine/PlaceOrder"
+	MatchingEngine_CancelOrder_FullMethodName        = "/kalency.matching.v1.MatchingEngine/CancelOrder"
+	MatchingEngine_AmendOrder_FullMethodName         = "/kalency.matching.v1.MatchingEngine/AmendOrder"
+	MatchingEngine_PlaceOrderBatch_FullMethodName    = "/kalency.matching.v1.MatchingEngine/PlaceOrderBatch"
+	MatchingEngine_CancelOrderBatch_FullMethodName   = "/kalency.matching.v1.MatchingEngine/CancelOrderBatch"
+	MatchingEngine_PlaceOrderList_FullMethodName     = "/kalency.matching.v1.MatchingEngine/PlaceOrderList"
+	MatchingEngine_CancelOrderList_FullMethodName    = "/kalency.matching.v1.MatchingEngine/CancelOrderList"
+	MatchingEngine_OpenOrders_FullMethodName         = "/kalency.matching.v1.MatchingEngine/OpenOrders"
+	MatchingEngine_GetWallet_FullMethodName          = "/kalency.matching.v1.MatchingEngine/GetWallet"
+	MatchingEngine_Transfer_FullMethodName           = "/kalency.matching.v1.MatchingEngine/Transfer"
+	MatchingEngine_Deposit_FullMethodName            = "/kalency.matching.v1.MatchingEngine/Deposit"
+	MatchingEngine_Withdraw_FullMethodName           = "/kalency.matching.v1.MatchingEngine/Withdraw"
+	MatchingEngine_ListFundingHistory_FullMethodName = "/kalency.matching.v1.MatchingEngine/ListFundingHistory"
+	MatchingEngine_GetOrderBook_FullMethodName       = "/kalency.matching.v1.MatchingEngine/GetOrderBook"
+	MatchingEngine_ListTrades_FullMethodName         = "/kalency.matching.v1.MatchingEngine/ListTrades"
+	MatchingEngine_GetTicker_FullMethodName          = "/kalency.matching.v1.MatchingEngine/GetTicker"
+	MatchingEngine_ListTickers_FullMethodName        = "/kalency.matching.v1.MatchingEngine/ListTickers"
+	MatchingEngine_StreamExecutions_FullMethodName   = "/kalency.matching.v1.MatchingEngine/StreamExecutions"
+)
+
+// MatchingEngineClient is the client API for MatchingEngine service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MatchingEngine is the order entry and market data API of the matching
+// engine. It carries the same operations as the engine's JSON HTTP API plus
+// order amends and a live execution stream.
+type MatchingEngineClient interface {
+	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*OrderAck, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*OrderAck, error)
+	// AmendOrder changes the price and/or total quantity of a resting limit
+	// order. Reducing the quantity at the same price keeps queue priority; any
+	// other change re-queues the order at the back of its new price level.
+	AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*OrderAck, error)
+	// PlaceOrderBatch and CancelOrderBatch process up to 100 orders under one
+	// engine lock and return one result per order, in request order.
+	PlaceOrderBatch(ctx context.Context, in *PlaceOrderBatchRequest, opts ...grpc.CallOption) (*OrderBatchResponse, error)
+	CancelOrderBatch(ctx context.Context, in *CancelOrderBatchRequest, opts ...grpc.CallOption) (*OrderBatchResponse, error)
+	PlaceOrderList(ctx context.Context, in *PlaceOrderListRequest, opts ...grpc.CallOption) (*OrderListAck, error)
+	CancelOrderList(ctx context.Context, in *CancelOrderListRequest, opts ...grpc.CallOption) (*OrderListAck, error)
+	OpenOrders(ctx context.Context, in *OpenOrdersRequest, opts ...grpc.CallOption) (*OpenOrdersResponse, error)
+	GetWallet(ctx context.Context, in *GetWalletRequest, opts ...grpc.CallOption) (*Wallet, error)
+	// Transfer moves available balance between a user's main account and its
+	// sub-accounts ("{user_id}:{account_id}").
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	// Deposit and Withdraw are idempotent by (user_id, request_id). Amounts
+	// above the asset's review threshold stay PENDING until an operator settles
+	// them over the admin HTTP API.
+	Deposit(ctx context.Context, in *FundingRequest, opts ...grpc.CallOption) (*FundingOperation, error)
+	Withdraw(ctx context.Context, in *FundingRequest, opts ...grpc.CallOption) (*FundingOperation, error)
+	ListFundingHistory(ctx context.Context, in *ListFundingHistoryRequest, opts ...grpc.CallOption) (*ListFundingHistoryResponse, error)
+	GetOrderBook(ctx context.Context, in *GetOrderBookRequest, opts ...grpc.CallOption) (*OrderBook, error)
+	ListTrades(ctx context.Context, in *ListTradesRequest, opts ...grpc.CallOption) (*ListTradesResponse, error)
+	GetTicker(ctx context.Context, in *GetTickerRequest, opts ...grpc.CallOption) (*Ticker, error)
+	ListTickers(ctx context.Context, in *ListTickersRequest, opts ...grpc.CallOption) (*ListTickersResponse, error)
+	// StreamExecutions pushes trades as they happen. There is no replay; a
+	// client that falls behind is disconnected with RESOURCE_EXHAUSTED.
+	StreamExecutions(ctx context.Context, in *StreamExecutionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Execution], error)
+}
+
+type matchingEngineClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatchingEngineClient(cc grpc.ClientConnInterface) MatchingEngineClient {
+	return &matchingEngineClient{cc}
+}
+
+func (c *matchingEngineClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*OrderAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderAck)
+	err := c.cc.Invoke(ctx, MatchingEngine_PlaceOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*OrderAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderAck)
+	err := c.cc.Invoke(ctx, MatchingEngine_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*OrderAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderAck)
+	err := c.cc.Invoke(ctx, MatchingEngine_AmendOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) PlaceOrderBatch(ctx context.Context, in *PlaceOrderBatchRequest, opts ...grpc.CallOption) (*OrderBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderBatchResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_PlaceOrderBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) CancelOrderBatch(ctx context.Context, in *CancelOrderBatchRequest, opts ...grpc.CallOption) (*OrderBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderBatchResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_CancelOrderBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) PlaceOrderList(ctx context.Context, in *PlaceOrderListRequest, opts ...grpc.CallOption) (*OrderListAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderListAck)
+	err := c.cc.Invoke(ctx, MatchingEngine_PlaceOrderList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) CancelOrderList(ctx context.Context, in *CancelOrderListRequest, opts ...grpc.CallOption) (*OrderListAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderListAck)
+	err := c.cc.Invoke(ctx, MatchingEngine_CancelOrderList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) OpenOrders(ctx context.Context, in *OpenOrdersRequest, opts ...grpc.CallOption) (*OpenOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpenOrdersResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_OpenOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) GetWallet(ctx context.Context, in *GetWalletRequest, opts ...grpc.CallOption) (*Wallet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Wallet)
+	err := c.cc.Invoke(ctx, MatchingEngine_GetWallet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_Transfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) Deposit(ctx context.Context, in *FundingRequest, opts ...grpc.CallOption) (*FundingOperation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FundingOperation)
+	err := c.cc.Invoke(ctx, MatchingEngine_Deposit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) Withdraw(ctx context.Context, in *FundingRequest, opts ...grpc.CallOption) (*FundingOperation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FundingOperation)
+	err := c.cc.Invoke(ctx, MatchingEngine_Withdraw_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) ListFundingHistory(ctx context.Context, in *ListFundingHistoryRequest, opts ...grpc.CallOption) (*ListFundingHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFundingHistoryResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_ListFundingHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) GetOrderBook(ctx context.Context, in *GetOrderBookRequest, opts ...grpc.CallOption) (*OrderBook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderBook)
+	err := c.cc.Invoke(ctx, MatchingEngine_GetOrderBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) ListTrades(ctx context.Context, in *ListTradesRequest, opts ...grpc.CallOption) (*ListTradesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTradesResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_ListTrades_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) GetTicker(ctx context.Context, in *GetTickerRequest, opts ...grpc.CallOption) (*Ticker, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticker)
+	err := c.cc.Invoke(ctx, MatchingEngine_GetTicker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) ListTickers(ctx context.Context, in *ListTickersRequest, opts ...grpc.CallOption) (*ListTickersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTickersResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_ListTickers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) StreamExecutions(ctx context.Context, in *StreamExecutionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Execution], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchingEngine_ServiceDesc.Streams[0], MatchingEngine_StreamExecutions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamExecutionsRequest, Execution]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchingEngine_StreamExecutionsClient = grpc.ServerStreamingClient[Execution]
+
+// MatchingEngineServer is the server API for MatchingEngine service.
+// All implementations must embed UnimplementedMatchingEngineServer
+// for forward compatibility.
+//
+// MatchingEngine is the order entry and market data API of the matching
+// engine. It carries the same operations as the engine's JSON HTTP API plus
+// order amends and a live execution stream.
+type MatchingEngineServer interface {
+	PlaceOrder(context.Context, *PlaceOrderRequest) (*OrderAck, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*OrderAck, error)
+	// AmendOrder changes the price and/or total quantity of a resting limit
+	// order. Reducing the quantity at the same price keeps queue priority; any
+	// other change re-queues the order at the back of its new price level.
+	AmendOrder(context.Context, *AmendOrderRequest) (*OrderAck, error)
+	// PlaceOrderBatch and CancelOrderBatch process up to 100 orders under one
+	// engine lock and return one result per order, in request order.
+	PlaceOrderBatch(context.Context, *PlaceOrderBatchRequest) (*OrderBatchResponse, error)
+	CancelOrderBatch(context.Context, *CancelOrderBatchRequest) (*OrderBatchResponse, error)
+	PlaceOrderList(context.Context, *PlaceOrderListRequest) (*OrderListAck, error)
+	CancelOrderList(context.Context, *CancelOrderListRequest) (*OrderListAck, error)
+	OpenOrders(context.Context, *OpenOrdersRequest) (*OpenOrdersResponse, error)
+	GetWallet(context.Context, *GetWalletRequest) (*Wallet, error)
+	// Transfer moves available balance between a user's main account and its
+	// sub-accounts ("{user_id}:{account_id}").
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	// Deposit and Withdraw are idempotent by (user_id, request_id). Amounts
+	// above the asset's review threshold stay PENDING until an operator settles
+	// them over the admin HTTP API.
+	Deposit(context.Context, *FundingRequest) (*FundingOperation, error)
+	Withdraw(context.Context, *FundingRequest) (*FundingOperation, error)
+	ListFundingHistory(context.Context, *ListFundingHistoryRequest) (*ListFundingHistoryResponse, error)
+	GetOrderBook(context.Context, *GetOrderBookRequest) (*OrderBook, error)
+	ListTrades(context.Context, *ListTradesRequest) (*ListTradesResponse, error)
+	GetTicker(context.Context, *GetTickerRequest) (*Ticker, error)
+	ListTickers(context.Context, *ListTickersRequest) (*ListTickersResponse, error)
+	// StreamExecutions pushes trades as they happen. There is no replay; a
+	// client that falls behind is disconnected with RESOURCE_EXHAUSTED.
+	StreamExecutions(*StreamExecutionsRequest, grpc.ServerStreamingServer[Execution]) error
+	mustEmbedUnimplementedMatchingEngineServer()
+}
+
+// UnimplementedMatchingEngineServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMatchingEngineServer struct{}
+
+func (UnimplementedMatchingEngineServer) PlaceOrder(context.Context, *PlaceOrderRequest) (*OrderAck, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlaceOrder not implemented")
+}
+func (UnimplementedMatchingEngineServer) CancelOrder(context.Context, *CancelOrderRequest) (*OrderAck, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedMatchingEngineServer) AmendOrder(context.Context, *AmendOrderRequest) (*OrderAck, error) {
+	return nil, status.Error(codes.Unimplemented, "method AmendOrder not implemented")
+}
+func (UnimplementedMatchingEngineServer) PlaceOrderBatch(context.Context, *PlaceOrderBatchRequest) (*OrderBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlaceOrderBatch not implemented")
+}
+func (UnimplementedMatchingEngineServer) CancelOrderBatch(context.Context, *CancelOrderBatchRequest) (*OrderBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelOrderBatch not implemented")
+}
+func (UnimplementedMatchingEngineServer) PlaceOrderList(context.Context, *PlaceOrderListRequest) (*OrderListAck, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlaceOrderList not implemented")
+}
+func (UnimplementedMatchingEngineServer) CancelOrderList(context.Context, *CancelOrderListRequest) (*OrderListAck, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelOrderList not implemented")
+}
+func (UnimplementedMatchingEngineServer) OpenOrders(context.Context, *OpenOrdersRequest) (*OpenOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method OpenOrders not implemented")
+}
+func (UnimplementedMatchingEngineServer) GetWallet(context.Context, *GetWalletRequest) (*Wallet, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWallet not implemented")
+}
+func (UnimplementedMatchingEngineServer) Transfer(context.Context, *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Transfer not implemented")
+}
+func (UnimplementedMatchingEngineServer) Deposit(context.Context, *FundingRequest) (*FundingOperation, error) {
+	return nil, status.Error(codes.Unimplemented, "method Deposit not implemented")
+}
+func (UnimplementedMatchingEngineServer) Withdraw(context.Context, *FundingRequest) (*FundingOperation, error) {
+	return nil, status.Error(codes.Unimplemented, "method Withdraw not implemented")
+}
+func (UnimplementedMatchingEngineServer) ListFundingHistory(context.Context, *ListFundingHistoryRequest) (*ListFundingHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFundingHistory not implemented")
+}
+func (UnimplementedMatchingEngineServer) GetOrderBook(context.Context, *GetOrderBookRequest) (*OrderBook, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrderBook not implemented")
+}
+func (UnimplementedMatchingEngineServer) ListTrades(context.Context, *ListTradesRequest) (*ListTradesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTrades not implemented")
+}
+func (UnimplementedMatchingEngineServer) GetTicker(context.Context, *GetTickerRequest) (*Ticker, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTicker not implemented")
+}
+func (UnimplementedMatchingEngineServer) ListTickers(context.Context, *ListTickersRequest) (*ListTickersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTickers not implemented")
+}
+func (UnimplementedMatchingEngineServer) StreamExecutions(*StreamExecutionsRequest, grpc.ServerStreamingServer[Execution]) error {
+	return status.Error(codes.Unimplemented, "method StreamExecutions not implemented")
+}
+func (UnimplementedMatchingEngineServer) mustEmbedUnimplementedMatchingEngineServer() {}
+func (UnimplementedMatchingEngineServer) testEmbeddedByValue()                        {}
+
+// UnsafeMatchingEngineServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatchingEngineServer will
+// result in compilation errors.
+type UnsafeMatchingEngineServer interface {
+	mustEmbedUnimplementedMatchingEngineServer()
+}
+
+func RegisterMatchingEngineServer(s grpc.ServiceRegistrar, srv MatchingEngineServer) {
+	// If the following call panics, it indicates UnimplementedMatchingEngineServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MatchingEngine_ServiceDesc, srv)
+}
+
+func _MatchingEngine_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).PlaceOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_PlaceOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).PlaceOrder(ctx, req.(*PlaceOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_AmendOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).AmendOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_AmendOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).AmendOrder(ctx, req.(*AmendOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_PlaceOrderBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).PlaceOrderBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_PlaceOrderBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).PlaceOrderBatch(ctx, req.(*PlaceOrderBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_CancelOrderBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).CancelOrderBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_CancelOrderBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).CancelOrderBatch(ctx, req.(*CancelOrderBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_PlaceOrderList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).PlaceOrderList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_PlaceOrderList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).PlaceOrderList(ctx, req.(*PlaceOrderListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_CancelOrderList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).CancelOrderList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_CancelOrderList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).CancelOrderList(ctx, req.(*CancelOrderListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_OpenOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).OpenOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_OpenOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).OpenOrders(ctx, req.(*OpenOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_GetWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).GetWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_GetWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).GetWallet(ctx, req.(*GetWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_Transfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_Deposit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).Deposit(ctx, req.(*FundingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_Withdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).Withdraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_Withdraw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).Withdraw(ctx, req.(*FundingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_ListFundingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFundingHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).ListFundingHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_ListFundingHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).ListFundingHistory(ctx, req.(*ListFundingHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).GetOrderBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_GetOrderBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).GetOrderBook(ctx, req.(*GetOrderBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_ListTrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTradesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).ListTrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_ListTrades_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).ListTrades(ctx, req.(*ListTradesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_GetTicker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTickerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).GetTicker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_GetTicker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).GetTicker(ctx, req.(*GetTickerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_ListTickers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTickersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).ListTickers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_ListTickers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).ListTickers(ctx, req.(*ListTickersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_StreamExecutions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamExecutionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchingEngineServer).StreamExecutions(m, &grpc.GenericServerStream[StreamExecutionsRequest, Execution]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchingEngine_StreamExecutionsServer = grpc.ServerStreamingServer[Execution]
+
+// MatchingEngine_ServiceDesc is the grpc.ServiceDesc for MatchingEngine service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MatchingEngine_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kalency.matching.v1.MatchingEngine",
+	HandlerType: (*MatchingEngineServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlaceOrder",
+			Handler:    _MatchingEngine_PlaceOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _MatchingEngine_CancelOrder_Handler,
+		},
+		{
+			MethodName: "AmendOrder",
+			Handler:    _MatchingEngine_AmendOrder_Handler,
+		},
+		{
+			MethodName: "PlaceOrderBatch",
+			Handler:    _MatchingEngine_PlaceOrderBatch_Handler,
+		},
+		{
+			MethodName: "CancelOrderBatch",
+			Handler:    _MatchingEngine_CancelOrderBatch_Handler,
+		},
+		{
+			MethodName: "PlaceOrderList",
+			Handler:    _MatchingEngine_PlaceOrderList_Handler,
+		},
+		{
+			MethodName: "CancelOrderList",
+			Handler:    _MatchingEngine_CancelOrderList_Handler,
+		},
+		{
+			MethodName: "OpenOrders",
+			Handler:    _MatchingEngine_OpenOrders_Handler,
+		},
+		{
+			MethodName: "GetWallet",
+			Handler:    _MatchingEngine_GetWallet_Handler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    _MatchingEngine_Transfer_Handler,
+		},
+		{
+			MethodName: "Deposit",
+			Handler:    _MatchingEngine_Deposit_Handler,
+		},
+		{
+			MethodName: "Withdraw",
+			Handler:    _MatchingEngine_Withdraw_Handler,
+		},
+		{
+			MethodName: "ListFundingHistory",
+			Handler:    _MatchingEngine_ListFundingHistory_Handler,
+		},
+		{
+			MethodName: "GetOrderBook",
+			Handler:    _MatchingEngine_GetOrderBook_Handler,
+		},
+		{
+			MethodName: "ListTrades",
+			Handler:    _MatchingEngine_ListTrades_Handler,
+		},
+		{
+			MethodName: "GetTicker",
+			Handler:    _MatchingEngine_GetTicker_Handler,
+		},
+		{
+			MethodName: "ListTickers",
+			Handler:    _MatchingEngine_ListTickers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamExecutions",
+			Handler:       _MatchingEngine_StreamExecutions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "kalency/matching/v1/engine.proto",
+}
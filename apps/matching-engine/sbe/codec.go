@@ -0,0 +1,326 @@
+// Package sbe is the binary order entry wire format. It sits outside internal
+// so clients such as the market simulator share the engine's codec.
+package sbe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Frames use the Simple Open Framing Header (big-endian message length
+// including the header, then the SBE 1.0 little-endian encoding type)
+// followed by an SBE message header and a fixed-layout block. Strings are
+// fixed-width, NUL padded ASCII.
+const (
+	sofhLength         = 6
+	headerLength       = 8
+	sbeEncodingType    = 0xEB50
+	SchemaID           = 1
+	SchemaVersion      = 1
+	maxFrameLength     = 1024
+	symbolLength       = 16
+	idLength           = 32
+	rejectReasonLength = 96
+)
+
+const (
+	TemplateNewOrder    uint16 = 1
+	TemplateCancelOrder uint16 = 2
+	TemplateOrderAck    uint16 = 3
+	TemplateOrderReject uint16 = 4
+	TemplateFill        uint16 = 5
+)
+
+const (
+	newOrderBlockLength    = 120
+	cancelOrderBlockLength = 72
+	orderAckBlockLength    = 112
+	orderRejectBlockLength = 104
+	fillBlockLength        = 144
+)
+
+var (
+	sideCodes      = []string{"", "BUY", "SELL"}
+	orderTypeCodes = []string{"", "MARKET", "LIMIT", "STOP_MARKET", "STOP_LIMIT"}
+	statusCodes    = []string{"", "PENDING", "ACCEPTED", "PARTIALLY_FILLED", "FILLED", "CANCELED", "REJECTED"}
+)
+
+const (
+	LiquidityMaker = "MAKER"
+	LiquidityTaker = "TAKER"
+)
+
+var liquidityCodes = []string{"", LiquidityMaker, LiquidityTaker}
+
+// NewOrder places an order. RequestID is echoed on the OrderAck or
+// OrderReject that answers it.
+type NewOrder struct {
+	RequestID     uint64
+	ClientOrderID string
+	UserID        string
+	Symbol        string
+	Side          string
+	Type          string
+	Price         int64
+	StopPrice     int64
+	Qty           int64
+}
+
+type CancelOrder struct {
+	RequestID uint64
+	UserID    string
+	OrderID   string
+}
+
+type OrderAck struct {
+	RequestID     uint64
+	OrderID       string
+	ClientOrderID string
+	Status        string
+	FilledQty     int64
+	RemainingQty  int64
+	AvgPrice      int64
+	// TransactTime is Unix nanoseconds.
+	TransactTime int64
+}
+
+type OrderReject struct {
+	RequestID uint64
+	Reason    string
+}
+
+// Fill is one trade on an order owned by a user the connection has placed
+// orders for. An aggressive order's fills are sent before its OrderAck.
+type Fill struct {
+	OrderID      string
+	TradeID      string
+	UserID       string
+	Symbol       string
+	Liquidity    string
+	Price        int64
+	Qty          int64
+	TransactTime int64
+}
+
+// Encode frames msg, which must be one of the message types above.
+func Encode(msg any) ([]byte, error) {
+	var (
+		template uint16
+		block    []byte
+		err      error
+	)
+	switch m := msg.(type) {
+	case NewOrder:
+		template, block = TemplateNewOrder, make([]byte, newOrderBlockLength)
+		binary.LittleEndian.PutUint64(block[0:], m.RequestID)
+		putInt64(block[8:], m.Price)
+		putInt64(block[16:], m.StopPrice)
+		putInt64(block[24:], m.Qty)
+		if block[32], err = code(sideCodes, "side", m.Side); err != nil {
+			return nil, err
+		}
+		if block[33], err = code(orderTypeCodes, "type", m.Type); err != nil {
+			return nil, err
+		}
+		err = putStrings(block, field{40, symbolLength, "symbol", m.Symbol}, field{56, idLength, "userId", m.UserID}, field{88, idLength, "clientOrderId", m.ClientOrderID})
+	case CancelOrder:
+		template, block = TemplateCancelOrder, make([]byte, cancelOrderBlockLength)
+		binary.LittleEndian.PutUint64(block[0:], m.RequestID)
+		err = putStrings(block, field{8, idLength, "userId", m.UserID}, field{40, idLength, "orderId", m.OrderID})
+	case OrderAck:
+		template, block = TemplateOrderAck, make([]byte, orderAckBlockLength)
+		binary.LittleEndian.PutUint64(block[0:], m.RequestID)
+		putInt64(block[8:], m.FilledQty)
+		putInt64(block[16:], m.RemainingQty)
+		putInt64(block[24:], m.AvgPrice)
+		putInt64(block[32:], m.TransactTime)
+		if block[40], err = code(statusCodes, "status", m.Status); err != nil {
+			return nil, err
+		}
+		err = putStrings(block, field{48, idLength, "orderId", m.OrderID}, field{80, idLength, "clientOrderId", m.ClientOrderID})
+	case OrderReject:
+		template, block = TemplateOrderReject, make([]byte, orderRejectBlockLength)
+		binary.LittleEndian.PutUint64(block[0:], m.RequestID)
+		reason := m.Reason
+		if len(reason) > rejectReasonLength {
+			reason = reason[:rejectReasonLength]
+		}
+		copy(block[8:], reason)
+	case Fill:
+		template, block = TemplateFill, make([]byte, fillBlockLength)
+		putInt64(block[0:], m.Price)
+		putInt64(block[8:], m.Qty)
+		putInt64(block[16:], m.TransactTime)
+		if block[24], err = code(liquidityCodes, "liquidity", m.Liquidity); err != nil {
+			return nil, err
+		}
+		err = putStrings(block, field{32, idLength, "orderId", m.OrderID}, field{64, idLength, "tradeId", m.TradeID}, field{96, idLength, "userId", m.UserID}, field{128, symbolLength, "symbol", m.Symbol})
+	default:
+		return nil, fmt.Errorf("unsupported message %T", msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	frame := make([]byte, sofhLength+headerLength+len(block))
+	binary.BigEndian.PutUint32(frame[0:], uint32(len(frame)))
+	binary.BigEndian.PutUint16(frame[4:], sbeEncodingType)
+	binary.LittleEndian.PutUint16(frame[6:], uint16(len(block)))
+	binary.LittleEndian.PutUint16(frame[8:], template)
+	binary.LittleEndian.PutUint16(frame[10:], SchemaID)
+	binary.LittleEndian.PutUint16(frame[12:], SchemaVersion)
+	copy(frame[sofhLength+headerLength:], block)
+	return frame, nil
+}
+
+// ReadMessage reads one frame and decodes it. Blocks longer than this
+// version's layout are accepted so fields can be appended later.
+func ReadMessage(r io.Reader) (any, error) {
+	var sofh [sofhLength]byte
+	if _, err := io.ReadFull(r, sofh[:]); err != nil {
+		return nil, err
+	}
+	length := int(binary.BigEndian.Uint32(sofh[0:]))
+	if binary.BigEndian.Uint16(sofh[4:]) != sbeEncodingType {
+		return nil, errors.New("unsupported encoding type")
+	}
+	if length < sofhLength+headerLength || length > maxFrameLength {
+		return nil, fmt.Errorf("invalid frame length %d", length)
+	}
+	frame := make([]byte, length-sofhLength)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+
+	blockLength := int(binary.LittleEndian.Uint16(frame[0:]))
+	template := binary.LittleEndian.Uint16(frame[2:])
+	if schema := binary.LittleEndian.Uint16(frame[4:]); schema != SchemaID {
+		return nil, fmt.Errorf("unsupported schema %d", schema)
+	}
+	block := frame[headerLength:]
+	if blockLength > len(block) {
+		return nil, errors.New("block length exceeds frame")
+	}
+	block = block[:blockLength]
+
+	switch template {
+	case TemplateNewOrder:
+		if len(block) < newOrderBlockLength {
+			return nil, errShortBlock
+		}
+		return NewOrder{
+			RequestID:     binary.LittleEndian.Uint64(block[0:]),
+			Price:         getInt64(block[8:]),
+			StopPrice:     getInt64(block[16:]),
+			Qty:           getInt64(block[24:]),
+			Side:          name(sideCodes, block[32]),
+			Type:          name(orderTypeCodes, block[33]),
+			Symbol:        getString(block[40 : 40+symbolLength]),
+			UserID:        getString(block[56 : 56+idLength]),
+			ClientOrderID: getString(block[88 : 88+idLength]),
+		}, nil
+	case TemplateCancelOrder:
+		if len(block) < cancelOrderBlockLength {
+			return nil, errShortBlock
+		}
+		return CancelOrder{
+			RequestID: binary.LittleEndian.Uint64(block[0:]),
+			UserID:    getString(block[8 : 8+idLength]),
+			OrderID:   getString(block[40 : 40+idLength]),
+		}, nil
+	case TemplateOrderAck:
+		if len(block) < orderAckBlockLength {
+			return nil, errShortBlock
+		}
+		return OrderAck{
+			RequestID:     binary.LittleEndian.Uint64(block[0:]),
+			FilledQty:     getInt64(block[8:]),
+			RemainingQty:  getInt64(block[16:]),
+			AvgPrice:      getInt64(block[24:]),
+			TransactTime:  getInt64(block[32:]),
+			Status:        name(statusCodes, block[40]),
+			OrderID:       getString(block[48 : 48+idLength]),
+			ClientOrderID: getString(block[80 : 80+idLength]),
+		}, nil
+	case TemplateOrderReject:
+		if len(block) < orderRejectBlockLength {
+			return nil, errShortBlock
+		}
+		return OrderReject{
+			RequestID: binary.LittleEndian.Uint64(block[0:]),
+			Reason:    getString(block[8 : 8+rejectReasonLength]),
+		}, nil
+	case TemplateFill:
+		if len(block) < fillBlockLength {
+			return nil, errShortBlock
+		}
+		return Fill{
+			Price:        getInt64(block[0:]),
+			Qty:          getInt64(block[8:]),
+			TransactTime: getInt64(block[16:]),
+			Liquidity:    name(liquidityCodes, block[24]),
+			OrderID:      getString(block[32 : 32+idLength]),
+			TradeID:      getString(block[64 : 64+idLength]),
+			UserID:       getString(block[96 : 96+idLength]),
+			Symbol:       getString(block[128 : 128+symbolLength]),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown template %d", template)
+	}
+}
+
+var errShortBlock = errors.New("block shorter than message layout")
+
+type field struct {
+	offset int
+	length int
+	name   string
+	value  string
+}
+
+func putStrings(block []byte, fields ...field) error {
+	for _, f := range fields {
+		if len(f.value) > f.length {
+			return fmt.Errorf("%s longer than %d bytes", f.name, f.length)
+		}
+		copy(block[f.offset:f.offset+f.length], f.value)
+	}
+	return nil
+}
+
+func getString(raw []byte) string {
+	for i, b := range raw {
+		if b == 0 {
+			return string(raw[:i])
+		}
+	}
+	return string(raw)
+}
+
+func putInt64(dst []byte, value int64) {
+	binary.LittleEndian.PutUint64(dst, uint64(value))
+}
+
+func getInt64(src []byte) int64 {
+	return int64(binary.LittleEndian.Uint64(src))
+}
+
+// code maps an enum name to its wire value. Empty maps to 0 so the engine can
+// report missing fields itself.
+func code(codes []string, fieldName, value string) (byte, error) {
+	for i, candidate := range codes {
+		if candidate == value {
+			return byte(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown %s %q", fieldName, value)
+}
+
+func name(codes []string, value byte) string {
+	if int(value) >= len(codes) {
+		return ""
+	}
+	return codes[value]
+}
@@ -0,0 +1,81 @@
+package sbe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestEncodeReadMessageRoundTrip(t *testing.T) {
+	messages := []any{
+		NewOrder{RequestID: 7, ClientOrderID: "c-1", UserID: "buyer1", Symbol: "BTC-USD", Side: "BUY", Type: "STOP_LIMIT", Price: 100, StopPrice: 95, Qty: 3},
+		CancelOrder{RequestID: 8, UserID: "buyer1", OrderID: "ord-1"},
+		OrderAck{RequestID: 7, OrderID: "ord-1", ClientOrderID: "c-1", Status: "PARTIALLY_FILLED", FilledQty: 1, RemainingQty: 2, AvgPrice: 100, TransactTime: 1700000000000000000},
+		OrderReject{RequestID: 9, Reason: "qty must be positive"},
+		Fill{OrderID: "ord-1", TradeID: "trd-1", UserID: "buyer1", Symbol: "BTC-USD", Liquidity: LiquidityTaker, Price: 100, Qty: 1, TransactTime: 42},
+	}
+
+	var stream bytes.Buffer
+	for _, msg := range messages {
+		frame, err := Encode(msg)
+		if err != nil {
+			t.Fatalf("encode %T failed: %v", msg, err)
+		}
+		if int(binary.BigEndian.Uint32(frame)) != len(frame) {
+			t.Fatalf("frame length header mismatch for %T", msg)
+		}
+		stream.Write(frame)
+	}
+	for _, want := range messages {
+		got, err := ReadMessage(&stream)
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		if got != want {
+			t.Fatalf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestReadMessageAcceptsLongerBlocks(t *testing.T) {
+	frame, err := Encode(CancelOrder{RequestID: 1, UserID: "u1", OrderID: "ord-1"})
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	extended := append(frame, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(extended, uint32(len(extended)))
+	binary.LittleEndian.PutUint16(extended[sofhLength:], cancelOrderBlockLength+4)
+
+	got, err := ReadMessage(bytes.NewReader(extended))
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if cancel, ok := got.(CancelOrder); !ok || cancel.OrderID != "ord-1" {
+		t.Fatalf("unexpected message: %+v", got)
+	}
+}
+
+func TestEncodeRejectsOversizedFields(t *testing.T) {
+	if _, err := Encode(NewOrder{UserID: strings.Repeat("u", idLength+1), Side: "BUY", Type: "LIMIT"}); err == nil {
+		t.Fatal("expected error for oversized userId")
+	}
+	if _, err := Encode(NewOrder{Side: "HOLD"}); err == nil {
+		t.Fatal("expected error for unknown side")
+	}
+}
+
+func TestReadMessageRejectsBadFrames(t *testing.T) {
+	frame, _ := Encode(OrderReject{RequestID: 1, Reason: "x"})
+	bad := append([]byte(nil), frame...)
+	binary.BigEndian.PutUint16(bad[4:], 0x1234)
+	if _, err := ReadMessage(bytes.NewReader(bad)); err == nil {
+		t.Fatal("expected error for unknown encoding type")
+	}
+
+	bad = append([]byte(nil), frame...)
+	binary.BigEndian.PutUint32(bad, maxFrameLength+1)
+	if _, err := ReadMessage(bytes.NewReader(bad)); err == nil {
+		t.Fatal("expected error for oversized frame")
+	}
+}
@@ -269,7 +269,11 @@ export default function HomeClient() {
         price
       });
 
-      toast({ title: "Order Update", description: `Order ${ack.orderId} ${ack.status}` });
+      if (ack.rejectCode) {
+        toast({ variant: "destructive", title: "Order Rejected", description: `${ack.rejectCode}: ${ack.rejectReason ?? ""}` });
+      } else {
+        toast({ title: "Order Update", description: `Order ${ack.orderId} ${ack.status}` });
+      }
       await refresh();
     } catch (err) {
       toast({ variant: "destructive", title: "Order Failed", description: err instanceof Error ? err.message : "Order failed" });
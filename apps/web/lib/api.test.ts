@@ -10,6 +10,7 @@ import {
   searchPairsByAPI,
   fetchTrades,
   mapChartIntervalToBackendTimeframe,
+  parseErrorBody,
   rangeFromPreset,
   summarizeTrades
 } from "./api";
@@ -45,6 +46,19 @@ describe("buildOrderPayload", () => {
   });
 });
 
+describe("parseErrorBody", () => {
+  it("reads code, message and details from JSON errors", () => {
+    const body = parseErrorBody(422, '{"code":"INSUFFICIENT_FUNDS","message":"insufficient buy balance","details":{"asset":"USD"}}');
+
+    expect(body).toEqual({ code: "INSUFFICIENT_FUNDS", message: "insufficient buy balance", details: { asset: "USD" } });
+  });
+
+  it("keeps plain text errors as the message", () => {
+    expect(parseErrorBody(502, "bad gateway")).toEqual({ code: "INTERNAL", message: "bad gateway" });
+    expect(parseErrorBody(500, "").message).toBe("request failed with 500");
+  });
+});
+
 describe("summarizeTrades", () => {
   it("returns last trade and cumulative quantity", () => {
     const result = summarizeTrades([
@@ -29,6 +29,8 @@ export type OrderAck = {
   avgPrice: number;
   clientOrderId?: string;
   symbol?: string;
+  rejectCode?: string;
+  rejectReason?: string;
   ts: string;
 };
 
@@ -200,10 +202,44 @@ function normalizeApiBase(base: string): string {
   return trimmed.endsWith("/") ? trimmed.slice(0, -1) : trimmed;
 }
 
+export type ApiErrorBody = {
+  code: string;
+  message: string;
+  details?: Record<string, string>;
+};
+
+// ApiError carries the code of an error response so callers can branch on it
+// rather than on the message.
+export class ApiError extends Error {
+  readonly code: string;
+  readonly status: number;
+  readonly details: Record<string, string>;
+
+  constructor(status: number, body: ApiErrorBody) {
+    super(body.message);
+    this.name = "ApiError";
+    this.code = body.code;
+    this.status = status;
+    this.details = body.details ?? {};
+  }
+}
+
+export function parseErrorBody(status: number, text: string): ApiErrorBody {
+  try {
+    const body = JSON.parse(text) as Partial<ApiErrorBody>;
+    if (typeof body.code === "string" && typeof body.message === "string") {
+      return { code: body.code, message: body.message, details: body.details };
+    }
+  } catch {
+    // Plain text bodies fall through.
+  }
+  return { code: "INTERNAL", message: text || `request failed with ${status}` };
+}
+
 async function parseJSON<T>(res: Response): Promise<T> {
   if (!res.ok) {
     const text = await res.text();
-    throw new Error(text || `request failed with ${res.status}`);
+    throw new ApiError(res.status, parseErrorBody(res.status, text));
   }
   return (await res.json()) as T;
 }
@@ -0,0 +1,17 @@
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'trader';
+
+CREATE TABLE IF NOT EXISTS audit_log (
+  audit_id BIGSERIAL PRIMARY KEY,
+  occurred_at TIMESTAMPTZ NOT NULL,
+  user_id TEXT NOT NULL,
+  role TEXT NOT NULL,
+  key_id TEXT NOT NULL DEFAULT '',
+  method TEXT NOT NULL,
+  path TEXT NOT NULL,
+  status INT NOT NULL,
+  remote_ip TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_user_id
+  ON audit_log(user_id, occurred_at);
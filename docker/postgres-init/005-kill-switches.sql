@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS kill_switches (
+  scope TEXT NOT NULL,
+  target TEXT NOT NULL DEFAULT '',
+  reason TEXT NOT NULL DEFAULT '',
+  created_by TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (scope, target)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS sub_accounts (
+  account_id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  UNIQUE (user_id, name)
+);
+
+-- An API key with an account_id trades only that sub-account.
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS account_id TEXT NOT NULL DEFAULT '';
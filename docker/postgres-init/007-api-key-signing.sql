@@ -0,0 +1,3 @@
+-- The HMAC key for signed requests, AES-GCM sealed with the gateway's
+-- API_KEY_ENCRYPTION_KEY. Keys created before this column cannot sign.
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS signing_secret BYTEA;